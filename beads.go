@@ -34,7 +34,7 @@ type (
 	IssueType = types.IssueType
 	// Dependency represents a relationship between issues.
 	Dependency = types.Dependency
-	// DependencyType represents the type of dependency (blocks, related, parent-child, discovered-from).
+	// DependencyType represents the type of dependency (blocks, related, parent-child, discovered-from, duplicate-of).
 	DependencyType = types.DependencyType
 	// Comment represents a user comment on an issue.
 	Comment = types.Comment
@@ -83,6 +83,7 @@ const (
 	DepRelated        = types.DepRelated
 	DepParentChild    = types.DepParentChild
 	DepDiscoveredFrom = types.DepDiscoveredFrom
+	DepDuplicateOf    = types.DepDuplicateOf
 )
 
 // SortPolicy constants
@@ -277,8 +278,8 @@ func FindAllDatabases() []DatabaseInfo {
 				store, err := sqlite.New(dbPath)
 				if err == nil {
 					ctx := context.Background()
-					if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err == nil {
-						issueCount = len(issues)
+					if count, err := store.CountIssues(ctx, types.IssueFilter{}); err == nil {
+						issueCount = count
 					}
 					_ = store.Close()
 				}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// acceptanceChecklist is the JSON shape of an issue's acceptance-criteria checklist
+type acceptanceChecklist struct {
+	ID           string                `json:"id"`
+	CheckedCount int                   `json:"checked_count"`
+	TotalCount   int                   `json:"total_count"`
+	Items        []types.ChecklistItem `json:"items"`
+}
+
+func newAcceptanceChecklist(issue *types.Issue) *acceptanceChecklist {
+	items := types.ParseChecklist(issue.AcceptanceCriteria)
+	if items == nil {
+		items = []types.ChecklistItem{}
+	}
+	checked, total := issue.AcceptanceProgress()
+	return &acceptanceChecklist{ID: issue.ID, CheckedCount: checked, TotalCount: total, Items: items}
+}
+
+// fetchIssue loads an issue through the daemon or directly from the store
+func fetchIssue(ctx context.Context, id string) (*types.Issue, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, err
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			return nil, fmt.Errorf("failed to parse issue data: %w", err)
+		}
+		return &issue, nil
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	return issue, nil
+}
+
+// setAcceptanceItem checks or unchecks the nth acceptance-criteria item of an issue
+func setAcceptanceItem(ctx context.Context, id string, n int, checked bool) (*types.Issue, error) {
+	issue, err := fetchIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	criteria, err := types.SetChecklistItem(issue.AcceptanceCriteria, n, checked)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	if criteria == issue.AcceptanceCriteria {
+		return issue, nil
+	}
+
+	if daemonClient != nil {
+		if _, err := daemonClient.Update(&rpc.UpdateArgs{ID: id, AcceptanceCriteria: &criteria}); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"acceptance_criteria": criteria}, actor); err != nil {
+			return nil, err
+		}
+		markDirtyAndScheduleFlush()
+	}
+	issue.AcceptanceCriteria = criteria
+	return issue, nil
+}
+
+// printAcceptanceChecklist prints the numbered checklist with a progress summary
+func printAcceptanceChecklist(list *acceptanceChecklist) {
+	if list.TotalCount == 0 {
+		fmt.Printf("%s has no acceptance-criteria checklist items\n", list.ID)
+		return
+	}
+	fmt.Printf("%s: %d/%d acceptance criteria checked\n", list.ID, list.CheckedCount, list.TotalCount)
+	for i, item := range list.Items {
+		mark := "[ ]"
+		if item.Checked {
+			mark = renderSuccess("[x]")
+		}
+		fmt.Printf("  %d. %s %s\n", i+1, mark, item.Text)
+	}
+}
+
+var acCmd = &cobra.Command{
+	Use:   "ac",
+	Short: "Track acceptance-criteria checklists",
+	Long: `Work with task-list items ("- [ ]" / "- [x]") in an issue's acceptance criteria.
+
+Items are numbered from 1 in the order they appear. Checking or unchecking an
+item only flips its mark; the rest of the text is left untouched.`,
+}
+
+var acListCmd = &cobra.Command{
+	Use:   "list [id]",
+	Short: "Show an issue's acceptance-criteria checklist",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		issue, err := fetchIssue(context.Background(), args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		list := newAcceptanceChecklist(issue)
+		if jsonOutput {
+			outputJSON(list)
+			return
+		}
+		printAcceptanceChecklist(list)
+	},
+}
+
+// runSetAcceptanceItem implements ac check and ac uncheck
+func runSetAcceptanceItem(args []string, checked bool) {
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 1 {
+		fmt.Fprintf(os.Stderr, "Error: item number must be a positive integer, got %q\n", args[1])
+		os.Exit(1)
+	}
+	issue, err := setAcceptanceItem(context.Background(), args[0], n, checked)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	list := newAcceptanceChecklist(issue)
+	if jsonOutput {
+		outputJSON(list)
+		return
+	}
+	printAcceptanceChecklist(list)
+}
+
+var acCheckCmd = &cobra.Command{
+	Use:   "check [id] [n]",
+	Short: "Check off the nth acceptance-criteria item",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSetAcceptanceItem(args, true)
+	},
+}
+
+var acUncheckCmd = &cobra.Command{
+	Use:   "uncheck [id] [n]",
+	Short: "Uncheck the nth acceptance-criteria item",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSetAcceptanceItem(args, false)
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{acListCmd, acCheckCmd, acUncheckCmd} {
+		cmd.ValidArgsFunction = completeSingleIssueID
+		acCmd.AddCommand(cmd)
+	}
+	rootCmd.AddCommand(acCmd)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSetAcceptanceItem(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	origStore, origDaemonClient, origAutoFlush := store, daemonClient, autoFlushEnabled
+	defer func() { store, daemonClient, autoFlushEnabled = origStore, origDaemonClient, origAutoFlush }()
+	store, daemonClient, autoFlushEnabled = s, nil, false
+
+	ctx := context.Background()
+	issue := &types.Issue{
+		Title:              "Login",
+		AcceptanceCriteria: "- [ ] Valid credentials sign in\n- [ ] Errors are shown",
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeFeature,
+	}
+	if err := s.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if _, err := setAcceptanceItem(ctx, issue.ID, 2, true); err != nil {
+		t.Fatalf("setAcceptanceItem failed: %v", err)
+	}
+	stored, _ := s.GetIssue(ctx, issue.ID)
+	if stored.AcceptanceCriteria != "- [ ] Valid credentials sign in\n- [x] Errors are shown" {
+		t.Errorf("acceptance criteria = %q", stored.AcceptanceCriteria)
+	}
+
+	list := newAcceptanceChecklist(stored)
+	if list.CheckedCount != 1 || list.TotalCount != 2 || len(list.Items) != 2 || !list.Items[1].Checked {
+		t.Errorf("unexpected checklist: %+v", list)
+	}
+
+	if _, err := setAcceptanceItem(ctx, issue.ID, 3, true); err == nil {
+		t.Error("expected error for missing item")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id...]",
+	Short: "Archive issues so they no longer appear in listings",
+	Long: `Archive issues instead of deleting them.
+
+Archived issues keep their history, dependencies, and comments, and are still
+exported to JSONL, but are hidden from 'bd list', 'bd ready', and other default
+listings. Use 'bd list --include-archived' to see them and 'bd unarchive' to restore.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runArchive(args, true)
+	},
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive [id...]",
+	Short: "Restore archived issues to default listings",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runArchive(args, false)
+	},
+}
+
+func runArchive(ids []string, archive bool) {
+	// If daemon is running but doesn't support this command, use direct storage
+	if daemonClient != nil && store == nil {
+		var err error
+		store, err = openLocalStore(dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = store.Close() }()
+	}
+
+	ctx := context.Background()
+	changed := []*types.Issue{}
+	count := 0
+	for _, id := range ids {
+		var err error
+		if archive {
+			err = store.ArchiveIssue(ctx, id, actor)
+		} else {
+			err = store.UnarchiveIssue(ctx, id, actor)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+		count++
+
+		if jsonOutput {
+			issue, _ := store.GetIssue(ctx, id)
+			if issue != nil {
+				changed = append(changed, issue)
+			}
+		} else if archive {
+			fmt.Printf("%s Archived %s\n", renderSuccess("✓"), id)
+		} else {
+			fmt.Printf("%s Unarchived %s\n", renderReverted("↻"), id)
+		}
+	}
+
+	if count > 0 {
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(changed)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+}
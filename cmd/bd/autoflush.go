@@ -13,9 +13,9 @@ import (
 	"sort"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/steveyegge/beads"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 	"golang.org/x/mod/semver"
 )
 
@@ -63,7 +63,7 @@ func autoImportIfNewer() {
 	jsonlPath := findJSONLPath()
 
 	// Read JSONL file
-	jsonlData, err := os.ReadFile(jsonlPath)
+	jsonlData, err := utils.ReadFileWithRetry(jsonlPath)
 	if err != nil {
 		// JSONL doesn't exist or can't be accessed, skip import
 		if os.Getenv("BD_DEBUG") != "" {
@@ -104,22 +104,18 @@ func autoImportIfNewer() {
 
 	// Check for Git merge conflict markers (bd-270)
 	// Only match if they appear as standalone lines (not embedded in JSON strings)
-	lines := bytes.Split(jsonlData, []byte("\n"))
-	for _, line := range lines {
-		trimmed := bytes.TrimSpace(line)
-		if bytes.HasPrefix(trimmed, []byte("<<<<<<< ")) ||
-			bytes.Equal(trimmed, []byte("=======")) ||
-			bytes.HasPrefix(trimmed, []byte(">>>>>>> ")) {
-			fmt.Fprintf(os.Stderr, "\n❌ Git merge conflict detected in %s\n\n", jsonlPath)
-			fmt.Fprintf(os.Stderr, "The JSONL file contains unresolved merge conflict markers.\n")
-			fmt.Fprintf(os.Stderr, "This prevents auto-import from loading your issues.\n\n")
-			fmt.Fprintf(os.Stderr, "To resolve:\n")
-			fmt.Fprintf(os.Stderr, "  1. Resolve the merge conflict in your Git client, OR\n")
-			fmt.Fprintf(os.Stderr, "  2. Export from database to regenerate clean JSONL:\n")
-			fmt.Fprintf(os.Stderr, "     bd export -o %s\n\n", jsonlPath)
-			fmt.Fprintf(os.Stderr, "After resolving, commit the fixed JSONL file.\n")
-			return
-		}
+	if hasConflictMarkers(jsonlData) {
+		fmt.Fprintf(os.Stderr, "\n❌ Git merge conflict detected in %s\n\n", jsonlPath)
+		fmt.Fprintf(os.Stderr, "The JSONL file contains unresolved merge conflict markers.\n")
+		fmt.Fprintf(os.Stderr, "This prevents auto-import from loading your issues.\n\n")
+		fmt.Fprintf(os.Stderr, "To resolve:\n")
+		fmt.Fprintf(os.Stderr, "  1. Reconcile both sides automatically:\n")
+		fmt.Fprintf(os.Stderr, "     bd merge-resolve %s\n", jsonlPath)
+		fmt.Fprintf(os.Stderr, "  2. Resolve the merge conflict in your Git client, OR\n")
+		fmt.Fprintf(os.Stderr, "  3. Export from database to regenerate clean JSONL:\n")
+		fmt.Fprintf(os.Stderr, "     bd export -o %s\n\n", jsonlPath)
+		fmt.Fprintf(os.Stderr, "After resolving, commit the fixed JSONL file.\n")
+		return
 	}
 
 	// Content changed - parse all issues
@@ -226,11 +222,7 @@ func autoImportIfNewer() {
 		}
 	}
 
-	// Store new hash after successful import
-	if err := store.SetMetadata(ctx, "last_import_hash", currentHash); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to update last_import_hash after import: %v\n", err)
-		fmt.Fprintf(os.Stderr, "This may cause auto-import to retry the same import on next operation.\n")
-	}
+	// last_import_hash is recorded by importIssuesCore after a successful import
 
 	// Store import timestamp (bd-159: for staleness detection)
 	importTime := time.Now().Format(time.RFC3339)
@@ -262,9 +254,8 @@ func checkVersionMismatch() {
 
 	// Compare versions: warn if binary is older than database
 	if dbVersion != Version {
-		yellow := color.New(color.FgYellow, color.Bold).SprintFunc()
-		fmt.Fprintf(os.Stderr, "\n%s\n", yellow("⚠️  WARNING: Version mismatch detected!"))
-		fmt.Fprintf(os.Stderr, "%s\n", yellow(fmt.Sprintf("⚠️  Your bd binary (v%s) differs from the database version (v%s)", Version, dbVersion)))
+		fmt.Fprintf(os.Stderr, "\n%s\n", renderAlert("⚠️  WARNING: Version mismatch detected!"))
+		fmt.Fprintf(os.Stderr, "%s\n", renderAlert(fmt.Sprintf("⚠️  Your bd binary (v%s) differs from the database version (v%s)", Version, dbVersion)))
 
 		// Use semantic version comparison (requires v prefix)
 		binaryVer := "v" + Version
@@ -275,12 +266,12 @@ func checkVersionMismatch() {
 
 		if cmp < 0 {
 			// Binary is older than database
-			fmt.Fprintf(os.Stderr, "%s\n", yellow("⚠️  Your binary appears to be OUTDATED."))
-			fmt.Fprintf(os.Stderr, "%s\n\n", yellow("⚠️  Some features may not work correctly. Rebuild: go build -o bd ./cmd/bd"))
+			fmt.Fprintf(os.Stderr, "%s\n", renderAlert("⚠️  Your binary appears to be OUTDATED."))
+			fmt.Fprintf(os.Stderr, "%s\n\n", renderAlert("⚠️  Some features may not work correctly. Rebuild: go build -o bd ./cmd/bd"))
 		} else if cmp > 0 {
 			// Binary is newer than database
-			fmt.Fprintf(os.Stderr, "%s\n", yellow("⚠️  Your binary appears NEWER than the database."))
-			fmt.Fprintf(os.Stderr, "%s\n\n", yellow("⚠️  The database will be upgraded automatically."))
+			fmt.Fprintf(os.Stderr, "%s\n", renderAlert("⚠️  Your binary appears NEWER than the database."))
+			fmt.Fprintf(os.Stderr, "%s\n\n", renderAlert("⚠️  The database will be upgraded automatically."))
 			// Update stored version to current
 			_ = store.SetMetadata(ctx, "bd_version", Version)
 		}
@@ -454,32 +445,19 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 		}
 	}()
 
-	// Write all issues as JSONL (with timestamp-only deduplication for bd-159)
+	// Write all issues as JSONL. Timestamp-only changes (bd-159) are handled by
+	// the incremental flush, which keeps the existing line; a full write must
+	// include every issue.
 	ctx := context.Background()
 	encoder := json.NewEncoder(f)
-	skippedCount := 0
 	exportedIDs := make([]string, 0, len(issues))
-	
+
 	for _, issue := range issues {
-		// Check if this is only a timestamp change (bd-159)
-		skip, err := shouldSkipExport(ctx, issue)
-		if err != nil {
-			// Log warning but continue - don't fail export on hash check errors
-			if os.Getenv("BD_DEBUG") != "" {
-				fmt.Fprintf(os.Stderr, "Debug: failed to check if %s should skip: %v\n", issue.ID, err)
-			}
-			skip = false
-		}
-		
-		if skip {
-			skippedCount++
-			continue
-		}
-		
+		issue.SortForExport()
 		if err := encoder.Encode(issue); err != nil {
 			return nil, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
-		
+
 		// Save content hash after successful export (bd-159)
 		contentHash, err := computeIssueContentHash(issue)
 		if err != nil {
@@ -491,26 +469,27 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 				fmt.Fprintf(os.Stderr, "Debug: failed to save export hash for %s: %v\n", issue.ID, err)
 			}
 		}
-		
+
 		exportedIDs = append(exportedIDs, issue.ID)
 	}
-	
-	// Report skipped issues if any (helps debugging bd-159)
-	if skippedCount > 0 && os.Getenv("BD_DEBUG") != "" {
-		fmt.Fprintf(os.Stderr, "Debug: auto-flush skipped %d issue(s) with timestamp-only changes\n", skippedCount)
-	}
 
 	// Close temp file before renaming
+	if err := utils.SyncFile(f); err != nil {
+		return nil, fmt.Errorf("failed to sync temp file: %w", err)
+	}
 	if err := f.Close(); err != nil {
 		return nil, fmt.Errorf("failed to close temp file: %w", err)
 	}
 	f = nil // Prevent defer cleanup
 
 	// Atomic rename
-	if err := os.Rename(tempPath, jsonlPath); err != nil {
+	if err := utils.RenameWithRetry(tempPath, jsonlPath); err != nil {
 		_ = os.Remove(tempPath) // Clean up on rename failure
 		return nil, fmt.Errorf("failed to rename file: %w", err)
 	}
+	if err := utils.SyncDir(filepath.Dir(jsonlPath)); err != nil {
+		return nil, fmt.Errorf("failed to sync directory: %w", err)
+	}
 
 	// Set appropriate file permissions (0644: rw-r--r--)
 	if err := os.Chmod(jsonlPath, 0644); err != nil {
@@ -530,7 +509,7 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 // immediately on command exit.
 //
 // Export modes:
-//   - Incremental (default): Exports only GetDirtyIssues(), merges with existing JSONL
+//   - Incremental (default): Exports only GetDirtyIssues(), rewriting just their lines in the existing JSONL
 //   - Full (after renumber): Exports all issues, rebuilds JSONL from scratch
 //
 // Error handling: Tracks consecutive failures. After 3+ failures, displays prominent
@@ -587,10 +566,9 @@ func flushToJSONL() {
 
 		// Show prominent warning after 3+ consecutive failures
 		if failCount >= 3 {
-			red := color.New(color.FgRed, color.Bold).SprintFunc()
-			fmt.Fprintf(os.Stderr, "\n%s\n", red("⚠️  CRITICAL: Auto-flush has failed "+fmt.Sprint(failCount)+" times consecutively!"))
-			fmt.Fprintf(os.Stderr, "%s\n", red("⚠️  Your JSONL file may be out of sync with the database."))
-			fmt.Fprintf(os.Stderr, "%s\n\n", red("⚠️  Run 'bd export -o .beads/issues.jsonl' manually to fix."))
+			fmt.Fprintf(os.Stderr, "\n%s\n", renderCritical("⚠️  CRITICAL: Auto-flush has failed "+fmt.Sprint(failCount)+" times consecutively!"))
+			fmt.Fprintf(os.Stderr, "%s\n", renderCritical("⚠️  Your JSONL file may be out of sync with the database."))
+			fmt.Fprintf(os.Stderr, "%s\n\n", renderCritical("⚠️  Run 'bd export -o .beads/issues.jsonl' manually to fix."))
 		}
 	}
 
@@ -610,7 +588,7 @@ func flushToJSONL() {
 
 	if fullExport {
 		// Full export: get ALL issues (needed after ID-changing operations like renumber)
-		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			recordFailure(fmt.Errorf("failed to get all issues: %w", err))
 			return
@@ -634,63 +612,35 @@ func flushToJSONL() {
 		}
 	}
 
-	// Read existing JSONL into a map (skip for full export - we'll rebuild from scratch)
-	issueMap := make(map[string]*types.Issue)
-	if !fullExport {
-		if existingFile, err := os.Open(jsonlPath); err == nil {
-			scanner := bufio.NewScanner(existingFile)
-			lineNum := 0
-			for scanner.Scan() {
-				lineNum++
-				line := scanner.Text()
-				if line == "" {
-					continue
-				}
-				var issue types.Issue
-				if err := json.Unmarshal([]byte(line), &issue); err == nil {
-					issueMap[issue.ID] = &issue
-				} else {
-					// Warn about malformed JSONL lines
-					fmt.Fprintf(os.Stderr, "Warning: skipping malformed JSONL line %d: %v\n", lineNum, err)
-				}
+	var exportedIDs []string
+	if fullExport {
+		// Full export: rebuild the file from scratch
+		issues := make([]*types.Issue, 0, len(dirtyIDs))
+		for _, issueID := range dirtyIDs {
+			issue, err := store.GetIssue(ctx, issueID)
+			if err != nil {
+				recordFailure(fmt.Errorf("failed to get issue %s: %w", issueID, err))
+				return
+			}
+			if issue == nil {
+				continue
 			}
-			_ = existingFile.Close()
-		}
-	}
-
-	// Fetch only dirty issues from DB
-	for _, issueID := range dirtyIDs {
-		issue, err := store.GetIssue(ctx, issueID)
-		if err != nil {
-			recordFailure(fmt.Errorf("failed to get issue %s: %w", issueID, err))
-			return
-		}
-		if issue == nil {
-			// Issue was deleted, remove from map
-			delete(issueMap, issueID)
-			continue
-		}
 
-		// Get dependencies for this issue
-		deps, err := store.GetDependencyRecords(ctx, issueID)
-		if err != nil {
-			recordFailure(fmt.Errorf("failed to get dependencies for %s: %w", issueID, err))
-			return
+			deps, err := store.GetDependencyRecords(ctx, issueID)
+			if err != nil {
+				recordFailure(fmt.Errorf("failed to get dependencies for %s: %w", issueID, err))
+				return
+			}
+			issue.Dependencies = deps
+			issues = append(issues, issue)
 		}
-		issue.Dependencies = deps
 
-		// Update map
-		issueMap[issueID] = issue
-	}
-
-	// Convert map to slice (will be sorted by writeJSONLAtomic)
-	issues := make([]*types.Issue, 0, len(issueMap))
-	for _, issue := range issueMap {
-		issues = append(issues, issue)
+		exportedIDs, err = writeJSONLAtomic(jsonlPath, issues)
+	} else {
+		// Incremental export: rewrite only the dirty issues' lines so unchanged
+		// issues stay byte-for-byte identical in the JSONL
+		exportedIDs, err = updateJSONLLines(ctx, jsonlPath, dirtyIDs)
 	}
-
-	// Write atomically using common helper
-	exportedIDs, err := writeJSONLAtomic(jsonlPath, issues)
 	if err != nil {
 		recordFailure(err)
 		return
@@ -706,7 +656,7 @@ func flushToJSONL() {
 	}
 
 	// Store hash of exported JSONL (fixes bd-84: enables hash-based auto-import)
-	jsonlData, err := os.ReadFile(jsonlPath)
+	jsonlData, err := utils.ReadFileWithRetry(jsonlPath)
 	if err == nil {
 		hasher := sha256.New()
 		hasher.Write(jsonlData)
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var claimCmd = &cobra.Command{
+	Use:   "claim <id>",
+	Short: "Claim an issue so no other agent takes it",
+	Long: `Claim an open issue: it moves to in_progress, is assigned to you, and no
+other agent can claim it until the lease runs out or you release it.
+
+When several agents pull from 'bd ready', each should claim an issue before
+working on it. Exactly one of them gets it; the rest exit with status 1 and
+can move on to the next issue:
+
+  for id in $(bd ready --json | jq -r '.[].id'); do
+    bd claim "$id" && break
+  done
+
+Claiming an issue you already hold renews the lease. A claim that has run
+out can be taken by anyone, so long-running work should renew it; 'bd ready'
+releases expired claims and leaves out issues other agents hold. Claims
+live in the database only; they are not exported to JSONL, so agents must
+share a database (directly or through the daemon) to coordinate.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		lease, _ := cmd.Flags().GetDuration("lease")
+		agent, _ := cmd.Flags().GetString("agent")
+		if agent == "" {
+			agent = actor
+		}
+
+		var result rpc.ClaimResponse
+		if daemonClient != nil {
+			resp, err := daemonClient.Claim(&rpc.ClaimArgs{ID: id, Agent: agent, Lease: lease.String()})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := json.Unmarshal(resp.Data, &result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			ctx := context.Background()
+			claimed, err := store.ClaimIssue(ctx, id, agent, lease)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			result.Claimed = claimed
+			result.Issue, _ = store.GetIssue(ctx, id)
+			if claimed {
+				expiresAt := time.Now().Add(lease)
+				result.ExpiresAt = &expiresAt
+				markDirtyAndScheduleFlush()
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+		} else if result.Claimed {
+			fmt.Printf("%s Claimed %s for %s until %s\n", renderSuccess("✓"), id, agent, result.ExpiresAt.Format("15:04:05"))
+		} else {
+			holder := ""
+			if result.Issue != nil && result.Issue.Assignee != "" {
+				holder = " by " + result.Issue.Assignee
+			}
+			fmt.Fprintf(os.Stderr, "%s is already claimed%s\n", id, holder)
+		}
+		if !result.Claimed {
+			os.Exit(1)
+		}
+	},
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release <id>",
+	Short: "Release your claim on an issue",
+	Long: `Release a claim taken with 'bd claim'. The issue goes back to open and is
+unassigned, so another agent can claim it. Anyone may release a claim whose
+lease has run out.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		agent, _ := cmd.Flags().GetString("agent")
+		if agent == "" {
+			agent = actor
+		}
+
+		var issue *types.Issue
+		if daemonClient != nil {
+			resp, err := daemonClient.Release(&rpc.ReleaseArgs{ID: id, Agent: agent})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := json.Unmarshal(resp.Data, &issue); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			ctx := context.Background()
+			if err := store.ReleaseIssue(ctx, id, agent); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			issue, _ = store.GetIssue(ctx, id)
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(issue)
+			return
+		}
+		fmt.Printf("%s Released %s\n", renderReverted("↻"), id)
+	},
+}
+
+func init() {
+	claimCmd.Flags().Duration("lease", 30*time.Minute, "How long the claim lasts unless renewed")
+	claimCmd.Flags().String("agent", "", "Who is claiming (default: the actor)")
+	releaseCmd.Flags().String("agent", "", "Who is releasing (default: the actor)")
+	rootCmd.AddCommand(claimCmd)
+	rootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <id>",
+	Short: "Create a new issue by copying an existing one",
+	Long: `Create a new open issue with a fresh ID, copying the title, description,
+design, acceptance criteria, type, priority, estimate, and labels of an existing issue.
+
+Status, assignee, and comments are not copied. Use --with-deps to also copy the
+source's dependencies, and the flags below to override copied fields.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		withDeps, _ := cmd.Flags().GetBool("with-deps")
+
+		overrides := make(map[string]interface{})
+		if cmd.Flags().Changed("title") {
+			title, _ := cmd.Flags().GetString("title")
+			overrides["title"] = title
+		}
+		if cmd.Flags().Changed("description") {
+			description, _ := cmd.Flags().GetString("description")
+			overrides["description"] = description
+		}
+		if cmd.Flags().Changed("priority") {
+			priority, _ := cmd.Flags().GetInt("priority")
+			overrides["priority"] = priority
+		}
+		if cmd.Flags().Changed("type") {
+			issueType, _ := cmd.Flags().GetString("type")
+			overrides["issue_type"] = issueType
+		}
+		if cmd.Flags().Changed("assignee") {
+			assignee, _ := cmd.Flags().GetString("assignee")
+			overrides["assignee"] = types.ResolveMe(assignee, actor)
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		clone, err := store.CloneIssue(ctx, args[0], overrides, actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if withDeps {
+			deps, err := store.GetDependencyRecords(ctx, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to read dependencies of %s: %v\n", args[0], err)
+			}
+			for _, dep := range deps {
+				newDep := &types.Dependency{
+					IssueID:     clone.ID,
+					DependsOnID: dep.DependsOnID,
+					Type:        dep.Type,
+				}
+				if err := store.AddDependency(ctx, newDep, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", clone.ID, dep.DependsOnID, err)
+				}
+			}
+			if clone, err = store.GetIssue(ctx, clone.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Schedule auto-flush
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(clone)
+		} else {
+			fmt.Printf("%s Cloned %s as %s\n", renderSuccess("✓"), args[0], clone.ID)
+			fmt.Printf("  Title: %s\n", clone.Title)
+			fmt.Printf("  Priority: P%d\n", clone.Priority)
+			fmt.Printf("  Status: %s\n", clone.Status)
+		}
+	},
+}
+
+func init() {
+	cloneCmd.Flags().String("title", "", "Title for the new issue")
+	cloneCmd.Flags().StringP("description", "d", "", "Description for the new issue")
+	cloneCmd.Flags().IntP("priority", "p", 2, "Priority (0-4, 0=highest)")
+	cloneCmd.Flags().StringP("type", "t", "", "Issue type (bug|feature|task|epic|chore)")
+	cloneCmd.Flags().StringP("assignee", "a", "", "Assignee (@me for yourself)")
+	cloneCmd.Flags().Bool("with-deps", false, "Also copy the source issue's dependencies")
+	rootCmd.AddCommand(cloneCmd)
+}
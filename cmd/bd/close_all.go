@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
+)
+
+// closeAllSelector is the filter of `bd close --all`. At least one field must be set
+// so a bare --all can't close every issue in the repository.
+type closeAllSelector struct {
+	Status   string
+	Labels   []string
+	Assignee string
+}
+
+func (sel closeAllSelector) empty() bool {
+	return sel.Status == "" && len(sel.Labels) == 0 && sel.Assignee == ""
+}
+
+// filter converts the selector to an issue filter
+func (sel closeAllSelector) filter() types.IssueFilter {
+	filter := types.IssueFilter{Labels: sel.Labels}
+	if sel.Status != "" {
+		status := types.Status(sel.Status)
+		filter.Status = &status
+	}
+	if sel.Assignee != "" {
+		assignee := sel.Assignee
+		filter.Assignee = &assignee
+	}
+	return filter
+}
+
+// unclosedSorted drops closed issues and sorts the rest by ID
+func unclosedSorted(issues []*types.Issue) []*types.Issue {
+	var result []*types.Issue
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed {
+			result = append(result, issue)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// findIssuesToClose returns the unclosed issues matching the selector
+func findIssuesToClose(ctx context.Context, s storage.Storage, sel closeAllSelector) ([]*types.Issue, error) {
+	issues, err := s.SearchIssues(ctx, "", sel.filter())
+	if err != nil {
+		return nil, err
+	}
+	return unclosedSorted(issues), nil
+}
+
+// closeIssues closes each issue and returns the IDs closed.
+// Failures are reported to stderr and skipped.
+func closeIssues(ctx context.Context, s storage.Storage, issues []*types.Issue, reason, actor string) []string {
+	var closedIDs []string
+	for _, issue := range issues {
+		if err := s.CloseIssue(ctx, issue.ID, reason, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", issue.ID, err)
+			continue
+		}
+		closedIDs = append(closedIDs, issue.ID)
+	}
+	return closedIDs
+}
+
+// closeIssuesViaDaemon closes the issues in one batch request
+func closeIssuesViaDaemon(issues []*types.Issue, reason string, force bool) ([]string, error) {
+	ops := make([]rpc.BatchOperation, len(issues))
+	for i, issue := range issues {
+		args, err := json.Marshal(&rpc.CloseArgs{ID: issue.ID, Reason: reason, Force: force})
+		if err != nil {
+			return nil, err
+		}
+		ops[i] = rpc.BatchOperation{Operation: rpc.OpClose, Args: args}
+	}
+
+	resp, err := daemonClient.Batch(&rpc.BatchArgs{Operations: ops})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	var batch rpc.BatchResponse
+	if err := json.Unmarshal(resp.Data, &batch); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// The batch stops at the first failure
+	var closedIDs []string
+	for i, result := range batch.Results {
+		if !result.Success {
+			fmt.Fprintf(os.Stderr, "Error closing %s: %s\n", issues[i].ID, result.Error)
+			break
+		}
+		closedIDs = append(closedIDs, issues[i].ID)
+	}
+	return closedIDs, nil
+}
+
+// runCloseAll implements `bd close --all`
+func runCloseAll(cmd *cobra.Command, reason string, force bool) {
+	sel := closeAllSelector{}
+	sel.Status, _ = cmd.Flags().GetString("status")
+	sel.Labels, _ = cmd.Flags().GetStringSlice("label")
+	sel.Assignee, _ = cmd.Flags().GetString("assignee")
+	sel.Assignee = types.ResolveMe(sel.Assignee, actor)
+	autoYes, _ := cmd.Flags().GetBool("yes")
+
+	if sel.empty() {
+		fmt.Fprintf(os.Stderr, "Error: --all requires at least one of --status, --label, or --assignee\n")
+		os.Exit(1)
+	}
+	if sel.Status != "" && !types.Status(sel.Status).IsValid() {
+		fmt.Fprintf(os.Stderr, "Error: invalid status %q\n", sel.Status)
+		os.Exit(1)
+	}
+	if jsonOutput && !autoYes {
+		// JSON mode can't prompt, and closing everything unasked is too easy a mistake
+		fmt.Fprintf(os.Stderr, "Error: --all with --json requires --yes\n")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if force {
+		ctx = workflow.WithForce(ctx)
+	}
+
+	var issues []*types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.List(&rpc.ListArgs{Status: sel.Status, Labels: sel.Labels, Assignee: sel.Assignee})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var listed []*types.Issue
+		if err := json.Unmarshal(resp.Data, &listed); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+			os.Exit(1)
+		}
+		issues = unclosedSorted(listed)
+	} else {
+		var err error
+		if issues, err = findIssuesToClose(ctx, store, sel); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if len(issues) == 0 {
+		if jsonOutput {
+			outputJSON([]string{})
+		} else {
+			fmt.Println("No matching open issues")
+		}
+		return
+	}
+
+	if !autoYes {
+		fmt.Printf("Found %d matching %s:\n", len(issues), pluralize(len(issues), "issue", "issues"))
+		for _, issue := range issues {
+			fmt.Printf("  - %s: %s\n", issue.ID, issue.Title)
+		}
+		fmt.Printf("\nClose %s? [y/N] ", pluralize(len(issues), "this issue", "these issues"))
+		var response string
+		_, _ = fmt.Scanln(&response) // Ignore errors, default to empty string
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("Canceled")
+			return
+		}
+	}
+
+	var closedIDs []string
+	if daemonClient != nil {
+		var err error
+		if closedIDs, err = closeIssuesViaDaemon(issues, reason, force); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		closedIDs = closeIssues(ctx, store, issues, reason, actor)
+		if len(closedIDs) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+	}
+
+	if jsonOutput {
+		if closedIDs == nil {
+			closedIDs = []string{}
+		}
+		outputJSON(closedIDs)
+		return
+	}
+	fmt.Printf("%s Closed %d of %d matching %s: %s\n", renderSuccess("✓"), len(closedIDs), len(issues),
+		pluralize(len(issues), "issue", "issues"), reason)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCloseAllSelectorEmpty(t *testing.T) {
+	if !(closeAllSelector{}).empty() {
+		t.Error("zero selector should be empty")
+	}
+	if (closeAllSelector{Labels: []string{"sprint-42"}}).empty() {
+		t.Error("selector with a label should not be empty")
+	}
+}
+
+func TestCloseAllClosesOnlyMatching(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	newIssue := func(title, assignee string, status types.Status, labels ...string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask, Assignee: assignee}
+		if err := s.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := s.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+
+	sprintAlice := newIssue("Sprint task A", "alice", types.StatusOpen, "sprint-42")
+	sprintBob := newIssue("Sprint task B", "bob", types.StatusInProgress, "sprint-42")
+	otherSprint := newIssue("Next sprint", "alice", types.StatusOpen, "sprint-43")
+	unlabeled := newIssue("Unlabeled", "alice", types.StatusOpen)
+
+	// Label and assignee combine with AND
+	issues, err := findIssuesToClose(ctx, s, closeAllSelector{Labels: []string{"sprint-42"}, Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("findIssuesToClose failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != sprintAlice.ID {
+		t.Fatalf("expected only %s, got %v", sprintAlice.ID, issues)
+	}
+
+	issues, err = findIssuesToClose(ctx, s, closeAllSelector{Labels: []string{"sprint-42"}})
+	if err != nil {
+		t.Fatalf("findIssuesToClose failed: %v", err)
+	}
+	closed := closeIssues(ctx, s, issues, "Sprint over", "test-user")
+	if len(closed) != 2 {
+		t.Fatalf("expected 2 issues closed, got %v", closed)
+	}
+
+	for _, issue := range []*types.Issue{sprintAlice, sprintBob} {
+		got, _ := s.GetIssue(ctx, issue.ID)
+		if got.Status != types.StatusClosed {
+			t.Errorf("%s should be closed, got %s", issue.ID, got.Status)
+		}
+	}
+	for _, issue := range []*types.Issue{otherSprint, unlabeled} {
+		got, _ := s.GetIssue(ctx, issue.ID)
+		if got.Status == types.StatusClosed {
+			t.Errorf("%s should not have been closed", issue.ID)
+		}
+	}
+
+	// Already-closed issues are not selected again
+	issues, err = findIssuesToClose(ctx, s, closeAllSelector{Labels: []string{"sprint-42"}})
+	if err != nil {
+		t.Fatalf("findIssuesToClose failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected no issues left to close, got %d", len(issues))
+	}
+}
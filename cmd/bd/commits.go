@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits on the commit lookup bd show runs, so a large history can't stall it
+const (
+	linkedCommitsLimit   = 10
+	linkedCommitsTimeout = 2 * time.Second
+)
+
+// CommitRef is a git commit whose message mentions an issue
+type CommitRef struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c CommitRef) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// commitIDPattern builds an extended regex matching id as a whole word, so
+// bd-1 doesn't match commits that only mention bd-10
+func commitIDPattern(id string) string {
+	const notIDChar = `[^[:alnum:]_-]`
+	return `(^|` + notIDChar + `)` + regexp.QuoteMeta(id) + `(` + notIDChar + `|$)`
+}
+
+// GetLinkedCommits returns up to limit commits (all of them if limit is 0) in the git
+// repository containing dir whose message mentions the issue ID, newest first. Outside
+// a git repository (or without git installed) there are no linked commits, so it
+// returns nil without an error.
+func GetLinkedCommits(ctx context.Context, dir, id string, limit int) ([]CommitRef, error) {
+	if err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--git-dir").Run(); err != nil {
+		return nil, nil
+	}
+
+	args := []string{"-C", dir, "log", "--extended-regexp",
+		"--grep=" + commitIDPattern(id), "--format=%H%x1f%an%x1f%aI%x1f%s"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	// #nosec G204 - the issue ID is passed as a single --grep argument, not through a shell
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		// A repository without commits yet has no history to search
+		if exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "-q", "--verify", "HEAD").Run() != nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var commits []CommitRef
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, CommitRef{Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// linkedCommitsDir is where bd show looks for the git repository: the workspace
+// containing the database, or the current directory if none is known
+func linkedCommitsDir() string {
+	if dbPath != "" {
+		return filepath.Dir(dbPath)
+	}
+	return "."
+}
+
+// showLinkedCommits returns the latest commits mentioning an issue for bd show,
+// giving up after linkedCommitsTimeout. Errors are ignored, since commit links
+// are a convenience.
+func showLinkedCommits(ctx context.Context, id string) []CommitRef {
+	ctx, cancel := context.WithTimeout(ctx, linkedCommitsTimeout)
+	defer cancel()
+	commits, _ := GetLinkedCommits(ctx, linkedCommitsDir(), id, linkedCommitsLimit)
+	return commits
+}
+
+// printLinkedCommits lists the latest commits mentioning an issue for bd show
+func printLinkedCommits(ctx context.Context, id string) {
+	commits := showLinkedCommits(ctx, id)
+	if len(commits) == 0 {
+		return
+	}
+	if len(commits) == linkedCommitsLimit {
+		fmt.Printf("\nCommits (latest %d):\n", len(commits))
+	} else {
+		fmt.Printf("\nCommits (%d):\n", len(commits))
+	}
+	for _, c := range commits {
+		fmt.Printf("  %s %s (%s, %s)\n", c.ShortHash(), c.Subject, c.Author, HumanizeTime(c.Date))
+	}
+}
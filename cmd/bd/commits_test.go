@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetLinkedCommits(t *testing.T) {
+	dir := t.TempDir()
+	runGitCmd(t, dir, "init")
+	configureGit(t, dir)
+
+	ctx := context.Background()
+
+	// A repository without commits has nothing linked
+	commits, err := GetLinkedCommits(ctx, dir, "bd-1", 0)
+	if err != nil {
+		t.Fatalf("GetLinkedCommits on empty repo failed: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("expected no commits in empty repo, got %v", commits)
+	}
+
+	for _, msg := range []string{
+		"Fix login redirect (bd-1)",
+		"Refactor session store for bd-10",
+		"bd-1: add regression test",
+		"Unrelated cleanup",
+	} {
+		runGitCmd(t, dir, "commit", "--allow-empty", "-m", msg)
+	}
+
+	commits, err = GetLinkedCommits(ctx, dir, "bd-1", 0)
+	if err != nil {
+		t.Fatalf("GetLinkedCommits failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits mentioning bd-1, got %+v", commits)
+	}
+	// Newest first
+	if commits[0].Subject != "bd-1: add regression test" || commits[1].Subject != "Fix login redirect (bd-1)" {
+		t.Errorf("unexpected subjects: %q, %q", commits[0].Subject, commits[1].Subject)
+	}
+	if len(commits[0].Hash) != 40 || commits[0].ShortHash() != commits[0].Hash[:7] {
+		t.Errorf("unexpected hash %q", commits[0].Hash)
+	}
+	if commits[0].Author != "Test User" || commits[0].Date.IsZero() {
+		t.Errorf("unexpected author/date: %q %v", commits[0].Author, commits[0].Date)
+	}
+
+	commits, _ = GetLinkedCommits(ctx, dir, "bd-10", 0)
+	if len(commits) != 1 {
+		t.Errorf("expected 1 commit mentioning bd-10, got %+v", commits)
+	}
+
+	// A limit keeps only the newest
+	commits, _ = GetLinkedCommits(ctx, dir, "bd-1", 1)
+	if len(commits) != 1 || commits[0].Subject != "bd-1: add regression test" {
+		t.Errorf("expected only the newest commit with limit 1, got %+v", commits)
+	}
+}
+
+func TestGetLinkedCommitsOutsideGitRepo(t *testing.T) {
+	// Stop git from finding a repository above the temp dir
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	commits, err := GetLinkedCommits(context.Background(), dir, "bd-1", 0)
+	if err != nil {
+		t.Fatalf("expected no error outside a git repo, got %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("expected no commits outside a git repo, got %v", commits)
+	}
+}
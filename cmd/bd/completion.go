@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// completionLimit caps the number of suggestions so completion stays fast in large databases
+const completionLimit = 50
+
+// isCompletionCommand reports whether cmd is one of cobra's shell completion commands,
+// which skip normal database and daemon initialization
+func isCompletionCommand(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+		return true
+	}
+	return cmd.Parent() != nil && cmd.Parent().Name() == "completion"
+}
+
+// issueIDCompletions returns "ID\tTitle" suggestions for issues whose ID starts with
+// toComplete, skipping IDs in exclude. With closedOnly, only closed issues are suggested;
+// otherwise closed issues are left out.
+func issueIDCompletions(ctx context.Context, s storage.Storage, toComplete string, exclude []string, closedOnly bool) ([]string, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+
+	var matches []*types.Issue
+	for _, issue := range issues {
+		if skip[issue.ID] || !strings.HasPrefix(issue.ID, toComplete) {
+			continue
+		}
+		if (issue.Status == types.StatusClosed) != closedOnly {
+			continue
+		}
+		matches = append(matches, issue)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ID < matches[j].ID
+	})
+	if len(matches) > completionLimit {
+		matches = matches[:completionLimit]
+	}
+
+	completions := make([]string, len(matches))
+	for i, issue := range matches {
+		completions[i] = issue.ID + "\t" + issue.Title
+	}
+	return completions, nil
+}
+
+// labelCompletions returns labels in use that start with toComplete, most used first
+func labelCompletions(ctx context.Context, s storage.Storage, toComplete string) ([]string, error) {
+	counts, err := s.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*types.LabelCount
+	for _, lc := range counts {
+		if strings.HasPrefix(lc.Label, toComplete) {
+			matches = append(matches, lc)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Count > matches[j].Count
+	})
+	if len(matches) > completionLimit {
+		matches = matches[:completionLimit]
+	}
+
+	completions := make([]string, len(matches))
+	for i, lc := range matches {
+		completions[i] = lc.Label
+	}
+	return completions, nil
+}
+
+// withCompletionStore opens the database directly for a completion request.
+// Completion must never print errors or prompt, so failures just yield no suggestions.
+// Against a remote daemon there is no local database to suggest from.
+func withCompletionStore(fn func(ctx context.Context, s storage.Storage) ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	if os.Getenv(daemonAddrEnv) != "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	path := dbPath
+	if path == "" {
+		path = beads.FindDatabasePath()
+	}
+	if path == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	s, err := sqlite.New(path)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer func() { _ = s.Close() }()
+
+	completions, err := fn(context.Background(), s)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeIssueIDs completes IDs of unclosed issues
+func completeIssueIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withCompletionStore(func(ctx context.Context, s storage.Storage) ([]string, error) {
+		return issueIDCompletions(ctx, s, toComplete, args, false)
+	})
+}
+
+// completeSingleIssueID completes the issue ID of commands that take one ID first
+func completeSingleIssueID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeIssueIDs(cmd, args, toComplete)
+}
+
+// completeClosedIssueIDs completes IDs of closed issues (for reopen)
+func completeClosedIssueIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withCompletionStore(func(ctx context.Context, s storage.Storage) ([]string, error) {
+		return issueIDCompletions(ctx, s, toComplete, args, true)
+	})
+}
+
+// completeIssueIDsAndLabels completes issue IDs and labels (for label add/remove)
+func completeIssueIDsAndLabels(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withCompletionStore(func(ctx context.Context, s storage.Storage) ([]string, error) {
+		ids, err := issueIDCompletions(ctx, s, toComplete, args, false)
+		if err != nil {
+			return nil, err
+		}
+		labels, err := labelCompletions(ctx, s, toComplete)
+		if err != nil {
+			return nil, err
+		}
+		return append(ids, labels...), nil
+	})
+}
+
+// completeLabels completes labels in use (for --label style flags)
+func completeLabels(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withCompletionStore(func(ctx context.Context, s storage.Storage) ([]string, error) {
+		return labelCompletions(ctx, s, toComplete)
+	})
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{
+		showCmd, updateCmd, closeCmd, deleteCmd, archiveCmd, depAddCmd, depRemoveCmd,
+	} {
+		cmd.ValidArgsFunction = completeIssueIDs
+	}
+	for _, cmd := range []*cobra.Command{
+		editCmd, logCmd, cloneCmd, splitCmd, depTreeCmd, commentsCmd, commentsAddCmd,
+	} {
+		cmd.ValidArgsFunction = completeSingleIssueID
+	}
+	reopenCmd.ValidArgsFunction = completeClosedIssueIDs
+	labelAddCmd.ValidArgsFunction = completeIssueIDsAndLabels
+	labelRemoveCmd.ValidArgsFunction = completeIssueIDsAndLabels
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueIDCompletions(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i := 0; i < completionLimit+10; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := testStore.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := testStore.CloseIssue(ctx, "bd-1", "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	all, err := issueIDCompletions(ctx, testStore, "", nil, false)
+	if err != nil {
+		t.Fatalf("issueIDCompletions failed: %v", err)
+	}
+	if len(all) != completionLimit {
+		t.Errorf("Expected results capped at %d, got %d", completionLimit, len(all))
+	}
+
+	prefixed, err := issueIDCompletions(ctx, testStore, "bd-1", []string{"bd-10"}, false)
+	if err != nil {
+		t.Fatalf("issueIDCompletions failed: %v", err)
+	}
+	// bd-11..bd-19 (bd-1 is closed, bd-10 already given)
+	if len(prefixed) != 9 || prefixed[0] != "bd-11\tIssue 10" {
+		t.Errorf("Unexpected prefixed completions: %v", prefixed)
+	}
+
+	closed, err := issueIDCompletions(ctx, testStore, "", nil, true)
+	if err != nil {
+		t.Fatalf("issueIDCompletions failed: %v", err)
+	}
+	if len(closed) != 1 || closed[0] != "bd-1\tIssue 0" {
+		t.Errorf("Expected only closed bd-1, got %v", closed)
+	}
+}
+
+func TestLabelCompletions(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i, labels := range [][]string{{"backend", "bug"}, {"backend"}, {"frontend"}} {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := testStore.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := testStore.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+	}
+
+	got, err := labelCompletions(ctx, testStore, "b")
+	if err != nil {
+		t.Fatalf("labelCompletions failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != "backend" || got[1] != "bug" {
+		t.Errorf("Expected [backend bug], got %v", got)
+	}
+
+	got, err = labelCompletions(ctx, testStore, "")
+	if err != nil {
+		t.Fatalf("labelCompletions failed: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected 3 labels, got %v", got)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
@@ -58,11 +57,13 @@ var createCmd = &cobra.Command{
 		priority, _ := cmd.Flags().GetInt("priority")
 		issueType, _ := cmd.Flags().GetString("type")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		assignee = types.ResolveMe(assignee, actor)
 		labels, _ := cmd.Flags().GetStringSlice("labels")
 		explicitID, _ := cmd.Flags().GetString("id")
 		externalRef, _ := cmd.Flags().GetString("external-ref")
 		deps, _ := cmd.Flags().GetStringSlice("deps")
 		forceCreate, _ := cmd.Flags().GetBool("force")
+		dueStr, _ := cmd.Flags().GetString("due")
 
 		// Validate explicit ID format if provided (prefix-number)
 		if explicitID != "" {
@@ -108,6 +109,12 @@ var createCmd = &cobra.Command{
 			externalRefPtr = &externalRef
 		}
 
+		dueDate, err := parseDueDate(dueStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			createArgs := &rpc.CreateArgs{
@@ -121,6 +128,7 @@ var createCmd = &cobra.Command{
 				Assignee:           assignee,
 				Labels:             labels,
 				Dependencies:       deps,
+				DueDate:            dueDate,
 			}
 
 			resp, err := daemonClient.Create(createArgs)
@@ -137,8 +145,7 @@ var createCmd = &cobra.Command{
 					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 					os.Exit(1)
 				}
-				green := color.New(color.FgGreen).SprintFunc()
-				fmt.Printf("%s Created issue: %s\n", green("✓"), issue.ID)
+				fmt.Printf("%s Created issue: %s\n", renderSuccess("✓"), issue.ID)
 				fmt.Printf("  Title: %s\n", issue.Title)
 				fmt.Printf("  Priority: P%d\n", issue.Priority)
 				fmt.Printf("  Status: %s\n", issue.Status)
@@ -158,6 +165,7 @@ var createCmd = &cobra.Command{
 			IssueType:          types.IssueType(issueType),
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
+			DueDate:            dueDate,
 		}
 
 		ctx := context.Background()
@@ -201,7 +209,7 @@ var createCmd = &cobra.Command{
 
 			// Validate dependency type
 			if !depType.IsValid() {
-				fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' (valid: blocks, related, parent-child, discovered-from)\n", depType)
+				fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' (valid: blocks, related, parent-child, discovered-from, duplicate-of)\n", depType)
 				continue
 			}
 
@@ -222,8 +230,7 @@ var createCmd = &cobra.Command{
 		if jsonOutput {
 			outputJSON(issue)
 		} else {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("%s Created issue: %s\n", green("✓"), issue.ID)
+			fmt.Printf("%s Created issue: %s\n", renderSuccess("✓"), issue.ID)
 			fmt.Printf("  Title: %s\n", issue.Title)
 			fmt.Printf("  Priority: P%d\n", issue.Priority)
 			fmt.Printf("  Status: %s\n", issue.Status)
@@ -239,10 +246,11 @@ func init() {
 	createCmd.Flags().String("acceptance", "", "Acceptance criteria")
 	createCmd.Flags().IntP("priority", "p", 2, "Priority (0-4, 0=highest)")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
-	createCmd.Flags().StringP("assignee", "a", "", "Assignee")
+	createCmd.Flags().StringP("assignee", "a", "", "Assignee (@me for yourself)")
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'bd-42' for partitioning)")
 	createCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	createCmd.Flags().String("due", "", "Due date (YYYY-MM-DD)")
 	createCmd.Flags().StringSlice("deps", []string{}, "Dependencies in format 'type:id' or 'id' (e.g., 'discovered-from:bd-20,blocks:bd-15' or 'bd-20')")
 	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
 	rootCmd.AddCommand(createCmd)
@@ -4,8 +4,12 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -17,10 +21,14 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/logging"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+	"golang.org/x/mod/semver"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -39,7 +47,14 @@ The daemon will:
 
 Use --stop to stop a running daemon.
 Use --status to check if daemon is running.
-Use --health to check daemon health and metrics.`,
+Use --health to check daemon health and metrics.
+Use --metrics-addr to serve metrics over HTTP in the Prometheus text format.
+Use --tcp-addr to let remote clients connect; they must send the token set in
+BEADS_DAEMON_TOKEN and point BEADS_DAEMON_ADDR at the daemon. The token grants
+every operation, including export, import, and shutdown, so outside a loopback
+address (e.g. behind an SSH tunnel) the daemon only serves TLS: pass --tls-cert
+and --tls-key, and have clients trust the certificate via BEADS_DAEMON_TLS_CA
+if it isn't signed by a system root.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		stop, _ := cmd.Flags().GetBool("stop")
 		status, _ := cmd.Flags().GetBool("status")
@@ -50,6 +65,10 @@ Use --health to check daemon health and metrics.`,
 		autoCommit, _ := cmd.Flags().GetBool("auto-commit")
 		autoPush, _ := cmd.Flags().GetBool("auto-push")
 		logFile, _ := cmd.Flags().GetString("log")
+		metricsAddr, _ := cmd.Flags().GetString("metrics-addr")
+		tcpAddr, _ := cmd.Flags().GetString("tcp-addr")
+		tlsCert, _ := cmd.Flags().GetString("tls-cert")
+		tlsKey, _ := cmd.Flags().GetString("tls-key")
 		global, _ := cmd.Flags().GetBool("global")
 
 		if interval <= 0 {
@@ -129,6 +148,33 @@ Use --health to check daemon health and metrics.`,
 			fmt.Fprintf(os.Stderr, "      Use local daemon (without --global) for auto-commit/auto-push features\n")
 			os.Exit(1)
 		}
+		if global && metricsAddr != "" {
+			fmt.Fprintf(os.Stderr, "Error: --metrics-addr is not supported with --global\n")
+			os.Exit(1)
+		}
+		if tcpAddr != "" {
+			if global {
+				fmt.Fprintf(os.Stderr, "Error: --tcp-addr is not supported with --global\n")
+				os.Exit(1)
+			}
+			if os.Getenv(daemonTokenEnv) == "" {
+				fmt.Fprintf(os.Stderr, "Error: --tcp-addr requires an auth token in %s\n", daemonTokenEnv)
+				os.Exit(1)
+			}
+			tlsConfig, err := loadDaemonServerTLS(tlsCert, tlsKey)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if tlsConfig == nil && !rpc.IsLoopbackAddr(tcpAddr) {
+				fmt.Fprintf(os.Stderr, "Error: --tcp-addr %s is not a loopback address; the auth token would be sent in cleartext\n", tcpAddr)
+				fmt.Fprintf(os.Stderr, "Hint: pass --tls-cert and --tls-key, or listen on 127.0.0.1 behind an SSH tunnel\n")
+				os.Exit(1)
+			}
+		} else if tlsCert != "" || tlsKey != "" {
+			fmt.Fprintf(os.Stderr, "Error: --tls-cert and --tls-key require --tcp-addr\n")
+			os.Exit(1)
+		}
 
 		// Validate we're in a git repo (skip for global daemon)
 		if !global && !isGitRepo() {
@@ -168,7 +214,7 @@ Use --health to check daemon health and metrics.`,
 			fmt.Printf("Logging to: %s\n", logFile)
 		}
 
-		startDaemon(interval, autoCommit, autoPush, logFile, pidFile, global)
+		startDaemon(interval, autoCommit, autoPush, logFile, metricsAddr, tcpAddr, tlsCert, tlsKey, pidFile, global)
 	},
 }
 
@@ -182,6 +228,10 @@ func init() {
 	daemonCmd.Flags().Bool("metrics", false, "Show detailed daemon metrics")
 	daemonCmd.Flags().Bool("migrate-to-global", false, "Migrate from local to global daemon")
 	daemonCmd.Flags().String("log", "", "Log file path (default: .beads/daemon.log)")
+	daemonCmd.Flags().String("metrics-addr", "", "Serve Prometheus metrics at http://ADDR/metrics (e.g. 127.0.0.1:9464)")
+	daemonCmd.Flags().String("tcp-addr", "", "Also accept RPC over TCP at ADDR (requires "+daemonTokenEnv+"; the token grants full access, so non-loopback ADDRs need --tls-cert/--tls-key)")
+	daemonCmd.Flags().String("tls-cert", "", "PEM certificate to serve --tcp-addr over TLS")
+	daemonCmd.Flags().String("tls-key", "", "PEM private key for --tls-cert")
 	daemonCmd.Flags().Bool("global", false, "Run as global daemon (socket at ~/.beads/bd.sock)")
 	rootCmd.AddCommand(daemonCmd)
 }
@@ -609,7 +659,7 @@ func stopDaemon(pidFile string) {
 	fmt.Println("Daemon killed")
 }
 
-func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pidFile string, global bool) {
+func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, metricsAddr, tcpAddr, tlsCert, tlsKey, pidFile string, global bool) {
 	logPath, err := getLogFilePath(logFile, global)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -617,7 +667,7 @@ func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pid
 	}
 
 	if os.Getenv("BD_DAEMON_FOREGROUND") == "1" {
-		runDaemonLoop(interval, autoCommit, autoPush, logPath, pidFile, global)
+		runDaemonLoop(interval, autoCommit, autoPush, logPath, metricsAddr, tcpAddr, tlsCert, tlsKey, pidFile, global)
 		return
 	}
 
@@ -639,6 +689,15 @@ func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pid
 	if logFile != "" {
 		args = append(args, "--log", logFile)
 	}
+	if metricsAddr != "" {
+		args = append(args, "--metrics-addr", metricsAddr)
+	}
+	if tcpAddr != "" {
+		args = append(args, "--tcp-addr", tcpAddr)
+	}
+	if tlsCert != "" {
+		args = append(args, "--tls-cert", tlsCert, "--tls-key", tlsKey)
+	}
 	if global {
 		args = append(args, "--global")
 	}
@@ -687,7 +746,7 @@ func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pid
 // exportToJSONLWithStore exports issues to JSONL using the provided store
 func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -757,6 +816,7 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 
 	// Write JSONL
 	for _, issue := range issues {
+		issue.SortForExport()
 		data, marshalErr := json.Marshal(issue)
 		if marshalErr != nil {
 			writeErr = fmt.Errorf("failed to marshal issue %s: %w", issue.ID, marshalErr)
@@ -773,16 +833,24 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 	}
 
 	// Close before rename
+	if writeErr = utils.SyncFile(tempFile); writeErr != nil {
+		writeErr = fmt.Errorf("failed to sync temp file: %w", writeErr)
+		return writeErr
+	}
 	if writeErr = tempFile.Close(); writeErr != nil {
 		writeErr = fmt.Errorf("failed to close temp file: %w", writeErr)
 		return writeErr
 	}
 
 	// Atomic rename
-	if writeErr = os.Rename(tempPath, jsonlPath); writeErr != nil {
+	if writeErr = utils.RenameWithRetry(tempPath, jsonlPath); writeErr != nil {
 		writeErr = fmt.Errorf("failed to rename temp file: %w", writeErr)
 		return writeErr
 	}
+	if writeErr = utils.SyncDir(filepath.Dir(jsonlPath)); writeErr != nil {
+		writeErr = fmt.Errorf("failed to sync directory: %w", writeErr)
+		return writeErr
+	}
 
 	return nil
 }
@@ -840,12 +908,33 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 	return err
 }
 
+// daemonLogger writes the daemon's leveled log. Messages are printf-style;
+// log writes at info level.
 type daemonLogger struct {
-	logFunc func(string, ...interface{})
+	logger *slog.Logger
+}
+
+func (d *daemonLogger) emit(level slog.Level, format string, args ...interface{}) {
+	if !d.logger.Enabled(context.Background(), level) {
+		return
+	}
+	d.logger.Log(context.Background(), level, fmt.Sprintf(format, args...))
+}
+
+func (d *daemonLogger) debug(format string, args ...interface{}) {
+	d.emit(slog.LevelDebug, format, args...)
 }
 
 func (d *daemonLogger) log(format string, args ...interface{}) {
-	d.logFunc(format, args...)
+	d.emit(slog.LevelInfo, format, args...)
+}
+
+func (d *daemonLogger) warn(format string, args ...interface{}) {
+	d.emit(slog.LevelWarn, format, args...)
+}
+
+func (d *daemonLogger) error(format string, args ...interface{}) {
+	d.emit(slog.LevelError, format, args...)
 }
 
 // validateDatabaseFingerprint checks that the database belongs to this repository
@@ -882,7 +971,7 @@ silent corruption when databases are copied between repositories.
 	// Validate repo ID matches current repository
 	currentRepoID, err := beads.ComputeRepoID()
 	if err != nil {
-		log.log("Warning: could not compute current repository ID: %v", err)
+		log.warn("could not compute current repository ID: %v", err)
 		return nil
 	}
 
@@ -913,6 +1002,47 @@ Solutions:
 	return nil
 }
 
+// dbVersionMismatchError reports a database last written by a newer bd
+type dbVersionMismatchError struct {
+	dbVersion string
+}
+
+func (e *dbVersionMismatchError) Error() string {
+	return fmt.Sprintf("database version %s is newer than this daemon (%s); refusing to start", e.dbVersion, Version)
+}
+
+// checkDaemonDBVersion compares the database's bd_version with this binary.
+// A database from an older bd was already migrated when the store opened, so
+// its version is brought up to date. A database from a newer bd may use
+// formats this daemon doesn't understand, so that is an error unless
+// BEADS_IGNORE_VERSION_MISMATCH=1.
+func checkDaemonDBVersion(ctx context.Context, store storage.Storage, log daemonLogger) error {
+	dbVersion, err := store.GetMetadata(ctx, "bd_version")
+	if err != nil && err.Error() != "metadata key not found: bd_version" {
+		return fmt.Errorf("failed to read database version: %w", err)
+	}
+
+	switch {
+	case dbVersion == Version:
+		return nil
+	case dbVersion == "":
+		log.warn("Database missing version metadata, setting to %s", Version)
+	case semver.Compare("v"+dbVersion, "v"+Version) > 0:
+		if os.Getenv("BEADS_IGNORE_VERSION_MISMATCH") != "1" {
+			return &dbVersionMismatchError{dbVersion: dbVersion}
+		}
+		log.warn("Proceeding despite version mismatch (database %s, daemon %s; BEADS_IGNORE_VERSION_MISMATCH=1)", dbVersion, Version)
+		return nil
+	default:
+		log.log("Upgraded database from version %s to %s", dbVersion, Version)
+	}
+
+	if err := store.SetMetadata(ctx, "bd_version", Version); err != nil {
+		return fmt.Errorf("failed to set database version: %w", err)
+	}
+	return nil
+}
+
 func setupDaemonLogger(logPath string) (*lumberjack.Logger, daemonLogger) {
 	maxSizeMB := getEnvInt("BEADS_DAEMON_LOG_MAX_SIZE", 10)
 	maxBackups := getEnvInt("BEADS_DAEMON_LOG_MAX_BACKUPS", 3)
@@ -927,13 +1057,10 @@ func setupDaemonLogger(logPath string) (*lumberjack.Logger, daemonLogger) {
 		Compress:   compress,
 	}
 
-	logger := daemonLogger{
-		logFunc: func(format string, args ...interface{}) {
-			msg := fmt.Sprintf(format, args...)
-			timestamp := time.Now().Format("2006-01-02 15:04:05")
-			_, _ = fmt.Fprintf(logF, "[%s] %s\n", timestamp, msg)
-		},
-	}
+	// Route RPC server and storage warnings into the daemon log as well
+	slogger := logging.New(logF, logging.LevelFromEnv())
+	logging.SetDefault(slogger)
+	logger := daemonLogger{logger: slogger}
 
 	return logF, logger
 }
@@ -945,7 +1072,7 @@ func setupDaemonLock(pidFile string, dbPath string, log daemonLogger) (io.Closer
 		if err == ErrDaemonLocked {
 			log.log("Daemon already running (lock held), exiting")
 		} else {
-			log.log("Error acquiring daemon lock: %v", err)
+			log.error("Error acquiring daemon lock: %v", err)
 		}
 		return nil, err
 	}
@@ -977,19 +1104,19 @@ func startRPCServer(ctx context.Context, socketPath string, store storage.Storag
 	go func() {
 		log.log("Starting RPC server: %s", socketPath)
 		if err := server.Start(ctx); err != nil {
-			log.log("RPC server error: %v", err)
+			log.error("RPC server error: %v", err)
 			serverErrChan <- err
 		}
 	}()
 
 	select {
 	case err := <-serverErrChan:
-		log.log("RPC server failed to start: %v", err)
+		log.error("RPC server failed to start: %v", err)
 		return nil, nil, err
 	case <-server.WaitReady():
 		log.log("RPC server ready (socket listening)")
 	case <-time.After(5 * time.Second):
-		log.log("WARNING: Server didn't signal ready after 5 seconds (may still be starting)")
+		log.warn("Server didn't signal ready after 5 seconds (may still be starting)")
 	}
 
 	return server, serverErrChan, nil
@@ -998,7 +1125,7 @@ func startRPCServer(ctx context.Context, socketPath string, store storage.Storag
 func runGlobalDaemon(log daemonLogger) {
 	globalDir, err := getGlobalBeadsDir()
 	if err != nil {
-		log.log("Error: cannot get global beads directory: %v", err)
+		log.error("cannot get global beads directory: %v", err)
 		os.Exit(1)
 	}
 	socketPath := filepath.Join(globalDir, "bd.sock")
@@ -1021,7 +1148,7 @@ func runGlobalDaemon(log daemonLogger) {
 
 	cancel()
 	if err := server.Stop(); err != nil {
-		log.log("Error stopping server: %v", err)
+		log.error("Error stopping server: %v", err)
 	}
 
 	log.log("Global daemon stopped")
@@ -1036,7 +1163,7 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 
 		jsonlPath := findJSONLPath()
 		if jsonlPath == "" {
-			log.log("Error: JSONL path not found")
+			log.error("JSONL path not found")
 			return
 		}
 
@@ -1045,7 +1172,7 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 		skip, holder, err := types.ShouldSkipDatabase(beadsDir)
 		if skip {
 			if err != nil {
-				log.log("Skipping database (lock check failed: %v)", err)
+				log.warn("Skipping database (lock check failed: %v)", err)
 			} else {
 				log.log("Skipping database (locked by %s)", holder)
 			}
@@ -1057,25 +1184,25 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 
 		// Integrity check: validate before export
 		if err := validatePreExport(syncCtx, store, jsonlPath); err != nil {
-			log.log("Pre-export validation failed: %v", err)
+			log.error("Pre-export validation failed: %v", err)
 			return
 		}
 
 		// Check for duplicate IDs (database corruption)
 		if err := checkDuplicateIDs(syncCtx, store); err != nil {
-			log.log("Duplicate ID check failed: %v", err)
+			log.error("Duplicate ID check failed: %v", err)
 			return
 		}
 
 		// Check for orphaned dependencies (warns but doesn't fail)
 		if orphaned, err := checkOrphanedDeps(syncCtx, store); err != nil {
-			log.log("Orphaned dependency check failed: %v", err)
+			log.warn("Orphaned dependency check failed: %v", err)
 		} else if len(orphaned) > 0 {
-			log.log("Found %d orphaned dependencies: %v", len(orphaned), orphaned)
+			log.warn("Found %d orphaned dependencies: %v", len(orphaned), orphaned)
 		}
 
 		if err := exportToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
-			log.log("Export failed: %v", err)
+			log.error("Export failed: %v", err)
 			return
 		}
 		log.log("Exported to JSONL")
@@ -1083,14 +1210,14 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 		if autoCommit {
 			hasChanges, err := gitHasChanges(syncCtx, jsonlPath)
 			if err != nil {
-				log.log("Error checking git status: %v", err)
+				log.error("Error checking git status: %v", err)
 				return
 			}
 
 			if hasChanges {
 				message := fmt.Sprintf("bd daemon sync: %s", time.Now().Format("2006-01-02 15:04:05"))
 				if err := gitCommit(syncCtx, jsonlPath, message); err != nil {
-					log.log("Commit failed: %v", err)
+					log.error("Commit failed: %v", err)
 					return
 				}
 				log.log("Committed changes")
@@ -1098,7 +1225,7 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 		}
 
 		if err := gitPull(syncCtx); err != nil {
-		log.log("Pull failed: %v", err)
+		log.error("Pull failed: %v", err)
 		return
 		}
 		log.log("Pulled from remote")
@@ -1106,12 +1233,12 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 		// Count issues before import for validation
 	beforeCount, err := countDBIssues(syncCtx, store)
 	if err != nil {
-		log.log("Failed to count issues before import: %v", err)
+		log.error("Failed to count issues before import: %v", err)
 		return
 	}
 
 	if err := importToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
-		log.log("Import failed: %v", err)
+		log.error("Import failed: %v", err)
 		return
 	}
 	log.log("Imported from JSONL")
@@ -1119,18 +1246,18 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 	// Validate import didn't cause data loss
 	afterCount, err := countDBIssues(syncCtx, store)
 	if err != nil {
-		log.log("Failed to count issues after import: %v", err)
+		log.error("Failed to count issues after import: %v", err)
 		return
 	}
 
 	if err := validatePostImport(beforeCount, afterCount); err != nil {
-		log.log("Post-import validation failed: %v", err)
+		log.error("Post-import validation failed: %v", err)
 		return
 	}
 
 		if autoPush && autoCommit {
 			if err := gitPush(syncCtx); err != nil {
-				log.log("Push failed: %v", err)
+				log.error("Push failed: %v", err)
 				return
 			}
 			log.log("Pushed to remote")
@@ -1140,7 +1267,48 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 	}
 }
 
-func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.Ticker, doSync func(), server *rpc.Server, serverErrChan chan error, log daemonLogger) {
+// createFlushFunc returns a function that exports the database to JSONL.
+// It is driven by the auto-flush debouncer and by 'bd flush', so it skips the
+// git and validation work done by the periodic sync cycle. It uses its own
+// context because the final flush runs during shutdown, after ctx is canceled.
+func createFlushFunc(store storage.Storage, log daemonLogger) func() error {
+	return func() error {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer flushCancel()
+
+		jsonlPath := findJSONLPath()
+		if jsonlPath == "" {
+			log.error("JSONL path not found")
+			return fmt.Errorf("JSONL path not found")
+		}
+
+		if skip, holder, _ := types.ShouldSkipDatabase(filepath.Dir(jsonlPath)); skip {
+			log.log("Skipping flush (locked by %s)", holder)
+			return fmt.Errorf("database is locked by %s", holder)
+		}
+
+		if err := exportToJSONLWithStore(flushCtx, store, jsonlPath); err != nil {
+			log.error("Flush failed: %v", err)
+			return err
+		}
+		log.log("Flushed to JSONL")
+		return nil
+	}
+}
+
+// stopServerWithFlush writes any debounced changes before stopping the RPC
+// server, since stopping the server also closes the store.
+func stopServerWithFlush(server *rpc.Server, flusher *Debouncer, log daemonLogger) {
+	if flusher != nil && flusher.Pending() {
+		log.log("Flushing pending changes before shutdown")
+		_ = flusher.Flush()
+	}
+	if err := server.Stop(); err != nil {
+		log.error("Error stopping RPC server: %v", err)
+	}
+}
+
+func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.Ticker, doSync func(), server *rpc.Server, serverErrChan chan error, flusher *Debouncer, log daemonLogger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, daemonSignals...)
 	defer signal.Stop(sigChan)
@@ -1159,28 +1327,22 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 			}
 			log.log("Received signal %v, shutting down gracefully...", sig)
 			cancel()
-			if err := server.Stop(); err != nil {
-				log.log("Error stopping RPC server: %v", err)
-			}
+			stopServerWithFlush(server, flusher, log)
 			return
 		case <-ctx.Done():
 			log.log("Context canceled, shutting down")
-			if err := server.Stop(); err != nil {
-				log.log("Error stopping RPC server: %v", err)
-			}
+			stopServerWithFlush(server, flusher, log)
 			return
 		case err := <-serverErrChan:
-			log.log("RPC server failed: %v", err)
+			log.error("RPC server failed: %v", err)
 			cancel()
-			if err := server.Stop(); err != nil {
-				log.log("Error stopping RPC server: %v", err)
-			}
+			stopServerWithFlush(server, flusher, log)
 			return
 		}
 	}
 }
 
-func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, pidFile string, global bool) {
+func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, metricsAddr, tcpAddr, tlsCert, tlsKey, pidFile string, global bool) {
 	logF, log := setupDaemonLogger(logPath)
 	defer func() { _ = logF.Close() }()
 
@@ -1192,7 +1354,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 			if foundDB := beads.FindDatabasePath(); foundDB != "" {
 				daemonDBPath = foundDB
 			} else {
-				log.log("Error: no beads database found")
+				log.error("no beads database found")
 				log.log("Hint: run 'bd init' to create a database or set BEADS_DB environment variable")
 				os.Exit(1)
 			}
@@ -1225,7 +1387,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 			}
 		}
 		if len(validDBs) > 1 {
-			log.log("Error: Multiple database files found in %s:", beadsDir)
+			log.error("Multiple database files found in %s:", beadsDir)
 			for _, db := range validDBs {
 				log.log("  - %s", filepath.Base(db))
 			}
@@ -1239,7 +1401,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	// Validate using canonical name
 	dbBaseName := filepath.Base(daemonDBPath)
 	if dbBaseName != beads.CanonicalDatabaseName {
-		log.log("Error: Non-canonical database name: %s", dbBaseName)
+		log.error("Non-canonical database name: %s", dbBaseName)
 		log.log("Expected: %s", beads.CanonicalDatabaseName)
 		log.log("")
 		log.log("Run 'bd init' to migrate to canonical name")
@@ -1250,7 +1412,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 
 	store, err := sqlite.New(daemonDBPath)
 	if err != nil {
-		log.log("Error: cannot open database: %v", err)
+		log.error("cannot open database: %v", err)
 		os.Exit(1)
 	}
 	defer func() { _ = store.Close() }()
@@ -1259,46 +1421,20 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	// Validate database fingerprint
 	if err := validateDatabaseFingerprint(store, &log); err != nil {
 		if os.Getenv("BEADS_IGNORE_REPO_MISMATCH") != "1" {
-			log.log("Error: %v", err)
+			log.error("%v", err)
 			os.Exit(1)
 		}
-		log.log("Warning: repository mismatch ignored (BEADS_IGNORE_REPO_MISMATCH=1)")
+		log.warn("repository mismatch ignored (BEADS_IGNORE_REPO_MISMATCH=1)")
 	}
 
 	// Validate schema version matches daemon version
-	versionCtx := context.Background()
-	dbVersion, err := store.GetMetadata(versionCtx, "bd_version")
-	if err != nil && err.Error() != "metadata key not found: bd_version" {
-		log.log("Error: failed to read database version: %v", err)
-		os.Exit(1)
-	}
-	
-	if dbVersion != "" && dbVersion != Version {
-		log.log("Error: Database schema version mismatch")
-		log.log("  Database version: %s", dbVersion)
-		log.log("  Daemon version: %s", Version)
-		log.log("")
-		log.log("The database was created with a different version of bd.")
-		log.log("This may cause compatibility issues.")
-		log.log("")
-		log.log("Options:")
-		log.log("  1. Run 'bd migrate' to update the database to the current version")
-		log.log("  2. Upgrade/downgrade bd to match database version: %s", dbVersion)
-		log.log("  3. Set BEADS_IGNORE_VERSION_MISMATCH=1 to proceed anyway (not recommended)")
-		log.log("")
-		
-		// Allow override via environment variable for emergencies
-		if os.Getenv("BEADS_IGNORE_VERSION_MISMATCH") != "1" {
-			os.Exit(1)
-		}
-		log.log("Warning: Proceeding despite version mismatch (BEADS_IGNORE_VERSION_MISMATCH=1)")
-	} else if dbVersion == "" {
-		// Old database without version metadata - set it now
-		log.log("Warning: Database missing version metadata, setting to %s", Version)
-		if err := store.SetMetadata(versionCtx, "bd_version", Version); err != nil {
-			log.log("Error: failed to set database version: %v", err)
-			os.Exit(1)
+	if err := checkDaemonDBVersion(context.Background(), store, log); err != nil {
+		log.error("%v", err)
+		var mismatch *dbVersionMismatchError
+		if errors.As(err, &mismatch) {
+			log.log("Upgrade bd to %s or later, or set BEADS_IGNORE_VERSION_MISMATCH=1 to proceed anyway (not recommended)", mismatch.dbVersion)
 		}
+		os.Exit(1)
 	}
 
 	// Get workspace path (.beads directory) - beadsDir already defined above
@@ -1319,5 +1455,66 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	doSync := createSyncFunc(ctx, store, autoCommit, autoPush, log)
 	doSync()
 
-	runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, log)
+	// Debounce JSONL exports after mutations so the file tracks the database
+	// between sync cycles without rewriting it on every request. 'bd flush'
+	// goes through the same debouncer so it also cancels any pending run.
+	exportJSONL := createFlushFunc(store, log)
+	flush := func() error {
+		err := exportJSONL()
+		server.Metrics().RecordFlush(err)
+		return err
+	}
+	var flusher *Debouncer
+	if config.GetBool("no-auto-flush") {
+		log.log("Auto-flush disabled (no-auto-flush)")
+		server.SetFlushHook(flush)
+	} else {
+		debounce := getDebounceDuration()
+		flusher = NewDebouncer(debounce, flush)
+		server.SetMutationHook(flusher.Trigger)
+		server.SetFlushHook(flusher.Flush)
+		log.log("Auto-flush enabled (debounce: %v)", debounce)
+	}
+
+	if tcpAddr != "" {
+		tlsConfig, err := loadDaemonServerTLS(tlsCert, tlsKey)
+		if err == nil {
+			var addr net.Addr
+			if addr, err = server.ServeTCP(tcpAddr, os.Getenv(daemonTokenEnv), tlsConfig); err == nil {
+				log.log("Accepting authenticated RPC connections on %s (TLS: %v)", addr, tlsConfig != nil)
+			}
+		}
+		if err != nil {
+			log.error("TCP listener disabled: %v", err)
+		}
+	}
+
+	if metricsAddr != "" {
+		metricsServer, err := startMetricsServer(metricsAddr, server, log)
+		if err != nil {
+			log.warn("metrics endpoint disabled: %v", err)
+		} else {
+			defer func() { _ = metricsServer.Close() }()
+		}
+	}
+
+	runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, flusher, log)
+}
+
+// startMetricsServer serves the RPC server's metrics at http://addr/metrics
+func startMetricsServer(addr string, server *rpc.Server, log daemonLogger) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", server.MetricsHandler())
+	httpServer := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.error("Metrics server error: %v", err)
+		}
+	}()
+	log.log("Metrics endpoint listening on http://%s/metrics", ln.Addr())
+	return httpServer, nil
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+const (
+	// daemonTokenEnv holds the auth token for RPC over TCP, on both the
+	// daemon (bd daemon --tcp-addr) and remote clients
+	daemonTokenEnv = "BEADS_DAEMON_TOKEN"
+	// daemonAddrEnv points the CLI at a remote daemon instead of a local database
+	daemonAddrEnv = "BEADS_DAEMON_ADDR"
+	// daemonTLSCAEnv names a PEM file of certificates the CLI trusts for a
+	// daemon serving TLS, e.g. its self-signed certificate
+	daemonTLSCAEnv = "BEADS_DAEMON_TLS_CA"
+)
+
+// loadDaemonServerTLS loads the certificate the daemon serves TCP with. It
+// returns nil, meaning plain TCP, when neither file is given.
+func loadDaemonServerTLS(certFile, keyFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// daemonClientTLS picks how to secure a connection to the daemon at addr: TLS
+// trusting the certificates in BEADS_DAEMON_TLS_CA if it is set, TLS with the
+// system roots for non-loopback addresses (the daemon won't serve those in
+// cleartext), and plain TCP otherwise.
+func daemonClientTLS(addr string) (*tls.Config, error) {
+	caFile := os.Getenv(daemonTLSCAEnv)
+	if caFile == "" {
+		if rpc.IsLoopbackAddr(addr) {
+			return nil, nil
+		}
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+
+	// #nosec G304 - the CA file is chosen by the user
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", daemonTLSCAEnv, err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s (%s)", caFile, daemonTLSCAEnv)
+	}
+	return &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}, nil
+}
+
+// remoteDaemon is set once the CLI is connected to a daemon over TCP. There is
+// no local database then, so direct-storage fallbacks must fail (see openLocalStore).
+var remoteDaemon bool
+
+// connectRemoteDaemon connects to the daemon at addr over TCP. There is no
+// local database in this mode, so there is nothing to fall back to.
+func connectRemoteDaemon(addr string) error {
+	token := os.Getenv(daemonTokenEnv)
+	if token == "" {
+		return fmt.Errorf("%s is set but %s is not", daemonAddrEnv, daemonTokenEnv)
+	}
+
+	tlsConfig, err := daemonClientTLS(addr)
+	if err != nil {
+		return err
+	}
+	client, err := rpc.ConnectTCP(addr, token, 5*time.Second, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	daemonClient = client
+	remoteDaemon = true
+	daemonStatus = DaemonStatus{
+		Mode:           cmdDaemon,
+		Connected:      true,
+		SocketPath:     addr,
+		FallbackReason: FallbackNone,
+		Health:         statusHealthy,
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
@@ -10,9 +12,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/logging"
+	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -266,6 +271,83 @@ func TestDaemonLogFileCreation(t *testing.T) {
 	}
 }
 
+func TestCheckDaemonDBVersion(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	log := daemonLogger{logger: logging.New(testLogWriter{t}, slog.LevelDebug)}
+
+	storedVersion := func() string {
+		v, err := store.GetMetadata(ctx, "bd_version")
+		if err != nil {
+			t.Fatalf("GetMetadata failed: %v", err)
+		}
+		return v
+	}
+
+	t.Run("newer database refuses to start", func(t *testing.T) {
+		if err := store.SetMetadata(ctx, "bd_version", "99.0.0"); err != nil {
+			t.Fatal(err)
+		}
+		err := checkDaemonDBVersion(ctx, store, log)
+		if err == nil {
+			t.Fatal("expected an error for a database from a newer bd")
+		}
+		if !strings.Contains(err.Error(), "99.0.0") || !strings.Contains(err.Error(), Version) {
+			t.Errorf("error should name both versions: %v", err)
+		}
+		if got := storedVersion(); got != "99.0.0" {
+			t.Errorf("bd_version changed to %q", got)
+		}
+	})
+
+	t.Run("override allows a newer database", func(t *testing.T) {
+		t.Setenv("BEADS_IGNORE_VERSION_MISMATCH", "1")
+		if err := checkDaemonDBVersion(ctx, store, log); err != nil {
+			t.Errorf("unexpected error with override: %v", err)
+		}
+	})
+
+	t.Run("older database is upgraded", func(t *testing.T) {
+		if err := store.SetMetadata(ctx, "bd_version", "0.1.0"); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkDaemonDBVersion(ctx, store, log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := storedVersion(); got != Version {
+			t.Errorf("bd_version = %q, want %q", got, Version)
+		}
+	})
+}
+
+func TestDaemonLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := daemonLogger{logger: logging.New(&buf, slog.LevelInfo)}
+
+	log.debug("probing %s", "socket")
+	log.log("Sync cycle complete")
+	log.warn("Skipping database (locked by %s)", "pid 42")
+	log.error("Export failed: %v", "disk full")
+
+	out := buf.String()
+	if strings.Contains(out, "probing socket") {
+		t.Errorf("debug message logged at info level:\n%s", out)
+	}
+	for _, want := range []string{
+		`level=INFO msg="Sync cycle complete"`,
+		`level=WARN msg="Skipping database (locked by pid 42)"`,
+		`level=ERROR msg="Export failed: disk full"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
 func TestDaemonIntervalParsing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -658,3 +740,152 @@ func (s *mockDaemonServer) Start(ctx context.Context) error {
 		conn.Close()
 	}
 }
+
+// testLogWriter sends daemon log records to the test log
+type testLogWriter struct{ t *testing.T }
+
+func (w testLogWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+// startAutoFlushTestDaemon starts an RPC server wired for auto-flush the way
+// runDaemonLoop does, counting flushes. It returns a connected client.
+func startAutoFlushTestDaemon(t *testing.T, debounce time.Duration) (client *rpc.Client, flusher *Debouncer, jsonlPath string, flushes *int32) {
+	t.Helper()
+
+	tmpDir := makeSocketTempDir(t)
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("Failed to create beads dir: %v", err)
+	}
+	testDBPath := filepath.Join(beadsDir, "test.db")
+	socketPath := filepath.Join(beadsDir, "bd.sock")
+	jsonlPath = filepath.Join(beadsDir, "issues.jsonl")
+
+	testStore, err := sqlite.New(testDBPath)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	ctx := context.Background()
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set issue_prefix: %v", err)
+	}
+
+	oldDBPath := dbPath
+	dbPath = testDBPath
+	t.Cleanup(func() { dbPath = oldDBPath })
+
+	log := daemonLogger{logger: logging.New(testLogWriter{t}, slog.LevelDebug)}
+
+	serverCtx, cancel := context.WithCancel(ctx)
+	server, _, err := startRPCServer(serverCtx, socketPath, testStore, tmpDir, testDBPath, log)
+	if err != nil {
+		cancel()
+		t.Fatalf("Failed to start RPC server: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		server.Stop()
+	})
+
+	flushes = new(int32)
+	flush := createFlushFunc(testStore, log)
+	flusher = NewDebouncer(debounce, func() error {
+		atomic.AddInt32(flushes, 1)
+		return flush()
+	})
+	server.SetMutationHook(flusher.Trigger)
+	server.SetFlushHook(flusher.Flush)
+
+	client, err = rpc.TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	client.SetDatabasePath(testDBPath)
+
+	return client, flusher, jsonlPath, flushes
+}
+
+func TestDaemonAutoFlushDebounced(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, flusher, jsonlPath, flushes := startAutoFlushTestDaemon(t, 200*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		args := &rpc.CreateArgs{Title: fmt.Sprintf("Issue %d", i), IssueType: "task", Priority: 2}
+		if _, err := client.Create(args); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(jsonlPath); err == nil {
+		t.Fatal("JSONL should not be written before the debounce interval")
+	}
+	if !flusher.Pending() {
+		t.Fatal("expected a pending flush after mutations")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for flusher.Pending() && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := atomic.LoadInt32(flushes); got != 1 {
+		t.Fatalf("expected a single debounced flush, got %d", got)
+	}
+	count, err := countIssuesInJSONL(jsonlPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSONL: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 issues in JSONL, got %d", count)
+	}
+}
+
+func TestFlushCommandWritesJSONLImmediately(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	// Debounce long enough that only an explicit flush can write the JSONL
+	client, flusher, jsonlPath, flushes := startAutoFlushTestDaemon(t, time.Hour)
+
+	oldClient := daemonClient
+	daemonClient = client
+	defer func() { daemonClient = oldClient }()
+
+	for i := 0; i < 2; i++ {
+		args := &rpc.CreateArgs{Title: fmt.Sprintf("Issue %d", i), IssueType: "task", Priority: 2}
+		if _, err := client.Create(args); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if !flusher.Pending() {
+		t.Fatal("expected a pending flush after mutations")
+	}
+
+	if err := flushNow(context.Background(), jsonlPath); err != nil {
+		t.Fatalf("flushNow failed: %v", err)
+	}
+
+	count, err := countIssuesInJSONL(jsonlPath)
+	if err != nil {
+		t.Fatalf("JSONL not written by flush: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 issues in JSONL right after flush, got %d", count)
+	}
+	if flusher.Pending() {
+		t.Error("expected flush to cancel the pending debounced run")
+	}
+	if got := atomic.LoadInt32(flushes); got != 1 {
+		t.Errorf("expected exactly one flush, got %d", got)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces bursts of Trigger calls into a single run of its action.
+// The action fires once no Trigger has been seen for the configured duration.
+// If maxDelay is non-zero, the action also fires no later than maxDelay after
+// the first Trigger of a burst, so a steady stream of triggers cannot postpone
+// it forever.
+//
+// Errors returned by the action are only surfaced through Flush; when the
+// timer fires the action is expected to report its own failures.
+//
+// Thread-safe: all timer state is protected by mu. The action itself is run
+// outside mu (so it may call Trigger) but is serialized by runMu, so a timer
+// firing and an explicit Flush never run the action concurrently.
+type Debouncer struct {
+	mu         sync.Mutex
+	runMu      sync.Mutex
+	timer      *time.Timer
+	seq        uint64 // bumped on every reschedule/cancel so stale timers are ignored
+	duration   time.Duration
+	maxDelay   time.Duration // 0 means no cap
+	burstStart time.Time     // first Trigger since the last run/cancel
+	action     func() error
+}
+
+// NewDebouncer creates a Debouncer that runs action after duration of quiet.
+func NewDebouncer(duration time.Duration, action func() error) *Debouncer {
+	return NewDebouncerWithMaxDelay(duration, 0, action)
+}
+
+// NewDebouncerWithMaxDelay creates a Debouncer that runs action after duration
+// of quiet, or at most maxDelay after the first Trigger of a burst.
+// A zero maxDelay behaves like NewDebouncer.
+func NewDebouncerWithMaxDelay(duration, maxDelay time.Duration, action func() error) *Debouncer {
+	return &Debouncer{
+		duration: duration,
+		maxDelay: maxDelay,
+		action:   action,
+	}
+}
+
+// Trigger (re)starts the quiet period. The action runs once the period
+// elapses without another Trigger, Flush, or Cancel, or once maxDelay has
+// passed since the burst began, whichever comes first.
+func (d *Debouncer) Trigger() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if d.timer == nil {
+		d.burstStart = now
+	}
+
+	wait := d.duration
+	if d.maxDelay > 0 {
+		if remaining := d.maxDelay - now.Sub(d.burstStart); remaining < wait {
+			wait = remaining
+		}
+		if wait < 0 {
+			wait = 0
+		}
+	}
+
+	d.stopLocked()
+	seq := d.seq
+	d.timer = time.AfterFunc(wait, func() {
+		d.fire(seq)
+	})
+}
+
+// Flush cancels any pending timer and runs the action immediately, returning
+// its error once it has completed. Used on shutdown and before commits so a
+// debounced write is never lost.
+func (d *Debouncer) Flush() error {
+	d.mu.Lock()
+	d.stopLocked()
+	d.mu.Unlock()
+
+	return d.run()
+}
+
+// Cancel discards any pending run without invoking the action.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopLocked()
+}
+
+// Pending reports whether a debounced run is scheduled but has not fired yet.
+func (d *Debouncer) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.timer != nil
+}
+
+// stopLocked stops the current timer and invalidates its callback. Caller must hold mu.
+func (d *Debouncer) stopLocked() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.seq++
+}
+
+// fire is the timer callback. It only runs the action if no Trigger, Flush,
+// or Cancel happened since the timer identified by seq was scheduled.
+func (d *Debouncer) fire(seq uint64) {
+	d.mu.Lock()
+	if seq != d.seq {
+		d.mu.Unlock()
+		return
+	}
+	d.timer = nil
+	d.mu.Unlock()
+
+	_ = d.run()
+}
+
+func (d *Debouncer) run() error {
+	d.runMu.Lock()
+	defer d.runMu.Unlock()
+	return d.action()
+}
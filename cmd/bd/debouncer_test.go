@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebouncerCoalescesTriggers(t *testing.T) {
+	var calls int32
+	d := NewDebouncer(30*time.Millisecond, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		d.Trigger()
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 call, got %d", got)
+	}
+}
+
+func TestDebouncerFlushFiresImmediately(t *testing.T) {
+	var calls int32
+	d := NewDebouncer(time.Hour, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	d.Trigger()
+	if err := d.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected Flush to run action once, got %d", got)
+	}
+	if d.Pending() {
+		t.Error("expected no pending run after Flush")
+	}
+}
+
+func TestDebouncerFlushCancelsTimer(t *testing.T) {
+	var calls int32
+	d := NewDebouncer(20*time.Millisecond, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	d.Trigger()
+	if err := d.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected timer to be cancelled by Flush, got %d calls", got)
+	}
+}
+
+func TestDebouncerPending(t *testing.T) {
+	fired := make(chan struct{}, 1)
+	d := NewDebouncer(20*time.Millisecond, func() error {
+		fired <- struct{}{}
+		return nil
+	})
+
+	if d.Pending() {
+		t.Fatal("expected no pending run before Trigger")
+	}
+
+	d.Trigger()
+	if !d.Pending() {
+		t.Fatal("expected pending run after Trigger")
+	}
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("action did not fire")
+	}
+	if d.Pending() {
+		t.Error("expected no pending run after action fired")
+	}
+
+	d.Trigger()
+	d.Cancel()
+	if d.Pending() {
+		t.Error("expected no pending run after Cancel")
+	}
+}
+
+func TestDebouncerMaxDelayBoundsStarvation(t *testing.T) {
+	var calls int32
+	d := NewDebouncerWithMaxDelay(40*time.Millisecond, 100*time.Millisecond, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	// Trigger faster than the debounce for well past maxDelay
+	deadline := time.Now().Add(250 * time.Millisecond)
+	firstFire := time.Duration(0)
+	start := time.Now()
+	for time.Now().Before(deadline) {
+		d.Trigger()
+		if firstFire == 0 && atomic.LoadInt32(&calls) > 0 {
+			firstFire = time.Since(start)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.Cancel()
+
+	if firstFire == 0 {
+		t.Fatal("expected action to fire despite continuous triggers")
+	}
+	if firstFire > 180*time.Millisecond {
+		t.Errorf("expected first fire near max delay (100ms), got %v", firstFire)
+	}
+}
+
+func TestDebouncerZeroMaxDelayKeepsDebouncing(t *testing.T) {
+	var calls int32
+	d := NewDebouncerWithMaxDelay(40*time.Millisecond, 0, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	deadline := time.Now().Add(150 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		d.Trigger()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Errorf("expected no fire while triggers keep arriving, got %d", got)
+	}
+	d.Cancel()
+}
+
+func TestDebouncerFlushReturnsActionError(t *testing.T) {
+	want := errors.New("export failed")
+	d := NewDebouncer(time.Hour, func() error {
+		return want
+	})
+
+	d.Trigger()
+	if err := d.Flush(); !errors.Is(err, want) {
+		t.Errorf("expected Flush to return action error, got %v", err)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -25,7 +24,8 @@ This command will:
 2. Update text references to "[deleted:ID]" in directly connected issues
 3. Delete the issues from the database
 
-This is a destructive operation that cannot be undone. Use with caution.
+Deleted issues are kept in the trash for trash_retention_days (default 30) and
+can be restored with 'bd undo-delete'. Text references are not restored.
 
 BATCH DELETION:
 
@@ -150,10 +150,7 @@ Force: Delete and orphan dependents
 
 		// Preview mode
 		if !force {
-			red := color.New(color.FgRed).SprintFunc()
-			yellow := color.New(color.FgYellow).SprintFunc()
-
-			fmt.Printf("\n%s\n", red("⚠️  DELETE PREVIEW"))
+			fmt.Printf("\n%s\n", renderError("⚠️  DELETE PREVIEW"))
 			fmt.Printf("\nIssue to delete:\n")
 			fmt.Printf("  %s: %s\n", issueID, issue.Title)
 
@@ -188,8 +185,8 @@ Force: Delete and orphan dependents
 				}
 			}
 
-			fmt.Printf("\n%s\n", yellow("This operation cannot be undone!"))
-			fmt.Printf("To proceed, run: %s\n\n", yellow("bd delete "+issueID+" --force"))
+			fmt.Printf("\n%s\n", renderWarning("Deleted issues can be restored from the trash with 'bd undo-delete'"))
+			fmt.Printf("To proceed, run: %s\n\n", renderWarning("bd delete "+issueID+" --force"))
 			return
 		}
 
@@ -233,35 +230,14 @@ Force: Delete and orphan dependents
 			}
 		}
 
-		// 2. Remove all dependency links (outgoing)
-		outgoingRemoved := 0
-		for _, dep := range depRecords {
-			if err := store.RemoveDependency(ctx, dep.IssueID, dep.DependsOnID, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to remove dependency %s → %s: %v\n",
-					dep.IssueID, dep.DependsOnID, err)
-			} else {
-				outgoingRemoved++
-			}
-		}
-
-		// 3. Remove inbound dependency links (issues that depend on this one)
-		inboundRemoved := 0
-		for _, dep := range dependents {
-			if err := store.RemoveDependency(ctx, dep.ID, issueID, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to remove dependency %s → %s: %v\n",
-					dep.ID, issueID, err)
-			} else {
-				inboundRemoved++
-			}
-		}
-
-		// 4. Delete the issue itself from database
+		// 2. Delete the issue itself from database, along with its dependency links
+		// in both directions (a snapshot is kept in the trash for 'bd undo-delete')
 		if err := deleteIssue(ctx, issueID); err != nil {
 			fmt.Fprintf(os.Stderr, "Error deleting issue: %v\n", err)
 			os.Exit(1)
 		}
 
-		// 5. Remove from JSONL (auto-flush can't see deletions)
+		// 3. Remove from JSONL (auto-flush can't see deletions)
 		if err := removeIssueFromJSONL(issueID); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to remove from JSONL: %v\n", err)
 		}
@@ -269,7 +245,7 @@ Force: Delete and orphan dependents
 		// Schedule auto-flush to update neighbors
 		markDirtyAndScheduleFlush()
 
-		totalDepsRemoved := outgoingRemoved + inboundRemoved
+		totalDepsRemoved := len(depRecords) + len(dependents)
 		if jsonOutput {
 			outputJSON(map[string]interface{}{
 				"deleted":              issueID,
@@ -277,8 +253,7 @@ Force: Delete and orphan dependents
 				"references_updated":   updatedIssueCount,
 			})
 		} else {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("%s Deleted %s\n", green("✓"), issueID)
+			fmt.Printf("%s Deleted %s\n", renderSuccess("✓"), issueID)
 			fmt.Printf("  Removed %d dependency link(s)\n", totalDepsRemoved)
 			fmt.Printf("  Updated text references in %d issue(s)\n", updatedIssueCount)
 		}
@@ -354,6 +329,7 @@ func removeIssueFromJSONL(issueID string) error {
 
 	enc := json.NewEncoder(out)
 	for _, iss := range issues {
+		iss.SortForExport()
 		if err := enc.Encode(iss); err != nil {
 			_ = out.Close()
 			_ = os.Remove(temp)
@@ -441,14 +417,13 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 		if dryRun {
 			fmt.Printf("\n(Dry-run mode - no changes made)\n")
 		} else {
-			yellow := color.New(color.FgYellow).SprintFunc()
-			fmt.Printf("\n%s\n", yellow("This operation cannot be undone!"))
+			fmt.Printf("\n%s\n", renderWarning("Deleted issues can be restored from the trash with 'bd undo-delete'"))
 			if cascade {
 				fmt.Printf("To proceed with cascade deletion, run: %s\n",
-					yellow("bd delete "+strings.Join(issueIDs, " ")+" --cascade --force"))
+					renderWarning("bd delete "+strings.Join(issueIDs, " ")+" --cascade --force"))
 			} else {
 				fmt.Printf("To proceed, run: %s\n",
-					yellow("bd delete "+strings.Join(issueIDs, " ")+" --force"))
+					renderWarning("bd delete "+strings.Join(issueIDs, " ")+" --force"))
 			}
 		}
 		return
@@ -515,26 +490,21 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 			"orphaned_issues":      result.OrphanedIssues,
 		})
 	} else {
-		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("%s Deleted %d issue(s)\n", green("✓"), result.DeletedCount)
+		fmt.Printf("%s Deleted %d issue(s)\n", renderSuccess("✓"), result.DeletedCount)
 		fmt.Printf("  Removed %d dependency link(s)\n", result.DependenciesCount)
 		fmt.Printf("  Removed %d label(s)\n", result.LabelsCount)
 		fmt.Printf("  Removed %d event(s)\n", result.EventsCount)
 		fmt.Printf("  Updated text references in %d issue(s)\n", updatedCount)
 		if len(result.OrphanedIssues) > 0 {
-			yellow := color.New(color.FgYellow).SprintFunc()
 			fmt.Printf("  %s Orphaned %d issue(s): %s\n",
-				yellow("⚠"), len(result.OrphanedIssues), strings.Join(result.OrphanedIssues, ", "))
+				renderWarning("⚠"), len(result.OrphanedIssues), strings.Join(result.OrphanedIssues, ", "))
 		}
 	}
 }
 
 // showDeletionPreview shows what would be deleted
 func showDeletionPreview(issueIDs []string, issues map[string]*types.Issue, cascade bool, depError error) {
-	red := color.New(color.FgRed).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-
-	fmt.Printf("\n%s\n", red("⚠️  DELETE PREVIEW"))
+	fmt.Printf("\n%s\n", renderError("⚠️  DELETE PREVIEW"))
 	fmt.Printf("\nIssues to delete (%d):\n", len(issueIDs))
 	for _, id := range issueIDs {
 		if issue := issues[id]; issue != nil {
@@ -543,11 +513,11 @@ func showDeletionPreview(issueIDs []string, issues map[string]*types.Issue, casc
 	}
 
 	if cascade {
-		fmt.Printf("\n%s Cascade mode enabled - will also delete all dependent issues\n", yellow("⚠"))
+		fmt.Printf("\n%s Cascade mode enabled - will also delete all dependent issues\n", renderWarning("⚠"))
 	}
 
 	if depError != nil {
-		fmt.Printf("\n%s\n", red(depError.Error()))
+		fmt.Printf("\n%s\n", renderError(depError.Error()))
 	}
 }
 
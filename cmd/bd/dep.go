@@ -3,19 +3,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 var depCmd = &cobra.Command{
-	Use:   "dep",
-	Short: "Manage dependencies",
+	Use:     "dep",
+	Aliases: []string{"deps"},
+	Short:   "Manage dependencies",
 }
 
 var depAddCmd = &cobra.Command{
@@ -24,6 +25,7 @@ var depAddCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		depType, _ := cmd.Flags().GetString("type")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 		// If daemon is running, use RPC
 		if daemonClient != nil {
@@ -31,6 +33,7 @@ var depAddCmd = &cobra.Command{
 				FromID:  args[0],
 				ToID:    args[1],
 				DepType: depType,
+				DryRun:  dryRun,
 			}
 
 			resp, err := daemonClient.AddDependency(depArgs)
@@ -39,14 +42,23 @@ var depAddCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
+			if dryRun {
+				var preview storage.DryRun
+				if err := json.Unmarshal(resp.Data, &preview); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				outputDepAddPreview(args[0], args[1], depType, &preview)
+				return
+			}
+
 			if jsonOutput {
 				fmt.Println(string(resp.Data))
 				return
 			}
 
-			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
-				green("✓"), args[0], args[1], depType)
+				renderSuccess("✓"), args[0], args[1], depType)
 			return
 		}
 
@@ -58,6 +70,15 @@ var depAddCmd = &cobra.Command{
 		}
 
 		ctx := context.Background()
+		if dryRun {
+			previewCtx, preview := storage.WithDryRun(ctx)
+			if err := store.AddDependency(previewCtx, dep, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			outputDepAddPreview(args[0], args[1], depType, preview)
+			return
+		}
 		if err := store.AddDependency(ctx, dep, actor); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -71,8 +92,7 @@ var depAddCmd = &cobra.Command{
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to check for cycles: %v\n", err)
 		} else if len(cycles) > 0 {
-			yellow := color.New(color.FgYellow).SprintFunc()
-			fmt.Fprintf(os.Stderr, "\n%s Warning: Dependency cycle detected!\n", yellow("⚠"))
+			fmt.Fprintf(os.Stderr, "\n%s Warning: Dependency cycle detected!\n", renderWarning("⚠"))
 			fmt.Fprintf(os.Stderr, "This can hide issues from the ready work list and cause confusion.\n\n")
 			fmt.Fprintf(os.Stderr, "Cycle path:\n")
 			for _, cycle := range cycles {
@@ -101,12 +121,29 @@ var depAddCmd = &cobra.Command{
 			return
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
-			green("✓"), args[0], args[1], depType)
+			renderSuccess("✓"), args[0], args[1], depType)
 	},
 }
 
+// outputDepAddPreview reports what dep add --dry-run found
+func outputDepAddPreview(issueID, dependsOnID, depType string, preview *storage.DryRun) {
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"issue_id":      issueID,
+			"depends_on_id": dependsOnID,
+			"type":          depType,
+			"cycle":         preview.Cycle,
+		})
+		return
+	}
+	if preview.Cycle {
+		fmt.Printf("%s Adding %s → %s would create a dependency cycle\n", renderWarning("⚠"), issueID, dependsOnID)
+		return
+	}
+	fmt.Printf("Would add dependency: %s depends on %s (%s)\n", issueID, dependsOnID, depType)
+}
+
 var depRemoveCmd = &cobra.Command{
 	Use:   "remove [issue-id] [depends-on-id]",
 	Short: "Remove a dependency",
@@ -130,9 +167,8 @@ var depRemoveCmd = &cobra.Command{
 				return
 			}
 
-			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Removed dependency: %s no longer depends on %s\n",
-				green("✓"), args[0], args[1])
+				renderSuccess("✓"), args[0], args[1])
 			return
 		}
 
@@ -155,9 +191,8 @@ var depRemoveCmd = &cobra.Command{
 			return
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("%s Removed dependency: %s no longer depends on %s\n",
-			green("✓"), args[0], args[1])
+			renderSuccess("✓"), args[0], args[1])
 	},
 }
 
@@ -169,7 +204,7 @@ var depTreeCmd = &cobra.Command{
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openLocalStore(dbPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 				os.Exit(1)
@@ -211,11 +246,10 @@ var depTreeCmd = &cobra.Command{
 			return
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
 		if reverse {
-			fmt.Printf("\n%s Dependent tree for %s:\n\n", cyan("🌲"), args[0])
+			fmt.Printf("\n%s Dependent tree for %s:\n\n", renderAccent("🌲"), args[0])
 		} else {
-			fmt.Printf("\n%s Dependency tree for %s:\n\n", cyan("🌲"), args[0])
+			fmt.Printf("\n%s Dependency tree for %s:\n\n", renderAccent("🌲"), args[0])
 		}
 
 		hasTruncation := false
@@ -234,9 +268,8 @@ var depTreeCmd = &cobra.Command{
 		}
 
 		if hasTruncation {
-			yellow := color.New(color.FgYellow).SprintFunc()
 			fmt.Printf("\n%s Warning: Tree truncated at depth %d (safety limit)\n",
-				yellow("⚠"), maxDepth)
+				renderWarning("⚠"), maxDepth)
 		}
 		fmt.Println()
 	},
@@ -249,7 +282,7 @@ var depCyclesCmd = &cobra.Command{
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openLocalStore(dbPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 				os.Exit(1)
@@ -274,13 +307,11 @@ var depCyclesCmd = &cobra.Command{
 		}
 
 		if len(cycles) == 0 {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("\n%s No dependency cycles detected\n\n", green("✓"))
+			fmt.Printf("\n%s No dependency cycles detected\n\n", renderSuccess("✓"))
 			return
 		}
 
-		red := color.New(color.FgRed).SprintFunc()
-		fmt.Printf("\n%s Found %d dependency cycles:\n\n", red("⚠"), len(cycles))
+		fmt.Printf("\n%s Found %d dependency cycles:\n\n", renderError("⚠"), len(cycles))
 		for i, cycle := range cycles {
 			fmt.Printf("%d. Cycle involving:\n", i+1)
 			for _, issue := range cycle {
@@ -291,8 +322,83 @@ var depCyclesCmd = &cobra.Command{
 	},
 }
 
+var depCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Find dependencies that point at missing issues",
+	Long: `Find dependencies whose target issue no longer exists.
+
+Dangling dependencies are skipped by 'bd dep tree' and ready work calculations,
+which can hide problems. Use --fix to remove them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		dangling, err := store.GetDanglingDependencies(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		removed := 0
+		if fix {
+			for _, dep := range dangling {
+				if err := store.RemoveDependency(ctx, dep.IssueID, dep.DependsOnID, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error removing %s → %s: %v\n", dep.IssueID, dep.DependsOnID, err)
+					os.Exit(1)
+				}
+				removed++
+			}
+			if removed > 0 {
+				markDirtyAndScheduleFlush()
+			}
+		}
+
+		if jsonOutput {
+			// Always output array, even if empty
+			if dangling == nil {
+				dangling = []*types.DanglingDependency{}
+			}
+			outputJSON(map[string]interface{}{
+				"dangling": dangling,
+				"removed":  removed,
+			})
+			return
+		}
+
+		if len(dangling) == 0 {
+			fmt.Printf("\n%s No dangling dependencies found\n\n", renderSuccess("✓"))
+			return
+		}
+
+		fmt.Printf("\n%s Found %d dangling dependencies:\n\n", renderError("⚠"), len(dangling))
+		for _, dep := range dangling {
+			fmt.Printf("  %s → %s (%s): target does not exist\n", dep.IssueID, dep.DependsOnID, dep.Type)
+		}
+		fmt.Println()
+
+		if fix {
+			fmt.Printf("%s Removed %d dangling dependencies\n\n", renderSuccess("✓"), removed)
+		} else {
+			fmt.Printf("Run 'bd dep check --fix' to remove them.\n\n")
+		}
+	},
+}
+
 func init() {
-	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from)")
+	depCheckCmd.Flags().Bool("fix", false, "Remove dangling dependencies")
+	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from|duplicate-of)")
+	depAddCmd.Flags().Bool("dry-run", false, "Check the dependency, including for cycles, without adding it")
 	depTreeCmd.Flags().Bool("show-all-paths", false, "Show all paths to nodes (no deduplication for diamond dependencies)")
 	depTreeCmd.Flags().IntP("max-depth", "d", 50, "Maximum tree depth to display (safety limit)")
 	depTreeCmd.Flags().Bool("reverse", false, "Show dependent tree (what was discovered from this) instead of dependency tree (what blocks this)")
@@ -300,5 +406,6 @@ func init() {
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depTreeCmd)
 	depCmd.AddCommand(depCyclesCmd)
+	depCmd.AddCommand(depCheckCmd)
 	rootCmd.AddCommand(depCmd)
 }
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fieldChange is a single field that differs between two versions of an issue
+type fieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// modifiedIssue is an issue present in both exports with at least one changed field
+type modifiedIssue struct {
+	ID      string        `json:"id"`
+	Title   string        `json:"title"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// jsonlDiff is the categorized difference between two JSONL exports
+type jsonlDiff struct {
+	Added    []*types.Issue   `json:"added"`
+	Removed  []*types.Issue   `json:"removed"`
+	Modified []*modifiedIssue `json:"modified"`
+}
+
+// diffFields lists the fields compared between versions, in display order
+var diffFields = []string{
+	"title", "status", "priority", "issue_type", "assignee",
+	"description", "design", "acceptance_criteria", "notes", "external_ref",
+}
+
+// issueFieldValue returns the comparable value of a field, in the same form
+// as the updates map the importer builds
+func issueFieldValue(issue *types.Issue, field string) interface{} {
+	switch field {
+	case "title":
+		return issue.Title
+	case "status":
+		return issue.Status
+	case "priority":
+		return issue.Priority
+	case "issue_type":
+		return issue.IssueType
+	case "assignee":
+		return issue.Assignee
+	case "description":
+		return issue.Description
+	case "design":
+		return issue.Design
+	case "acceptance_criteria":
+		return issue.AcceptanceCriteria
+	case "notes":
+		return issue.Notes
+	case "external_ref":
+		return issue.ExternalRef
+	}
+	return nil
+}
+
+// diffIssues matches issues by ID and reports added, removed, and modified issues.
+// Field comparisons use the importer's fieldComparator, so a diff reports exactly
+// the changes an import of newIssues over oldIssues would apply. Labels are
+// compared as sets.
+func diffIssues(oldIssues, newIssues []*types.Issue) *jsonlDiff {
+	result := &jsonlDiff{
+		Added:    []*types.Issue{},
+		Removed:  []*types.Issue{},
+		Modified: []*modifiedIssue{},
+	}
+
+	oldByID := make(map[string]*types.Issue, len(oldIssues))
+	for _, issue := range oldIssues {
+		oldByID[issue.ID] = issue
+	}
+	newByID := make(map[string]*types.Issue, len(newIssues))
+	for _, issue := range newIssues {
+		newByID[issue.ID] = issue
+	}
+
+	fc := newFieldComparator()
+	for _, newIssue := range newIssues {
+		oldIssue, ok := oldByID[newIssue.ID]
+		if !ok {
+			result.Added = append(result.Added, newIssue)
+			continue
+		}
+
+		var changes []fieldChange
+		for _, field := range diffFields {
+			newVal := issueFieldValue(newIssue, field)
+			if fc.checkFieldChanged(field, oldIssue, newVal) {
+				changes = append(changes, fieldChange{
+					Field: field,
+					Old:   derefDiffValue(issueFieldValue(oldIssue, field)),
+					New:   derefDiffValue(newVal),
+				})
+			}
+		}
+		if !sameLabels(oldIssue.Labels, newIssue.Labels) {
+			changes = append(changes, fieldChange{Field: "labels", Old: oldIssue.Labels, New: newIssue.Labels})
+		}
+
+		if len(changes) > 0 {
+			result.Modified = append(result.Modified, &modifiedIssue{
+				ID:      newIssue.ID,
+				Title:   newIssue.Title,
+				Changes: changes,
+			})
+		}
+	}
+
+	for _, oldIssue := range oldIssues {
+		if _, ok := newByID[oldIssue.ID]; !ok {
+			result.Removed = append(result.Removed, oldIssue)
+		}
+	}
+
+	sort.Slice(result.Added, func(i, j int) bool { return result.Added[i].ID < result.Added[j].ID })
+	sort.Slice(result.Removed, func(i, j int) bool { return result.Removed[i].ID < result.Removed[j].ID })
+	sort.Slice(result.Modified, func(i, j int) bool { return result.Modified[i].ID < result.Modified[j].ID })
+	return result
+}
+
+// derefDiffValue turns *string values into plain strings for display
+func derefDiffValue(v interface{}) interface{} {
+	if s, ok := v.(*string); ok {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return v
+}
+
+// sameLabels reports whether two label lists contain the same labels, ignoring order
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool, len(a))
+	for _, label := range a {
+		set[label] = true
+	}
+	for _, label := range b {
+		if !set[label] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDiffValue renders a changed value on one line, truncating long text
+func formatDiffValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) > 60 {
+		s = s[:57] + "..."
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.jsonl> <new.jsonl>",
+	Short: "Compare two JSONL exports",
+	Long: `Compare two JSONL exports and report added, removed, and modified issues
+with per-field changes. Useful for reviewing what changed between two commits:
+
+  git show HEAD~5:.beads/issues.jsonl > /tmp/old.jsonl
+  bd diff /tmp/old.jsonl .beads/issues.jsonl`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		oldIssues, err := loadIssuesFromJSONL(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		newIssues, err := loadIssuesFromJSONL(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[1], err)
+			os.Exit(1)
+		}
+
+		result := diffIssues(oldIssues, newIssues)
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+
+		if len(result.Added) == 0 && len(result.Removed) == 0 && len(result.Modified) == 0 {
+			fmt.Println("No differences")
+			return
+		}
+
+		for _, issue := range result.Added {
+			fmt.Printf("%s %s: %s\n", renderSuccess("+"), issue.ID, issue.Title)
+		}
+		for _, issue := range result.Removed {
+			fmt.Printf("%s %s: %s\n", renderError("-"), issue.ID, issue.Title)
+		}
+		for _, mod := range result.Modified {
+			fmt.Printf("%s %s: %s\n", renderWarning("~"), mod.ID, mod.Title)
+			for _, change := range mod.Changes {
+				fmt.Printf("    %s: %s → %s\n", change.Field, formatDiffValue(change.Old), formatDiffValue(change.New))
+			}
+		}
+		fmt.Printf("\n%d added, %d removed, %d modified\n", len(result.Added), len(result.Removed), len(result.Modified))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
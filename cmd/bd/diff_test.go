@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func writeDiffJSONL(t *testing.T, path string, issues []*types.Issue) {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, issue := range issues {
+		data, err := json.Marshal(issue)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestDiffIssues(t *testing.T) {
+	ref := "gh-12"
+	before := []*types.Issue{
+		{ID: "bd-1", Title: "Unchanged", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Labels: []string{"a", "b"}},
+		{ID: "bd-2", Title: "Will change", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, Description: "Old"},
+		{ID: "bd-3", Title: "Will be removed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+	}
+	after := []*types.Issue{
+		{ID: "bd-1", Title: "Unchanged", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Labels: []string{"b", "a"}},
+		{ID: "bd-2", Title: "Will change", Status: types.StatusClosed, Priority: 0, IssueType: types.TypeBug, Description: "Old", ExternalRef: &ref, Labels: []string{"urgent"}},
+		{ID: "bd-4", Title: "Added", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature},
+	}
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.jsonl")
+	newPath := filepath.Join(dir, "new.jsonl")
+	writeDiffJSONL(t, oldPath, before)
+	writeDiffJSONL(t, newPath, after)
+
+	oldIssues, err := loadIssuesFromJSONL(oldPath)
+	if err != nil {
+		t.Fatalf("loadIssuesFromJSONL failed: %v", err)
+	}
+	newIssues, err := loadIssuesFromJSONL(newPath)
+	if err != nil {
+		t.Fatalf("loadIssuesFromJSONL failed: %v", err)
+	}
+
+	result := diffIssues(oldIssues, newIssues)
+
+	if len(result.Added) != 1 || result.Added[0].ID != "bd-4" {
+		t.Errorf("Expected bd-4 added, got %v", result.Added)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].ID != "bd-3" {
+		t.Errorf("Expected bd-3 removed, got %v", result.Removed)
+	}
+	if len(result.Modified) != 1 || result.Modified[0].ID != "bd-2" {
+		t.Fatalf("Expected only bd-2 modified, got %v", result.Modified)
+	}
+
+	changed := make(map[string]fieldChange)
+	for _, change := range result.Modified[0].Changes {
+		changed[change.Field] = change
+	}
+	if len(changed) != 4 {
+		t.Errorf("Expected status, priority, external_ref, and labels to change, got %v", result.Modified[0].Changes)
+	}
+	if c := changed["status"]; c.Old != types.StatusOpen || c.New != types.StatusClosed {
+		t.Errorf("Unexpected status change: %+v", c)
+	}
+	if c := changed["priority"]; c.Old != 2 || c.New != 0 {
+		t.Errorf("Unexpected priority change: %+v", c)
+	}
+	if c := changed["external_ref"]; c.Old != "" || c.New != "gh-12" {
+		t.Errorf("Unexpected external_ref change: %+v", c)
+	}
+	if _, ok := changed["labels"]; !ok {
+		t.Error("Expected labels change")
+	}
+
+	// Identical exports have no differences
+	same := diffIssues(oldIssues, oldIssues)
+	if len(same.Added)+len(same.Removed)+len(same.Modified) != 0 {
+		t.Errorf("Expected no differences, got %+v", same)
+	}
+}
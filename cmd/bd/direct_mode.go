@@ -8,6 +8,39 @@ import (
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
+// errRemoteDaemon is returned instead of opening local storage while the CLI
+// talks to a remote daemon: there is no local database to fall back to, and
+// opening one would quietly read or write the wrong issues.
+var errRemoteDaemon = fmt.Errorf("not supported against a remote daemon (%s is set)", daemonAddrEnv)
+
+// openLocalStore opens the SQLite database at path for commands the daemon
+// can't serve. It fails with errRemoteDaemon when connected to a remote daemon,
+// and while another client holds the daemon's exclusive lock.
+func openLocalStore(path string) (*sqlite.SQLiteStorage, error) {
+	if remoteDaemon {
+		return nil, errRemoteDaemon
+	}
+	if err := checkDaemonExclusiveLock(); err != nil {
+		return nil, err
+	}
+	return sqlite.New(path)
+}
+
+// checkDaemonExclusiveLock refuses direct storage access while someone else
+// holds the daemon's exclusive lock. The daemon only enforces the lock on RPC
+// requests, so a write straight to the database would slip past it. Daemons
+// that can't report their status are assumed to be unlocked.
+func checkDaemonExclusiveLock() error {
+	if daemonClient == nil || daemonClient.HoldsExclusive() {
+		return nil
+	}
+	status, err := daemonClient.Status()
+	if err != nil || !status.ExclusiveLockActive {
+		return nil
+	}
+	return fmt.Errorf("database is locked by %s; direct access is refused until the daemon's exclusive lock is released", status.ExclusiveLockHolder)
+}
+
 // ensureDirectMode makes sure the CLI is operating in direct-storage mode.
 // If the daemon is active, it is cleanly disconnected and the shared store is opened.
 func ensureDirectMode(reason string) error {
@@ -22,6 +55,12 @@ func ensureDirectMode(reason string) error {
 
 // fallbackToDirectMode disables the daemon client and ensures a local store is ready.
 func fallbackToDirectMode(reason string) error {
+	if remoteDaemon {
+		return errRemoteDaemon
+	}
+	if err := checkDaemonExclusiveLock(); err != nil {
+		return err
+	}
 	disableDaemonForFallback(reason)
 	return ensureStoreActive()
 }
@@ -56,6 +95,9 @@ func ensureStoreActive() error {
 	if active {
 		return nil
 	}
+	if remoteDaemon {
+		return errRemoteDaemon
+	}
 
 	if dbPath == "" {
 		if found := beads.FindDatabasePath(); found != "" {
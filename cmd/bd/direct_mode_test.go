@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/rpc"
@@ -144,3 +145,133 @@ func TestFallbackToDirectModeEnablesFlush(t *testing.T) {
 		t.Fatalf("expected JSONL export to contain neighbor issue ID %s", neighbor.ID)
 	}
 }
+
+func TestDirectModeRefusedAgainstRemoteDaemon(t *testing.T) {
+	origDaemonClient := daemonClient
+	origRemote := remoteDaemon
+	origStore := store
+	origStoreActive := storeActive
+	origDBPath := dbPath
+	defer func() {
+		storeMutex.Lock()
+		store = origStore
+		storeActive = origStoreActive
+		storeMutex.Unlock()
+		daemonClient = origDaemonClient
+		remoteDaemon = origRemote
+		dbPath = origDBPath
+	}()
+
+	// A local database exists, but a remote daemon must never fall back to it
+	testDBPath := filepath.Join(t.TempDir(), ".beads", "test.db")
+	_ = newTestStore(t, testDBPath)
+
+	dbPath = ""
+	storeMutex.Lock()
+	store = nil
+	storeActive = false
+	storeMutex.Unlock()
+	daemonClient = &rpc.Client{}
+	remoteDaemon = true
+
+	if err := ensureDirectMode("test fallback"); err != errRemoteDaemon {
+		t.Errorf("ensureDirectMode: expected errRemoteDaemon, got %v", err)
+	}
+	if daemonClient == nil {
+		t.Error("expected the remote daemon client to be kept")
+	}
+	if err := ensureStoreActive(); err != errRemoteDaemon {
+		t.Errorf("ensureStoreActive: expected errRemoteDaemon, got %v", err)
+	}
+	if s, err := openLocalStore(testDBPath); err != errRemoteDaemon {
+		if s != nil {
+			_ = s.Close()
+		}
+		t.Errorf("openLocalStore: expected errRemoteDaemon, got %v", err)
+	}
+	if store != nil {
+		t.Error("expected no local store to be opened")
+	}
+}
+
+func TestDirectModeRefusedWhileDaemonLocked(t *testing.T) {
+	origDaemonClient := daemonClient
+	origStore := store
+	origStoreActive := storeActive
+	origDBPath := dbPath
+	defer func() {
+		storeMutex.Lock()
+		store = origStore
+		storeActive = origStoreActive
+		storeMutex.Unlock()
+		daemonClient = origDaemonClient
+		dbPath = origDBPath
+	}()
+
+	// Keep the socket path short enough for unix sockets
+	tmpDir, err := os.MkdirTemp("", "bd-lock-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	testDBPath := filepath.Join(tmpDir, ".beads", "test.db")
+	socketPath := filepath.Join(tmpDir, "bd.sock")
+	serverStore := newTestStore(t, testDBPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := rpc.NewServer(socketPath, serverStore, tmpDir, testDBPath)
+	go func() { _ = server.Start(ctx) }()
+	<-server.WaitReady()
+	defer func() { _ = server.Stop() }()
+
+	holder, err := rpc.TryConnect(socketPath)
+	if err != nil || holder == nil {
+		t.Fatalf("failed to connect holder: %v", err)
+	}
+	defer holder.Close()
+	client, err := rpc.TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := holder.AcquireExclusive(&rpc.AcquireExclusiveArgs{Holder: "bulk-rename"}); err != nil {
+		t.Fatalf("AcquireExclusive failed: %v", err)
+	}
+
+	dbPath = testDBPath
+	storeMutex.Lock()
+	store = nil
+	storeActive = false
+	storeMutex.Unlock()
+	daemonClient = client
+
+	if err := fallbackToDirectMode("test fallback"); err == nil || !strings.Contains(err.Error(), "locked by bulk-rename") {
+		t.Errorf("fallbackToDirectMode error = %v, want locked by bulk-rename", err)
+	}
+	if daemonClient != client {
+		t.Error("expected the daemon client to be kept")
+	}
+	if s, err := openLocalStore(testDBPath); err == nil {
+		_ = s.Close()
+		t.Error("openLocalStore should fail while the lock is held")
+	}
+
+	// The lock holder itself may still fall back
+	daemonClient = holder
+	s, err := openLocalStore(testDBPath)
+	if err != nil {
+		t.Fatalf("openLocalStore for the holder failed: %v", err)
+	}
+	_ = s.Close()
+
+	if err := holder.ReleaseExclusive(); err != nil {
+		t.Fatalf("ReleaseExclusive failed: %v", err)
+	}
+	daemonClient = client
+	if s, err = openLocalStore(testDBPath); err != nil {
+		t.Fatalf("openLocalStore after release failed: %v", err)
+	}
+	_ = s.Close()
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// dueDateLayout is the date format accepted by --due and --due-before
+const dueDateLayout = "2006-01-02"
+
+// parseDueDate parses a YYYY-MM-DD date (local time) or an RFC3339 timestamp.
+// An empty string returns nil, which clears the due date.
+func parseDueDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.ParseInLocation(dueDateLayout, value, time.Local); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", value)
+	}
+	return &t, nil
+}
+
+var dueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "Show open issues with upcoming or past due dates",
+	Long: `Show unclosed issues due within the next --days days (default 7), including
+overdue ones, soonest first. Use --overdue to show only issues past their due date.
+
+Set due dates with 'bd create --due 2025-01-31' or 'bd update <id> --due 2025-01-31'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		overdueOnly, _ := cmd.Flags().GetBool("overdue")
+		days, _ := cmd.Flags().GetInt("days")
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		var issues []*types.Issue
+		var err error
+		if overdueOnly {
+			issues, err = store.GetOverdueIssues(ctx)
+		} else {
+			issues, err = getIssuesDueWithin(ctx, time.Duration(days)*24*time.Hour)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			// Always output array, even if empty
+			if issues == nil {
+				issues = []*types.Issue{}
+			}
+			outputJSON(issues)
+			return
+		}
+
+		if len(issues) == 0 {
+			if overdueOnly {
+				fmt.Printf("\n%s No overdue issues\n\n", renderSuccess("✨"))
+			} else {
+				fmt.Printf("\n%s Nothing due in the next %d day(s)\n\n", renderSuccess("✨"), days)
+			}
+			return
+		}
+
+		now := time.Now()
+		fmt.Printf("\nDue issues (%d):\n\n", len(issues))
+		for _, issue := range issues {
+			fmt.Printf("[%s] %s: %s\n", renderPriority(issue.Priority), issue.ID, issue.Title)
+			due := issue.DueDate.Format(dueDateLayout)
+			if issue.DueDate.Before(now) {
+				fmt.Printf("  %s\n", renderError(fmt.Sprintf("Due %s (overdue by %s)", due, formatDuration(now.Sub(*issue.DueDate)))))
+			} else {
+				fmt.Printf("  Due %s (in %s)\n", due, formatDuration(issue.DueDate.Sub(now)))
+			}
+		}
+		fmt.Println()
+	},
+}
+
+// getIssuesDueWithin returns unclosed issues due before now+window, soonest first
+func getIssuesDueWithin(ctx context.Context, window time.Duration) ([]*types.Issue, error) {
+	before := time.Now().Add(window)
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{DueBefore: &before})
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*types.Issue
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed {
+			due = append(due, issue)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].DueDate.Before(*due[j].DueDate)
+	})
+	return due, nil
+}
+
+func init() {
+	dueCmd.Flags().Bool("overdue", false, "Show only issues past their due date")
+	dueCmd.Flags().Int("days", 7, "Show issues due within this many days")
+	rootCmd.AddCommand(dueCmd)
+}
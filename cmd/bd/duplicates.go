@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -128,21 +127,17 @@ Example:
 			}
 			outputJSON(output)
 		} else {
-			yellow := color.New(color.FgYellow).SprintFunc()
-			cyan := color.New(color.FgCyan).SprintFunc()
-			green := color.New(color.FgGreen).SprintFunc()
-
-			fmt.Printf("%s Found %d duplicate group(s):\n\n", yellow("🔍"), len(duplicateGroups))
+			fmt.Printf("%s Found %d duplicate group(s):\n\n", renderWarning("🔍"), len(duplicateGroups))
 
 			for i, group := range duplicateGroups {
 				target := chooseMergeTarget(group, refCounts)
-				fmt.Printf("%s Group %d: %s\n", cyan("━━"), i+1, group[0].Title)
+				fmt.Printf("%s Group %d: %s\n", renderAccent("━━"), i+1, group[0].Title)
 
 				for _, issue := range group {
 					refs := refCounts[issue.ID]
 					marker := "  "
 					if issue.ID == target.ID {
-						marker = green("→ ")
+						marker = renderSuccess("→ ")
 					}
 					fmt.Printf("%s%s (%s, P%d, %d references)\n",
 						marker, issue.ID, issue.Status, issue.Priority, refs)
@@ -155,17 +150,17 @@ Example:
 					}
 				}
 				fmt.Printf("  %s bd merge %s --into %s\n\n",
-					cyan("Suggested:"), strings.Join(sources, " "), target.ID)
+					renderAccent("Suggested:"), strings.Join(sources, " "), target.ID)
 			}
 
 			if autoMerge {
 				if dryRun {
-					fmt.Printf("%s Dry run - would execute %d merge(s)\n", yellow("⚠"), len(mergeCommands))
+					fmt.Printf("%s Dry run - would execute %d merge(s)\n", renderWarning("⚠"), len(mergeCommands))
 				} else {
-					fmt.Printf("%s Merged %d group(s)\n", green("✓"), len(mergeCommands))
+					fmt.Printf("%s Merged %d group(s)\n", renderSuccess("✓"), len(mergeCommands))
 				}
 			} else {
-				fmt.Printf("%s Run with --auto-merge to execute all suggested merges\n", cyan("💡"))
+				fmt.Printf("%s Run with --auto-merge to execute all suggested merges\n", renderAccent("💡"))
 			}
 		}
 	},
@@ -3,13 +3,16 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 var epicCmd = &cobra.Command{
@@ -78,11 +81,6 @@ var epicStatusCmd = &cobra.Command{
 			return
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		yellow := color.New(color.FgYellow).SprintFunc()
-		green := color.New(color.FgGreen).SprintFunc()
-		bold := color.New(color.Bold).SprintFunc()
-
 		for _, epicStatus := range epics {
 			epic := epicStatus.Epic
 			percentage := 0
@@ -92,29 +90,191 @@ var epicStatusCmd = &cobra.Command{
 
 			statusIcon := ""
 			if epicStatus.EligibleForClose {
-				statusIcon = green("✓")
+				statusIcon = renderSuccess("✓")
 			} else if percentage > 0 {
-				statusIcon = yellow("○")
+				statusIcon = renderWarning("○")
 			} else {
 				statusIcon = "○"
 			}
 
-			fmt.Printf("%s %s %s\n", statusIcon, cyan(epic.ID), bold(epic.Title))
+			fmt.Printf("%s %s %s\n", statusIcon, renderAccent(epic.ID), renderEmphasis(epic.Title))
 			fmt.Printf("   Progress: %d/%d children closed (%d%%)\n",
 				epicStatus.ClosedChildren, epicStatus.TotalChildren, percentage)
 			if epicStatus.EligibleForClose {
-				fmt.Printf("   %s\n", green("Eligible for closure"))
+				fmt.Printf("   %s\n", renderSuccess("Eligible for closure"))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+var epicShowCmd = &cobra.Command{
+	Use:   "show [epic-id]",
+	Short: "Show progress rollup for an epic",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		progress, err := store.GetEpicProgress(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting epic progress: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(progress); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+				os.Exit(1)
 			}
+			return
+		}
+
+		epic := progress.Epic
+
+		fmt.Printf("\n%s %s [%s]\n\n", renderAccent(epic.ID), renderEmphasis(epic.Title), epic.Status)
+		if epic.IssueType != types.TypeEpic {
+			fmt.Printf("%s %s is a %s, not an epic\n\n", renderWarning("⚠"), epic.ID, epic.IssueType)
+		}
+
+		if progress.TotalChildren == 0 {
+			fmt.Println("No child issues")
 			fmt.Println()
+			return
+		}
+
+		fmt.Printf("  %s %.0f%%\n\n", renderProgressBar(progress.PercentComplete, 30), progress.PercentComplete)
+		fmt.Printf("  Children:    %d total\n", progress.TotalChildren)
+		fmt.Printf("    Closed:      %d\n", progress.ClosedChildren)
+		fmt.Printf("    In progress: %d\n", progress.InProgressChildren)
+		fmt.Printf("    Blocked:     %d\n", progress.BlockedChildren)
+		fmt.Printf("    Open:        %d\n", progress.OpenChildren)
+		if progress.EstimatedMinutes > 0 {
+			fmt.Printf("  Estimates:   %d min total, %d min done, %d min remaining\n",
+				progress.EstimatedMinutes, progress.CompletedMinutes, progress.RemainingMinutes)
 		}
+		fmt.Println()
 	},
 }
 
+var epicEtaCmd = &cobra.Command{
+	Use:   "eta [epic-id]",
+	Short: "Project when an epic will be finished",
+	Long: `Project an epic's completion date from the estimates of its unclosed children
+and a throughput rate in minutes of estimated work finished per day.
+
+Children without an estimate are listed instead of being counted as zero,
+since the projection would otherwise be too optimistic.
+
+  bd epic eta bd-12 --rate 240`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		rate, _ := cmd.Flags().GetFloat64("rate")
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		progress, err := store.GetEpicProgress(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting epic progress: %v\n", err)
+			os.Exit(1)
+		}
+		eta, err := store.ProjectEpicCompletion(ctx, args[0], rate)
+		var unestimated *types.UnestimatedChildrenError
+		if err != nil && !errors.As(err, &unestimated) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			result := map[string]interface{}{
+				"epic_id":              progress.Epic.ID,
+				"rate":                 rate,
+				"remaining_minutes":    progress.RemainingMinutes,
+				"unestimated_children": []string{},
+			}
+			if unestimated != nil {
+				result["unestimated_children"] = unestimated.ChildIDs
+			} else {
+				result["projected_completion"] = eta
+			}
+			outputJSON(result)
+			if unestimated != nil {
+				os.Exit(1)
+			}
+			return
+		}
+
+		epic := progress.Epic
+
+		fmt.Printf("\n%s %s\n\n", renderAccent(epic.ID), renderEmphasis(epic.Title))
+		if unestimated != nil {
+			fmt.Printf("%s Can't project completion: %d unclosed %s without an estimate:\n", renderWarning("⚠"),
+				len(unestimated.ChildIDs), pluralize(len(unestimated.ChildIDs), "child", "children"))
+			for _, id := range unestimated.ChildIDs {
+				fmt.Printf("  - %s\n", id)
+			}
+			fmt.Println()
+			os.Exit(1)
+		}
+
+		fmt.Printf("  Remaining:  %d min across %d unclosed %s\n", progress.RemainingMinutes,
+			progress.TotalChildren-progress.ClosedChildren,
+			pluralize(progress.TotalChildren-progress.ClosedChildren, "child", "children"))
+		fmt.Printf("  Rate:       %g min/day\n", rate)
+		fmt.Printf("  Projected:  %s (%.1f days)\n\n", eta.Format("2006-01-02"), float64(progress.RemainingMinutes)/rate)
+	},
+}
+
+// renderProgressBar draws a fixed-width text progress bar for a 0-100 percentage
+func renderProgressBar(percent float64, width int) string {
+	filled := int(percent * float64(width) / 100)
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return "[" + renderSuccess(strings.Repeat("█", filled)) + strings.Repeat("░", width-filled) + "]"
+}
+
+// epicAutoCloseReason is recorded on the closed event when close-eligible closes an epic
+const epicAutoCloseReason = "all children complete"
+
 var closeEligibleEpicsCmd = &cobra.Command{
 	Use:   "close-eligible",
 	Short: "Close epics where all children are complete",
+	Long: `Close every open epic whose children are all closed.
+
+Epics with no children are never eligible. Each closed epic records a
+'closed' event with the reason "all children complete". Prompts for
+confirmation unless --yes or --json is given.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		autoYes, _ := cmd.Flags().GetBool("yes")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 
 		var eligibleEpics []*types.EpicStatus
@@ -142,11 +302,7 @@ var closeEligibleEpicsCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error getting eligible epics: %v\n", err)
 				os.Exit(1)
 			}
-			for _, epic := range epics {
-				if epic.EligibleForClose {
-					eligibleEpics = append(eligibleEpics, epic)
-				}
-			}
+			eligibleEpics = filterEligibleEpics(epics)
 		}
 
 		if len(eligibleEpics) == 0 {
@@ -175,13 +331,28 @@ var closeEligibleEpicsCmd = &cobra.Command{
 			return
 		}
 
+		if !autoYes && !jsonOutput {
+			fmt.Printf("Found %d epic(s) with all children complete:\n", len(eligibleEpics))
+			for _, epicStatus := range eligibleEpics {
+				fmt.Printf("  - %s: %s\n", epicStatus.Epic.ID, epicStatus.Epic.Title)
+			}
+			fmt.Print("\nClose these epics? [y/N] ")
+			var response string
+			_, _ = fmt.Scanln(&response) // Ignore errors, default to empty string
+			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+				fmt.Println("Canceled")
+				return
+			}
+		}
+
 		// Actually close the epics
-		closedIDs := []string{}
-		for _, epicStatus := range eligibleEpics {
-			if daemonClient != nil {
+		var closedIDs []string
+		if daemonClient != nil {
+			for _, epicStatus := range eligibleEpics {
 				resp, err := daemonClient.CloseIssue(&rpc.CloseArgs{
 					ID:     epicStatus.Epic.ID,
-					Reason: "All children completed",
+					Reason: epicAutoCloseReason,
+					Force:  true,
 				})
 				if err != nil || !resp.Success {
 					errMsg := ""
@@ -193,15 +364,16 @@ var closeEligibleEpicsCmd = &cobra.Command{
 					fmt.Fprintf(os.Stderr, "Error closing %s: %s\n", epicStatus.Epic.ID, errMsg)
 					continue
 				}
-			} else {
-				ctx := context.Background()
-				err := store.CloseIssue(ctx, epicStatus.Epic.ID, "All children completed", "system")
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", epicStatus.Epic.ID, err)
-					continue
-				}
+				closedIDs = append(closedIDs, epicStatus.Epic.ID)
 			}
-			closedIDs = append(closedIDs, epicStatus.Epic.ID)
+		} else {
+			closedIDs = closeEligibleEpics(context.Background(), store, eligibleEpics, actor)
+			if len(closedIDs) > 0 {
+				markDirtyAndScheduleFlush()
+			}
+		}
+		if closedIDs == nil {
+			closedIDs = []string{}
 		}
 
 		if jsonOutput {
@@ -223,14 +395,49 @@ var closeEligibleEpicsCmd = &cobra.Command{
 	},
 }
 
+// filterEligibleEpics keeps epics whose children are all closed.
+// Epics with no children are never eligible.
+func filterEligibleEpics(epics []*types.EpicStatus) []*types.EpicStatus {
+	var eligible []*types.EpicStatus
+	for _, epic := range epics {
+		if epic.EligibleForClose && epic.TotalChildren > 0 {
+			eligible = append(eligible, epic)
+		}
+	}
+	return eligible
+}
+
+// closeEligibleEpics closes each epic with the auto-close reason and returns the IDs closed.
+// Failures are reported to stderr and skipped.
+func closeEligibleEpics(ctx context.Context, s storage.Storage, epics []*types.EpicStatus, actor string) []string {
+	var closedIDs []string
+	for _, epicStatus := range epics {
+		// Every child is closed, so the epic's own status history doesn't matter
+		if err := s.CloseIssue(workflow.WithForce(ctx), epicStatus.Epic.ID, epicAutoCloseReason, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", epicStatus.Epic.ID, err)
+			continue
+		}
+		closedIDs = append(closedIDs, epicStatus.Epic.ID)
+	}
+	return closedIDs
+}
+
 func init() {
 	epicCmd.AddCommand(epicStatusCmd)
 	epicCmd.AddCommand(closeEligibleEpicsCmd)
+	epicCmd.AddCommand(epicShowCmd)
+	epicCmd.AddCommand(epicEtaCmd)
 
 	epicStatusCmd.Flags().Bool("eligible-only", false, "Show only epics eligible for closure")
 	epicStatusCmd.Flags().Bool("json", false, "Output in JSON format")
 
+	epicShowCmd.Flags().Bool("json", false, "Output in JSON format")
+
+	epicEtaCmd.Flags().Float64("rate", 240, "Minutes of estimated work finished per day")
+	epicEtaCmd.Flags().Bool("json", false, "Output in JSON format")
+
 	closeEligibleEpicsCmd.Flags().Bool("dry-run", false, "Preview what would be closed without making changes")
+	closeEligibleEpicsCmd.Flags().BoolP("yes", "y", false, "Close without prompting for confirmation")
 	closeEligibleEpicsCmd.Flags().Bool("json", false, "Output in JSON format")
 
 	rootCmd.AddCommand(epicCmd)
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCloseEligibleEpics(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "issues.db"))
+	defer testStore.Close()
+	ctx := context.Background()
+
+	create := func(title string, issueType types.IssueType) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	addChild := func(child, parent *types.Issue) {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	doneEpic := create("All children closed", types.TypeEpic)
+	partialEpic := create("One child still open", types.TypeEpic)
+	emptyEpic := create("No children", types.TypeEpic)
+
+	done1 := create("Done 1", types.TypeTask)
+	done2 := create("Done 2", types.TypeTask)
+	partialDone := create("Partial done", types.TypeTask)
+	partialOpen := create("Partial open", types.TypeTask)
+	addChild(done1, doneEpic)
+	addChild(done2, doneEpic)
+	addChild(partialDone, partialEpic)
+	addChild(partialOpen, partialEpic)
+	for _, issue := range []*types.Issue{done1, done2, partialDone} {
+		if err := testStore.CloseIssue(ctx, issue.ID, "Done", "test"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+	}
+
+	epics, err := testStore.GetEpicsEligibleForClosure(ctx)
+	if err != nil {
+		t.Fatalf("GetEpicsEligibleForClosure failed: %v", err)
+	}
+	eligible := filterEligibleEpics(epics)
+	if len(eligible) != 1 || eligible[0].Epic.ID != doneEpic.ID {
+		t.Fatalf("Expected only %s to be eligible, got %v", doneEpic.ID, eligible)
+	}
+
+	closed := closeEligibleEpics(ctx, testStore, eligible, "test")
+	if len(closed) != 1 || closed[0] != doneEpic.ID {
+		t.Fatalf("Expected %s to be closed, got %v", doneEpic.ID, closed)
+	}
+
+	for _, tc := range []struct {
+		issue  *types.Issue
+		status types.Status
+	}{
+		{doneEpic, types.StatusClosed},
+		{partialEpic, types.StatusOpen},
+		{emptyEpic, types.StatusOpen},
+	} {
+		got, err := testStore.GetIssue(ctx, tc.issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Status != tc.status {
+			t.Errorf("%s (%s): expected status %s, got %s", tc.issue.ID, tc.issue.Title, tc.status, got.Status)
+		}
+	}
+
+	events, err := testStore.GetEvents(ctx, doneEpic.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	foundReason := false
+	for _, e := range events {
+		if e.EventType == types.EventClosed && e.Comment != nil && *e.Comment == epicAutoCloseReason {
+			foundReason = true
+		}
+	}
+	if !foundReason {
+		t.Errorf("Expected closed event with reason %q", epicAutoCloseReason)
+	}
+}
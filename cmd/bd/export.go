@@ -1,17 +1,22 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // countIssuesInJSONL counts the number of issues in a JSONL file
@@ -43,6 +48,37 @@ func countIssuesInJSONL(path string) (int, error) {
 	return count, nil
 }
 
+// exportBatchSize is how many issues the streaming exporter reads from SQLite per query
+const exportBatchSize = 500
+
+// forEachIssueByID calls fn for every issue matching status (nil for all), including
+// archived issues, in ID order. SQLite databases are read in batches through a cursor
+// so memory stays bounded; other backends already hold every issue in memory.
+func forEachIssueByID(ctx context.Context, s storage.Storage, status *types.Status, fn func(*types.Issue) error) error {
+	if sqliteStore, ok := s.(*sqlite.SQLiteStorage); ok {
+		return sqliteStore.IterateIssues(ctx, status, exportBatchSize, fn)
+	}
+
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: status, IncludeArchived: true})
+	if err != nil {
+		return err
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+	for _, issue := range issues {
+		labels, err := s.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		issue.Labels = labels
+		if err := fn(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateExportPath checks if the output path is safe to write to
 func validateExportPath(path string) error {
 	// Get absolute path to normalize it
@@ -79,30 +115,68 @@ var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export issues to JSONL format",
 	Long: `Export all issues to JSON Lines format (one JSON object per line).
-Issues are sorted by ID for consistent diffs.
+Issues are sorted by ID for consistent diffs, and are streamed from the
+database in batches so memory use stays bounded on large databases.
+
+Output to stdout by default, or use -o flag for file output.
+
+Use --include events,comments with -o to also write each issue's audit events
+and comments to sidecar files, for a full-fidelity backup:
+
+  bd export -o backup.jsonl --include events,comments
+    backup.jsonl           issues
+    backup_events.jsonl    events, one per line
+    backup_comments.jsonl  comments, one per line
 
-Output to stdout by default, or use -o flag for file output.`,
+Restore with: bd import -i backup.jsonl --include events,comments
+
+Use --follow to keep running after the export and write each issue again,
+as a complete JSON line, whenever it changes (like tail -f). Changes are
+picked up by polling the database every --interval, including changes
+made through the daemon. Deletions are not reported. Stop with Ctrl+C.
+
+  bd export --follow | jq -c 'select(.status == "closed")'`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
 		statusFilter, _ := cmd.Flags().GetString("status")
 		force, _ := cmd.Flags().GetBool("force")
+		includeValue, _ := cmd.Flags().GetString("include")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
 
 		if format != "jsonl" {
 			fmt.Fprintf(os.Stderr, "Error: only 'jsonl' format is currently supported\n")
 			os.Exit(1)
 		}
 
+		include, err := parseBundleInclude(includeValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(include) > 0 && output == "" {
+			fmt.Fprintf(os.Stderr, "Error: --include requires an output file (-o)\n")
+			os.Exit(1)
+		}
+		if follow && (output != "" || len(include) > 0) {
+			fmt.Fprintf(os.Stderr, "Error: --follow writes to stdout and can't be combined with -o or --include\n")
+			os.Exit(1)
+		}
+		if follow && interval <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --interval must be positive\n")
+			os.Exit(1)
+		}
+
 		// Export command doesn't work with daemon - need direct access
 		// Ensure we have a direct store connection
 		if store == nil {
 			// Initialize store directly even if daemon is running
-			var err error
 			if dbPath == "" {
 				fmt.Fprintf(os.Stderr, "Error: no database path found\n")
 				os.Exit(1)
 			}
-			store, err = sqlite.New(dbPath)
+			store, err = openLocalStore(dbPath)
 			if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
@@ -110,81 +184,21 @@ Output to stdout by default, or use -o flag for file output.`,
 			defer func() { _ = store.Close() }()
 			}
 
-			// Build filter
-		filter := types.IssueFilter{}
+		// Build filter
+		var status *types.Status
 		if statusFilter != "" {
-			status := types.Status(statusFilter)
-			filter.Status = &status
-		}
-
-		// Get all issues
-		ctx := context.Background()
-		issues, err := store.SearchIssues(ctx, "", filter)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Safety check: prevent exporting empty database over non-empty JSONL
-		if len(issues) == 0 && output != "" && !force {
-			existingCount, err := countIssuesInJSONL(output)
-			if err != nil {
-				// If we can't read the file, it might not exist yet, which is fine
-				if !os.IsNotExist(err) {
-					fmt.Fprintf(os.Stderr, "Warning: failed to read existing JSONL: %v\n", err)
-				}
-			} else if existingCount > 0 {
-				fmt.Fprintf(os.Stderr, "Error: refusing to export empty database over non-empty JSONL file\n")
-				fmt.Fprintf(os.Stderr, "  Database has 0 issues, JSONL has %d issues\n", existingCount)
-				fmt.Fprintf(os.Stderr, "  This would result in data loss!\n")
-				fmt.Fprintf(os.Stderr, "Hint: Use --force to override this safety check, or delete the JSONL file first:\n")
-				fmt.Fprintf(os.Stderr, "  bd export -o %s --force\n", output)
-				fmt.Fprintf(os.Stderr, "  rm %s\n", output)
-				os.Exit(1)
-			}
-		}
-
-		// Warning: check if export would lose >50% of issues
-		if output != "" {
-			existingCount, err := countIssuesInJSONL(output)
-			if err == nil && existingCount > 0 {
-				lossPercent := float64(existingCount-len(issues)) / float64(existingCount) * 100
-				if lossPercent > 50 {
-					fmt.Fprintf(os.Stderr, "WARNING: Export would lose %.1f%% of issues!\n", lossPercent)
-					fmt.Fprintf(os.Stderr, "  Existing JSONL: %d issues\n", existingCount)
-					fmt.Fprintf(os.Stderr, "  Database: %d issues\n", len(issues))
-					fmt.Fprintf(os.Stderr, "  This suggests database staleness or corruption.\n")
-					fmt.Fprintf(os.Stderr, "Press Ctrl+C to abort, or Enter to continue: ")
-					// Read a line from stdin to wait for user confirmation
-					var response string
-					_, _ = fmt.Scanln(&response) // ignore EOF on empty input
-				}
-			}
+			s := types.Status(statusFilter)
+			status = &s
 		}
 
-		// Sort by ID for consistent output
-		sort.Slice(issues, func(i, j int) bool {
-			return issues[i].ID < issues[j].ID
-		})
-
-		// Populate dependencies for all issues in one query (avoids N+1 problem)
-		allDeps, err := store.GetAllDependencyRecords(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting dependencies: %v\n", err)
-			os.Exit(1)
-		}
-		for _, issue := range issues {
-			issue.Dependencies = allDeps[issue.ID]
-		}
-
-		// Populate labels for all issues
-		for _, issue := range issues {
-			labels, err := store.GetLabels(ctx, issue.ID)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting labels for %s: %v\n", issue.ID, err)
+		if follow {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if err := followIssues(ctx, store, status, interval, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-			issue.Labels = labels
+			return
 		}
 
 		// Open output
@@ -221,44 +235,100 @@ Output to stdout by default, or use -o flag for file output.`,
 
 			out = tempFile
 		}
+		// abort discards the partially written temp file; deferred cleanup doesn't run on os.Exit
+		abort := func() {
+			if tempFile != nil {
+				_ = tempFile.Close()
+				_ = os.Remove(tempPath)
+			}
+			os.Exit(1)
+		}
 
-		// Write JSONL (with timestamp-only deduplication for bd-164)
-		encoder := json.NewEncoder(out)
-		exportedIDs := make([]string, 0, len(issues))
-		skippedCount := 0
-		for _, issue := range issues {
-			// Check if this is only a timestamp change (bd-164)
-			skip, err := shouldSkipExport(ctx, issue)
+		// Stream issues in ID order. Only IDs and hashes are kept, so memory
+		// stays bounded on huge databases.
+		ctx := context.Background()
+		writer := bufio.NewWriter(out)
+		encoder := json.NewEncoder(writer)
+		var allIDs, exportedIDs []string
+		exportHashes := make(map[string]string)
+		err = forEachIssueByID(ctx, store, status, func(issue *types.Issue) error {
+			allIDs = append(allIDs, issue.ID)
+
+			deps, err := store.GetDependencyRecords(ctx, issue.ID)
 			if err != nil {
-				// Log warning but continue - don't fail export on hash check errors
-				fmt.Fprintf(os.Stderr, "Warning: failed to check if %s should skip: %v\n", issue.ID, err)
-				skip = false
-			}
-			
-			if skip {
-				skippedCount++
-				continue
+				return fmt.Errorf("failed to get dependencies for %s: %w", issue.ID, err)
 			}
-			
+			issue.Dependencies = deps
+			issue.SortForExport()
+
+			// Every issue is written, even if only its timestamps changed since the
+			// last export: an export is a full snapshot, and skipping unchanged
+			// issues left them out of the file entirely
 			if err := encoder.Encode(issue); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding issue %s: %v\n", issue.ID, err)
-				os.Exit(1)
+				return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 			}
-			
-			// Save content hash after successful export (bd-164)
+
 			contentHash, err := computeIssueContentHash(issue)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to compute hash for %s: %v\n", issue.ID, err)
-			} else if err := store.SetExportHash(ctx, issue.ID, contentHash); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save export hash for %s: %v\n", issue.ID, err)
+			} else {
+				exportHashes[issue.ID] = contentHash
 			}
-			
 			exportedIDs = append(exportedIDs, issue.ID)
+			return nil
+		})
+		if err == nil {
+			err = writer.Flush()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			abort()
+		}
+
+		// Safety checks now that the issue count is known, before replacing the target file
+		if output != "" {
+			existingCount, err := countIssuesInJSONL(output)
+			if err != nil {
+				// If we can't read the file, it might not exist yet, which is fine
+				if !os.IsNotExist(err) {
+					fmt.Fprintf(os.Stderr, "Warning: failed to read existing JSONL: %v\n", err)
+				}
+			} else if existingCount > 0 {
+				// Prevent exporting empty database over non-empty JSONL
+				if len(allIDs) == 0 && !force {
+					fmt.Fprintf(os.Stderr, "Error: refusing to export empty database over non-empty JSONL file\n")
+					fmt.Fprintf(os.Stderr, "  Database has 0 issues, JSONL has %d issues\n", existingCount)
+					fmt.Fprintf(os.Stderr, "  This would result in data loss!\n")
+					fmt.Fprintf(os.Stderr, "Hint: Use --force to override this safety check, or delete the JSONL file first:\n")
+					fmt.Fprintf(os.Stderr, "  bd export -o %s --force\n", output)
+					fmt.Fprintf(os.Stderr, "  rm %s\n", output)
+					abort()
+				}
+
+				// Warn if export would lose >50% of issues
+				lossPercent := float64(existingCount-len(allIDs)) / float64(existingCount) * 100
+				if lossPercent > 50 {
+					fmt.Fprintf(os.Stderr, "WARNING: Export would lose %.1f%% of issues!\n", lossPercent)
+					fmt.Fprintf(os.Stderr, "  Existing JSONL: %d issues\n", existingCount)
+					fmt.Fprintf(os.Stderr, "  Database: %d issues\n", len(allIDs))
+					fmt.Fprintf(os.Stderr, "  This suggests database staleness or corruption.\n")
+					fmt.Fprintf(os.Stderr, "Press Ctrl+C to abort, or Enter to continue: ")
+					// Read a line from stdin to wait for user confirmation
+					var response string
+					_, _ = fmt.Scanln(&response) // ignore EOF on empty input
+				}
+			}
 		}
-		
-		// Report skipped issues if any (helps debugging bd-159)
-		if skippedCount > 0 && (output == "" || output == findJSONLPath()) {
-			fmt.Fprintf(os.Stderr, "Skipped %d issue(s) with timestamp-only changes\n", skippedCount)
+
+		// Save content hashes after successful export (bd-164)
+		for _, id := range exportedIDs {
+			contentHash, ok := exportHashes[id]
+			if !ok {
+				continue
+			}
+			if err := store.SetExportHash(ctx, id, contentHash); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save export hash for %s: %v\n", id, err)
+			}
 		}
 
 		// Only clear dirty issues and auto-flush state if exporting to the default JSONL path
@@ -277,23 +347,43 @@ Output to stdout by default, or use -o flag for file output.`,
 		// If writing to file, atomically replace the target file
 		if tempFile != nil {
 			// Close the temp file before renaming
+			if err := utils.SyncFile(tempFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to sync temporary file: %v\n", err)
+			}
 			if err := tempFile.Close(); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to close temporary file: %v\n", err)
 			}
 			tempFile = nil // Prevent cleanup
 
 			// Atomically replace the target file
-			if err := os.Rename(tempPath, finalPath); err != nil {
+			if err := utils.RenameWithRetry(tempPath, finalPath); err != nil {
 			_ = os.Remove(tempPath) // Clean up on failure
 			fmt.Fprintf(os.Stderr, "Error replacing output file: %v\n", err)
 			os.Exit(1)
 			}
+			if err := utils.SyncDir(filepath.Dir(finalPath)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to sync output directory: %v\n", err)
+			}
 
 			// Set appropriate file permissions (0600: rw-------)
 			if err := os.Chmod(finalPath, 0600); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
 			}
 		}
+
+		// Write sidecar files for events and comments of every exported issue,
+		// including ones skipped above for timestamp-only changes
+		if len(include) > 0 {
+			for _, kind := range include {
+				path := bundleSidecarPath(output, kind)
+				count, err := writeBundleSidecar(ctx, store, allIDs, path, kind)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error exporting %s: %v\n", kind, err)
+					os.Exit(1)
+				}
+				fmt.Fprintf(os.Stderr, "Exported %d %s to %s\n", count, kind, path)
+			}
+		}
 	},
 }
 
@@ -302,5 +392,8 @@ func init() {
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
 	exportCmd.Flags().Bool("force", false, "Force export even if database is empty")
+	exportCmd.Flags().String("include", "", "Also export related records to sidecar files (events,comments)")
+	exportCmd.Flags().Bool("follow", false, "Keep running and write issues again as they change")
+	exportCmd.Flags().Duration("interval", defaultFollowInterval, "How often --follow checks for changes")
 	rootCmd.AddCommand(exportCmd)
 }
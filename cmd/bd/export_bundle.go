@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Record kinds that `bd export --include` can write alongside the issues file.
+//
+// A bundle is the issues JSONL plus one sidecar JSONL file per included kind:
+//
+//	issues.jsonl           one issue per line, sorted by ID
+//	issues_events.jsonl    one event per line, sorted by issue ID, then time
+//	issues_comments.jsonl  one comment per line, sorted by issue ID, then time
+const (
+	bundleEvents   = "events"
+	bundleComments = "comments"
+)
+
+// parseBundleInclude parses a comma-separated --include value such as "events,comments"
+func parseBundleInclude(value string) ([]string, error) {
+	var kinds []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		kind := strings.TrimSpace(part)
+		if kind == "" || seen[kind] {
+			continue
+		}
+		if kind != bundleEvents && kind != bundleComments {
+			return nil, fmt.Errorf("unknown --include value %q (valid: %s, %s)", kind, bundleEvents, bundleComments)
+		}
+		seen[kind] = true
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// bundleSidecarPath returns the path of a kind's sidecar file next to an issues file,
+// e.g. issues.jsonl → issues_events.jsonl. The underscore sorts after ".", so the
+// issues file is still the first *.jsonl found when a bundle is written into .beads.
+func bundleSidecarPath(issuesPath, kind string) string {
+	stem := strings.TrimSuffix(issuesPath, filepath.Ext(issuesPath))
+	return stem + "_" + kind + ".jsonl"
+}
+
+// writeBundleSidecar writes the events or comments of the given issues to path,
+// atomically replacing any existing file. Returns the number of records written.
+func writeBundleSidecar(ctx context.Context, s storage.Storage, issueIDs []string, path, kind string) (int, error) {
+	ids := append([]string(nil), issueIDs...)
+	sort.Strings(ids)
+
+	var records []interface{}
+	for _, id := range ids {
+		switch kind {
+		case bundleEvents:
+			events, err := s.GetEvents(ctx, id, 0)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get events for %s: %w", id, err)
+			}
+			sort.SliceStable(events, func(i, j int) bool {
+				if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+					return events[i].CreatedAt.Before(events[j].CreatedAt)
+				}
+				return events[i].ID < events[j].ID
+			})
+			for _, event := range events {
+				records = append(records, event)
+			}
+		case bundleComments:
+			comments, err := s.GetIssueComments(ctx, id)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get comments for %s: %w", id, err)
+			}
+			sort.SliceStable(comments, func(i, j int) bool {
+				if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+					return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+				}
+				return comments[i].ID < comments[j].ID
+			})
+			for _, comment := range comments {
+				records = append(records, comment)
+			}
+		default:
+			return 0, fmt.Errorf("unknown bundle kind %q", kind)
+		}
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp.*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer func() { _ = os.Remove(tempPath) }()
+
+	encoder := json.NewEncoder(tempFile)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			_ = tempFile.Close()
+			return 0, fmt.Errorf("failed to encode %s: %w", kind, err)
+		}
+	}
+	if err := utils.SyncFile(tempFile); err != nil {
+		_ = tempFile.Close()
+		return 0, fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := utils.RenameWithRetry(tempPath, path); err != nil {
+		return 0, fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	if err := utils.SyncDir(filepath.Dir(path)); err != nil {
+		return 0, fmt.Errorf("failed to sync directory: %w", err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
+	}
+	return len(records), nil
+}
+
+// importBundleSidecar reads an events or comments sidecar file and inserts its records,
+// keeping their original timestamps. Issue IDs are translated through idMapping so
+// records follow issues remapped during collision resolution. Records already present
+// or belonging to unknown issues are skipped. Returns the number of records inserted.
+func importBundleSidecar(ctx context.Context, s *sqlite.SQLiteStorage, path, kind string, idMapping map[string]string) (int, error) {
+	// #nosec G304 - path is derived from the user-provided import file
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []*types.Event
+	var comments []*types.Comment
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		switch kind {
+		case bundleEvents:
+			var event types.Event
+			if err := json.Unmarshal(line, &event); err != nil {
+				return 0, fmt.Errorf("%s line %d: %w", path, lineNum, err)
+			}
+			if newID, ok := idMapping[event.IssueID]; ok {
+				event.IssueID = newID
+			}
+			events = append(events, &event)
+		case bundleComments:
+			var comment types.Comment
+			if err := json.Unmarshal(line, &comment); err != nil {
+				return 0, fmt.Errorf("%s line %d: %w", path, lineNum, err)
+			}
+			if newID, ok := idMapping[comment.IssueID]; ok {
+				comment.IssueID = newID
+			}
+			comments = append(comments, &comment)
+		default:
+			return 0, fmt.Errorf("unknown bundle kind %q", kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if kind == bundleEvents {
+		return s.ImportEvents(ctx, events)
+	}
+	return s.ImportComments(ctx, comments)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBundleSidecarPath(t *testing.T) {
+	if got := bundleSidecarPath("/tmp/backup.jsonl", bundleEvents); got != "/tmp/backup_events.jsonl" {
+		t.Errorf("events path = %q", got)
+	}
+	if got := bundleSidecarPath("issues.jsonl", bundleComments); got != "issues_comments.jsonl" {
+		t.Errorf("comments path = %q", got)
+	}
+
+	// The issues file must stay the first *.jsonl in its directory
+	names := []string{
+		bundleSidecarPath("issues.jsonl", bundleEvents),
+		bundleSidecarPath("issues.jsonl", bundleComments),
+		"issues.jsonl",
+	}
+	sort.Strings(names)
+	if names[0] != "issues.jsonl" {
+		t.Errorf("issues.jsonl should sort first, got %v", names)
+	}
+}
+
+func TestParseBundleInclude(t *testing.T) {
+	kinds, err := parseBundleInclude("events, comments,events")
+	if err != nil {
+		t.Fatalf("parseBundleInclude failed: %v", err)
+	}
+	if len(kinds) != 2 || kinds[0] != bundleEvents || kinds[1] != bundleComments {
+		t.Errorf("kinds = %v", kinds)
+	}
+	if kinds, _ := parseBundleInclude(""); len(kinds) != 0 {
+		t.Errorf("empty include should give no kinds, got %v", kinds)
+	}
+	if _, err := parseBundleInclude("events,labels"); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	src, cleanupSrc := setupTestDB(t)
+	defer cleanupSrc()
+	dst, cleanupDst := setupTestDB(t)
+	defer cleanupDst()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Fix login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := src.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := src.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := src.AddIssueComment(ctx, issue.ID, "carol", "Reproduced on staging"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if _, err := src.AddIssueComment(ctx, issue.ID, "bob", "Fix is up for review"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	issuesPath := filepath.Join(dir, "backup.jsonl")
+	for _, kind := range []string{bundleEvents, bundleComments} {
+		if _, err := writeBundleSidecar(ctx, src, []string{issue.ID}, bundleSidecarPath(issuesPath, kind), kind); err != nil {
+			t.Fatalf("writeBundleSidecar(%s) failed: %v", kind, err)
+		}
+	}
+
+	// The destination has the issue (as a plain issue import would create it) but no history
+	restored := &types.Issue{ID: issue.ID, Title: issue.Title, Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeBug}
+	if err := dst.CreateIssue(ctx, restored, "import"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, kind := range []string{bundleEvents, bundleComments} {
+		if _, err := importBundleSidecar(ctx, dst, bundleSidecarPath(issuesPath, kind), kind, nil); err != nil {
+			t.Fatalf("importBundleSidecar(%s) failed: %v", kind, err)
+		}
+	}
+
+	srcEvents, _ := src.GetEvents(ctx, issue.ID, 0)
+	dstEvents, _ := dst.GetEvents(ctx, issue.ID, 0)
+	dstKeys := make(map[string]bool)
+	for _, e := range dstEvents {
+		dstKeys[string(e.EventType)+"/"+e.Actor+"/"+e.CreatedAt.UTC().String()] = true
+	}
+	for _, e := range srcEvents {
+		if !dstKeys[string(e.EventType)+"/"+e.Actor+"/"+e.CreatedAt.UTC().String()] {
+			t.Errorf("event %s by %s at %v not restored", e.EventType, e.Actor, e.CreatedAt)
+		}
+	}
+
+	srcComments, _ := src.GetIssueComments(ctx, issue.ID)
+	dstComments, _ := dst.GetIssueComments(ctx, issue.ID)
+	if len(dstComments) != len(srcComments) {
+		t.Fatalf("expected %d comments, got %d", len(srcComments), len(dstComments))
+	}
+	for i := range srcComments {
+		if dstComments[i].Author != srcComments[i].Author || dstComments[i].Text != srcComments[i].Text {
+			t.Errorf("comment %d = %s: %q, want %s: %q", i, dstComments[i].Author, dstComments[i].Text, srcComments[i].Author, srcComments[i].Text)
+		}
+		if !dstComments[i].CreatedAt.Equal(srcComments[i].CreatedAt) {
+			t.Errorf("comment %d timestamp = %v, want %v", i, dstComments[i].CreatedAt, srcComments[i].CreatedAt)
+		}
+	}
+
+	// Importing the same bundle again adds nothing
+	for _, kind := range []string{bundleEvents, bundleComments} {
+		count, err := importBundleSidecar(ctx, dst, bundleSidecarPath(issuesPath, kind), kind, nil)
+		if err != nil {
+			t.Fatalf("re-import of %s failed: %v", kind, err)
+		}
+		if count != 0 {
+			t.Errorf("re-import of %s inserted %d records, want 0", kind, count)
+		}
+	}
+}
+
+func TestImportBundleSidecarRemapsAndSkipsUnknown(t *testing.T) {
+	src, cleanupSrc := setupTestDB(t)
+	defer cleanupSrc()
+	dst, cleanupDst := setupTestDB(t)
+	defer cleanupDst()
+
+	ctx := context.Background()
+
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := src.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if _, err := src.AddIssueComment(ctx, issue.ID, "alice", "note on "+issue.Title); err != nil {
+			t.Fatalf("AddIssueComment failed: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "backup_comments.jsonl")
+	if _, err := writeBundleSidecar(ctx, src, []string{a.ID, b.ID}, path, bundleComments); err != nil {
+		t.Fatalf("writeBundleSidecar failed: %v", err)
+	}
+
+	// Only A exists in the destination, under a remapped ID
+	remapped := &types.Issue{ID: "bd-100", Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := dst.CreateIssue(ctx, remapped, "import"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	count, err := importBundleSidecar(ctx, dst, path, bundleComments, map[string]string{a.ID: remapped.ID})
+	if err != nil {
+		t.Fatalf("importBundleSidecar failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 comment imported, got %d", count)
+	}
+	comments, _ := dst.GetIssueComments(ctx, remapped.ID)
+	if len(comments) != 1 || comments[0].Text != "note on A" {
+		t.Errorf("unexpected comments on %s: %+v", remapped.ID, comments)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// defaultFollowInterval is how often bd export --follow polls for changes
+const defaultFollowInterval = time.Second
+
+// followIssues writes every issue matching status as a JSON line, then polls s
+// every interval and writes an issue again whenever its exported form changes,
+// until ctx is canceled. Each line goes out in a single Write, so readers never
+// see a partial object. Deleted issues are not reported.
+func followIssues(ctx context.Context, s storage.Storage, status *types.Status, interval time.Duration, w io.Writer) error {
+	seen := make(map[string][sha256.Size]byte)
+	var writeErr error
+
+	poll := func() error {
+		return forEachIssueByID(ctx, s, status, func(issue *types.Issue) error {
+			deps, err := s.GetDependencyRecords(ctx, issue.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get dependencies for %s: %w", issue.ID, err)
+			}
+			issue.Dependencies = deps
+			issue.SortForExport()
+
+			line, err := json.Marshal(issue)
+			if err != nil {
+				return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+			}
+			line = append(line, '\n')
+
+			sum := sha256.Sum256(line)
+			if prev, ok := seen[issue.ID]; ok && prev == sum {
+				return nil
+			}
+			if _, err := w.Write(line); err != nil {
+				writeErr = err
+				return err
+			}
+			seen[issue.ID] = sum
+			return nil
+		})
+	}
+
+	if err := poll(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := poll(); err != nil {
+				if writeErr != nil {
+					// The reader went away (e.g. a closed pipe)
+					return writeErr
+				}
+				if ctx.Err() != nil {
+					return nil
+				}
+				// A busy or briefly locked database shouldn't end the stream
+				fmt.Fprintf(os.Stderr, "Warning: failed to read issues: %v\n", err)
+			}
+		}
+	}
+}
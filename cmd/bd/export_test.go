@@ -4,9 +4,14 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -258,3 +263,275 @@ func TestExportCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestExportStreamsLargeDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDB := filepath.Join(tmpDir, "test.db")
+	s := newTestStore(t, testDB)
+	defer s.Close()
+
+	ctx := context.Background()
+
+	// Enough issues to span several streaming batches
+	total := exportBatchSize*2 + 37
+	issues := make([]*types.Issue, total)
+	for i := range issues {
+		issues[i] = &types.Issue{
+			Title:     fmt.Sprintf("Synthetic issue %d", i),
+			Priority:  i % 5,
+			IssueType: types.TypeTask,
+			Status:    types.StatusOpen,
+		}
+	}
+	if err := s.CreateIssues(ctx, issues, "test-user"); err != nil {
+		t.Fatalf("Failed to create issues: %v", err)
+	}
+
+	labeled := make(map[string]bool)
+	deps := make(map[string]string)
+	for i := 0; i < total; i += 97 {
+		if err := s.AddLabel(ctx, issues[i].ID, "sampled", "test-user"); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+		labeled[issues[i].ID] = true
+		if i > 0 {
+			dep := &types.Dependency{IssueID: issues[i].ID, DependsOnID: issues[i-1].ID, Type: types.DepBlocks}
+			if err := s.AddDependency(ctx, dep, "test-user"); err != nil {
+				t.Fatalf("Failed to add dependency: %v", err)
+			}
+			deps[issues[i].ID] = issues[i-1].ID
+		}
+	}
+
+	exportPath := filepath.Join(tmpDir, "large.jsonl")
+	store = s
+	dbPath = testDB
+	exportCmd.Flags().Set("output", exportPath)
+	exportCmd.Flags().Set("status", "")
+	exportCmd.Flags().Set("include", "")
+	exportCmd.Run(exportCmd, []string{})
+
+	file, err := os.Open(exportPath)
+	if err != nil {
+		t.Fatalf("Failed to open export file: %v", err)
+	}
+	defer file.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("Failed to parse JSONL line %d: %v", len(ids)+1, err)
+		}
+		ids = append(ids, issue.ID)
+
+		if labeled[issue.ID] != (len(issue.Labels) == 1) {
+			t.Errorf("%s: unexpected labels %v", issue.ID, issue.Labels)
+		}
+		if want, ok := deps[issue.ID]; ok {
+			if len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != want {
+				t.Errorf("%s: expected dependency on %s, got %v", issue.ID, want, issue.Dependencies)
+			}
+		} else if len(issue.Dependencies) != 0 {
+			t.Errorf("%s: unexpected dependencies %v", issue.ID, issue.Dependencies)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+
+	if len(ids) != total {
+		t.Fatalf("Expected %d issues in export, got %d", total, len(ids))
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Error("Expected export to be sorted by ID")
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			t.Errorf("Issue %s exported twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestExportIsDeterministic(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDB := filepath.Join(tmpDir, "test.db")
+	s := newTestStore(t, testDB)
+	defer s.Close()
+
+	ctx := context.Background()
+
+	issues := make([]*types.Issue, 4)
+	for i := range issues {
+		issues[i] = &types.Issue{
+			Title:     fmt.Sprintf("Issue %d", i),
+			Priority:  2,
+			IssueType: types.TypeTask,
+			Status:    types.StatusOpen,
+		}
+		if err := s.CreateIssue(ctx, issues[i], "test-user"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+	}
+
+	// Labels and dependencies added out of order
+	for _, label := range []string{"zeta", "alpha", "mid"} {
+		if err := s.AddLabel(ctx, issues[0].ID, label, "test-user"); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+	}
+	for _, target := range []int{3, 1, 2} {
+		dep := &types.Dependency{IssueID: issues[0].ID, DependsOnID: issues[target].ID, Type: types.DepBlocks}
+		if err := s.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+	}
+
+	store = s
+	dbPath = testDB
+	exportCmd.Flags().Set("status", "")
+	exportCmd.Flags().Set("include", "")
+
+	export := func(name string) []byte {
+		path := filepath.Join(tmpDir, name)
+		exportCmd.Flags().Set("output", path)
+		exportCmd.Run(exportCmd, []string{})
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read export: %v", err)
+		}
+		return data
+	}
+
+	first := export("first.jsonl")
+	second := export("second.jsonl")
+	if string(first) != string(second) {
+		t.Fatalf("exports differ:\n%s\n---\n%s", first, second)
+	}
+
+	var found *types.Issue
+	scanner := bufio.NewScanner(strings.NewReader(string(first)))
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("Failed to parse JSONL: %v", err)
+		}
+		if issue.ID == issues[0].ID {
+			found = &issue
+		}
+	}
+	if found == nil {
+		t.Fatalf("issue %s missing from export (got %d bytes)", issues[0].ID, len(first))
+	}
+	if !sort.StringsAreSorted(found.Labels) || len(found.Labels) != 3 {
+		t.Errorf("expected 3 sorted labels, got %v", found.Labels)
+	}
+	var targets []string
+	for _, dep := range found.Dependencies {
+		targets = append(targets, dep.DependsOnID)
+	}
+	if !sort.StringsAreSorted(targets) || len(targets) != 3 {
+		t.Errorf("expected 3 dependencies sorted by target, got %v", targets)
+	}
+}
+
+// chunkRecorder keeps each Write as a separate chunk
+type chunkRecorder struct {
+	mu     sync.Mutex
+	chunks []string
+}
+
+func (r *chunkRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chunks = append(r.chunks, string(p))
+	return len(p), nil
+}
+
+func (r *chunkRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.chunks...)
+}
+
+func TestExportFollowEmitsChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := newTestStore(t, filepath.Join(tmpDir, "test.db"))
+	defer s.Close()
+
+	ctx := context.Background()
+	first := &types.Issue{Title: "First", Priority: 2, IssueType: types.TypeTask, Status: types.StatusOpen}
+	if err := s.CreateIssue(ctx, first, "test-user"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	out := &chunkRecorder{}
+	followCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- followIssues(followCtx, s, nil, 10*time.Millisecond, out)
+	}()
+
+	// waitFor polls until the emitted issues satisfy cond
+	waitFor := func(desc string, cond func([]types.Issue) bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			var issues []types.Issue
+			for _, chunk := range out.snapshot() {
+				if !strings.HasSuffix(chunk, "\n") || strings.Count(chunk, "\n") != 1 {
+					t.Fatalf("Write was not exactly one line: %q", chunk)
+				}
+				var issue types.Issue
+				if err := json.Unmarshal([]byte(chunk), &issue); err != nil {
+					t.Fatalf("Emitted line is not valid JSON: %v: %q", err, chunk)
+				}
+				issues = append(issues, issue)
+			}
+			if cond(issues) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Timed out waiting for %s; got %v", desc, out.snapshot())
+	}
+
+	waitFor("initial snapshot", func(issues []types.Issue) bool { return len(issues) == 1 })
+
+	if err := s.UpdateIssue(ctx, first.ID, map[string]interface{}{"title": "First (edited)"}, "test-user"); err != nil {
+		t.Fatalf("Failed to update issue: %v", err)
+	}
+	second := &types.Issue{Title: "Second", Priority: 1, IssueType: types.TypeBug, Status: types.StatusOpen}
+	if err := s.CreateIssue(ctx, second, "test-user"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	waitFor("edit and new issue", func(issues []types.Issue) bool {
+		edited, created := false, false
+		for _, issue := range issues {
+			edited = edited || (issue.ID == first.ID && issue.Title == "First (edited)")
+			created = created || issue.ID == second.ID
+		}
+		return edited && created
+	})
+
+	// Unchanged issues aren't repeated on later polls
+	count := len(out.snapshot())
+	time.Sleep(50 * time.Millisecond)
+	if got := len(out.snapshot()); got != count {
+		t.Errorf("Expected no new lines without changes, got %d more", got-count)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("followIssues returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("followIssues did not stop after cancel")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var flushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Write pending changes to JSONL now",
+	Long: `Write pending issue changes to .beads/issues.jsonl immediately instead of
+waiting for the auto-flush debounce.
+
+With a daemon running, this asks the daemon to flush and returns once the
+JSONL has been written. Run it before committing to make sure the JSONL
+reflects every change made so far.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonlPath := findJSONLPath()
+
+		if err := flushNow(context.Background(), jsonlPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"flushed":    true,
+				"jsonl_path": jsonlPath,
+			})
+			return
+		}
+		fmt.Printf("%s Flushed to %s\n", renderSuccess("✓"), jsonlPath)
+	},
+}
+
+// flushNow writes pending changes to jsonlPath, through the daemon's
+// debouncer when a daemon is running
+func flushNow(ctx context.Context, jsonlPath string) error {
+	if daemonClient != nil {
+		if err := daemonClient.Flush(); err != nil {
+			return fmt.Errorf("daemon flush failed: %w", err)
+		}
+		return nil
+	}
+	return exportToJSONL(ctx, jsonlPath)
+}
+
+func init() {
+	rootCmd.AddCommand(flushCmd)
+}
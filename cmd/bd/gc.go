@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// gcTempFileAge is how old a leftover temp file must be before gc removes it,
+// so an export or flush in progress is never disturbed
+const gcTempFileAge = time.Hour
+
+// gcReport describes what a gc run removed, or would remove with --dry-run
+type gcReport struct {
+	DryRun           bool     `json:"dry_run"`
+	IssuesCompacted  int      `json:"issues_compacted"`
+	EventsCompacted  int      `json:"events_compacted"`
+	TrashPurged      int      `json:"trash_purged"`
+	TempFilesRemoved []string `json:"temp_files_removed"`
+	BytesReclaimed   int64    `json:"bytes_reclaimed"`
+}
+
+// runGC compacts event histories longer than keepEvents, purges expired trash,
+// removes stale temp files next to the database, and vacuums the database.
+// With dryRun, nothing is changed and the report shows what would be removed;
+// bytes reclaimed then only counts temp files, since vacuum savings aren't known ahead.
+func runGC(ctx context.Context, s *sqlite.SQLiteStorage, keepEvents int, dryRun bool, now time.Time) (*gcReport, error) {
+	report := &gcReport{DryRun: dryRun, TempFilesRemoved: []string{}}
+
+	dbSizeBefore := fileSize(s.Path())
+
+	// Compact long event histories. A history already compacted to keepEvents plus
+	// its summary event is left alone.
+	counts, err := s.GetEventCounts(ctx, keepEvents+1)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		// The newest dropped event becomes the summary rather than being deleted
+		dropped := counts[id] - keepEvents - 1
+		if !dryRun {
+			if dropped, err = s.CompactEvents(ctx, id, keepEvents); err != nil {
+				return nil, fmt.Errorf("failed to compact events of %s: %w", id, err)
+			}
+		}
+		report.IssuesCompacted++
+		report.EventsCompacted += dropped
+	}
+
+	// Purge expired trash
+	if dryRun {
+		cutoff, err := s.TrashRetentionCutoff(ctx)
+		if err != nil {
+			return nil, err
+		}
+		trash, err := s.ListTrash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, deleted := range trash {
+			if deleted.DeletedAt.Before(cutoff) {
+				report.TrashPurged++
+			}
+		}
+	} else if report.TrashPurged, err = s.PurgeExpiredTrash(ctx); err != nil {
+		return nil, err
+	}
+
+	// Remove temp files left behind by interrupted exports and flushes
+	stale, err := findStaleTempFiles(filepath.Dir(s.Path()), now.Add(-gcTempFileAge))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range stale {
+		size := fileSize(path)
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+		report.TempFilesRemoved = append(report.TempFilesRemoved, path)
+		report.BytesReclaimed += size
+	}
+
+	if !dryRun {
+		if err := s.Vacuum(ctx); err != nil {
+			return nil, err
+		}
+		if saved := dbSizeBefore - fileSize(s.Path()); saved > 0 {
+			report.BytesReclaimed += saved
+		}
+	}
+
+	return report, nil
+}
+
+// findStaleTempFiles returns temp files (name.tmp.*) in dir last modified before cutoff
+func findStaleTempFiles(dir string, cutoff time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.Contains(entry.Name(), ".tmp.") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since ReadDir
+		}
+		if info.ModTime().Before(cutoff) {
+			stale = append(stale, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return stale, nil
+}
+
+// fileSize returns the size of path, or 0 if it can't be read
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Compact event histories and clean up leftover data",
+	Long: `Tidy up a long-running database:
+
+  - Compact each issue's event history to its most recent --keep-events events,
+    replacing older ones with a single summary event
+  - Purge trash entries older than trash_retention_days
+  - Remove temp files left in .beads by interrupted exports (older than an hour)
+  - Vacuum the database to reclaim the freed space
+
+Use --dry-run to see what would be removed without changing anything.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		keepEvents, _ := cmd.Flags().GetInt("keep-events")
+
+		if keepEvents < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --keep-events must be non-negative\n")
+			os.Exit(1)
+		}
+
+		if daemonClient != nil {
+			if err := ensureDirectMode("daemon does not support gc"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else if store == nil {
+			if err := ensureStoreActive(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: gc not supported by this storage backend\n")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		report, err := runGC(ctx, sqliteStore, keepEvents, dryRun, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !dryRun && report.TrashPurged > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+
+		verb := "Removed"
+		if dryRun {
+			verb = "Would remove"
+		}
+		fmt.Printf("%s %d %s from %d %s\n", verb, report.EventsCompacted, pluralize(report.EventsCompacted, "event", "events"),
+			report.IssuesCompacted, pluralize(report.IssuesCompacted, "issue", "issues"))
+		fmt.Printf("%s %d expired trash %s\n", verb, report.TrashPurged, pluralize(report.TrashPurged, "entry", "entries"))
+		fmt.Printf("%s %d stale temp %s\n", verb, len(report.TempFilesRemoved), pluralize(len(report.TempFilesRemoved), "file", "files"))
+		for _, path := range report.TempFilesRemoved {
+			fmt.Printf("  %s\n", path)
+		}
+
+		if dryRun {
+			fmt.Printf("\nDry run: no changes made\n")
+			return
+		}
+		fmt.Printf("\n%s Reclaimed %s\n", renderSuccess("✓"), formatBytes(report.BytesReclaimed))
+	},
+}
+
+// pluralize picks the singular or plural form for n
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+func init() {
+	gcCmd.Flags().Bool("dry-run", false, "Show what would be removed without changing anything")
+	gcCmd.Flags().Int("keep-events", 100, "Number of most recent events to keep per issue")
+	rootCmd.AddCommand(gcCmd)
+}
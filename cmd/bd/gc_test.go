@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRunGC(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	busy := &types.Issue{Title: "Busy issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	quiet := &types.Issue{Title: "Quiet issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	doomed := &types.Issue{Title: "Deleted issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{busy, quiet, doomed} {
+		if err := testStore.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for i := 0; i < 9; i++ {
+		if err := testStore.AddComment(ctx, busy.ID, "alice", "progress"); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+	if err := testStore.DeleteIssue(ctx, doomed.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	// Everything in the trash has expired
+	if err := testStore.SetConfig(ctx, "trash_retention_days", "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	beadsDir := filepath.Dir(testStore.Path())
+	staleTemp := filepath.Join(beadsDir, "issues.jsonl.tmp.12345")
+	freshTemp := filepath.Join(beadsDir, "issues.jsonl.tmp.67890")
+	for _, path := range []string{staleTemp, freshTemp} {
+		if err := os.WriteFile(path, []byte("partial export\n"), 0600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	old := now.Add(-2 * gcTempFileAge)
+	if err := os.Chtimes(staleTemp, old, old); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+
+	// Dry run reports without changing anything
+	report, err := runGC(ctx, testStore, 3, true, now)
+	if err != nil {
+		t.Fatalf("runGC dry run failed: %v", err)
+	}
+	if report.IssuesCompacted != 1 || report.EventsCompacted != 6 {
+		t.Errorf("dry run: expected 6 events from 1 issue, got %d from %d", report.EventsCompacted, report.IssuesCompacted)
+	}
+	if report.TrashPurged != 1 {
+		t.Errorf("dry run: expected 1 trash entry, got %d", report.TrashPurged)
+	}
+	if len(report.TempFilesRemoved) != 1 || report.TempFilesRemoved[0] != staleTemp {
+		t.Errorf("dry run: expected only %s, got %v", staleTemp, report.TempFilesRemoved)
+	}
+	if _, err := os.Stat(staleTemp); err != nil {
+		t.Errorf("dry run removed %s", staleTemp)
+	}
+	if events, _ := testStore.GetEvents(ctx, busy.ID, 0); len(events) != 10 {
+		t.Errorf("dry run changed events: got %d", len(events))
+	}
+
+	// Real run
+	report, err = runGC(ctx, testStore, 3, false, now)
+	if err != nil {
+		t.Fatalf("runGC failed: %v", err)
+	}
+	if report.EventsCompacted != 6 || report.TrashPurged != 1 || len(report.TempFilesRemoved) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if _, err := os.Stat(staleTemp); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed", staleTemp)
+	}
+	if _, err := os.Stat(freshTemp); err != nil {
+		t.Errorf("expected recent %s to be kept", freshTemp)
+	}
+	if events, _ := testStore.GetEvents(ctx, busy.ID, 0); len(events) != 4 {
+		t.Errorf("expected 3 events plus a summary, got %d", len(events))
+	}
+	if events, _ := testStore.GetEvents(ctx, quiet.ID, 0); len(events) != 1 {
+		t.Errorf("expected short history to be untouched, got %d events", len(events))
+	}
+	if trash, _ := testStore.ListTrash(ctx); len(trash) != 0 {
+		t.Errorf("expected trash to be purged, got %d entries", len(trash))
+	}
+
+	// A second run finds nothing left to do
+	report, err = runGC(ctx, testStore, 3, false, now)
+	if err != nil {
+		t.Fatalf("second runGC failed: %v", err)
+	}
+	if report.EventsCompacted != 0 || report.TrashPurged != 0 || len(report.TempFilesRemoved) != 0 {
+		t.Errorf("expected nothing to do on second run, got %+v", report)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,667 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// bdHooksVersion is the version of the hook scripts written by this bd.
+// Bump it whenever a script changes so installed hooks get upgraded.
+const bdHooksVersion = 3
+
+// hookVersionMarker precedes the version number in each bd hook script.
+// Hooks written before versioning have no marker and count as version 0.
+const hookVersionMarker = "# bd-hooks-version: "
+
+// bdHookNames are the git hooks bd installs, in install order
+var bdHookNames = []string{"pre-commit", "post-merge"}
+
+// bdHookScripts maps each hook name to its script
+var bdHookScripts = map[string]string{
+	"pre-commit": `#!/bin/sh
+#
+# bd (beads) pre-commit hook
+` + hookVersionMarker + strconv.Itoa(bdHooksVersion) + `
+#
+# This hook ensures that any pending bd issue changes are flushed to
+# .beads/issues.jsonl before the commit is created, preventing the
+# race condition where daemon auto-flush fires after the commit.
+
+# Run the pre-commit hook bd moved aside when it was installed, so it keeps working
+if [ -x "$0.backup" ]; then
+    "$0.backup" "$@" || exit $?
+fi
+
+# Check if bd is available
+if ! command -v bd >/dev/null 2>&1; then
+    echo "Warning: bd command not found, skipping pre-commit flush" >&2
+    exit 0
+fi
+
+# Check if we're in a bd workspace
+if [ ! -d .beads ]; then
+    # Not a bd workspace, nothing to do
+    exit 0
+fi
+
+# Flush pending changes to JSONL
+# Use --flush-only to skip git operations (we're already in a git hook)
+# Suppress output unless there's an error
+if ! bd sync --flush-only >/dev/null 2>&1; then
+    echo "Error: Failed to flush bd changes to JSONL" >&2
+    echo "Run 'bd sync --flush-only' manually to diagnose" >&2
+    exit 1
+fi
+
+# If the JSONL file was modified, stage it
+if [ -f .beads/issues.jsonl ]; then
+    git add .beads/issues.jsonl 2>/dev/null || true
+fi
+
+exit 0
+`,
+	"post-merge": `#!/bin/sh
+#
+# bd (beads) post-merge hook
+` + hookVersionMarker + strconv.Itoa(bdHooksVersion) + `
+#
+# This hook imports updated issues from .beads/issues.jsonl after a
+# git pull or merge, ensuring the database stays in sync with git.
+
+# Run the post-merge hook bd moved aside when it was installed, so it keeps working
+if [ -x "$0.backup" ]; then
+    "$0.backup" "$@"
+fi
+
+# Check if bd is available
+if ! command -v bd >/dev/null 2>&1; then
+    echo "Warning: bd command not found, skipping post-merge import" >&2
+    exit 0
+fi
+
+# Check if we're in a bd workspace
+if [ ! -d .beads ]; then
+    # Not a bd workspace, nothing to do
+    exit 0
+fi
+
+# Check if issues.jsonl exists and was updated
+if [ ! -f .beads/issues.jsonl ]; then
+    exit 0
+fi
+
+# Import the updated JSONL
+# The auto-import feature should handle this, but we force it here
+# to ensure immediate sync after merge
+if ! bd import -i .beads/issues.jsonl --resolve-collisions >/dev/null 2>&1; then
+    echo "Warning: Failed to import bd changes after merge" >&2
+    echo "Run 'bd import -i .beads/issues.jsonl --resolve-collisions' manually" >&2
+    # Don't fail the merge, just warn
+fi
+
+exit 0
+`,
+}
+
+// windowsHookScripts are batch equivalents of bdHookScripts, written next to them
+// on Windows as <hook>.cmd. Git never runs them: Git for Windows runs the
+// extensionless POSIX scripts through its bundled sh. They are helpers for running
+// the bd hook steps by hand, or from tooling that calls hooks through cmd.exe.
+// Like the POSIX scripts they run <hook>.backup first, through sh.
+var windowsHookScripts = map[string]string{
+	"pre-commit": `@echo off
+REM bd (beads) pre-commit hook
+REM bd-hooks-version: ` + strconv.Itoa(bdHooksVersion) + `
+REM
+REM Flushes pending bd issue changes to .beads\issues.jsonl before the commit.
+REM Git doesn't run .cmd hooks; run this by hand where the POSIX hook can't run.
+
+REM Run the pre-commit hook bd moved aside when it was installed, as the POSIX hook does
+if not exist "%~dpn0.backup" goto flush
+where sh >nul 2>&1
+if errorlevel 1 (
+    echo Error: sh not found, cannot run %~n0.backup 1>&2
+    exit /b 1
+)
+sh "%~dpn0.backup" %*
+if errorlevel 1 exit /b %errorlevel%
+:flush
+
+where bd >nul 2>&1
+if errorlevel 1 (
+    echo Warning: bd command not found, skipping pre-commit flush 1>&2
+    exit /b 0
+)
+
+if not exist .beads exit /b 0
+
+bd sync --flush-only >nul 2>&1
+if errorlevel 1 (
+    echo Error: Failed to flush bd changes to JSONL 1>&2
+    echo Run 'bd sync --flush-only' manually to diagnose 1>&2
+    exit /b 1
+)
+
+if exist .beads\issues.jsonl git add .beads/issues.jsonl >nul 2>&1
+exit /b 0
+`,
+	"post-merge": `@echo off
+REM bd (beads) post-merge hook
+REM bd-hooks-version: ` + strconv.Itoa(bdHooksVersion) + `
+REM
+REM Imports updated issues from .beads\issues.jsonl after a git pull or merge.
+REM Git doesn't run .cmd hooks; run this by hand where the POSIX hook can't run.
+
+REM Run the post-merge hook bd moved aside when it was installed, as the POSIX hook does
+if not exist "%~dpn0.backup" goto import
+where sh >nul 2>&1
+if errorlevel 1 (
+    echo Warning: sh not found, skipping %~n0.backup 1>&2
+    goto import
+)
+sh "%~dpn0.backup" %*
+:import
+
+where bd >nul 2>&1
+if errorlevel 1 (
+    echo Warning: bd command not found, skipping post-merge import 1>&2
+    exit /b 0
+)
+
+if not exist .beads\issues.jsonl exit /b 0
+
+bd import -i .beads/issues.jsonl --resolve-collisions >nul 2>&1
+if errorlevel 1 (
+    echo Warning: Failed to import bd changes after merge 1>&2
+    echo Run 'bd import -i .beads/issues.jsonl --resolve-collisions' manually 1>&2
+)
+exit /b 0
+`,
+}
+
+// huskyHookCommands are the bd steps added to husky-managed hook files. Unlike the
+// standalone scripts they never exit early, since husky runs other steps in the same file.
+var huskyHookCommands = map[string]string{
+	"pre-commit": `if command -v bd >/dev/null 2>&1 && [ -d .beads ]; then
+    if ! bd sync --flush-only >/dev/null 2>&1; then
+        echo "Error: Failed to flush bd changes to JSONL" >&2
+        exit 1
+    fi
+    if [ -f .beads/issues.jsonl ]; then
+        git add .beads/issues.jsonl 2>/dev/null || true
+    fi
+fi
+`,
+	"post-merge": `if command -v bd >/dev/null 2>&1 && [ -f .beads/issues.jsonl ]; then
+    if ! bd import -i .beads/issues.jsonl --resolve-collisions >/dev/null 2>&1; then
+        echo "Warning: Failed to import bd changes after merge" >&2
+    fi
+fi
+`,
+}
+
+// huskyBlockStart and huskyBlockEnd delimit the bd block in a husky hook file
+func huskyBlockStart(name string) string { return "# >>> bd (beads) " + name + " hook >>>\n" }
+func huskyBlockEnd(name string) string   { return "# <<< bd (beads) " + name + " hook <<<\n" }
+
+// huskyHookBlock returns the delimited bd block for a husky hook file
+func huskyHookBlock(name string) string {
+	return huskyBlockStart(name) + hookVersionMarker + strconv.Itoa(bdHooksVersion) + "\n" +
+		huskyHookCommands[name] + huskyBlockEnd(name)
+}
+
+// removeHuskyBlock returns content without its bd block for the hook, if any
+func removeHuskyBlock(content, name string) string {
+	start := strings.Index(content, huskyBlockStart(name))
+	if start < 0 {
+		return content
+	}
+	end := strings.Index(content[start:], huskyBlockEnd(name))
+	if end < 0 {
+		return content
+	}
+	return content[:start] + content[start+end+len(huskyBlockEnd(name)):]
+}
+
+// resolveHooksDir returns the directory git runs hooks from for the repository at
+// repoDir, honoring core.hooksPath. husky is true when that directory belongs to
+// husky, whose hook files are committed and shared, so bd adds to them instead of
+// replacing them.
+func resolveHooksDir(repoDir string) (dir string, husky bool) {
+	out, err := exec.Command("git", "-C", repoDir, "config", "--get", "core.hooksPath").Output()
+	hooksPath := strings.TrimSpace(string(out))
+	if err != nil || hooksPath == "" {
+		return filepath.Join(repoDir, ".git", "hooks"), false
+	}
+	if !filepath.IsAbs(hooksPath) {
+		hooksPath = filepath.Join(repoDir, hooksPath)
+	}
+
+	// husky v9 points core.hooksPath at .husky/_, whose generated scripts run the
+	// user's hooks in .husky; older versions point it at .husky itself
+	if filepath.Base(hooksPath) == "_" && filepath.Base(filepath.Dir(hooksPath)) == ".husky" {
+		return filepath.Dir(hooksPath), true
+	}
+	return hooksPath, filepath.Base(hooksPath) == ".husky"
+}
+
+// gitHooksDir returns the hooks directory of the repository in the current directory
+func gitHooksDir() string {
+	dir, _ := resolveHooksDir(".")
+	return dir
+}
+
+// parseHookVersion reports whether a hook script was written by bd and, if so,
+// its version (0 for hooks written before versioning)
+func parseHookVersion(content string) (version int, isBD bool) {
+	if !strings.Contains(content, "bd (beads)") {
+		return 0, false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if rest, ok := strings.CutPrefix(line, hookVersionMarker); ok {
+			if v, err := strconv.Atoi(strings.TrimSpace(rest)); err == nil {
+				return v, true
+			}
+		}
+	}
+	return 0, true
+}
+
+// readHookVersion reads the hook at path; exists is false if there is no hook
+func readHookVersion(path string) (version int, isBD, exists bool) {
+	// #nosec G304 - controlled path from git directory
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false, false
+	}
+	version, isBD = parseHookVersion(string(content))
+	return version, isBD, true
+}
+
+// hooksInstalled checks if bd git hooks are installed
+func hooksInstalled() bool {
+	return hooksInstalledIn(gitHooksDir())
+}
+
+// hooksInstalledIn checks if every bd hook is installed in hooksDir, at any version
+func hooksInstalledIn(hooksDir string) bool {
+	for _, name := range bdHookNames {
+		// #nosec G304 - controlled path from git directory
+		content, err := os.ReadFile(filepath.Join(hooksDir, name))
+		if err != nil || !strings.Contains(string(content), "bd (beads) "+name+" hook") {
+			return false
+		}
+	}
+	return true
+}
+
+// outdatedHooks returns the bd hooks in hooksDir written by an older bd
+func outdatedHooks(hooksDir string) []string {
+	var outdated []string
+	for _, name := range bdHookNames {
+		version, isBD, _ := readHookVersion(filepath.Join(hooksDir, name))
+		if isBD && version < bdHooksVersion {
+			outdated = append(outdated, name)
+		}
+	}
+	return outdated
+}
+
+// installGitHooks installs git hooks inline (no external dependencies)
+func installGitHooks() error {
+	dir, husky := resolveHooksDir(".")
+	if husky {
+		return installHuskyHooks(dir)
+	}
+	return installGitHooksIn(dir)
+}
+
+// installGitHooksIn writes the current bd hooks into hooksDir. Existing bd hooks of
+// any version are overwritten. Other hooks are moved aside to <hook>.backup, and the
+// bd hook runs them first so both keep working; this also covers hooks generated by
+// the pre-commit framework.
+func installGitHooksIn(hooksDir string) error {
+	return installGitHooksFor(hooksDir, runtime.GOOS)
+}
+
+// installGitHooksFor installs the hooks for the given GOOS: the POSIX scripts
+// everywhere, plus the manual .cmd helpers on Windows
+func installGitHooksFor(hooksDir, goos string) error {
+	// Ensure hooks directory exists
+	if err := os.MkdirAll(hooksDir, 0750); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	for _, name := range bdHookNames {
+		hookPath := filepath.Join(hooksDir, name)
+
+		// Backup existing non-bd hook
+		if _, isBD, exists := readHookVersion(hookPath); exists && !isBD {
+			if err := backupHook(hookPath); err != nil {
+				return err
+			}
+		}
+
+		// Write hook (executable scripts need 0700)
+		// #nosec G306 - git hooks must be executable
+		if err := os.WriteFile(hookPath, []byte(bdHookScripts[name]), 0700); err != nil {
+			return fmt.Errorf("failed to write %s hook: %w", name, err)
+		}
+
+		if goos == "windows" {
+			// #nosec G306 - git hooks must be executable
+			if err := os.WriteFile(hookPath+".cmd", []byte(windowsHookScripts[name]), 0700); err != nil {
+				return fmt.Errorf("failed to write %s.cmd hook: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backupHook moves a non-bd hook aside to <hook>.backup, where the bd hook runs it.
+// A backup left by an earlier install is kept as <hook>.backup.<n> rather than
+// overwritten, so the hook that was running is the one that keeps running.
+func backupHook(hookPath string) error {
+	backup := hookPath + ".backup"
+	if _, err := os.Lstat(backup); err == nil {
+		for n := 1; ; n++ {
+			older := fmt.Sprintf("%s.%d", backup, n)
+			if _, err := os.Lstat(older); os.IsNotExist(err) {
+				if err := os.Rename(backup, older); err != nil {
+					return fmt.Errorf("failed to keep earlier hook backup: %w", err)
+				}
+				break
+			}
+		}
+	}
+	if err := os.Rename(hookPath, backup); err != nil {
+		return fmt.Errorf("failed to backup existing hook: %w", err)
+	}
+	return nil
+}
+
+// installHuskyHooks adds the bd block to each hook file in a husky directory,
+// replacing the block left by an earlier install and keeping everything else
+func installHuskyHooks(huskyDir string) error {
+	for _, name := range bdHookNames {
+		hookPath := filepath.Join(huskyDir, name)
+
+		content := "#!/bin/sh\n"
+		mode := os.FileMode(0700)
+		// #nosec G304 - controlled path from git hooks directory
+		if existing, err := os.ReadFile(hookPath); err == nil {
+			content = removeHuskyBlock(string(existing), name)
+			if info, err := os.Stat(hookPath); err == nil {
+				mode = info.Mode().Perm()
+			}
+		}
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += huskyHookBlock(name)
+
+		if err := os.WriteFile(hookPath, []byte(content), mode); err != nil {
+			return fmt.Errorf("failed to write %s hook: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// hookStatus describes one bd hook slot for bd hooks status
+type hookStatus struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Installed  bool   `json:"installed"`             // A bd hook (or husky block) is present
+	Version    int    `json:"version,omitempty"`     // Version of the installed bd hook
+	Outdated   bool   `json:"outdated,omitempty"`    // Written by an older bd
+	ThirdParty bool   `json:"third_party,omitempty"` // A hook bd didn't write is in the way
+	Chained    bool   `json:"chained,omitempty"`     // The original hook was kept as <hook>.backup
+}
+
+// getHooksStatus reports the state of each bd hook in hooksDir
+func getHooksStatus(hooksDir string) []hookStatus {
+	statuses := make([]hookStatus, 0, len(bdHookNames))
+	for _, name := range bdHookNames {
+		path := filepath.Join(hooksDir, name)
+		version, isBD, exists := readHookVersion(path)
+		st := hookStatus{Name: name, Path: path}
+		switch {
+		case isBD:
+			st.Installed = true
+			st.Version = version
+			st.Outdated = version < bdHooksVersion
+		case exists:
+			st.ThirdParty = true
+		}
+		if _, err := os.Stat(path + ".backup"); err == nil {
+			st.Chained = true
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+// uninstallGitHooks removes the bd hooks from the current repository
+func uninstallGitHooks() ([]string, error) {
+	dir, husky := resolveHooksDir(".")
+	if husky {
+		return uninstallHuskyHooks(dir)
+	}
+	return uninstallGitHooksIn(dir)
+}
+
+// uninstallGitHooksIn removes bd hooks from hooksDir and moves any <hook>.backup
+// back into place. Hooks bd didn't write are left alone. Returns the hooks removed.
+func uninstallGitHooksIn(hooksDir string) ([]string, error) {
+	var removed []string
+	for _, name := range bdHookNames {
+		hookPath := filepath.Join(hooksDir, name)
+		if _, isBD, _ := readHookVersion(hookPath); !isBD {
+			continue
+		}
+		if err := os.Remove(hookPath); err != nil {
+			return removed, fmt.Errorf("failed to remove %s hook: %w", name, err)
+		}
+		removed = append(removed, name)
+
+		// Windows variant, if one was written
+		// #nosec G304 - controlled path from git directory
+		if content, err := os.ReadFile(hookPath + ".cmd"); err == nil && strings.Contains(string(content), "bd (beads)") {
+			if err := os.Remove(hookPath + ".cmd"); err != nil {
+				return removed, fmt.Errorf("failed to remove %s.cmd hook: %w", name, err)
+			}
+		}
+
+		backup := hookPath + ".backup"
+		if _, err := os.Stat(backup); err == nil {
+			if err := os.Rename(backup, hookPath); err != nil {
+				return removed, fmt.Errorf("failed to restore original %s hook: %w", name, err)
+			}
+		}
+	}
+	return removed, nil
+}
+
+// uninstallHuskyHooks removes the bd block from each husky hook file, deleting files
+// that held nothing else. Returns the hooks removed.
+func uninstallHuskyHooks(huskyDir string) ([]string, error) {
+	var removed []string
+	for _, name := range bdHookNames {
+		hookPath := filepath.Join(huskyDir, name)
+		// #nosec G304 - controlled path from git hooks directory
+		existing, err := os.ReadFile(hookPath)
+		if err != nil {
+			continue
+		}
+		content := removeHuskyBlock(string(existing), name)
+		if content == string(existing) {
+			continue
+		}
+		if strings.TrimSpace(content) == "#!/bin/sh" || strings.TrimSpace(content) == "" {
+			err = os.Remove(hookPath)
+		} else {
+			mode := os.FileMode(0700)
+			if info, statErr := os.Stat(hookPath); statErr == nil {
+				mode = info.Mode().Perm()
+			}
+			err = os.WriteFile(hookPath, []byte(content), mode)
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to update %s hook: %w", name, err)
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// isHooksCommand reports whether cmd is bd hooks or one of its subcommands,
+// which work on the git repository without opening the database
+func isHooksCommand(cmd *cobra.Command) bool {
+	return cmd == hooksCmd || cmd.Parent() == hooksCmd
+}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage the bd git hooks",
+	Long: `Manage the git hooks that keep .beads/issues.jsonl in sync with commits:
+
+  pre-commit   flushes pending changes to JSONL and stages it
+  post-merge   imports JSONL changes brought in by a pull or merge`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the bd git hooks",
+	Long: `Install the bd git hooks into .git/hooks.
+
+Existing bd hooks are replaced with the current version. Other hooks are
+moved aside to <hook>.backup and run by the bd hook before its own steps,
+so they keep working. An older <hook>.backup is kept as <hook>.backup.1
+(or the next free number) rather than overwritten.
+
+If core.hooksPath points at husky, bd's steps are appended to the husky
+hook files in .husky instead.
+
+On Windows, batch variants (<hook>.cmd) are also written. Git itself never
+runs them (Git for Windows runs the POSIX scripts through its bundled sh);
+they are for running the bd hook steps by hand from cmd.exe.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isGitRepo() {
+			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
+			os.Exit(1)
+		}
+		if err := installGitHooks(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error installing hooks: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s Installed bd git hooks (version %d)\n", renderSuccess("✓"), bdHooksVersion)
+	},
+}
+
+var hooksUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Replace bd git hooks written by an older bd",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isGitRepo() {
+			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
+			os.Exit(1)
+		}
+		if !hooksInstalled() {
+			fmt.Fprintf(os.Stderr, "Error: bd git hooks are not installed (run 'bd hooks install')\n")
+			os.Exit(1)
+		}
+
+		outdated := outdatedHooks(gitHooksDir())
+		if len(outdated) == 0 {
+			fmt.Printf("bd git hooks are up to date (version %d)\n", bdHooksVersion)
+			return
+		}
+		if err := installGitHooks(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error upgrading hooks: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s Upgraded %s to version %d\n", renderSuccess("✓"), strings.Join(outdated, ", "), bdHooksVersion)
+	},
+}
+
+var hooksStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which bd git hooks are installed",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isGitRepo() {
+			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
+			os.Exit(1)
+		}
+
+		statuses := getHooksStatus(gitHooksDir())
+		if jsonOutput {
+			outputJSON(statuses)
+			return
+		}
+
+		for _, st := range statuses {
+			var state string
+			switch {
+			case st.Outdated:
+				state = fmt.Sprintf("%s version %d (outdated, run 'bd hooks upgrade')", renderWarning("⚠"), st.Version)
+			case st.Installed:
+				state = fmt.Sprintf("%s version %d", renderSuccess("✓"), st.Version)
+			case st.ThirdParty:
+				state = "not installed (another hook is in place)"
+			default:
+				state = "not installed"
+			}
+			if st.Chained {
+				state += ", runs " + st.Name + ".backup first"
+			}
+			fmt.Printf("%-11s %s\n", st.Name+":", state)
+		}
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the bd git hooks and restore the originals",
+	Long: `Remove the bd git hooks. Hooks bd moved aside to <hook>.backup when it was
+installed are moved back into place. Hooks bd didn't write are left alone.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !isGitRepo() {
+			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
+			os.Exit(1)
+		}
+
+		removed, err := uninstallGitHooks()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error uninstalling hooks: %v\n", err)
+			os.Exit(1)
+		}
+		if removed == nil {
+			removed = []string{}
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"removed": removed})
+			return
+		}
+		if len(removed) == 0 {
+			fmt.Println("No bd git hooks installed")
+			return
+		}
+		fmt.Printf("%s Removed %s\n", renderSuccess("✓"), strings.Join(removed, ", "))
+	},
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUpgradeCmd)
+	hooksCmd.AddCommand(hooksStatusCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
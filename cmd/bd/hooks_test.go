@@ -0,0 +1,308 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// oldBDPreCommit is a pre-commit hook as written by bd before hooks were versioned
+const oldBDPreCommit = `#!/bin/sh
+#
+# bd (beads) pre-commit hook
+#
+bd sync --flush-only >/dev/null 2>&1
+`
+
+func writeHook(t *testing.T, hooksDir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(hooksDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// #nosec G306 - test hook must be executable
+	if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0700); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readHook(t *testing.T, hooksDir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(hooksDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestParseHookVersion(t *testing.T) {
+	if v, isBD := parseHookVersion(bdHookScripts["pre-commit"]); !isBD || v != bdHooksVersion {
+		t.Errorf("current hook = version %d (bd=%v), want %d", v, isBD, bdHooksVersion)
+	}
+	if v, isBD := parseHookVersion(oldBDPreCommit); !isBD || v != 0 {
+		t.Errorf("unversioned bd hook = version %d (bd=%v), want 0", v, isBD)
+	}
+	if _, isBD := parseHookVersion("#!/bin/sh\nmake lint\n"); isBD {
+		t.Error("user hook detected as a bd hook")
+	}
+}
+
+func TestInstallGitHooksUpgradesOldBDHooks(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+	writeHook(t, hooksDir, "pre-commit", oldBDPreCommit)
+
+	if got := outdatedHooks(hooksDir); len(got) != 1 || got[0] != "pre-commit" {
+		t.Fatalf("outdatedHooks = %v, want [pre-commit]", got)
+	}
+
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("installGitHooksIn failed: %v", err)
+	}
+
+	for _, name := range bdHookNames {
+		if got := readHook(t, hooksDir, name); got != bdHookScripts[name] {
+			t.Errorf("%s was not replaced with the current script", name)
+		}
+	}
+	// An old bd hook is replaced, not backed up
+	if _, err := os.Stat(filepath.Join(hooksDir, "pre-commit.backup")); !os.IsNotExist(err) {
+		t.Error("old bd hook should not be backed up")
+	}
+	if got := outdatedHooks(hooksDir); len(got) != 0 {
+		t.Errorf("outdatedHooks after install = %v", got)
+	}
+	if !hooksInstalledIn(hooksDir) {
+		t.Error("hooks should be installed")
+	}
+}
+
+func TestInstallGitHooksBacksUpUserHooks(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+	userHook := "#!/bin/sh\nmake lint\n"
+	writeHook(t, hooksDir, "pre-commit", userHook)
+
+	if hooksInstalledIn(hooksDir) {
+		t.Fatal("user hook should not count as bd hooks installed")
+	}
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("installGitHooksIn failed: %v", err)
+	}
+
+	if got := readHook(t, hooksDir, "pre-commit.backup"); got != userHook {
+		t.Errorf("backup = %q, want the user hook", got)
+	}
+	if got := readHook(t, hooksDir, "pre-commit"); !strings.Contains(got, "bd (beads) pre-commit hook") {
+		t.Error("pre-commit was not replaced with the bd hook")
+	}
+
+	// Installing again keeps the backup intact
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("second installGitHooksIn failed: %v", err)
+	}
+	if got := readHook(t, hooksDir, "pre-commit.backup"); got != userHook {
+		t.Errorf("backup after reinstall = %q, want the user hook", got)
+	}
+
+	// A new user hook replacing bd's is backed up without losing the old backup
+	newHook := "#!/bin/sh\nmake test\n"
+	writeHook(t, hooksDir, "pre-commit", newHook)
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("third installGitHooksIn failed: %v", err)
+	}
+	if got := readHook(t, hooksDir, "pre-commit.backup"); got != newHook {
+		t.Errorf("backup = %q, want the new user hook", got)
+	}
+	if got := readHook(t, hooksDir, "pre-commit.backup.1"); got != userHook {
+		t.Errorf("pre-commit.backup.1 = %q, want the earlier user hook", got)
+	}
+}
+
+func TestInstalledHookRunsOriginalHook(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitCmd(t, repoDir, "init")
+	configureGit(t, repoDir)
+
+	// The user's hook leaves a marker file behind when it runs
+	marker := filepath.Join(t.TempDir(), "user-hook-ran")
+	hooksDir := filepath.Join(repoDir, ".git", "hooks")
+	writeHook(t, hooksDir, "pre-commit", "#!/bin/sh\ntouch '"+marker+"'\n")
+
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("installGitHooksIn failed: %v", err)
+	}
+	runGitCmd(t, repoDir, "commit", "--allow-empty", "-m", "Trigger hooks")
+
+	if _, err := os.Stat(marker); err != nil {
+		t.Error("original pre-commit hook did not run after bd hooks were installed")
+	}
+}
+
+func TestInstalledHookStopsOnFailingOriginalHook(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitCmd(t, repoDir, "init")
+	configureGit(t, repoDir)
+
+	hooksDir := filepath.Join(repoDir, ".git", "hooks")
+	writeHook(t, hooksDir, "pre-commit", "#!/bin/sh\necho 'lint failed' >&2\nexit 1\n")
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("installGitHooksIn failed: %v", err)
+	}
+
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", "Should be rejected")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err == nil {
+		t.Error("commit should fail when the original pre-commit hook fails")
+	}
+}
+
+func TestInstallGitHooksIntegratesWithHusky(t *testing.T) {
+	repoDir := t.TempDir()
+	runGitCmd(t, repoDir, "init")
+	runGitCmd(t, repoDir, "config", "core.hooksPath", ".husky/_")
+
+	huskyDir := filepath.Join(repoDir, ".husky")
+	userHook := "npx lint-staged\n"
+	writeHook(t, huskyDir, "pre-commit", userHook)
+
+	dir, husky := resolveHooksDir(repoDir)
+	if !husky || dir != huskyDir {
+		t.Fatalf("resolveHooksDir = %s (husky=%v), want %s (husky)", dir, husky, huskyDir)
+	}
+
+	// Installing twice must not duplicate the bd block
+	for i := 0; i < 2; i++ {
+		if err := installHuskyHooks(dir); err != nil {
+			t.Fatalf("installHuskyHooks failed: %v", err)
+		}
+	}
+
+	content := readHook(t, huskyDir, "pre-commit")
+	if !strings.HasPrefix(content, userHook) {
+		t.Errorf("user's husky steps were not kept:\n%s", content)
+	}
+	if n := strings.Count(content, huskyBlockStart("pre-commit")); n != 1 {
+		t.Errorf("expected one bd block, found %d:\n%s", n, content)
+	}
+	if !hooksInstalledIn(huskyDir) {
+		t.Error("bd hooks should count as installed in the husky directory")
+	}
+	if got := outdatedHooks(huskyDir); len(got) != 0 {
+		t.Errorf("outdatedHooks = %v, want none", got)
+	}
+	if _, err := os.Stat(filepath.Join(huskyDir, "_", "pre-commit")); !os.IsNotExist(err) {
+		t.Error("husky's generated scripts should be left alone")
+	}
+}
+
+func TestHooksInstallStatusUninstall(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+	userHook := "#!/bin/sh\nmake lint\n"
+	writeHook(t, hooksDir, "pre-commit", userHook)
+
+	statuses := getHooksStatus(hooksDir)
+	if !statuses[0].ThirdParty || statuses[0].Installed || statuses[1].Installed {
+		t.Fatalf("unexpected status before install: %+v", statuses)
+	}
+
+	if err := installGitHooksIn(hooksDir); err != nil {
+		t.Fatalf("installGitHooksIn failed: %v", err)
+	}
+	for _, st := range getHooksStatus(hooksDir) {
+		if !st.Installed || st.Version != bdHooksVersion || st.Outdated || st.ThirdParty {
+			t.Errorf("unexpected status after install: %+v", st)
+		}
+		if want := st.Name == "pre-commit"; st.Chained != want {
+			t.Errorf("%s chained = %v, want %v", st.Name, st.Chained, want)
+		}
+	}
+
+	removed, err := uninstallGitHooksIn(hooksDir)
+	if err != nil {
+		t.Fatalf("uninstallGitHooksIn failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("removed = %v, want both hooks", removed)
+	}
+	if got := readHook(t, hooksDir, "pre-commit"); got != userHook {
+		t.Errorf("original pre-commit not restored, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, "pre-commit.backup")); !os.IsNotExist(err) {
+		t.Error("backup should be moved back into place")
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, "post-merge")); !os.IsNotExist(err) {
+		t.Error("bd post-merge hook should be removed")
+	}
+
+	// Uninstalling again leaves the user's hook alone
+	removed, err = uninstallGitHooksIn(hooksDir)
+	if err != nil || len(removed) != 0 {
+		t.Errorf("second uninstall = %v, %v; want nothing removed", removed, err)
+	}
+	if got := readHook(t, hooksDir, "pre-commit"); got != userHook {
+		t.Errorf("user hook changed by second uninstall: %q", got)
+	}
+}
+
+func TestUninstallHuskyHooks(t *testing.T) {
+	huskyDir := filepath.Join(t.TempDir(), ".husky")
+	userHook := "npx lint-staged\n"
+	writeHook(t, huskyDir, "pre-commit", userHook)
+
+	if err := installHuskyHooks(huskyDir); err != nil {
+		t.Fatalf("installHuskyHooks failed: %v", err)
+	}
+	if _, err := uninstallHuskyHooks(huskyDir); err != nil {
+		t.Fatalf("uninstallHuskyHooks failed: %v", err)
+	}
+
+	if got := readHook(t, huskyDir, "pre-commit"); got != userHook {
+		t.Errorf("husky pre-commit = %q, want the user's steps only", got)
+	}
+	// bd created post-merge itself, so it's removed entirely
+	if _, err := os.Stat(filepath.Join(huskyDir, "post-merge")); !os.IsNotExist(err) {
+		t.Error("post-merge created by bd should be removed")
+	}
+}
+
+func TestInstallGitHooksPerGOOS(t *testing.T) {
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			hooksDir := filepath.Join(t.TempDir(), "hooks")
+			if err := installGitHooksFor(hooksDir, goos); err != nil {
+				t.Fatalf("installGitHooksFor failed: %v", err)
+			}
+
+			for _, name := range bdHookNames {
+				// The POSIX script is always written; Git for Windows runs it too
+				if got := readHook(t, hooksDir, name); got != bdHookScripts[name] {
+					t.Errorf("%s is not the POSIX script", name)
+				}
+				// The batch helpers chain the backed-up hook like the POSIX scripts
+				if !strings.Contains(windowsHookScripts[name], `sh "%~dpn0.backup"`) {
+					t.Errorf("%s.cmd doesn't run %s.backup", name, name)
+				}
+
+				_, err := os.Stat(filepath.Join(hooksDir, name+".cmd"))
+				if goos == "windows" {
+					if err != nil {
+						t.Errorf("%s.cmd not written on windows: %v", name, err)
+					} else if got := readHook(t, hooksDir, name+".cmd"); got != windowsHookScripts[name] {
+						t.Errorf("%s.cmd is not the batch script", name)
+					}
+				} else if !os.IsNotExist(err) {
+					t.Errorf("%s.cmd should only be written on windows", name)
+				}
+			}
+
+			// Uninstall removes the variants too
+			if _, err := uninstallGitHooksIn(hooksDir); err != nil {
+				t.Fatalf("uninstallGitHooksIn failed: %v", err)
+			}
+			entries, _ := os.ReadDir(hooksDir)
+			if len(entries) != 0 {
+				t.Errorf("hooks left after uninstall: %v", entries)
+			}
+		})
+	}
+}
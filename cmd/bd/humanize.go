@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// HumanizeTime renders t relative to now, e.g. "3 days ago" or "in 2 hours".
+// Used for human-readable output only; --json keeps RFC3339 timestamps.
+func HumanizeTime(t time.Time) string {
+	return humanizeTimeAt(t, time.Now())
+}
+
+// humanizeTimeAt renders t relative to now, rounding down to the largest whole unit
+func humanizeTimeAt(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+
+	const (
+		day   = 24 * time.Hour
+		week  = 7 * day
+		month = 30 * day
+		year  = 365 * day
+	)
+	var n int
+	var unit string
+	switch {
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < day:
+		n, unit = int(d/time.Hour), "hour"
+	case d < week:
+		n, unit = int(d/day), "day"
+	case d < month:
+		n, unit = int(d/week), "week"
+	case d < year:
+		n, unit = int(d/month), "month"
+	default:
+		n, unit = int(d/year), "year"
+	}
+
+	amount := fmt.Sprintf("%d %s", n, pluralize(n, unit, unit+"s"))
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
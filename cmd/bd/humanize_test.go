@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeTimeAt(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   string
+	}{
+		{"now", 0, "just now"},
+		{"seconds ago", -59 * time.Second, "just now"},
+		{"seconds ahead", 30 * time.Second, "just now"},
+		{"one minute ago", -time.Minute, "1 minute ago"},
+		{"minutes ago", -59 * time.Minute, "59 minutes ago"},
+		{"one hour ago", -time.Hour, "1 hour ago"},
+		{"hours ago", -23*time.Hour - 59*time.Minute, "23 hours ago"},
+		{"one day ago", -24 * time.Hour, "1 day ago"},
+		{"days ago", -3 * 24 * time.Hour, "3 days ago"},
+		{"six days ago", -(7*24*time.Hour - time.Minute), "6 days ago"},
+		{"one week ago", -7 * 24 * time.Hour, "1 week ago"},
+		{"weeks ago", -29 * 24 * time.Hour, "4 weeks ago"},
+		{"one month ago", -30 * 24 * time.Hour, "1 month ago"},
+		{"one year ago", -365 * 24 * time.Hour, "1 year ago"},
+		{"in minutes", 5 * time.Minute, "in 5 minutes"},
+		{"in hours", 2 * time.Hour, "in 2 hours"},
+		{"in one day", 36 * time.Hour, "in 1 day"},
+		{"in weeks", 14 * 24 * time.Hour, "in 2 weeks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := humanizeTimeAt(now.Add(tt.offset), now); got != tt.want {
+				t.Errorf("humanizeTimeAt(now%+v) = %q, want %q", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
@@ -10,11 +10,12 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 var importCmd = &cobra.Command{
-	Use:   "import",
+	Use:   "import [path]",
 	Short: "Import issues from JSONL format",
 	Long: `Import issues from JSON Lines format (one JSON object per line).
 
@@ -26,15 +27,105 @@ Behavior:
   - Collisions (same ID, different content) are detected
   - Use --resolve-collisions to automatically remap colliding issues
   - Use --dedupe-after to find and merge content duplicates after import
-  - Use --dry-run to preview changes without applying them`,
+  - Use --dry-run to preview changes without applying them
+  - Use --include events,comments to also restore the sidecar files written
+    by 'bd export --include' (e.g. backup_events.jsonl next to backup.jsonl)
+
+Other sources (--from) create new issues rather than syncing by ID:
+  bd import --from markdown-dir docs/issues
+    Each .md file becomes an issue. YAML frontmatter keys title, status,
+    priority, type, assignee, and labels (or tags) are mapped; without a
+    title the file name is used, and the body becomes the description.
+    Statuses and priorities are read leniently ("Done", "High", "P1").
+    Files that can't be mapped are reported and left out.
+  bd import --from csv tracker.csv --mapping title=Summary,status=State
+    Each row after the header becomes an issue. Columns named after a field
+    (or a common synonym like summary, state, owner, tags) map to it unless
+    --mapping says otherwise. Fields: id, title, description, design,
+    acceptance_criteria, notes, status, priority, type, assignee, labels,
+    external_ref. A row whose id is already taken is skipped, or given a new
+    ID with --resolve-collisions. Rows that can't be mapped are reported.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		input, _ := cmd.Flags().GetString("input")
+		from, _ := cmd.Flags().GetString("from")
+		mappingSpec, _ := cmd.Flags().GetString("mapping")
 		skipUpdate, _ := cmd.Flags().GetBool("skip-existing")
 		strict, _ := cmd.Flags().GetBool("strict")
 		resolveCollisions, _ := cmd.Flags().GetBool("resolve-collisions")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		renameOnImport, _ := cmd.Flags().GetBool("rename-on-import")
 		dedupeAfter, _ := cmd.Flags().GetBool("dedupe-after")
+		includeValue, _ := cmd.Flags().GetString("include")
+
+		if len(args) == 1 {
+			if input != "" {
+				fmt.Fprintf(os.Stderr, "Error: give the input as an argument or with -i, not both\n")
+				os.Exit(1)
+			}
+			input = args[0]
+		}
+
+		switch from {
+		case importSourceJSONL:
+		case importSourceMarkdownDir:
+			if input == "" {
+				fmt.Fprintf(os.Stderr, "Error: --from %s needs a directory\n", from)
+				os.Exit(1)
+			}
+			items, skipped, err := parseMarkdownDir(input)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			runForeignImport(from, items, skipped, dryRun, resolveCollisions)
+			return
+		case importSourceCSV:
+			mapping, err := parseCSVMapping(mappingSpec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			in := os.Stdin
+			if input != "" {
+				// #nosec G304 - user-provided file path is intentional
+				f, err := os.Open(input)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error opening input file: %v\n", err)
+					os.Exit(1)
+				}
+				defer func() { _ = f.Close() }()
+				in = f
+			}
+			items, skipped, unused, err := parseCSVIssues(in, mapping)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(unused) > 0 {
+				fmt.Fprintf(os.Stderr, "Note: ignored columns: %s\n", strings.Join(unused, ", "))
+			}
+			runForeignImport(from, items, skipped, dryRun, resolveCollisions)
+			return
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown import source %q (valid: %s, %s, %s)\n",
+				from, importSourceJSONL, importSourceMarkdownDir, importSourceCSV)
+			os.Exit(1)
+		}
+		if mappingSpec != "" && from != importSourceCSV {
+			fmt.Fprintf(os.Stderr, "Error: --mapping only applies to --from csv\n")
+			os.Exit(1)
+		}
+
+		include, err := parseBundleInclude(includeValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(include) > 0 && input == "" {
+			fmt.Fprintf(os.Stderr, "Error: --include requires an input file (-i)\n")
+			os.Exit(1)
+		}
 
 		// Open input
 		in := os.Stdin
@@ -58,6 +149,7 @@ Behavior:
 		scanner := bufio.NewScanner(in)
 
 		var allIssues []*types.Issue
+		var sourceLines []int
 		lineNum := 0
 
 		for scanner.Scan() {
@@ -69,6 +161,13 @@ Behavior:
 				continue
 			}
 
+			// Detect unresolved git merge conflicts before attempting to parse
+			if isConflictMarkerLine(line) {
+				fmt.Fprintf(os.Stderr, "Error: git merge conflict marker at line %d\n", lineNum)
+				fmt.Fprintf(os.Stderr, "Run 'bd merge-resolve <file>' to reconcile both sides, then import again.\n")
+				os.Exit(1)
+			}
+
 			// Parse JSON
 			var issue types.Issue
 			if err := json.Unmarshal([]byte(line), &issue); err != nil {
@@ -77,6 +176,7 @@ Behavior:
 			}
 
 			allIssues = append(allIssues, &issue)
+			sourceLines = append(sourceLines, lineNum)
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -91,12 +191,20 @@ Behavior:
 			SkipUpdate:        skipUpdate,
 			Strict:            strict,
 			RenameOnImport:    renameOnImport,
+			SourceLines:       sourceLines,
 		}
 
 		result, err := importIssuesCore(ctx, dbPath, store, allIssues, opts)
 
 		// Handle errors and special cases
 		if err != nil {
+			// Check if it's a schema validation error (strict mode)
+			if result != nil && len(result.ValidationErrors) > 0 {
+				printValidationErrors(result.ValidationErrors)
+				fmt.Fprintf(os.Stderr, "\nImport aborted: fix the invalid lines or re-run without --strict to skip them.\n")
+				os.Exit(1)
+			}
+
 			// Check if it's a prefix mismatch error
 			if result != nil && result.PrefixMismatch {
 				fmt.Fprintf(os.Stderr, "\n=== Prefix Mismatch Detected ===\n")
@@ -126,6 +234,18 @@ Behavior:
 			os.Exit(1)
 		}
 
+		// Nothing to do if this exact content was already imported
+		// (sidecar files may still carry new events or comments)
+		if result.SkippedWholeImport && len(include) == 0 {
+			fmt.Fprintf(os.Stderr, "Import skipped: content unchanged since last import (%d issues)\n", result.Unchanged)
+			return
+		}
+
+		// Report issues skipped due to schema violations
+		if len(result.ValidationErrors) > 0 {
+			printValidationErrors(result.ValidationErrors)
+		}
+
 		// Handle dry-run mode
 		if dryRun {
 			if result.PrefixMismatch {
@@ -179,6 +299,20 @@ Behavior:
 			fmt.Fprintf(os.Stderr, "\nAll text and dependency references have been updated.\n")
 		}
 
+		// Report dependencies that couldn't be imported because their target is missing
+		if len(result.DanglingDependencies) > 0 {
+			fmt.Fprintf(os.Stderr, "\n=== Dangling Dependencies ===\n")
+			fmt.Fprintf(os.Stderr, "Skipped %d dependencies whose target does not exist:\n", len(result.DanglingDependencies))
+			for _, dep := range result.DanglingDependencies {
+				fmt.Fprintf(os.Stderr, "  %s → %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+			}
+		}
+
+		// Restore events and comments from sidecar files
+		if len(include) > 0 {
+			importBundleSidecars(ctx, input, include, result.IDMapping)
+		}
+
 		// Schedule auto-flush after import completes
 		markDirtyAndScheduleFlush()
 
@@ -200,7 +334,7 @@ Behavior:
 			fmt.Fprintf(os.Stderr, "\n=== Post-Import Duplicate Detection ===\n")
 
 			// Get all issues (fresh after import)
-			allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error fetching issues for deduplication: %v\n", err)
 				os.Exit(1)
@@ -245,13 +379,49 @@ Behavior:
 	},
 }
 
+// importBundleSidecars imports the events and comments sidecar files next to input
+func importBundleSidecars(ctx context.Context, input string, include []string, idMapping map[string]string) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		var err error
+		sqliteStore, err = openLocalStore(dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = sqliteStore.Close() }()
+	}
+
+	for _, kind := range include {
+		path := bundleSidecarPath(input, kind)
+		count, err := importBundleSidecar(ctx, sqliteStore, path, kind, idMapping)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error importing %s: %v\n", kind, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Imported %d %s from %s\n", count, kind, path)
+	}
+}
+
+// printValidationErrors reports schema violations found during import
+func printValidationErrors(errs []ImportError) {
+	fmt.Fprintf(os.Stderr, "\n=== Validation Errors ===\n")
+	fmt.Fprintf(os.Stderr, "Invalid issues: %d error(s)\n\n", len(errs))
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "  %s\n", e.Error())
+	}
+}
+
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+	importCmd.Flags().String("from", importSourceJSONL, "Source format: jsonl, markdown-dir, or csv")
+	importCmd.Flags().String("mapping", "", "CSV column mapping as field=Column pairs, e.g. title=Summary,status=State")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
-	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
+	importCmd.Flags().Bool("strict", false, "Fail on validation or dependency errors instead of skipping/warning")
 	importCmd.Flags().Bool("resolve-collisions", false, "Automatically resolve ID collisions by remapping")
 	importCmd.Flags().Bool("dedupe-after", false, "Detect and report content duplicates after import")
 	importCmd.Flags().Bool("dry-run", false, "Preview collision detection without making changes")
 	importCmd.Flags().Bool("rename-on-import", false, "Rename imported issues to match database prefix (updates all references)")
+	importCmd.Flags().String("include", "", "Also import sidecar files written by 'bd export --include' (events,comments)")
 	rootCmd.AddCommand(importCmd)
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -89,3 +90,61 @@ func TestImportReturnsCorrectCounts(t *testing.T) {
 	t.Logf("Second import: Created=%d, Updated=%d, Unchanged=%d, Skipped=%d",
 		result2.Created, result2.Updated, result2.Unchanged, result2.Skipped)
 }
+
+// TestImportAppliesArchivedAt verifies that archiving and unarchiving an
+// existing issue in another clone reaches this one through import
+func TestImportAppliesArchivedAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		ID:        "test-1",
+		Title:     "Archive me",
+		Status:    types.StatusClosed,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	issue.ClosedAt = &closedAt
+	opts := ImportOptions{}
+	if _, err := importIssuesCore(ctx, dbPath, store, []*types.Issue{issue}, opts); err != nil {
+		t.Fatalf("Initial import failed: %v", err)
+	}
+
+	archived := *issue
+	archivedAt := time.Now().Truncate(time.Second)
+	archived.ArchivedAt = &archivedAt
+	result, err := importIssuesCore(ctx, dbPath, store, []*types.Issue{&archived}, opts)
+	if err != nil {
+		t.Fatalf("Archive import failed: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Archive import: expected Updated=1, got %d", result.Updated)
+	}
+	got, err := store.GetIssue(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("Failed to get issue: %v", err)
+	}
+	if got.ArchivedAt == nil || !got.ArchivedAt.Equal(archivedAt) {
+		t.Errorf("Expected archived_at %v after import, got %v", archivedAt, got.ArchivedAt)
+	}
+
+	unarchived := *issue
+	unarchived.ArchivedAt = nil
+	result, err = importIssuesCore(ctx, dbPath, store, []*types.Issue{&unarchived}, opts)
+	if err != nil {
+		t.Fatalf("Unarchive import failed: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Unarchive import: expected Updated=1, got %d", result.Updated)
+	}
+	got, err = store.GetIssue(ctx, "test-1")
+	if err != nil {
+		t.Fatalf("Failed to get issue: %v", err)
+	}
+	if got.ArchivedAt != nil {
+		t.Errorf("Expected archived_at cleared after import, got %v", got.ArchivedAt)
+	}
+}
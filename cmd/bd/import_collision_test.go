@@ -732,3 +732,82 @@ func TestImportCounterSyncAfterHighID(t *testing.T) {
 		t.Errorf("Expected new issue to get ID bd-101, got %s", newIssue.ID)
 	}
 }
+
+// TestImportRemapsEmbeddedDependencies verifies that when collision resolution remaps an
+// imported issue, dependencies in the import batch follow it to the new ID
+func TestImportRemapsEmbeddedDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	testStore := newTestStoreWithPrefix(t, dbPath, "bd")
+	ctx := context.Background()
+
+	existing := &types.Issue{
+		ID:        "bd-1",
+		Title:     "Existing issue",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	if err := testStore.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("Failed to create existing issue: %v", err)
+	}
+
+	jsonl := `{"id":"bd-1","title":"Incoming issue","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-52","type":"related"}]}
+{"id":"bd-50","title":"Depends on incoming bd-1","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"bd-50","depends_on_id":"bd-1","type":"blocks"}]}
+{"id":"bd-51","title":"Depends on missing issue","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"bd-51","depends_on_id":"bd-99","type":"blocks"}]}
+{"id":"bd-52","title":"Plain issue","status":"open","priority":3,"issue_type":"chore"}`
+
+	var issues []*types.Issue
+	for _, line := range strings.Split(jsonl, "\n") {
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("Failed to parse JSONL: %v", err)
+		}
+		issues = append(issues, &issue)
+	}
+
+	result, err := importIssuesCore(ctx, dbPath, testStore, issues, ImportOptions{ResolveCollisions: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	newID, ok := result.IDMapping["bd-1"]
+	if !ok {
+		t.Fatalf("Expected bd-1 to be remapped, got mapping %v", result.IDMapping)
+	}
+
+	// bd-50's dependency follows the remapped issue
+	deps, err := testStore.GetDependencyRecords(ctx, "bd-50")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != newID {
+		t.Errorf("Expected bd-50 to depend on %s, got %v", newID, deps)
+	}
+
+	// The remapped issue owns its own dependencies under the new ID
+	deps, err = testStore.GetDependencyRecords(ctx, newID)
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-52" {
+		t.Errorf("Expected %s to depend on bd-52, got %v", newID, deps)
+	}
+
+	// The existing bd-1 is untouched
+	deps, err = testStore.GetDependencyRecords(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 0 {
+		t.Errorf("Expected existing bd-1 to have no dependencies, got %v", deps)
+	}
+
+	// The dependency on a nonexistent issue is surfaced as dangling
+	if len(result.DanglingDependencies) != 1 {
+		t.Fatalf("Expected 1 dangling dependency, got %v", result.DanglingDependencies)
+	}
+	if dep := result.DanglingDependencies[0]; dep.IssueID != "bd-51" || dep.DependsOnID != "bd-99" {
+		t.Errorf("Unexpected dangling dependency: %s → %s", dep.IssueID, dep.DependsOnID)
+	}
+}
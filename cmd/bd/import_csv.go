@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// csvFields are the issue fields a CSV column can map to
+var csvFields = []string{
+	"id", "title", "description", "design", "acceptance_criteria", "notes",
+	"status", "priority", "type", "assignee", "labels", "external_ref",
+}
+
+// csvColumnAliases are the header names, besides the field's own name, that
+// map to a field when --mapping doesn't say. Names are compared after
+// normalizeForeignValue.
+var csvColumnAliases = map[string][]string{
+	"title":       {"summary", "name", "subject"},
+	"description": {"body", "details"},
+	"status":      {"state"},
+	"type":        {"issue_type", "kind"},
+	"assignee":    {"owner", "assigned_to"},
+	"labels":      {"tags"},
+}
+
+// parseCSVMapping parses --mapping, a comma-separated list of field=Column
+// pairs, into a map from field to column name
+func parseCSVMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		column = strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q (expected field=Column)", pair)
+		}
+		if !isCSVField(field) {
+			return nil, fmt.Errorf("unknown field %q in mapping (valid: %s)", field, strings.Join(csvFields, ", "))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+func isCSVField(field string) bool {
+	for _, f := range csvFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCSVColumns finds the column index of each field: the column named in
+// mapping, or else a column named after the field or one of its aliases. It
+// also returns the header names of the columns no field uses.
+func resolveCSVColumns(header []string, mapping map[string]string) (map[string]int, []string, error) {
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, dup := byName[normalizeForeignValue(name)]; !dup {
+			byName[normalizeForeignValue(name)] = i
+		}
+	}
+
+	columns := make(map[string]int)
+	used := make(map[int]bool)
+	for _, field := range csvFields {
+		if column, ok := mapping[field]; ok {
+			i, found := byName[normalizeForeignValue(column)]
+			if !found {
+				return nil, nil, fmt.Errorf("mapping for %s names column %q, which is not in the header", field, column)
+			}
+			columns[field] = i
+			used[i] = true
+		}
+	}
+	for _, field := range csvFields {
+		if _, ok := columns[field]; ok {
+			continue
+		}
+		for _, name := range append([]string{field}, csvColumnAliases[field]...) {
+			if i, found := byName[name]; found && !used[i] {
+				columns[field] = i
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, nil, fmt.Errorf("no title column (map one with --mapping title=Column)")
+	}
+
+	var unused []string
+	for i, name := range header {
+		if !used[i] {
+			unused = append(unused, name)
+		}
+	}
+	return columns, unused, nil
+}
+
+// parseCSVIssues reads a CSV with a header row as issues, mapping columns to
+// fields per mapping (see resolveCSVColumns). Rows that can't be mapped are
+// returned as skips. It also returns the columns that were ignored.
+func parseCSVIssues(r io.Reader, mapping map[string]string) ([]*foreignIssue, []foreignSkip, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns, unused, err := resolveCSVColumns(header, mapping)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var items []*foreignIssue
+	var skipped []foreignSkip
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				skipped = append(skipped, foreignSkip{Source: fmt.Sprintf("line %d", parseErr.StartLine), Reason: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		source := fmt.Sprintf("line %d", line)
+
+		item, err := csvRecordToIssue(source, record, columns)
+		if err != nil {
+			skipped = append(skipped, foreignSkip{Source: source, Reason: err.Error()})
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, skipped, unused, nil
+}
+
+// csvRecordToIssue maps one CSV row to an issue
+func csvRecordToIssue(source string, record []string, columns map[string]int) (*foreignIssue, error) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	issue := &types.Issue{
+		ID:                 value("id"),
+		Title:              value("title"),
+		Description:        value("description"),
+		Design:             value("design"),
+		AcceptanceCriteria: value("acceptance_criteria"),
+		Notes:              value("notes"),
+		Assignee:           value("assignee"),
+	}
+	if issue.Title == "" {
+		return nil, fmt.Errorf("no title")
+	}
+
+	status, err := parseLenientStatus(value("status"))
+	if err != nil {
+		return nil, err
+	}
+	setForeignStatus(issue, status)
+	if issue.Priority, err = parseLenientPriority(value("priority")); err != nil {
+		return nil, err
+	}
+	if issue.IssueType, err = parseLenientIssueType(value("type")); err != nil {
+		return nil, err
+	}
+	if ref := value("external_ref"); ref != "" {
+		issue.ExternalRef = &ref
+	}
+	if err := issue.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &foreignIssue{Source: source, Issue: issue, Labels: splitForeignList(value("labels"))}, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const sampleCSV = `Key,Summary,State,Priority,Issue Type,Owner,Tags,Details,Sprint
+test-10,"Fix login, then logout",In Progress,High,Bug,alice,"auth; frontend","Steps:
+1. Log in
+2. Log out",S1
+,Write docs,Done,P3,Story,,docs,"He said ""ship it""",S1
+,Tidy scripts,To Do,,,bob,,,S2
+,,Open,1,Task,,,,S2
+,Unknown state,Someday,1,Task,,,,S2
+,Odd priority,Open,urgent-ish,Task,,,,S2
+test-10,Duplicate key,Open,2,Task,,,,S3
+`
+
+func TestParseCSVIssues(t *testing.T) {
+	mapping, err := parseCSVMapping("id=Key, status=State")
+	if err != nil {
+		t.Fatalf("parseCSVMapping failed: %v", err)
+	}
+	items, skipped, unused, err := parseCSVIssues(strings.NewReader(sampleCSV), mapping)
+	if err != nil {
+		t.Fatalf("parseCSVIssues failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(unused, []string{"Sprint"}) {
+		t.Errorf("unused columns = %v, want [Sprint]", unused)
+	}
+	if len(items) != 4 {
+		t.Fatalf("mapped %d rows, want 4", len(items))
+	}
+
+	first := items[0].Issue
+	if first.ID != "test-10" || first.Title != "Fix login, then logout" || first.Status != types.StatusInProgress ||
+		first.Priority != 1 || first.IssueType != types.TypeBug || first.Assignee != "alice" {
+		t.Errorf("first row = %+v", first)
+	}
+	if first.Description != "Steps:\n1. Log in\n2. Log out" {
+		t.Errorf("multi-line description = %q", first.Description)
+	}
+	if !reflect.DeepEqual(items[0].Labels, []string{"auth", "frontend"}) {
+		t.Errorf("labels = %v", items[0].Labels)
+	}
+
+	second := items[1].Issue
+	if second.Status != types.StatusClosed || second.ClosedAt == nil || second.Priority != 3 ||
+		second.IssueType != types.TypeFeature || second.Description != `He said "ship it"` {
+		t.Errorf("second row = %+v", second)
+	}
+	third := items[2].Issue
+	if third.Status != types.StatusOpen || third.Priority != 2 || third.IssueType != types.TypeTask || third.Assignee != "bob" {
+		t.Errorf("row with defaults = %+v", third)
+	}
+
+	// Quoted newlines mean source lines aren't row numbers
+	wantSkips := map[string]string{
+		"line 7": "no title",
+		"line 8": `unknown status "Someday"`,
+		"line 9": `unknown priority "urgent-ish"`,
+	}
+	if len(skipped) != len(wantSkips) {
+		t.Fatalf("skipped = %v, want %v", skipped, wantSkips)
+	}
+	for _, skip := range skipped {
+		if !strings.Contains(skip.Reason, wantSkips[skip.Source]) || wantSkips[skip.Source] == "" {
+			t.Errorf("%s skipped with %q, want %q", skip.Source, skip.Reason, wantSkips[skip.Source])
+		}
+	}
+}
+
+func TestParseCSVMappingErrors(t *testing.T) {
+	for _, spec := range []string{"title", "bogus=Summary", "title="} {
+		if _, err := parseCSVMapping(spec); err == nil {
+			t.Errorf("parseCSVMapping(%q) succeeded, want error", spec)
+		}
+	}
+
+	mapping, _ := parseCSVMapping("title=Headline")
+	if _, _, _, err := parseCSVIssues(strings.NewReader("Summary\nx\n"), mapping); err == nil || !strings.Contains(err.Error(), "Headline") {
+		t.Errorf("missing mapped column error = %v", err)
+	}
+	if _, _, _, err := parseCSVIssues(strings.NewReader("Foo,Bar\nx,y\n"), nil); err == nil || !strings.Contains(err.Error(), "no title column") {
+		t.Errorf("missing title column error = %v", err)
+	}
+}
+
+func TestImportCSVCollisions(t *testing.T) {
+	testStore := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	existing := &types.Issue{ID: "test-10", Title: "Already here", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	read := func() []*foreignIssue {
+		items, _, _, err := parseCSVIssues(strings.NewReader(sampleCSV), map[string]string{"id": "Key"})
+		if err != nil {
+			t.Fatalf("parseCSVIssues failed: %v", err)
+		}
+		return items
+	}
+
+	created, failed := createForeignIssues(ctx, testStore, read(), false)
+	if len(created) != 2 || len(failed) != 2 {
+		t.Fatalf("without resolving: created %d, failed %v", len(created), failed)
+	}
+	for _, skip := range failed {
+		if !strings.Contains(skip.Reason, "test-10 already exists") {
+			t.Errorf("%s failed with %q", skip.Source, skip.Reason)
+		}
+	}
+	if got, _ := testStore.GetIssue(ctx, "test-10"); got.Title != "Already here" {
+		t.Errorf("test-10 was overwritten: %q", got.Title)
+	}
+
+	created, failed = createForeignIssues(ctx, testStore, read(), true)
+	if len(failed) != 0 || len(created) != 4 {
+		t.Fatalf("resolving: created %d, failed %v", len(created), failed)
+	}
+	for _, issue := range created {
+		if issue.ID == "test-10" {
+			t.Errorf("%q reused the taken ID test-10", issue.Title)
+		}
+	}
+	labels, _ := testStore.GetLabels(ctx, created[0].ID)
+	if !reflect.DeepEqual(labels, []string{"auth", "frontend"}) {
+		t.Errorf("labels of %s = %v", created[0].ID, labels)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Sources 'bd import --from' can read
+const (
+	importSourceJSONL       = "jsonl"
+	importSourceMarkdownDir = "markdown-dir"
+	importSourceCSV         = "csv"
+)
+
+// foreignIssue is an issue read from a source that isn't bd's own format. It
+// has no ID until it is created.
+type foreignIssue struct {
+	Source string // Where it came from (file or row), for reports
+	Issue  *types.Issue
+	Labels []string
+	Notes  []string // Input that was read but ignored
+}
+
+// foreignSkip is input that could not be turned into an issue
+type foreignSkip struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// statusAliases maps the status names other trackers use to bd's statuses.
+// Keys are normalized by normalizeForeignValue.
+var statusAliases = map[string]types.Status{
+	"open": types.StatusOpen, "todo": types.StatusOpen, "to_do": types.StatusOpen,
+	"new": types.StatusOpen, "backlog": types.StatusOpen, "proposed": types.StatusOpen,
+	"in_progress": types.StatusInProgress, "doing": types.StatusInProgress, "wip": types.StatusInProgress,
+	"started": types.StatusInProgress, "active": types.StatusInProgress, "in_review": types.StatusInProgress,
+	"blocked": types.StatusBlocked, "on_hold": types.StatusBlocked, "waiting": types.StatusBlocked,
+	"closed": types.StatusClosed, "done": types.StatusClosed, "resolved": types.StatusClosed,
+	"fixed": types.StatusClosed, "complete": types.StatusClosed, "completed": types.StatusClosed,
+	"wontfix": types.StatusClosed, "wont_fix": types.StatusClosed, "cancelled": types.StatusClosed, "canceled": types.StatusClosed,
+}
+
+// priorityAliases maps priority names to bd's 0-4 scale
+var priorityAliases = map[string]int{
+	"critical": 0, "blocker": 0, "urgent": 0, "highest": 0,
+	"high": 1, "major": 1,
+	"medium": 2, "normal": 2, "moderate": 2,
+	"low": 3, "minor": 3,
+	"lowest": 4, "trivial": 4,
+}
+
+// typeAliases maps issue type names to bd's types
+var typeAliases = map[string]types.IssueType{
+	"bug": types.TypeBug, "defect": types.TypeBug,
+	"feature": types.TypeFeature, "story": types.TypeFeature, "enhancement": types.TypeFeature,
+	"task": types.TypeTask, "epic": types.TypeEpic,
+	"chore": types.TypeChore, "maintenance": types.TypeChore,
+}
+
+// normalizeForeignValue lowercases s and joins its words with underscores, so
+// "In Progress", "in-progress" and "IN_PROGRESS" compare equal
+func normalizeForeignValue(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer("-", " ", "_", " ", "'", "").Replace(s)
+	return strings.Join(strings.Fields(s), "_")
+}
+
+// parseLenientStatus reads a status as other trackers write it. Empty means open.
+func parseLenientStatus(s string) (types.Status, error) {
+	if strings.TrimSpace(s) == "" {
+		return types.StatusOpen, nil
+	}
+	if status, ok := statusAliases[normalizeForeignValue(s)]; ok {
+		return status, nil
+	}
+	return "", fmt.Errorf("unknown status %q", s)
+}
+
+// parseLenientPriority reads a priority as 0-4, P0-P4, or a name like "high".
+// Empty means the default priority, 2.
+func parseLenientPriority(s string) (int, error) {
+	value := normalizeForeignValue(s)
+	if value == "" {
+		return 2, nil
+	}
+	if p, err := strconv.Atoi(strings.TrimPrefix(value, "p")); err == nil && p >= 0 && p <= 4 {
+		return p, nil
+	}
+	if p, ok := priorityAliases[value]; ok {
+		return p, nil
+	}
+	return 0, fmt.Errorf("unknown priority %q", s)
+}
+
+// parseLenientIssueType reads an issue type, accepting common synonyms.
+// Empty means task.
+func parseLenientIssueType(s string) (types.IssueType, error) {
+	value := normalizeForeignValue(s)
+	if value == "" {
+		return types.TypeTask, nil
+	}
+	if t, ok := typeAliases[value]; ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown issue type %q", s)
+}
+
+// splitForeignList splits a list separated by commas or semicolons, dropping
+// empty entries
+func splitForeignList(s string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// setForeignStatus sets the issue's status, stamping closed_at for closed issues
+func setForeignStatus(issue *types.Issue, status types.Status) {
+	issue.Status = status
+	issue.ClosedAt = nil
+	if status == types.StatusClosed {
+		now := time.Now()
+		issue.ClosedAt = &now
+	}
+}
+
+// createForeignIssues creates each issue and adds its labels. An issue whose
+// ID is already taken, in the database or earlier in items, is a collision:
+// it is skipped, or with resolveCollisions given a new ID. Issues that fail
+// to create are returned as skips; label failures are warnings.
+func createForeignIssues(ctx context.Context, s storage.Storage, items []*foreignIssue, resolveCollisions bool) ([]*types.Issue, []foreignSkip) {
+	var created []*types.Issue
+	var failed []foreignSkip
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if id := item.Issue.ID; id != "" {
+			existing, err := s.GetIssue(ctx, id)
+			if err != nil {
+				failed = append(failed, foreignSkip{Source: item.Source, Reason: err.Error()})
+				continue
+			}
+			if existing != nil || seen[id] {
+				if !resolveCollisions {
+					failed = append(failed, foreignSkip{Source: item.Source,
+						Reason: fmt.Sprintf("ID %s already exists (use --resolve-collisions to give it a new ID)", id)})
+					continue
+				}
+				item.Issue.ID = ""
+				item.Notes = append(item.Notes, fmt.Sprintf("ID %s already exists, created with a new ID", id))
+			}
+			seen[id] = true
+		}
+
+		if err := s.CreateIssue(ctx, item.Issue, actor); err != nil {
+			failed = append(failed, foreignSkip{Source: item.Source, Reason: err.Error()})
+			continue
+		}
+		for _, label := range item.Labels {
+			if err := s.AddLabel(ctx, item.Issue.ID, label, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, item.Issue.ID, err)
+			}
+		}
+		created = append(created, item.Issue)
+	}
+	return created, failed
+}
+
+// runForeignImport creates the issues read from a non-bd source, or with
+// dryRun just lists them, and reports what could not be mapped
+func runForeignImport(from string, items []*foreignIssue, skipped []foreignSkip, dryRun, resolveCollisions bool) {
+	var created []*types.Issue
+	if dryRun {
+		for _, item := range items {
+			created = append(created, item.Issue)
+		}
+	} else {
+		if len(items) > 0 {
+			if err := ensureDirectMode(fmt.Sprintf("import --from %s requires direct database access", from)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		var failed []foreignSkip
+		created, failed = createForeignIssues(context.Background(), store, items, resolveCollisions)
+		skipped = append(skipped, failed...)
+		if len(created) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+	}
+
+	for _, item := range items {
+		for _, note := range item.Notes {
+			fmt.Fprintf(os.Stderr, "Note: %s: %s\n", item.Source, note)
+		}
+	}
+
+	if jsonOutput {
+		if created == nil {
+			created = []*types.Issue{}
+		}
+		if skipped == nil {
+			skipped = []foreignSkip{}
+		}
+		outputJSON(map[string]interface{}{"created": created, "skipped": skipped, "dry_run": dryRun})
+	} else {
+		for _, issue := range created {
+			id := issue.ID
+			if dryRun {
+				id = "(new)"
+			}
+			fmt.Printf("  %s: %s [P%d, %s, %s]\n", id, issue.Title, issue.Priority, issue.IssueType, issue.Status)
+		}
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, "\n%s Could not import %d:\n", renderError("✗"), len(skipped))
+			for _, skip := range skipped {
+				fmt.Fprintf(os.Stderr, "  %s: %s\n", skip.Source, skip.Reason)
+			}
+		}
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Fprintf(os.Stderr, "%s %d issues from %s", verb, len(created), from)
+		if len(skipped) > 0 {
+			fmt.Fprintf(os.Stderr, ", %d skipped", len(skipped))
+		}
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+}
@@ -398,3 +398,123 @@ func TestImportMultipleUnchangedIssues(t *testing.T) {
 			changedInitialTS, issue2After.UpdatedAt)
 	}
 }
+
+// TestReimportIdenticalContentSkipsWholeImport verifies that re-importing content whose
+// hash matches last_import_hash short-circuits without touching storage
+func TestReimportIdenticalContentSkipsWholeImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDBPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	testStore := newTestStoreWithPrefix(t, testDBPath, "bd")
+	ctx := context.Background()
+
+	makeIssues := func() []*types.Issue {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		return []*types.Issue{
+			{ID: "bd-1", Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "bd-2", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, CreatedAt: now, UpdatedAt: now, Labels: []string{"backend"}},
+		}
+	}
+
+	first, err := importIssuesCore(ctx, testDBPath, testStore, makeIssues(), ImportOptions{})
+	if err != nil {
+		t.Fatalf("First import failed: %v", err)
+	}
+	if first.SkippedWholeImport || first.Created != 2 {
+		t.Fatalf("Expected first import to create 2 issues, got %+v", first)
+	}
+
+	// Simulate the export that follows an import (clears dirty state)
+	if err := testStore.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("Failed to clear dirty issues: %v", err)
+	}
+	eventsBefore, err := testStore.GetEvents(ctx, "bd-2", 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+
+	second, err := importIssuesCore(ctx, testDBPath, testStore, makeIssues(), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Second import failed: %v", err)
+	}
+	if !second.SkippedWholeImport {
+		t.Fatalf("Expected identical re-import to be skipped, got %+v", second)
+	}
+	if second.Created != 0 || second.Updated != 0 || second.Unchanged != 2 {
+		t.Errorf("Expected 0 created, 0 updated, 2 unchanged; got %+v", second)
+	}
+
+	// No storage writes: nothing dirty and no new events
+	dirty, err := testStore.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 0 {
+		t.Errorf("Expected no dirty issues after skipped import, got %v", dirty)
+	}
+	eventsAfter, err := testStore.GetEvents(ctx, "bd-2", 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(eventsAfter) != len(eventsBefore) {
+		t.Errorf("Expected no new events, had %d now %d", len(eventsBefore), len(eventsAfter))
+	}
+
+	// Unexported local changes must force the import to run again
+	if err := testStore.AddLabel(ctx, "bd-1", "local", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	third, err := importIssuesCore(ctx, testDBPath, testStore, makeIssues(), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Third import failed: %v", err)
+	}
+	if third.SkippedWholeImport {
+		t.Error("Expected import to run when database has unexported changes")
+	}
+}
+
+// TestReimportWithDifferentOptionsRuns verifies that an import which didn't
+// apply its content verbatim (here --skip-existing) doesn't make a later full
+// import of the same content a no-op
+func TestReimportWithDifferentOptionsRuns(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDBPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	testStore := newTestStoreWithPrefix(t, testDBPath, "bd")
+	ctx := context.Background()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	original := &types.Issue{ID: "bd-1", Title: "Original", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+	if _, err := importIssuesCore(ctx, testDBPath, testStore, []*types.Issue{original}, ImportOptions{}); err != nil {
+		t.Fatalf("Initial import failed: %v", err)
+	}
+	if err := testStore.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("Failed to clear dirty issues: %v", err)
+	}
+
+	makeIssues := func() []*types.Issue {
+		rank := 2.5
+		return []*types.Issue{{ID: "bd-1", Title: "Original", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now, Rank: &rank}}
+	}
+
+	skipped, err := importIssuesCore(ctx, testDBPath, testStore, makeIssues(), ImportOptions{SkipUpdate: true})
+	if err != nil {
+		t.Fatalf("Skip-existing import failed: %v", err)
+	}
+	if skipped.Skipped != 1 {
+		t.Fatalf("Expected skip-existing import to skip 1 issue, got %+v", skipped)
+	}
+
+	full, err := importIssuesCore(ctx, testDBPath, testStore, makeIssues(), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Full import failed: %v", err)
+	}
+	if full.SkippedWholeImport || full.Updated != 1 {
+		t.Fatalf("Expected full import to update 1 issue, got %+v", full)
+	}
+	got, err := testStore.GetIssue(ctx, "bd-1")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Rank == nil || *got.Rank != 2.5 {
+		t.Errorf("Expected rank 2.5 after full import, got %v", got.Rank)
+	}
+}
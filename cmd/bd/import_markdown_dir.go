@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"go.yaml.in/yaml/v3"
+)
+
+// parseMarkdownDir reads every .md file under dir as an issue. Recognized
+// frontmatter keys (title, status, priority, type, assignee, labels or tags)
+// set the matching fields; without a title the file name is used, and the
+// body becomes the description. Files that can't be read as issues, and
+// files that aren't markdown, are returned as skips. Hidden files and
+// directories are ignored.
+func parseMarkdownDir(dir string) ([]*foreignIssue, []foreignSkip, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var items []*foreignIssue
+	var skipped []foreignSkip
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			skipped = append(skipped, foreignSkip{Source: rel, Reason: "not a markdown file"})
+			return nil
+		}
+
+		// #nosec G304 - walking a user-provided directory is intentional
+		data, err := os.ReadFile(path)
+		if err != nil {
+			skipped = append(skipped, foreignSkip{Source: rel, Reason: err.Error()})
+			return nil
+		}
+		item, err := parseMarkdownDocument(rel, data)
+		if err != nil {
+			skipped = append(skipped, foreignSkip{Source: rel, Reason: err.Error()})
+			return nil
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, skipped, nil
+}
+
+// parseMarkdownDocument turns one markdown file, named by path, into an issue
+func parseMarkdownDocument(path string, data []byte) (*foreignIssue, error) {
+	frontmatter, body, err := splitFrontmatter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if frontmatter != nil {
+		if err := yaml.Unmarshal(frontmatter, &fields); err != nil {
+			return nil, fmt.Errorf("invalid frontmatter: %w", err)
+		}
+	}
+
+	item := &foreignIssue{
+		Source: path,
+		Issue: &types.Issue{
+			Title:       titleFromFileName(path),
+			Description: strings.TrimSpace(string(body)),
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+		},
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var ignored []string
+	for _, key := range keys {
+		value := fields[key]
+		text := frontmatterString(value)
+		switch strings.ToLower(key) {
+		case "title":
+			if strings.TrimSpace(text) != "" {
+				item.Issue.Title = strings.TrimSpace(text)
+			}
+		case "status":
+			status, err := parseLenientStatus(text)
+			if err != nil {
+				return nil, err
+			}
+			setForeignStatus(item.Issue, status)
+		case "priority":
+			if item.Issue.Priority, err = parseLenientPriority(text); err != nil {
+				return nil, err
+			}
+		case "type":
+			if item.Issue.IssueType, err = parseLenientIssueType(text); err != nil {
+				return nil, err
+			}
+		case "assignee":
+			item.Issue.Assignee = strings.TrimSpace(text)
+		case "labels", "tags":
+			item.Labels = append(item.Labels, frontmatterList(value)...)
+		default:
+			ignored = append(ignored, key)
+		}
+	}
+	if len(ignored) > 0 {
+		item.Notes = append(item.Notes, "ignored frontmatter keys: "+strings.Join(ignored, ", "))
+	}
+
+	if err := item.Issue.Validate(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// splitFrontmatter separates a leading YAML block fenced by "---" lines from
+// the rest of the document. Without one, frontmatter is nil and body is data.
+func splitFrontmatter(data []byte) (frontmatter, body []byte, err error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.SplitAfter(text, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return nil, []byte(text), nil
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if line := strings.TrimSpace(lines[i]); line == "---" || line == "..." {
+			return []byte(strings.Join(lines[1:i], "")), []byte(strings.Join(lines[i+1:], "")), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("frontmatter is missing its closing ---")
+}
+
+// titleFromFileName turns "notes/fix-login_bug.md" into "fix login bug"
+func titleFromFileName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// frontmatterString renders a scalar frontmatter value as text
+func frontmatterString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// frontmatterList reads a YAML list or a comma-separated string
+func frontmatterList(value interface{}) []string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return splitForeignList(frontmatterString(value))
+	}
+	var items []string
+	for _, v := range list {
+		if item := strings.TrimSpace(frontmatterString(v)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
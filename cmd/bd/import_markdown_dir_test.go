@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseMarkdownDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"login-bug.md": `---
+title: Login fails on Safari
+status: In Progress
+priority: High
+type: defect
+assignee: alice
+labels: [frontend, auth]
+owner: bob
+---
+Users on Safari 17 can't log in.
+`,
+		"plain_notes.md":     "Just some notes with no frontmatter.\n",
+		"nested/cleanup.md":  "---\nstatus: done\ntags: chore, tidy\n---\n\nRemove the old scripts.\n",
+		"bad-yaml.md":        "---\ntitle: [unclosed\n---\nbody\n",
+		"unclosed.md":        "---\ntitle: Never ends\n",
+		"bad-status.md":      "---\nstatus: someday\n---\n",
+		"diagram.png":        "not markdown",
+		".hidden/ignored.md": "ignored",
+		"crlf.md":            "---\r\ntitle: Windows file\r\npriority: P0\r\n---\r\nBody\r\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, skipped, err := parseMarkdownDir(dir)
+	if err != nil {
+		t.Fatalf("parseMarkdownDir failed: %v", err)
+	}
+
+	bySource := map[string]*foreignIssue{}
+	for _, item := range items {
+		bySource[filepath.ToSlash(item.Source)] = item
+	}
+	if len(bySource) != 4 {
+		t.Fatalf("imported %d files, want 4: %v", len(bySource), bySource)
+	}
+
+	login := bySource["login-bug.md"]
+	if login == nil {
+		t.Fatal("login-bug.md was not imported")
+	}
+	want := types.Issue{Title: "Login fails on Safari", Description: "Users on Safari 17 can't log in.",
+		Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug, Assignee: "alice"}
+	if got := *login.Issue; !reflect.DeepEqual(got, want) {
+		t.Errorf("login-bug.md = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(login.Labels, []string{"frontend", "auth"}) {
+		t.Errorf("labels = %v", login.Labels)
+	}
+	if len(login.Notes) != 1 || !strings.Contains(login.Notes[0], "owner") {
+		t.Errorf("notes = %v, want the ignored owner key", login.Notes)
+	}
+
+	if plain := bySource["plain_notes.md"]; plain == nil || plain.Issue.Title != "plain notes" ||
+		plain.Issue.Description != "Just some notes with no frontmatter." || plain.Issue.Status != types.StatusOpen {
+		t.Errorf("plain_notes.md = %+v", plain)
+	}
+	cleanup := bySource["nested/cleanup.md"]
+	if cleanup == nil || cleanup.Issue.Status != types.StatusClosed || cleanup.Issue.ClosedAt == nil ||
+		cleanup.Issue.Description != "Remove the old scripts." || !reflect.DeepEqual(cleanup.Labels, []string{"chore", "tidy"}) {
+		t.Errorf("nested/cleanup.md = %+v", cleanup)
+	}
+	if crlf := bySource["crlf.md"]; crlf == nil || crlf.Issue.Title != "Windows file" || crlf.Issue.Priority != 0 {
+		t.Errorf("crlf.md = %+v", crlf)
+	}
+
+	reasons := map[string]string{}
+	for _, skip := range skipped {
+		reasons[filepath.ToSlash(skip.Source)] = skip.Reason
+	}
+	wantSkips := map[string]string{
+		"bad-yaml.md":   "invalid frontmatter",
+		"unclosed.md":   "closing ---",
+		"bad-status.md": `unknown status "someday"`,
+		"diagram.png":   "not a markdown file",
+	}
+	if len(reasons) != len(wantSkips) {
+		t.Errorf("skipped = %v, want %v", reasons, wantSkips)
+	}
+	for source, reason := range wantSkips {
+		if !strings.Contains(reasons[source], reason) {
+			t.Errorf("%s skipped with %q, want it to mention %q", source, reasons[source], reason)
+		}
+	}
+}
+
+func TestCreateForeignIssues(t *testing.T) {
+	dir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(dir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	docs := filepath.Join(dir, "docs")
+	if err := os.MkdirAll(docs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(docs, "a.md"), []byte("---\ntitle: First\nlabels: [docs]\n---\nBody\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(docs, "b.md"), []byte("---\nstatus: resolved\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	items, skipped, err := parseMarkdownDir(docs)
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("parseMarkdownDir = %v, %v", skipped, err)
+	}
+	created, failed := createForeignIssues(ctx, testStore, items, false)
+	if len(failed) != 0 || len(created) != 2 {
+		t.Fatalf("created %d, failed %v", len(created), failed)
+	}
+
+	issues, err := testStore.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	var titles []string
+	for _, issue := range issues {
+		titles = append(titles, issue.Title)
+		if issue.Title == "First" && !reflect.DeepEqual(issue.Labels, []string{"docs"}) {
+			t.Errorf("First has labels %v", issue.Labels)
+		}
+		if issue.Title == "b" && issue.Status != types.StatusClosed {
+			t.Errorf("b has status %s", issue.Status)
+		}
+	}
+	sort.Strings(titles)
+	if !reflect.DeepEqual(titles, []string{"First", "b"}) {
+		t.Errorf("titles = %v", titles)
+	}
+}
+
+func TestParseLenientValues(t *testing.T) {
+	for input, want := range map[string]types.Status{"": types.StatusOpen, "To Do": types.StatusOpen,
+		"in-progress": types.StatusInProgress, "On Hold": types.StatusBlocked, "Won't Fix": types.StatusClosed} {
+		if got, err := parseLenientStatus(input); err != nil || got != want {
+			t.Errorf("parseLenientStatus(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for input, want := range map[string]int{"": 2, "0": 0, "P3": 3, "p1": 1, "Critical": 0, "low": 3} {
+		if got, err := parseLenientPriority(input); err != nil || got != want {
+			t.Errorf("parseLenientPriority(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"5", "P9", "soonish"} {
+		if _, err := parseLenientPriority(input); err == nil {
+			t.Errorf("parseLenientPriority(%q) succeeded, want error", input)
+		}
+	}
+	if got, err := parseLenientIssueType("Story"); err != nil || got != types.TypeFeature {
+		t.Errorf("parseLenientIssueType(Story) = %v, %v", got, err)
+	}
+}
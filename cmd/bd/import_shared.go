@@ -168,6 +168,7 @@ type ImportOptions struct {
 	Strict             bool // Fail on any error (dependencies, labels, etc.)
 	RenameOnImport     bool // Rename imported issues to match database prefix
 	SkipPrefixValidation bool // Skip prefix validation (for auto-import)
+	SourceLines        []int // Optional JSONL line number for each issue (for validation errors)
 }
 
 // ImportResult contains statistics about the import operation
@@ -182,19 +183,26 @@ type ImportResult struct {
 	PrefixMismatch  bool              // Prefix mismatch detected
 	ExpectedPrefix  string            // Database configured prefix
 	MismatchPrefixes map[string]int    // Map of mismatched prefixes to count
+	ValidationErrors []ImportError     // Schema violations (invalid issues are skipped unless Strict)
+	SkippedWholeImport bool            // Content hash matched last import, nothing was done
+	DanglingDependencies []*types.Dependency // Dependencies whose target doesn't exist (not imported)
 }
 
+// ImportError describes a schema violation found in an imported issue
+type ImportError = importer.ImportError
+
 // importIssuesCore handles the core import logic used by both manual and auto-import.
 // This function:
 // - Opens a direct SQLite connection if needed (daemon mode)
+// - Skips the whole import if the content matches last_import_hash
 // - Detects and handles collisions
 // - Imports issues, dependencies, and labels
+// - Records last_import_hash after an import that applied the content verbatim
 // - Returns detailed results
 //
 // The caller is responsible for:
 // - Reading and parsing JSONL into issues slice
 // - Displaying results to the user
-// - Setting metadata (e.g., last_import_hash)
 func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage, issues []*types.Issue, opts ImportOptions) (*ImportResult, error) {
 	// Convert ImportOptions to importer.Options
 	importerOpts := importer.Options{
@@ -204,11 +212,15 @@ func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage,
 		Strict:               opts.Strict,
 		RenameOnImport:       opts.RenameOnImport,
 		SkipPrefixValidation: opts.SkipPrefixValidation,
+		SourceLines:          opts.SourceLines,
 	}
 
 	// Delegate to the importer package
 	result, err := importer.ImportIssues(ctx, dbPath, store, issues, importerOpts)
 	if err != nil {
+		if result != nil && len(result.ValidationErrors) > 0 {
+			return &ImportResult{ValidationErrors: result.ValidationErrors}, err
+		}
 		return nil, err
 	}
 
@@ -224,6 +236,9 @@ func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage,
 		PrefixMismatch:   result.PrefixMismatch,
 		ExpectedPrefix:   result.ExpectedPrefix,
 		MismatchPrefixes: result.MismatchPrefixes,
+		ValidationErrors: result.ValidationErrors,
+		SkippedWholeImport: result.SkippedWholeImport,
+		DanglingDependencies: result.DanglingDependencies,
 	}, nil
 }
 
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// parseTestJSONL parses JSONL lines the same way `bd import` does, tracking line numbers
+func parseTestJSONL(t *testing.T, lines []string) ([]*types.Issue, []int) {
+	t.Helper()
+	var issues []*types.Issue
+	var sourceLines []int
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("line %d: failed to parse: %v", i+1, err)
+		}
+		issues = append(issues, &issue)
+		sourceLines = append(sourceLines, i+1)
+	}
+	return issues, sourceLines
+}
+
+var mixedValidityJSONL = []string{
+	`{"id":"test-1","title":"Valid issue","status":"open","priority":1,"issue_type":"task"}`,
+	`{"id":"test-2","title":"Bad status","status":"inprogress","priority":1,"issue_type":"task"}`,
+	``,
+	`{"id":"test-3","title":"Bad priority","status":"open","priority":9,"issue_type":"bug"}`,
+	`{"id":"test-4","title":"","status":"open","priority":2,"issue_type":"feature"}`,
+	`{"id":"test-5","title":"Bad type","status":"open","priority":2,"issue_type":"story"}`,
+	`{"id":"test-6","title":"Another valid issue","status":"in_progress","priority":0,"issue_type":"bug"}`,
+}
+
+func TestImportValidation_SkipsInvalidIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issues, sourceLines := parseTestJSONL(t, mixedValidityJSONL)
+	result, err := importIssuesCore(ctx, dbPath, store, issues, ImportOptions{SourceLines: sourceLines})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if result.Created != 2 {
+		t.Errorf("Expected 2 created, got %d", result.Created)
+	}
+	if result.Skipped != 4 {
+		t.Errorf("Expected 4 skipped, got %d", result.Skipped)
+	}
+
+	// Each invalid line reported with its source line number and field
+	want := map[int]string{
+		2: "status",
+		4: "priority",
+		5: "title",
+		6: "issue_type",
+	}
+	if len(result.ValidationErrors) != len(want) {
+		t.Fatalf("Expected %d validation errors, got %d: %v", len(want), len(result.ValidationErrors), result.ValidationErrors)
+	}
+	for _, e := range result.ValidationErrors {
+		field, ok := want[e.Line]
+		if !ok {
+			t.Errorf("Unexpected validation error at line %d: %v", e.Line, e)
+			continue
+		}
+		if e.Field != field {
+			t.Errorf("Line %d: expected field %q, got %q", e.Line, field, e.Field)
+		}
+	}
+
+	// Valid issues were imported, invalid ones were not
+	for _, id := range []string{"test-1", "test-6"} {
+		if issue, _ := store.GetIssue(ctx, id); issue == nil {
+			t.Errorf("Expected %s to be imported", id)
+		}
+	}
+	for _, id := range []string{"test-2", "test-3", "test-4", "test-5"} {
+		if issue, _ := store.GetIssue(ctx, id); issue != nil {
+			t.Errorf("Expected invalid %s to be skipped", id)
+		}
+	}
+}
+
+func TestImportValidation_StrictFailsWithAllErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issues, sourceLines := parseTestJSONL(t, mixedValidityJSONL)
+	result, err := importIssuesCore(ctx, dbPath, store, issues, ImportOptions{Strict: true, SourceLines: sourceLines})
+	if err == nil {
+		t.Fatal("Expected strict import to fail on invalid issues")
+	}
+	if result == nil || len(result.ValidationErrors) != 4 {
+		t.Fatalf("Expected 4 validation errors in result, got %+v", result)
+	}
+	if !strings.Contains(err.Error(), "line 2") || !strings.Contains(err.Error(), "line 6") {
+		t.Errorf("Expected error to reference line numbers, got: %v", err)
+	}
+
+	// Nothing should have been imported
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("Expected no issues imported in strict mode, got %d", len(all))
+	}
+}
+
+func TestImportValidation_ClosedAtInvariant(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "issues.db")
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issues, sourceLines := parseTestJSONL(t, []string{
+		`{"id":"test-1","title":"Closed without closed_at","status":"closed","priority":1,"issue_type":"task"}`,
+		`{"id":"test-2","title":"Open with closed_at","status":"open","priority":1,"issue_type":"task","closed_at":"2025-01-02T00:00:00Z"}`,
+		`{"id":"test-3","title":"Closed","status":"closed","priority":1,"issue_type":"task","closed_at":"2025-01-02T00:00:00Z"}`,
+	})
+	result, err := importIssuesCore(ctx, dbPath, store, issues, ImportOptions{SourceLines: sourceLines})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if result.Created != 1 || len(result.ValidationErrors) != 2 {
+		t.Fatalf("Expected 1 created and 2 validation errors, got %d and %v", result.Created, result.ValidationErrors)
+	}
+	for _, e := range result.ValidationErrors {
+		if e.Field != "closed_at" {
+			t.Errorf("Line %d: expected field closed_at, got %q", e.Line, e.Field)
+		}
+	}
+}
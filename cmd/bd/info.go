@@ -73,10 +73,8 @@ Examples:
 			// Get issue count from direct store
 			if store != nil {
 				ctx := context.Background()
-				filter := types.IssueFilter{}
-				issues, err := store.SearchIssues(ctx, "", filter)
-				if err == nil {
-					info["issue_count"] = len(issues)
+				if count, err := store.CountIssues(ctx, types.IssueFilter{}); err == nil {
+					info["issue_count"] = count
 				}
 			}
 		}
@@ -7,7 +7,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads"
 	"github.com/steveyegge/beads/internal/config"
@@ -115,15 +114,12 @@ With --no-db: creates .beads/ directory and issues.jsonl file instead of SQLite
 			}
 
 			if !quiet {
-				green := color.New(color.FgGreen).SprintFunc()
-				cyan := color.New(color.FgCyan).SprintFunc()
-
-				fmt.Printf("\n%s bd initialized successfully in --no-db mode!\n\n", green("✓"))
-				fmt.Printf("  Mode: %s\n", cyan("no-db (JSONL-only)"))
-				fmt.Printf("  Issues file: %s\n", cyan(jsonlPath))
-				fmt.Printf("  Issue prefix: %s\n", cyan(prefix))
-				fmt.Printf("  Issues will be named: %s\n\n", cyan(prefix+"-1, "+prefix+"-2, ..."))
-				fmt.Printf("Run %s to get started.\n\n", cyan("bd --no-db quickstart"))
+				fmt.Printf("\n%s bd initialized successfully in --no-db mode!\n\n", renderSuccess("✓"))
+				fmt.Printf("  Mode: %s\n", renderAccent("no-db (JSONL-only)"))
+				fmt.Printf("  Issues file: %s\n", renderAccent(jsonlPath))
+				fmt.Printf("  Issue prefix: %s\n", renderAccent(prefix))
+				fmt.Printf("  Issues will be named: %s\n\n", renderAccent(prefix+"-1, "+prefix+"-2, ..."))
+				fmt.Printf("Run %s to get started.\n\n", renderAccent("bd --no-db quickstart"))
 			}
 			return
 		}
@@ -250,6 +246,9 @@ if isGitRepo() && !hooksInstalled() {
 	} else {
 		// Defer to interactive prompt below
 	}
+} else if isGitRepo() && len(outdatedHooks(gitHooksDir())) > 0 {
+	// Hooks from an older bd: replace them with the current version
+	_ = installGitHooks()
 }
 
 // Skip output if quiet mode
@@ -257,20 +256,16 @@ if quiet {
 		return
 }
 
-		green := color.New(color.FgGreen).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-
-		fmt.Printf("\n%s bd initialized successfully!\n\n", green("✓"))
-		fmt.Printf("  Database: %s\n", cyan(initDBPath))
-		fmt.Printf("  Issue prefix: %s\n", cyan(prefix))
-		fmt.Printf("  Issues will be named: %s\n\n", cyan(prefix+"-1, "+prefix+"-2, ..."))
+		fmt.Printf("\n%s bd initialized successfully!\n\n", renderSuccess("✓"))
+		fmt.Printf("  Database: %s\n", renderAccent(initDBPath))
+		fmt.Printf("  Issue prefix: %s\n", renderAccent(prefix))
+		fmt.Printf("  Issues will be named: %s\n\n", renderAccent(prefix+"-1, "+prefix+"-2, ..."))
 	
 	// Interactive git hooks prompt for humans
 	if isGitRepo() && !hooksInstalled() {
-		fmt.Printf("%s Git hooks not installed\n", yellow("⚠"))
+		fmt.Printf("%s Git hooks not installed\n", renderWarning("⚠"))
 		fmt.Printf("  Install git hooks to prevent race conditions between commits and auto-flush.\n")
-		fmt.Printf("  Run: %s\n\n", cyan("./examples/git-hooks/install.sh"))
+		fmt.Printf("  Run: %s\n\n", renderAccent("./examples/git-hooks/install.sh"))
 		
 		// Prompt to install
 		fmt.Printf("Install git hooks now? [Y/n] ")
@@ -281,14 +276,14 @@ if quiet {
 		if response == "" || response == "y" || response == "yes" {
 			if err := installGitHooks(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error installing hooks: %v\n", err)
-				fmt.Printf("You can install manually with: %s\n\n", cyan("./examples/git-hooks/install.sh"))
+				fmt.Printf("You can install manually with: %s\n\n", renderAccent("./examples/git-hooks/install.sh"))
 			} else {
-				fmt.Printf("%s Git hooks installed successfully!\n\n", green("✓"))
+				fmt.Printf("%s Git hooks installed successfully!\n\n", renderSuccess("✓"))
 			}
 		}
 	}
 	
-	fmt.Printf("Run %s to get started.\n\n", cyan("bd quickstart"))
+	fmt.Printf("Run %s to get started.\n\n", renderAccent("bd quickstart"))
 	},
 }
 
@@ -298,155 +293,6 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 }
 
-// hooksInstalled checks if bd git hooks are installed
-func hooksInstalled() bool {
-	preCommit := filepath.Join(".git", "hooks", "pre-commit")
-	postMerge := filepath.Join(".git", "hooks", "post-merge")
-	
-	// Check if both hooks exist
-	_, err1 := os.Stat(preCommit)
-	_, err2 := os.Stat(postMerge)
-	
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	
-	// Verify they're bd hooks by checking for signature comment
-	// #nosec G304 - controlled path from git directory
-	preCommitContent, err := os.ReadFile(preCommit)
-	if err != nil || !strings.Contains(string(preCommitContent), "bd (beads) pre-commit hook") {
-		return false
-	}
-	
-	// #nosec G304 - controlled path from git directory
-	postMergeContent, err := os.ReadFile(postMerge)
-	if err != nil || !strings.Contains(string(postMergeContent), "bd (beads) post-merge hook") {
-		return false
-	}
-	
-	return true
-}
-
-// installGitHooks installs git hooks inline (no external dependencies)
-func installGitHooks() error {
-	hooksDir := filepath.Join(".git", "hooks")
-	
-	// Ensure hooks directory exists
-	if err := os.MkdirAll(hooksDir, 0750); err != nil {
-		return fmt.Errorf("failed to create hooks directory: %w", err)
-	}
-	
-	// pre-commit hook
-	preCommitPath := filepath.Join(hooksDir, "pre-commit")
-	preCommitContent := `#!/bin/sh
-#
-# bd (beads) pre-commit hook
-#
-# This hook ensures that any pending bd issue changes are flushed to
-# .beads/issues.jsonl before the commit is created, preventing the
-# race condition where daemon auto-flush fires after the commit.
-
-# Check if bd is available
-if ! command -v bd >/dev/null 2>&1; then
-    echo "Warning: bd command not found, skipping pre-commit flush" >&2
-    exit 0
-fi
-
-# Check if we're in a bd workspace
-if [ ! -d .beads ]; then
-    # Not a bd workspace, nothing to do
-    exit 0
-fi
-
-# Flush pending changes to JSONL
-# Use --flush-only to skip git operations (we're already in a git hook)
-# Suppress output unless there's an error
-if ! bd sync --flush-only >/dev/null 2>&1; then
-    echo "Error: Failed to flush bd changes to JSONL" >&2
-    echo "Run 'bd sync --flush-only' manually to diagnose" >&2
-    exit 1
-fi
-
-# If the JSONL file was modified, stage it
-if [ -f .beads/issues.jsonl ]; then
-    git add .beads/issues.jsonl 2>/dev/null || true
-fi
-
-exit 0
-`
-	
-	// post-merge hook
-	postMergePath := filepath.Join(hooksDir, "post-merge")
-	postMergeContent := `#!/bin/sh
-#
-# bd (beads) post-merge hook
-#
-# This hook imports updated issues from .beads/issues.jsonl after a
-# git pull or merge, ensuring the database stays in sync with git.
-
-# Check if bd is available
-if ! command -v bd >/dev/null 2>&1; then
-    echo "Warning: bd command not found, skipping post-merge import" >&2
-    exit 0
-fi
-
-# Check if we're in a bd workspace
-if [ ! -d .beads ]; then
-    # Not a bd workspace, nothing to do
-    exit 0
-fi
-
-# Check if issues.jsonl exists and was updated
-if [ ! -f .beads/issues.jsonl ]; then
-    exit 0
-fi
-
-# Import the updated JSONL
-# The auto-import feature should handle this, but we force it here
-# to ensure immediate sync after merge
-if ! bd import -i .beads/issues.jsonl --resolve-collisions >/dev/null 2>&1; then
-    echo "Warning: Failed to import bd changes after merge" >&2
-    echo "Run 'bd import -i .beads/issues.jsonl --resolve-collisions' manually" >&2
-    # Don't fail the merge, just warn
-fi
-
-exit 0
-`
-	
-	// Backup existing hooks if present
-	for _, hookPath := range []string{preCommitPath, postMergePath} {
-		if _, err := os.Stat(hookPath); err == nil {
-			// Read existing hook to check if it's already a bd hook
-			// #nosec G304 - controlled path from git directory
-			content, err := os.ReadFile(hookPath)
-			if err == nil && strings.Contains(string(content), "bd (beads)") {
-				// Already a bd hook, skip backup
-				continue
-			}
-			
-			// Backup non-bd hook
-			backup := hookPath + ".backup"
-			if err := os.Rename(hookPath, backup); err != nil {
-				return fmt.Errorf("failed to backup existing hook: %w", err)
-			}
-		}
-	}
-	
-	// Write pre-commit hook (executable scripts need 0700)
-	// #nosec G306 - git hooks must be executable
-	if err := os.WriteFile(preCommitPath, []byte(preCommitContent), 0700); err != nil {
-		return fmt.Errorf("failed to write pre-commit hook: %w", err)
-	}
-	
-	// Write post-merge hook (executable scripts need 0700)
-	// #nosec G306 - git hooks must be executable
-	if err := os.WriteFile(postMergePath, []byte(postMergeContent), 0700); err != nil {
-		return fmt.Errorf("failed to write post-merge hook: %w", err)
-	}
-	
-	return nil
-}
-
 // migrateOldDatabases detects and migrates old database files to beads.db
 func migrateOldDatabases(targetPath string, quiet bool) error {
 	targetDir := filepath.Dir(targetPath)
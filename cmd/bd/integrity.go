@@ -203,7 +203,7 @@ func countDBIssuesFast(ctx context.Context, store storage.Storage) (int, error)
 	}
 
 	// Fallback: load all issues and count them (slow but always works)
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return 0, fmt.Errorf("failed to count database issues: %w", err)
 	}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// jsonlLines holds the raw lines of a JSONL file indexed by issue ID, so an
+// incremental flush can replace just the lines of changed issues and leave
+// every other line byte-for-byte as it was. This keeps git diffs down to the
+// issues that actually changed.
+type jsonlLines struct {
+	lines  [][]byte       // raw lines without trailing newline; nil marks a removed line
+	ids    []string       // issue ID of each line
+	index  map[string]int // issue ID -> position in lines
+	sorted bool           // IDs appear in ascending order, as writeJSONLAtomic writes them
+}
+
+// readJSONLLines loads path into a jsonlLines. A missing file yields an empty
+// set. Lines that can't be parsed are dropped with a warning, matching the
+// previous map-based flush.
+func readJSONLLines(path string) (*jsonlLines, error) {
+	j := &jsonlLines{index: make(map[string]int), sorted: true}
+
+	// #nosec G304 - controlled path from config
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSONL: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// bufio.Reader rather than Scanner: issue lines can exceed Scanner's 64KB limit
+	reader := bufio.NewReader(f)
+	lastID := ""
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, fmt.Errorf("failed to read JSONL: %w", readErr)
+		}
+		line = bytes.TrimRight(line, "\r\n")
+		if len(bytes.TrimSpace(line)) > 0 {
+			var entry struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(line, &entry); err != nil || entry.ID == "" {
+				fmt.Fprintf(os.Stderr, "Warning: skipping malformed JSONL line %d: %v\n", lineNum, err)
+			} else {
+				if prev, ok := j.index[entry.ID]; ok {
+					// Duplicate ID: the later line wins, as with the map-based flush
+					j.lines[prev] = nil
+				}
+				if entry.ID < lastID {
+					j.sorted = false
+				}
+				lastID = entry.ID
+				j.index[entry.ID] = len(j.lines)
+				j.lines = append(j.lines, line)
+				j.ids = append(j.ids, entry.ID)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+	}
+	return j, nil
+}
+
+// has reports whether the file contains a line for id
+func (j *jsonlLines) has(id string) bool {
+	_, ok := j.index[id]
+	return ok
+}
+
+// set replaces the line for id in place, or inserts it for a new issue.
+// New issues go at their sorted position when the file is sorted by ID,
+// otherwise they are appended.
+func (j *jsonlLines) set(id string, line []byte) {
+	if i, ok := j.index[id]; ok {
+		j.lines[i] = line
+		return
+	}
+	if !j.sorted {
+		j.index[id] = len(j.lines)
+		j.lines = append(j.lines, line)
+		j.ids = append(j.ids, id)
+		return
+	}
+
+	pos := sort.SearchStrings(j.ids, id)
+	j.lines = append(j.lines, nil)
+	copy(j.lines[pos+1:], j.lines[pos:])
+	j.lines[pos] = line
+	j.ids = append(j.ids, "")
+	copy(j.ids[pos+1:], j.ids[pos:])
+	j.ids[pos] = id
+	for i := pos; i < len(j.ids); i++ {
+		if j.lines[i] != nil {
+			j.index[j.ids[i]] = i
+		}
+	}
+}
+
+// remove drops the line for id, if present
+func (j *jsonlLines) remove(id string) {
+	if i, ok := j.index[id]; ok {
+		j.lines[i] = nil
+		delete(j.index, id)
+	}
+}
+
+// bytes returns the file content, one line per remaining issue
+func (j *jsonlLines) bytes() []byte {
+	var buf bytes.Buffer
+	for _, line := range j.lines {
+		if line == nil {
+			continue
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// updateJSONLLines applies the current database state of dirtyIDs to the
+// JSONL file at jsonlPath, rewriting only those issues' lines. Issues whose
+// only change is a timestamp keep their existing line (bd-159). Returns the
+// IDs that were written.
+func updateJSONLLines(ctx context.Context, jsonlPath string, dirtyIDs []string) ([]string, error) {
+	lines, err := readJSONLLines(jsonlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedIDs := make([]string, 0, len(dirtyIDs))
+	for _, issueID := range dirtyIDs {
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+		}
+		if issue == nil {
+			// Issue was deleted, drop its line
+			lines.remove(issueID)
+			continue
+		}
+
+		deps, err := store.GetDependencyRecords(ctx, issueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for %s: %w", issueID, err)
+		}
+		issue.Dependencies = deps
+		issue.SortForExport()
+
+		if lines.has(issueID) {
+			skip, err := shouldSkipExport(ctx, issue)
+			if err != nil && os.Getenv("BD_DEBUG") != "" {
+				fmt.Fprintf(os.Stderr, "Debug: failed to check if %s should skip: %v\n", issue.ID, err)
+			}
+			if skip {
+				continue
+			}
+		}
+
+		data, err := json.Marshal(issue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode issue %s: %w", issueID, err)
+		}
+		lines.set(issueID, data)
+
+		if contentHash, err := computeIssueContentHash(issue); err == nil {
+			if err := store.SetExportHash(ctx, issue.ID, contentHash); err != nil && os.Getenv("BD_DEBUG") != "" {
+				fmt.Fprintf(os.Stderr, "Debug: failed to save export hash for %s: %v\n", issue.ID, err)
+			}
+		}
+		exportedIDs = append(exportedIDs, issueID)
+	}
+
+	if err := writeFileAtomic(jsonlPath, lines.bytes()); err != nil {
+		return nil, err
+	}
+	return exportedIDs, nil
+}
+
+// writeFileAtomic replaces path with data using the same temp file + rename
+// pattern as writeJSONLAtomic
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := fmt.Sprintf("%s.tmp.%d", path, os.Getpid())
+	// #nosec G304 - controlled path from config
+	f, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := utils.SyncFile(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := utils.RenameWithRetry(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to rename file: %w", err)
+	}
+	if err := utils.SyncDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	if err := os.Chmod(path, 0644); err != nil && os.Getenv("BD_DEBUG") != "" {
+		fmt.Fprintf(os.Stderr, "Debug: failed to set file permissions: %v\n", err)
+	}
+	return nil
+}
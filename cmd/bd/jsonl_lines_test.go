@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestJSONLLinesSetAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bd-1","title":"one"}
+{"id":"bd-3","title":"three"}
+{"id":"bd-5","title":"five"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := readJSONLLines(path)
+	if err != nil {
+		t.Fatalf("readJSONLLines failed: %v", err)
+	}
+
+	lines.set("bd-3", []byte(`{"id":"bd-3","title":"THREE"}`))
+	lines.set("bd-4", []byte(`{"id":"bd-4","title":"four"}`))
+	lines.set("bd-9", []byte(`{"id":"bd-9","title":"nine"}`))
+	lines.remove("bd-1")
+
+	want := `{"id":"bd-3","title":"THREE"}
+{"id":"bd-4","title":"four"}
+{"id":"bd-5","title":"five"}
+{"id":"bd-9","title":"nine"}
+`
+	if got := string(lines.bytes()); got != want {
+		t.Errorf("unexpected content:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestJSONLLinesAppendsWhenUnsorted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bd-5","title":"five"}
+{"id":"bd-1","title":"one"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := readJSONLLines(path)
+	if err != nil {
+		t.Fatalf("readJSONLLines failed: %v", err)
+	}
+	lines.set("bd-3", []byte(`{"id":"bd-3","title":"three"}`))
+
+	want := content + `{"id":"bd-3","title":"three"}
+`
+	if got := string(lines.bytes()); got != want {
+		t.Errorf("expected new issue appended to unsorted file, got:\n%s", got)
+	}
+}
+
+func TestJSONLLinesMissingFile(t *testing.T) {
+	lines, err := readJSONLLines(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if err != nil {
+		t.Fatalf("readJSONLLines failed: %v", err)
+	}
+	lines.set("bd-1", []byte(`{"id":"bd-1"}`))
+	if got := string(lines.bytes()); got != "{\"id\":\"bd-1\"}\n" {
+		t.Errorf("unexpected content: %q", got)
+	}
+}
+
+func TestIncrementalFlushMinimalDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDBPath := dbPath
+	dbPath = filepath.Join(tmpDir, "test.db")
+	defer func() { dbPath = oldDBPath }()
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+
+	testStore := newTestStore(t, dbPath)
+	oldStore := store
+	store = testStore
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	defer func() {
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+		store = oldStore
+	}()
+
+	ctx := context.Background()
+	for i, title := range []string{"First", "Second", "Third"} {
+		issue := &types.Issue{
+			ID:        fmt.Sprintf("test-%d", i+1),
+			Title:     title,
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+	}
+
+	flushMutex.Lock()
+	isDirty = true
+	flushMutex.Unlock()
+	flushToJSONL()
+
+	before, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSONL: %v", err)
+	}
+
+	if err := testStore.UpdateIssue(ctx, "test-2", map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Fatalf("Failed to update issue: %v", err)
+	}
+	flushMutex.Lock()
+	isDirty = true
+	flushMutex.Unlock()
+	flushToJSONL()
+
+	after, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSONL: %v", err)
+	}
+
+	beforeLines := strings.Split(strings.TrimSpace(string(before)), "\n")
+	afterLines := strings.Split(strings.TrimSpace(string(after)), "\n")
+	if len(beforeLines) != 3 || len(afterLines) != 3 {
+		t.Fatalf("expected 3 lines before and after, got %d and %d", len(beforeLines), len(afterLines))
+	}
+	for i := range beforeLines {
+		changed := beforeLines[i] != afterLines[i]
+		isUpdated := strings.Contains(afterLines[i], `"id":"test-2"`)
+		if changed != isUpdated {
+			t.Errorf("line %d: changed=%v, want changed only for test-2\nbefore: %s\nafter:  %s",
+				i+1, changed, beforeLines[i], afterLines[i])
+		}
+	}
+}
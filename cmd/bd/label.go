@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
@@ -46,14 +45,13 @@ func processBatchLabelOperation(issueIDs []string, label string, operation strin
 				"label":    label,
 			})
 		} else {
-			green := color.New(color.FgGreen).SprintFunc()
 			verb := "Added"
 			prep := "to"
 			if operation == "removed" {
 				verb = "Removed"
 				prep = "from"
 			}
-			fmt.Printf("%s %s label '%s' %s %s\n", green("✓"), verb, label, prep, issueID)
+			fmt.Printf("%s %s label '%s' %s %s\n", renderSuccess("✓"), verb, label, prep, issueID)
 		}
 	}
 
@@ -110,9 +108,15 @@ var labelRemoveCmd = &cobra.Command{
 
 var labelListCmd = &cobra.Command{
 	Use:   "list [issue-id]",
-	Short: "List labels for an issue",
-	Args:  cobra.ExactArgs(1),
+	Short: "List labels for an issue, or all labels with usage counts",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		// Without an issue ID, list every label in the database
+		if len(args) == 0 {
+			labelListAllCmd.Run(cmd, args)
+			return
+		}
+
 		issueID := args[0]
 
 		ctx := context.Background()
@@ -156,8 +160,7 @@ var labelListCmd = &cobra.Command{
 			return
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		fmt.Printf("\n%s Labels for %s:\n", cyan("🏷"), issueID)
+		fmt.Printf("\n%s Labels for %s:\n", renderAccent("🏷"), issueID)
 		for _, label := range labels {
 			fmt.Printf("  - %s\n", label)
 		}
@@ -167,12 +170,11 @@ var labelListCmd = &cobra.Command{
 
 var labelListAllCmd = &cobra.Command{
 	Use:   "list-all",
-	Short: "List all unique labels in the database",
+	Short: "List all unique labels in the database with usage counts",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
-		var issues []*types.Issue
-		var err error
+		var counts []*types.LabelCount
 
 		// Use daemon if available
 		if daemonClient != nil {
@@ -182,41 +184,34 @@ var labelListAllCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
+			var issues []*types.Issue
 			if err := json.Unmarshal(resp.Data, &issues); err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+
+			// Labels are already in the issue from daemon
+			labelCounts := make(map[string]int)
+			for _, issue := range issues {
+				for _, label := range issue.Labels {
+					labelCounts[label]++
+				}
+			}
+			for label, count := range labelCounts {
+				counts = append(counts, &types.LabelCount{Label: label, Count: count})
+			}
+			types.SortLabelCounts(counts)
 		} else {
 			// Direct mode
-			issues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+			var err error
+			counts, err = store.ListLabels(ctx)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
 		}
 
-		// Collect unique labels with counts
-		labelCounts := make(map[string]int)
-		for _, issue := range issues {
-			if daemonClient != nil {
-				// Labels are already in the issue from daemon
-				for _, label := range issue.Labels {
-					labelCounts[label]++
-				}
-			} else {
-				// Direct mode - need to fetch labels
-				labels, err := store.GetLabels(ctx, issue.ID)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error getting labels for %s: %v\n", issue.ID, err)
-					os.Exit(1)
-				}
-				for _, label := range labels {
-					labelCounts[label]++
-				}
-			}
-		}
-
-		if len(labelCounts) == 0 {
+		if len(counts) == 0 {
 			if jsonOutput {
 				outputJSON([]string{})
 			} else {
@@ -225,53 +220,120 @@ var labelListAllCmd = &cobra.Command{
 			return
 		}
 
-		// Sort labels alphabetically
-		labels := make([]string, 0, len(labelCounts))
-		for label := range labelCounts {
-			labels = append(labels, label)
-		}
-		sort.Strings(labels)
-
 		if jsonOutput {
 			// Output as array of {label, count} objects
-			type labelInfo struct {
-				Label string `json:"label"`
-				Count int    `json:"count"`
-			}
-			result := make([]labelInfo, 0, len(labels))
-			for _, label := range labels {
-				result = append(result, labelInfo{
-					Label: label,
-					Count: labelCounts[label],
-				})
-			}
-			outputJSON(result)
+			outputJSON(counts)
 			return
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		fmt.Printf("\n%s All labels (%d unique):\n", cyan("🏷"), len(labels))
+		fmt.Printf("\n%s All labels (%d unique):\n", renderAccent("🏷"), len(counts))
 
 		// Find longest label for alignment
 		maxLen := 0
-		for _, label := range labels {
-			if len(label) > maxLen {
-				maxLen = len(label)
+		for _, lc := range counts {
+			if len(lc.Label) > maxLen {
+				maxLen = len(lc.Label)
 			}
 		}
 
-		for _, label := range labels {
-			padding := strings.Repeat(" ", maxLen-len(label))
-			fmt.Printf("  %s%s  (%d issues)\n", label, padding, labelCounts[label])
+		for _, lc := range counts {
+			padding := strings.Repeat(" ", maxLen-len(lc.Label))
+			fmt.Printf("  %s%s  (%d issues)\n", lc.Label, padding, lc.Count)
 		}
 		fmt.Println()
 	},
 }
 
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename [old-label] [new-label]",
+	Short: "Rename a label on every issue that has it",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLabelMerge(args[:1], args[1], "renamed")
+	},
+}
+
+var labelMergeCmd = &cobra.Command{
+	Use:   "merge [label...] [into-label]",
+	Short: "Merge one or more labels into another",
+	Long: `Replace each source label with the target label on every issue.
+
+Issues that already have the target label keep a single copy.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLabelMerge(args[:len(args)-1], args[len(args)-1], "merged")
+	},
+}
+
+// runLabelMerge replaces the from labels with into across all issues and reports the result
+func runLabelMerge(from []string, into string, operation string) {
+	// If daemon is running but doesn't support this command, use direct storage
+	if daemonClient != nil && store == nil {
+		var err error
+		store, err = openLocalStore(dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = store.Close() }()
+	}
+
+	ctx := context.Background()
+
+	// Collect affected issues up front so we can report them
+	affected := make(map[string]bool)
+	for _, label := range from {
+		if label == into {
+			continue
+		}
+		issues, err := store.GetIssuesByLabel(ctx, label)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, issue := range issues {
+			affected[issue.ID] = true
+		}
+	}
+
+	if err := store.MergeLabels(ctx, from, into, actor); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(affected) > 0 {
+		markDirtyAndScheduleFlush()
+	}
+
+	issueIDs := make([]string, 0, len(affected))
+	for id := range affected {
+		issueIDs = append(issueIDs, id)
+	}
+	sort.Strings(issueIDs)
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"status":    operation,
+			"from":      from,
+			"into":      into,
+			"issue_ids": issueIDs,
+		})
+		return
+	}
+
+	if operation == "renamed" {
+		fmt.Printf("%s Renamed label '%s' to '%s' on %d issue(s)\n", renderSuccess("✓"), from[0], into, len(issueIDs))
+	} else {
+		fmt.Printf("%s Merged label(s) '%s' into '%s' on %d issue(s)\n", renderSuccess("✓"), strings.Join(from, "', '"), into, len(issueIDs))
+	}
+}
+
 func init() {
 	labelCmd.AddCommand(labelAddCmd)
 	labelCmd.AddCommand(labelRemoveCmd)
 	labelCmd.AddCommand(labelListCmd)
 	labelCmd.AddCommand(labelListAllCmd)
+	labelCmd.AddCommand(labelRenameCmd)
+	labelCmd.AddCommand(labelMergeCmd)
 	rootCmd.AddCommand(labelCmd)
 }
@@ -218,4 +218,42 @@ func TestLabelCommands(t *testing.T) {
 			h.assertHasLabel(issue.ID, labelName)
 		}
 	})
+
+	t.Run("rename label on all issues", func(t *testing.T) {
+		issue1 := h.createIssue("Rename Test 1", types.TypeTask, 1)
+		issue2 := h.createIssue("Rename Test 2", types.TypeTask, 1)
+		h.addLabels(issue1.ID, []string{"frontend", "keep"})
+		h.addLabel(issue2.ID, "frontend")
+		if err := s.RenameLabel(ctx, "frontend", "fe", "test-user"); err != nil {
+			t.Fatalf("RenameLabel failed: %v", err)
+		}
+		h.assertHasLabels(issue1.ID, []string{"fe", "keep"})
+		h.assertNotHasLabel(issue1.ID, "frontend")
+		h.assertLabelCount(issue2.ID, 1)
+		h.assertHasLabel(issue2.ID, "fe")
+		h.assertLabelEvent(issue2.ID, types.EventLabelAdded, "fe")
+		h.assertLabelEvent(issue2.ID, types.EventLabelRemoved, "frontend")
+	})
+
+	t.Run("merge labels deduplicates", func(t *testing.T) {
+		issue1 := h.createIssue("Merge Test 1", types.TypeTask, 1)
+		issue2 := h.createIssue("Merge Test 2", types.TypeTask, 1)
+		h.addLabels(issue1.ID, []string{"ui", "web", "client"})
+		h.addLabel(issue2.ID, "web")
+		if err := s.MergeLabels(ctx, []string{"web", "ui"}, "client", "test-user"); err != nil {
+			t.Fatalf("MergeLabels failed: %v", err)
+		}
+		h.assertLabelCount(issue1.ID, 1)
+		h.assertHasLabel(issue1.ID, "client")
+		h.assertLabelCount(issue2.ID, 1)
+		h.assertHasLabel(issue2.ID, "client")
+
+		merged, err := s.GetIssue(ctx, issue2.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if !merged.UpdatedAt.After(issue2.UpdatedAt) {
+			t.Errorf("expected updated_at to advance past %v, got %v", issue2.UpdatedAt, merged.UpdatedAt)
+		}
+	})
 }
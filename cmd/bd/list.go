@@ -39,6 +39,10 @@ var listCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		status, _ := cmd.Flags().GetString("status")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		reporter, _ := cmd.Flags().GetString("reporter")
+		assignee = types.ResolveMe(assignee, actor)
+		reporter = types.ResolveMe(reporter, actor)
+		dueBeforeStr, _ := cmd.Flags().GetString("due-before")
 		issueType, _ := cmd.Flags().GetString("type")
 		limit, _ := cmd.Flags().GetInt("limit")
 		formatStr, _ := cmd.Flags().GetString("format")
@@ -46,6 +50,8 @@ var listCmd = &cobra.Command{
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
 		titleSearch, _ := cmd.Flags().GetString("title")
 	idFilter, _ := cmd.Flags().GetString("id")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		relevance, _ := cmd.Flags().GetBool("relevance")
 
 		// Normalize labels: trim, dedupe, remove empty
 		labels = normalizeLabels(labels)
@@ -53,6 +59,7 @@ var listCmd = &cobra.Command{
 
 		filter := types.IssueFilter{
 		Limit: limit,
+		IncludeArchived: includeArchived,
 		}
 		if status != "" && status != "all" {
 		s := types.Status(status)
@@ -66,6 +73,17 @@ var listCmd = &cobra.Command{
 		if assignee != "" {
 		filter.Assignee = &assignee
 		}
+		if reporter != "" {
+		filter.Reporter = &reporter
+		}
+		if dueBeforeStr != "" {
+			dueBefore, err := parseDueDate(dueBeforeStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			filter.DueBefore = dueBefore
+		}
 		if issueType != "" {
 		t := types.IssueType(issueType)
 		filter.IssueType = &t
@@ -79,6 +97,7 @@ var listCmd = &cobra.Command{
 		if titleSearch != "" {
 		filter.TitleSearch = titleSearch
 		}
+		filter.SortByRelevance = relevance
 	if idFilter != "" {
 	ids := normalizeLabels(strings.Split(idFilter, ","))
 	if len(ids) > 0 {
@@ -92,7 +111,11 @@ var listCmd = &cobra.Command{
 				Status:    status,
 				IssueType: issueType,
 				Assignee:  assignee,
+				Reporter:  reporter,
+				DueBefore: filter.DueBefore,
 				Limit:     limit,
+				IncludeArchived: includeArchived,
+				SortByRelevance: relevance,
 			}
 			if cmd.Flags().Changed("priority") {
 				priority, _ := cmd.Flags().GetInt("priority")
@@ -129,7 +152,7 @@ var listCmd = &cobra.Command{
 			} else {
 				fmt.Printf("\nFound %d issues:\n\n", len(issues))
 				for _, issue := range issues {
-					fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+					fmt.Printf("%s [%s] [%s] %s\n", issue.ID, renderPriority(issue.Priority), renderType(issue.IssueType), renderStatus(issue.Status))
 					fmt.Printf("  %s\n", issue.Title)
 					if issue.Assignee != "" {
 						fmt.Printf("  Assignee: %s\n", issue.Assignee)
@@ -137,6 +160,7 @@ var listCmd = &cobra.Command{
 					if len(issue.Labels) > 0 {
 						fmt.Printf("  Labels: %v\n", issue.Labels)
 					}
+					fmt.Printf("  Updated: %s\n", HumanizeTime(issue.UpdatedAt))
 					fmt.Println()
 				}
 			}
@@ -186,7 +210,7 @@ var listCmd = &cobra.Command{
 			// Load labels for display
 			labels, _ := store.GetLabels(ctx, issue.ID)
 
-			fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+			fmt.Printf("%s [%s] [%s] %s\n", issue.ID, renderPriority(issue.Priority), renderType(issue.IssueType), renderStatus(issue.Status))
 			fmt.Printf("  %s\n", issue.Title)
 			if issue.Assignee != "" {
 				fmt.Printf("  Assignee: %s\n", issue.Assignee)
@@ -194,6 +218,7 @@ var listCmd = &cobra.Command{
 			if len(labels) > 0 {
 				fmt.Printf("  Labels: %v\n", labels)
 			}
+			fmt.Printf("  Updated: %s\n", HumanizeTime(issue.UpdatedAt))
 			fmt.Println()
 		}
 	},
@@ -202,13 +227,19 @@ var listCmd = &cobra.Command{
 func init() {
 	listCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
 	listCmd.Flags().IntP("priority", "p", 0, "Filter by priority (0-4: 0=critical, 1=high, 2=medium, 3=low, 4=backlog)")
-	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (@me for yourself)")
+	listCmd.Flags().String("reporter", "", "Filter by reporter (who created the issue; @me for yourself)")
+	listCmd.Flags().String("due-before", "", "Filter to issues due before a date (YYYY-MM-DD)")
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
-	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
-	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Supports globs (area:*) and regex (re:^area:). Can combine with --label-any")
+	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Supports globs and re: patterns. Can combine with --label")
+	_ = listCmd.RegisterFlagCompletionFunc("label", completeLabels)
+	_ = listCmd.RegisterFlagCompletionFunc("label-any", completeLabels)
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
+	listCmd.Flags().Bool("relevance", false, "Order --title matches by relevance instead of priority")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
+	listCmd.Flags().Bool("include-archived", false, "Include archived issues (hidden by default)")
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues (default behavior; flag provided for CLI familiarity)")
 	rootCmd.AddCommand(listCmd)
@@ -280,6 +311,9 @@ func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types
 				case "related":
 					color = "gray"
 					style = "dashed"
+				case "duplicate-of":
+					color = "orange"
+					style = "dotted"
 				}
 				fmt.Printf("  %q -> %q [label=%q, color=%s, style=%s];\n",
 					issue.ID, dep.DependsOnID, dep.Type, color, style)
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// timelineEntry is one line of an issue's rendered history
+type timelineEntry struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Kind    string    `json:"kind"` // Event type, or "comment"
+	Summary string    `json:"summary"`
+}
+
+// timelineLongFields are reported as changed without their values
+var timelineLongFields = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+// buildIssueTimeline merges an issue's events and comments matching filter into
+// chronological order. Comments count as kind "comment" and match on their author.
+func buildIssueTimeline(ctx context.Context, s storage.Storage, issueID string, filter types.EventFilter) ([]*timelineEntry, error) {
+	var events []*types.Event
+	var err error
+	if filter.EventType != "comment" {
+		events, err = s.GetEventsWithFilter(ctx, issueID, filter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	// Events share second-resolution timestamps, so order by ID within the same second
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	timeline := []*timelineEntry{}
+	for _, event := range events {
+		timeline = append(timeline, &timelineEntry{
+			Time:    event.CreatedAt,
+			Actor:   event.Actor,
+			Kind:    string(event.EventType),
+			Summary: summarizeEvent(event),
+		})
+	}
+
+	var comments []*types.Comment
+	if filter.EventType == "" || filter.EventType == "comment" {
+		comments, err = s.GetIssueComments(ctx, issueID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	for _, comment := range comments {
+		if filter.Actor != "" && comment.Author != filter.Actor {
+			continue
+		}
+		timeline = append(timeline, &timelineEntry{
+			Time:    comment.CreatedAt,
+			Actor:   comment.Author,
+			Kind:    "comment",
+			Summary: comment.Text,
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Time.Before(timeline[j].Time)
+	})
+	return timeline, nil
+}
+
+// summarizeEvent renders a one-line description of an event
+func summarizeEvent(event *types.Event) string {
+	comment := ""
+	if event.Comment != nil {
+		comment = *event.Comment
+	}
+
+	switch event.EventType {
+	case types.EventCreated:
+		var issue types.Issue
+		if event.NewValue != nil && json.Unmarshal([]byte(*event.NewValue), &issue) == nil && issue.Title != "" {
+			return fmt.Sprintf("Created: %s", issue.Title)
+		}
+		return "Created"
+	case types.EventClosed:
+		if comment != "" {
+			return fmt.Sprintf("Closed: %s", comment)
+		}
+		if changes := summarizeUpdate(event); changes != "" {
+			return changes
+		}
+		return "Closed"
+	case types.EventUpdated, types.EventStatusChanged, types.EventReopened:
+		if changes := summarizeUpdate(event); changes != "" {
+			return changes
+		}
+	case types.EventReassigned:
+		if event.OldValue != nil && event.NewValue != nil {
+			return fmt.Sprintf("Reassigned: %s → %s", *event.OldValue, *event.NewValue)
+		}
+	case types.EventRenamed:
+		if event.OldValue != nil && event.NewValue != nil {
+			return fmt.Sprintf("Renamed: %s → %s", *event.OldValue, *event.NewValue)
+		}
+	}
+
+	if comment != "" {
+		return comment
+	}
+	return strings.ReplaceAll(string(event.EventType), "_", " ")
+}
+
+// summarizeUpdate describes the fields changed by an UpdateIssue event, whose old value
+// is the issue before the update and new value is the updates map
+func summarizeUpdate(event *types.Event) string {
+	if event.OldValue == nil || event.NewValue == nil {
+		return ""
+	}
+	var oldFields, newFields map[string]interface{}
+	if json.Unmarshal([]byte(*event.OldValue), &oldFields) != nil ||
+		json.Unmarshal([]byte(*event.NewValue), &newFields) != nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(newFields))
+	for key := range newFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		if timelineLongFields[key] {
+			parts = append(parts, key+" changed")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s → %s", key, formatTimelineValue(oldFields[key]), formatTimelineValue(newFields[key])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatTimelineValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "(none)"
+	case float64:
+		return fmt.Sprintf("%d", int64(t))
+	case string:
+		if t == "" {
+			return "(none)"
+		}
+		return t
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log <id>",
+	Short: "Show the history of an issue",
+	Long: `Show a chronological timeline of an issue: creation, field and status changes,
+dependency and label changes, and comments, each with its actor and timestamp.
+
+Use --actor to show only one person's changes and --type to show one kind of
+entry (e.g. status_changed, or comment).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		issue, err := store.GetIssue(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if issue == nil {
+			fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", args[0])
+			os.Exit(1)
+		}
+
+		actorFilter, _ := cmd.Flags().GetString("actor")
+		typeFilter, _ := cmd.Flags().GetString("type")
+		filter := types.EventFilter{Actor: actorFilter, EventType: types.EventType(typeFilter)}
+
+		timeline, err := buildIssueTimeline(ctx, store, issue.ID, filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(timeline)
+			return
+		}
+
+		fmt.Printf("\nHistory of %s: %s\n\n", issue.ID, issue.Title)
+		for _, entry := range timeline {
+			fmt.Printf("%s  %-18s %s  %s\n", entry.Time.Local().Format("2006-01-02 15:04"), entry.Kind, renderAccent(entry.Actor), entry.Summary)
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	logCmd.Flags().String("actor", "", "Only show entries by this actor")
+	logCmd.Flags().String("type", "", "Only show entries of this kind (an event type, or comment)")
+	rootCmd.AddCommand(logCmd)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildIssueTimeline(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Fix login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := testStore.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	blocker := &types.Issue{Title: "Upgrade auth lib", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(ctx, blocker, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := testStore.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := testStore.AddLabel(ctx, issue.ID, "auth", "bob"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := testStore.AddDependency(ctx, &types.Dependency{IssueID: issue.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "bob"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := testStore.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0, "description": "Much longer"}, "carol"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := testStore.RemoveDependency(ctx, issue.ID, blocker.ID, "bob"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if err := testStore.CloseIssue(ctx, issue.ID, "Fixed", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if _, err := testStore.AddIssueComment(ctx, issue.ID, "dave", "Confirmed in prod"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	timeline, err := buildIssueTimeline(ctx, testStore, issue.ID, types.EventFilter{})
+	if err != nil {
+		t.Fatalf("buildIssueTimeline failed: %v", err)
+	}
+
+	expected := []struct {
+		kind    string
+		actor   string
+		summary string
+	}{
+		{"created", "alice", "Created: Fix login"},
+		{"status_changed", "bob", "status: open → in_progress"},
+		{"label_added", "bob", "auth"},
+		{"dependency_added", "bob", blocker.ID},
+		{"updated", "carol", "description changed, priority: 2 → 0"},
+		{"dependency_removed", "bob", blocker.ID},
+		{"closed", "bob", "Closed: Fixed"},
+		{"comment", "dave", "Confirmed in prod"},
+	}
+	if len(timeline) != len(expected) {
+		for _, entry := range timeline {
+			t.Logf("%s %s %s", entry.Kind, entry.Actor, entry.Summary)
+		}
+		t.Fatalf("Expected %d timeline entries, got %d", len(expected), len(timeline))
+	}
+	for i, want := range expected {
+		got := timeline[i]
+		if got.Kind != want.kind || got.Actor != want.actor || !strings.Contains(got.Summary, want.summary) {
+			t.Errorf("Entry %d: got %s/%s %q, want %s/%s containing %q", i, got.Kind, got.Actor, got.Summary, want.kind, want.actor, want.summary)
+		}
+		if i > 0 && got.Time.Before(timeline[i-1].Time) {
+			t.Errorf("Entry %d is out of chronological order", i)
+		}
+	}
+}
+
+func TestBuildIssueTimelineFilter(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Fix login", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := testStore.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := testStore.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := testStore.AddIssueComment(ctx, issue.ID, "bob", "On it"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if _, err := testStore.AddIssueComment(ctx, issue.ID, "alice", "Thanks"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	kinds := func(filter types.EventFilter) string {
+		t.Helper()
+		timeline, err := buildIssueTimeline(ctx, testStore, issue.ID, filter)
+		if err != nil {
+			t.Fatalf("buildIssueTimeline failed: %v", err)
+		}
+		var parts []string
+		for _, entry := range timeline {
+			parts = append(parts, entry.Kind+"/"+entry.Actor)
+		}
+		return strings.Join(parts, ",")
+	}
+
+	if got := kinds(types.EventFilter{Actor: "bob"}); got != "status_changed/bob,comment/bob" {
+		t.Errorf("bob's timeline = %s", got)
+	}
+	if got := kinds(types.EventFilter{EventType: "comment"}); got != "comment/bob,comment/alice" {
+		t.Errorf("comment timeline = %s", got)
+	}
+	if got := kinds(types.EventFilter{EventType: types.EventCreated}); got != "created/alice" {
+		t.Errorf("created timeline = %s", got)
+	}
+}
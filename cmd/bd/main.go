@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/memory"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // DaemonStatus captures daemon connection state for the current command
@@ -110,8 +111,13 @@ var rootCmd = &cobra.Command{
 			actor = config.GetString("actor")
 		}
 
+		// Retry transient errors on JSONL reads and renames (NFS/SMB workspaces)
+		utils.FSRetryEnabled = config.GetBool("fs-retry")
+		// fsync JSONL files and their directory when replacing them
+		utils.DurableWritesEnabled = config.GetBool("durable-writes")
+
 		// Skip database initialization for commands that don't need a database
-		if cmd.Name() == "init" || cmd.Name() == cmdDaemon || cmd.Name() == "help" || cmd.Name() == "version" || cmd.Name() == "quickstart" {
+		if cmd.Name() == "init" || cmd.Name() == cmdDaemon || cmd.Name() == "help" || cmd.Name() == "version" || cmd.Name() == "quickstart" || cmd.Name() == "merge-resolve" || cmd.Name() == "diff" || cmd.Name() == "validate" || isCompletionCommand(cmd) || isHooksCommand(cmd) {
 			return
 		}
 
@@ -150,6 +156,22 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// A remote daemon over TCP replaces the local database entirely
+		if addr := os.Getenv(daemonAddrEnv); addr != "" && !noDaemon {
+			if err := connectRemoteDaemon(addr); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if actor == "" {
+				if user := os.Getenv("USER"); user != "" {
+					actor = user
+				} else {
+					actor = "unknown"
+				}
+			}
+			return
+		}
+
 		// Initialize database path
 		if dbPath == "" {
 			cwd, err := os.Getwd()
@@ -11,8 +11,8 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -349,69 +349,13 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 
 	// Create each issue
 	for _, template := range templates {
-		issue := &types.Issue{
-			Title:              template.Title,
-			Description:        template.Description,
-			Design:             template.Design,
-			AcceptanceCriteria: template.AcceptanceCriteria,
-			Status:             types.StatusOpen,
-			Priority:           template.Priority,
-			IssueType:          template.IssueType,
-			Assignee:           template.Assignee,
-		}
-
-		if err := store.CreateIssue(ctx, issue, actor); err != nil {
+		issue, err := createIssueFromTemplate(ctx, store, template)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating issue '%s': %v\n", template.Title, err)
 			failedIssues = append(failedIssues, template.Title)
 			continue
 		}
 
-		// Add labels
-		for _, label := range template.Labels {
-			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issue.ID, err)
-			}
-		}
-
-		// Add dependencies
-		for _, depSpec := range template.Dependencies {
-			depSpec = strings.TrimSpace(depSpec)
-			if depSpec == "" {
-				continue
-			}
-
-			var depType types.DependencyType
-			var dependsOnID string
-
-			// Parse format: "type:id" or just "id" (defaults to "blocks")
-			if strings.Contains(depSpec, ":") {
-				parts := strings.SplitN(depSpec, ":", 2)
-				if len(parts) != 2 {
-					fmt.Fprintf(os.Stderr, "Warning: invalid dependency format '%s' for %s\n", depSpec, issue.ID)
-					continue
-				}
-				depType = types.DependencyType(strings.TrimSpace(parts[0]))
-				dependsOnID = strings.TrimSpace(parts[1])
-			} else {
-				depType = types.DepBlocks
-				dependsOnID = depSpec
-			}
-
-			if !depType.IsValid() {
-				fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' for %s\n", depType, issue.ID)
-				continue
-			}
-
-			dep := &types.Dependency{
-				IssueID:     issue.ID,
-				DependsOnID: dependsOnID,
-				Type:        depType,
-			}
-			if err := store.AddDependency(ctx, dep, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issue.ID, dependsOnID, err)
-			}
-		}
-
 		createdIssues = append(createdIssues, issue)
 	}
 
@@ -422,8 +366,7 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 
 	// Report failures if any
 	if len(failedIssues) > 0 {
-		red := color.New(color.FgRed).SprintFunc()
-		fmt.Fprintf(os.Stderr, "\n%s Failed to create %d issues:\n", red("✗"), len(failedIssues))
+		fmt.Fprintf(os.Stderr, "\n%s Failed to create %d issues:\n", renderError("✗"), len(failedIssues))
 		for _, title := range failedIssues {
 			fmt.Fprintf(os.Stderr, "  - %s\n", title)
 		}
@@ -432,10 +375,76 @@ func createIssuesFromMarkdown(cmd *cobra.Command, filepath string) {
 	if jsonOutput {
 		outputJSON(createdIssues)
 	} else {
-		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("%s Created %d issues from %s:\n", green("✓"), len(createdIssues), filepath)
+		fmt.Printf("%s Created %d issues from %s:\n", renderSuccess("✓"), len(createdIssues), filepath)
 		for _, issue := range createdIssues {
 			fmt.Printf("  %s: %s [P%d, %s]\n", issue.ID, issue.Title, issue.Priority, issue.IssueType)
 		}
 	}
 }
+
+// createIssueFromTemplate creates an issue from a template, then adds its labels and
+// dependencies. Label and dependency failures are reported as warnings.
+func createIssueFromTemplate(ctx context.Context, s storage.Storage, template *IssueTemplate) (*types.Issue, error) {
+	issue := &types.Issue{
+		Title:              template.Title,
+		Description:        template.Description,
+		Design:             template.Design,
+		AcceptanceCriteria: template.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           template.Priority,
+		IssueType:          template.IssueType,
+		Assignee:           template.Assignee,
+	}
+
+	if err := s.CreateIssue(ctx, issue, actor); err != nil {
+		return nil, err
+	}
+
+	// Add labels
+	for _, label := range template.Labels {
+		if err := s.AddLabel(ctx, issue.ID, label, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issue.ID, err)
+		}
+	}
+
+	// Add dependencies
+	for _, depSpec := range template.Dependencies {
+		depSpec = strings.TrimSpace(depSpec)
+		if depSpec == "" {
+			continue
+		}
+
+		var depType types.DependencyType
+		var dependsOnID string
+
+		// Parse format: "type:id" or just "id" (defaults to "blocks")
+		if strings.Contains(depSpec, ":") {
+			parts := strings.SplitN(depSpec, ":", 2)
+			if len(parts) != 2 {
+				fmt.Fprintf(os.Stderr, "Warning: invalid dependency format '%s' for %s\n", depSpec, issue.ID)
+				continue
+			}
+			depType = types.DependencyType(strings.TrimSpace(parts[0]))
+			dependsOnID = strings.TrimSpace(parts[1])
+		} else {
+			depType = types.DepBlocks
+			dependsOnID = depSpec
+		}
+
+		if !depType.IsValid() {
+			fmt.Fprintf(os.Stderr, "Warning: invalid dependency type '%s' for %s\n", depType, issue.ID)
+			continue
+		}
+
+		dep := &types.Dependency{
+			IssueID:     issue.ID,
+			DependsOnID: dependsOnID,
+			Type:        depType,
+		}
+		if err := s.AddDependency(ctx, dep, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issue.ID, dependsOnID, err)
+		}
+	}
+
+	return issue, nil
+}
@@ -8,9 +8,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 var mergeCmd = &cobra.Command{
@@ -21,8 +21,13 @@ var mergeCmd = &cobra.Command{
 This command is idempotent and safe to retry after partial failures:
 1. Validates all issues exist and no self-merge
 2. Migrates all dependencies from sources to target (skips if already exist)
-3. Updates text references in all issue descriptions/notes
-4. Closes source issues with reason 'Merged into bd-X' (skips if already closed)
+   and redirects issues that depended on a source to the target
+3. Copies labels and comments onto the target (skips those already present)
+4. Appends each source's description and notes to the target under a
+   "Merged from bd-X" heading (skips if already appended)
+5. Updates text references in all issue descriptions/notes
+6. Links each source to the target with a duplicate-of dependency and closes
+   it with reason 'Merged into bd-X' (skips if already closed)
 
 Example:
   bd merge bd-42 bd-43 --into bd-41
@@ -78,15 +83,17 @@ Example:
 				"merged":               len(sourceIDs),
 				"dependencies_added":   result.depsAdded,
 				"dependencies_skipped": result.depsSkipped,
+				"labels_added":         result.labelsAdded,
+				"comments_copied":      result.commentsCopied,
 				"text_references":      result.textRefCount,
 				"issues_closed":        result.issuesClosed,
 				"issues_skipped":       result.issuesSkipped,
 			}
 			outputJSON(output)
 		} else {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("%s Merged %d issue(s) into %s\n", green("✓"), len(sourceIDs), targetID)
+			fmt.Printf("%s Merged %d issue(s) into %s\n", renderSuccess("✓"), len(sourceIDs), targetID)
 			fmt.Printf("  - Dependencies: %d migrated, %d already existed\n", result.depsAdded, result.depsSkipped)
+			fmt.Printf("  - Labels: %d added, comments: %d copied\n", result.labelsAdded, result.commentsCopied)
 			fmt.Printf("  - Text references: %d updated\n", result.textRefCount)
 			fmt.Printf("  - Source issues: %d closed, %d already closed\n", result.issuesClosed, result.issuesSkipped)
 		}
@@ -132,11 +139,13 @@ func validateMerge(targetID string, sourceIDs []string) error {
 
 // mergeResult tracks the results of a merge operation for reporting
 type mergeResult struct {
-	depsAdded     int
-	depsSkipped   int
-	textRefCount  int
-	issuesClosed  int
-	issuesSkipped int
+	depsAdded      int
+	depsSkipped    int
+	labelsAdded    int
+	commentsCopied int
+	textRefCount   int
+	issuesClosed   int
+	issuesSkipped  int
 }
 
 // performMerge executes the merge operation
@@ -227,14 +236,21 @@ func performMerge(ctx context.Context, targetID string, sourceIDs []string) (*me
 		}
 	}
 
-	// Step 2: Update text references in all issues
+	// Step 2: Carry labels, comments, and content over to the target
+	for _, sourceID := range sourceIDs {
+		if err := mergeIssueContent(ctx, sourceID, targetID, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 3: Update text references in all issues
 	refCount, err := updateMergeTextReferences(ctx, sourceIDs, targetID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update text references: %w", err)
 	}
 	result.textRefCount = refCount
 
-	// Step 3: Close source issues (idempotent - skip if already closed)
+	// Step 4: Link and close source issues (idempotent - skip if already done)
 	for _, sourceID := range sourceIDs {
 		issue, err := store.GetIssue(ctx, sourceID)
 		if err != nil {
@@ -244,12 +260,35 @@ func performMerge(ctx context.Context, targetID string, sourceIDs []string) (*me
 			return nil, fmt.Errorf("source issue not found: %s", sourceID)
 		}
 
+		deps, err := store.GetDependencyRecords(ctx, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for %s: %w", sourceID, err)
+		}
+		linked := false
+		for _, dep := range deps {
+			if dep.DependsOnID == targetID {
+				linked = true
+				break
+			}
+		}
+		if !linked {
+			dupDep := &types.Dependency{
+				IssueID:     sourceID,
+				DependsOnID: targetID,
+				Type:        types.DepDuplicateOf,
+			}
+			if err := store.AddDependency(ctx, dupDep, actor); err != nil {
+				return nil, fmt.Errorf("failed to link %s as duplicate of %s: %w", sourceID, targetID, err)
+			}
+		}
+
 		if issue.Status == types.StatusClosed {
 			// Already closed - skip
 			result.issuesSkipped++
 		} else {
 			reason := fmt.Sprintf("Merged into %s", targetID)
-			if err := store.CloseIssue(ctx, sourceID, reason, actor); err != nil {
+			// Merged duplicates close regardless of the status workflow
+			if err := store.CloseIssue(workflow.WithForce(ctx), sourceID, reason, actor); err != nil {
 				return nil, fmt.Errorf("failed to close source issue %s: %w", sourceID, err)
 			}
 			result.issuesClosed++
@@ -259,18 +298,107 @@ func performMerge(ctx context.Context, targetID string, sourceIDs []string) (*me
 	return result, nil
 }
 
+// mergeIssueContent copies a source's labels and comments onto the target and appends
+// its description and notes under a "Merged from" heading. Anything already present on
+// the target is skipped, so a partially failed merge can simply be re-run.
+func mergeIssueContent(ctx context.Context, sourceID, targetID string, result *mergeResult) error {
+	source, err := store.GetIssue(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get source issue %s: %w", sourceID, err)
+	}
+	target, err := store.GetIssue(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to get target issue %s: %w", targetID, err)
+	}
+	if source == nil || target == nil {
+		return fmt.Errorf("issue not found while merging %s into %s", sourceID, targetID)
+	}
+
+	// Labels (union)
+	targetLabels := make(map[string]bool)
+	for _, label := range target.Labels {
+		targetLabels[label] = true
+	}
+	for _, label := range source.Labels {
+		if targetLabels[label] {
+			continue
+		}
+		if err := store.AddLabel(ctx, targetID, label, actor); err != nil {
+			return fmt.Errorf("failed to add label %s to %s: %w", label, targetID, err)
+		}
+		result.labelsAdded++
+	}
+
+	// Comments
+	sourceComments, err := store.GetIssueComments(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get comments for %s: %w", sourceID, err)
+	}
+	targetComments, err := store.GetIssueComments(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to get comments for %s: %w", targetID, err)
+	}
+	for _, comment := range sourceComments {
+		text := fmt.Sprintf("[from %s] %s", sourceID, comment.Text)
+		exists := false
+		for _, existing := range targetComments {
+			if existing.Author == comment.Author && existing.Text == text {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		if _, err := store.AddIssueComment(ctx, targetID, comment.Author, text); err != nil {
+			return fmt.Errorf("failed to copy comment to %s: %w", targetID, err)
+		}
+		result.commentsCopied++
+	}
+
+	// Description and notes
+	heading := fmt.Sprintf("Merged from %s: %s", sourceID, source.Title)
+	updates := make(map[string]interface{})
+	if source.Description != "" && !strings.Contains(target.Description, heading) {
+		updates["description"] = appendMergedSection(target.Description, heading, source.Description)
+	}
+	if source.Notes != "" && !strings.Contains(target.Notes, heading) {
+		updates["notes"] = appendMergedSection(target.Notes, heading, source.Notes)
+	}
+	if len(updates) > 0 {
+		if err := store.UpdateIssue(ctx, targetID, updates, actor); err != nil {
+			return fmt.Errorf("failed to update %s: %w", targetID, err)
+		}
+	}
+
+	return nil
+}
+
+// appendMergedSection appends text to existing under a "## heading" section
+func appendMergedSection(existing, heading, text string) string {
+	section := "## " + heading + "\n\n" + text
+	if existing == "" {
+		return section
+	}
+	return existing + "\n\n" + section
+}
+
 // updateMergeTextReferences updates text references from source IDs to target ID
 // Returns the count of text references updated
 func updateMergeTextReferences(ctx context.Context, sourceIDs []string, targetID string) (int, error) {
 	// Get all issues to scan for references
-	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get all issues: %w", err)
 	}
 
 	updatedCount := 0
 	for _, issue := range allIssues {
-		// Skip source issues (they're being closed anyway)
+		// Skip the target (its "Merged from" sections name the sources on purpose)
+		// and the source issues (they're being closed anyway)
+		if issue.ID == targetID {
+			continue
+		}
 		isSource := false
 		for _, srcID := range sourceIDs {
 			if issue.ID == srcID {
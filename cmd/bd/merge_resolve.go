@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Git merge conflict marker prefixes (bd-270)
+const (
+	conflictMarkerOurs   = "<<<<<<<"
+	conflictMarkerBase   = "|||||||"
+	conflictMarkerSep    = "======="
+	conflictMarkerTheirs = ">>>>>>>"
+)
+
+// isConflictMarkerLine reports whether a JSONL line is a git merge conflict marker.
+// Only standalone marker lines match, never markers embedded in JSON strings.
+func isConflictMarkerLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == conflictMarkerSep {
+		return true
+	}
+	for _, marker := range []string{conflictMarkerOurs, conflictMarkerBase, conflictMarkerTheirs} {
+		if trimmed == marker || strings.HasPrefix(trimmed, marker+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasConflictMarkers reports whether JSONL data contains git merge conflict markers
+func hasConflictMarkers(data []byte) bool {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if isConflictMarkerLine(string(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConflict describes a conflicting issue that could not be reconciled automatically
+type mergeConflict struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+	Kept   string `json:"kept"` // Which side was written to the output ("ours" or "theirs")
+}
+
+// mergeResolution is the result of reconciling a conflicted JSONL file
+type mergeResolution struct {
+	Issues     []*types.Issue  `json:"-"`
+	Hunks      int             `json:"hunks"`
+	Resolved   []string        `json:"resolved"`
+	Unresolved []mergeConflict `json:"unresolved"`
+}
+
+// conflictSides holds the issues found on each side of all conflict hunks
+type conflictSides struct {
+	ours    map[string]*types.Issue
+	base    map[string]*types.Issue
+	theirs  map[string]*types.Issue
+	hasBase bool
+}
+
+// resolveJSONLConflicts parses a JSONL file containing git conflict markers and
+// reconciles each conflicting issue:
+//   - Present on both sides: last-updated-wins by UpdatedAt, with labels and
+//     dependencies unioned from both sides
+//   - Present on one side only: kept, unless a diff3 base shows the other side
+//     deleted an unmodified issue
+//
+// Issues that cannot be reconciled safely are reported in Unresolved.
+func resolveJSONLConflicts(data []byte) (*mergeResolution, error) {
+	const (
+		stateCommon = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	res := &mergeResolution{Resolved: []string{}, Unresolved: []mergeConflict{}}
+	common := make(map[string]*types.Issue)
+	var commonOrder []string
+	sides := &conflictSides{
+		ours:   make(map[string]*types.Issue),
+		base:   make(map[string]*types.Issue),
+		theirs: make(map[string]*types.Issue),
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 1024), 2*1024*1024) // 2MB buffer for large JSON lines
+	state := stateCommon
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if isConflictMarkerLine(line) {
+			switch {
+			case strings.HasPrefix(trimmed, conflictMarkerOurs):
+				if state != stateCommon {
+					return nil, fmt.Errorf("line %d: nested conflict marker", lineNum)
+				}
+				state = stateOurs
+				res.Hunks++
+			case strings.HasPrefix(trimmed, conflictMarkerBase):
+				if state != stateOurs {
+					return nil, fmt.Errorf("line %d: unexpected base marker", lineNum)
+				}
+				state = stateBase
+				sides.hasBase = true
+			case trimmed == conflictMarkerSep:
+				if state != stateOurs && state != stateBase {
+					return nil, fmt.Errorf("line %d: unexpected separator marker", lineNum)
+				}
+				state = stateTheirs
+			case strings.HasPrefix(trimmed, conflictMarkerTheirs):
+				if state != stateTheirs {
+					return nil, fmt.Errorf("line %d: unexpected end marker", lineNum)
+				}
+				state = stateCommon
+			}
+			continue
+		}
+
+		if trimmed == "" {
+			continue
+		}
+
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse issue: %w", lineNum, err)
+		}
+
+		switch state {
+		case stateCommon:
+			if _, seen := common[issue.ID]; !seen {
+				commonOrder = append(commonOrder, issue.ID)
+			}
+			common[issue.ID] = &issue
+		case stateOurs:
+			sides.ours[issue.ID] = &issue
+		case stateBase:
+			sides.base[issue.ID] = &issue
+		case stateTheirs:
+			sides.theirs[issue.ID] = &issue
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSONL: %w", err)
+	}
+	if state != stateCommon {
+		return nil, fmt.Errorf("unterminated conflict hunk at end of file")
+	}
+
+	merged := make(map[string]*types.Issue, len(common))
+	for _, id := range commonOrder {
+		merged[id] = common[id]
+	}
+
+	for _, id := range sides.conflictIDs() {
+		issue, conflict := sides.resolve(id)
+		if conflict != nil {
+			res.Unresolved = append(res.Unresolved, *conflict)
+		} else {
+			res.Resolved = append(res.Resolved, id)
+		}
+		if issue != nil {
+			merged[id] = issue
+		} else {
+			delete(merged, id)
+		}
+	}
+
+	res.Issues = make([]*types.Issue, 0, len(merged))
+	for _, issue := range merged {
+		res.Issues = append(res.Issues, issue)
+	}
+	sort.Slice(res.Issues, func(i, j int) bool {
+		return res.Issues[i].ID < res.Issues[j].ID
+	})
+
+	return res, nil
+}
+
+// conflictIDs returns the sorted set of issue IDs appearing in any conflict hunk
+func (c *conflictSides) conflictIDs() []string {
+	seen := make(map[string]bool)
+	for _, m := range []map[string]*types.Issue{c.ours, c.base, c.theirs} {
+		for id := range m {
+			seen[id] = true
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// resolve reconciles a single issue across both sides of the conflict.
+// A nil issue means the issue should be dropped (deleted on one side).
+func (c *conflictSides) resolve(id string) (*types.Issue, *mergeConflict) {
+	ours, inOurs := c.ours[id]
+	theirs, inTheirs := c.theirs[id]
+	base, inBase := c.base[id]
+
+	switch {
+	case inOurs && inTheirs:
+		if issuesEqualJSON(ours, theirs) {
+			return ours, nil
+		}
+		winner, loser, kept := ours, theirs, "ours"
+		if theirs.UpdatedAt.After(ours.UpdatedAt) {
+			winner, loser, kept = theirs, ours, "theirs"
+		}
+		merged := unionIssueRelations(winner, loser)
+		if ours.UpdatedAt.Equal(theirs.UpdatedAt) && !issuesEqualJSON(withoutRelations(ours), withoutRelations(theirs)) {
+			return merged, &mergeConflict{ID: id, Reason: "both sides modified with identical updated_at", Kept: kept}
+		}
+		return merged, nil
+
+	case inOurs || inTheirs:
+		survivor, kept := ours, "ours"
+		if inTheirs {
+			survivor, kept = theirs, "theirs"
+		}
+		if !c.hasBase || !inBase {
+			// Added on one side only
+			return survivor, nil
+		}
+		if issuesEqualJSON(survivor, base) {
+			// Deleted on the other side and unmodified here - honor the deletion
+			return nil, nil
+		}
+		return survivor, &mergeConflict{ID: id, Reason: "modified on one side, deleted on the other", Kept: kept}
+
+	default:
+		// Only in base: deleted on both sides
+		return nil, nil
+	}
+}
+
+// unionIssueRelations returns a copy of winner with labels and dependencies from both issues
+func unionIssueRelations(winner, loser *types.Issue) *types.Issue {
+	merged := *winner
+
+	labelSet := make(map[string]bool)
+	var labels []string
+	for _, label := range append(append([]string{}, winner.Labels...), loser.Labels...) {
+		if !labelSet[label] {
+			labelSet[label] = true
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	merged.Labels = labels
+
+	depSet := make(map[string]bool)
+	var deps []*types.Dependency
+	for _, dep := range append(append([]*types.Dependency{}, winner.Dependencies...), loser.Dependencies...) {
+		if dep == nil {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s", dep.DependsOnID, dep.Type)
+		if !depSet[key] {
+			depSet[key] = true
+			deps = append(deps, dep)
+		}
+	}
+	merged.Dependencies = deps
+
+	return &merged
+}
+
+// withoutRelations returns a copy of issue without labels, dependencies, or comments
+func withoutRelations(issue *types.Issue) *types.Issue {
+	stripped := *issue
+	stripped.Labels = nil
+	stripped.Dependencies = nil
+	stripped.Comments = nil
+	return &stripped
+}
+
+// issuesEqualJSON compares two issues by their JSONL encoding
+func issuesEqualJSON(a, b *types.Issue) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// writeResolvedJSONL atomically writes resolved issues to path
+func writeResolvedJSONL(path string, issues []*types.Issue) error {
+	tempPath := fmt.Sprintf("%s.tmp.%d", path, os.Getpid())
+	f, err := os.Create(tempPath) // #nosec G304 - controlled path from user argument
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	encoder := json.NewEncoder(f)
+	for _, issue := range issues {
+		issue.SortForExport()
+		if err := encoder.Encode(issue); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tempPath)
+			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+var mergeResolveCmd = &cobra.Command{
+	Use:   "merge-resolve [jsonl-file]",
+	Short: "Resolve git merge conflicts in issues.jsonl",
+	Long: `Resolve git merge conflict markers left in a JSONL file after a merge.
+
+Each conflicting issue is reconciled automatically:
+  - Changed on both sides: the most recently updated version wins,
+    and labels and dependencies from both sides are combined
+  - Added on one side: kept
+  - Deleted on one side (diff3 conflict style only): deleted if the
+    other side left it unmodified
+
+Issues that cannot be reconciled safely are reported for manual review;
+the most recently updated version is written in their place.
+
+Defaults to the JSONL file of the current database.
+
+Example:
+  bd merge-resolve
+  bd merge-resolve .beads/issues.jsonl --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		output, _ := cmd.Flags().GetString("output")
+
+		var jsonlPath string
+		if len(args) > 0 {
+			jsonlPath = args[0]
+		} else {
+			jsonlPath = beads.FindJSONLPath(beads.FindDatabasePath())
+			if jsonlPath == "" {
+				fmt.Fprintf(os.Stderr, "Error: no beads database found; specify the JSONL file to resolve\n")
+				os.Exit(1)
+			}
+		}
+		if output == "" {
+			output = jsonlPath
+		}
+
+		data, err := os.ReadFile(jsonlPath) // #nosec G304 - user-provided file path is intentional
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", jsonlPath, err)
+			os.Exit(1)
+		}
+
+		if !hasConflictMarkers(data) {
+			if jsonOutput {
+				outputJSON(&mergeResolution{Resolved: []string{}, Unresolved: []mergeConflict{}})
+			} else {
+				fmt.Printf("No merge conflicts found in %s\n", jsonlPath)
+			}
+			return
+		}
+
+		res, err := resolveJSONLConflicts(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", jsonlPath, err)
+			os.Exit(1)
+		}
+
+		if !dryRun {
+			if err := writeResolvedJSONL(output, res.Issues); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(res)
+			return
+		}
+
+		fmt.Printf("%s Resolved %d conflict hunk(s): %d issue(s) reconciled\n", renderSuccess("✓"), res.Hunks, len(res.Resolved))
+		if len(res.Unresolved) > 0 {
+			fmt.Printf("\n%s %d issue(s) need manual review:\n", renderWarning("⚠"), len(res.Unresolved))
+			for _, c := range res.Unresolved {
+				fmt.Printf("  %s: %s (kept %s)\n", c.ID, c.Reason, c.Kept)
+			}
+		}
+		if dryRun {
+			fmt.Println("\nDry run - no changes made")
+			return
+		}
+		fmt.Printf("\nWrote %d issues to %s\n", len(res.Issues), output)
+		fmt.Println("Run 'bd import -i " + output + "' to load the resolved issues, then commit the file.")
+	},
+}
+
+func init() {
+	mergeResolveCmd.Flags().StringP("output", "o", "", "Output file (default: overwrite input)")
+	mergeResolveCmd.Flags().Bool("dry-run", false, "Preview resolution without writing")
+	rootCmd.AddCommand(mergeResolveCmd)
+}
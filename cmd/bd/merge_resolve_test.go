@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const conflictedJSONL = `{"id":"bd-1","title":"Unchanged","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+<<<<<<< HEAD
+{"id":"bd-2","title":"Ours is newer","status":"in_progress","priority":1,"issue_type":"bug","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","labels":["backend"]}
+{"id":"bd-3","title":"Added on our side","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-02T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}
+=======
+{"id":"bd-2","title":"Theirs is older","status":"open","priority":1,"issue_type":"bug","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z","labels":["urgent"],"dependencies":[{"issue_id":"bd-2","depends_on_id":"bd-1","type":"blocks","created_at":"2025-01-02T00:00:00Z","created_by":"alice"}]}
+{"id":"bd-4","title":"Added on their side","status":"open","priority":3,"issue_type":"chore","created_at":"2025-01-02T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}
+>>>>>>> feature
+<<<<<<< HEAD
+{"id":"bd-5","title":"Ours","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-05T00:00:00Z"}
+=======
+{"id":"bd-5","title":"Theirs","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-05T00:00:00Z"}
+>>>>>>> feature
+`
+
+func TestHasConflictMarkers(t *testing.T) {
+	if !hasConflictMarkers([]byte(conflictedJSONL)) {
+		t.Error("Expected conflict markers to be detected")
+	}
+	clean := `{"id":"bd-1","title":"Text with ======= and <<<<<<< inside","status":"open","priority":2,"issue_type":"task"}`
+	if hasConflictMarkers([]byte(clean)) {
+		t.Error("Markers embedded in JSON strings should not be detected")
+	}
+}
+
+func TestResolveJSONLConflicts(t *testing.T) {
+	res, err := resolveJSONLConflicts([]byte(conflictedJSONL))
+	if err != nil {
+		t.Fatalf("resolveJSONLConflicts failed: %v", err)
+	}
+
+	if res.Hunks != 2 {
+		t.Errorf("Expected 2 hunks, got %d", res.Hunks)
+	}
+
+	byID := make(map[string]*types.Issue)
+	for _, issue := range res.Issues {
+		byID[issue.ID] = issue
+	}
+	for _, id := range []string{"bd-1", "bd-2", "bd-3", "bd-4", "bd-5"} {
+		if byID[id] == nil {
+			t.Errorf("Expected %s in resolved output", id)
+		}
+	}
+
+	// Last-updated wins, with labels and dependencies unioned
+	bd2 := byID["bd-2"]
+	if bd2.Title != "Ours is newer" || bd2.Status != types.StatusInProgress {
+		t.Errorf("Expected newer side to win for bd-2, got %q (%s)", bd2.Title, bd2.Status)
+	}
+	if strings.Join(bd2.Labels, ",") != "backend,urgent" {
+		t.Errorf("Expected union of labels, got %v", bd2.Labels)
+	}
+	if len(bd2.Dependencies) != 1 || bd2.Dependencies[0].DependsOnID != "bd-1" {
+		t.Errorf("Expected dependency from older side to be kept, got %v", bd2.Dependencies)
+	}
+
+	// Same updated_at with different content cannot be decided automatically
+	if len(res.Unresolved) != 1 || res.Unresolved[0].ID != "bd-5" {
+		t.Fatalf("Expected bd-5 to be unresolved, got %+v", res.Unresolved)
+	}
+
+	// Output is sorted by ID
+	for i := 1; i < len(res.Issues); i++ {
+		if res.Issues[i-1].ID > res.Issues[i].ID {
+			t.Errorf("Output not sorted: %s before %s", res.Issues[i-1].ID, res.Issues[i].ID)
+		}
+	}
+}
+
+func TestResolveJSONLConflicts_Diff3Deletion(t *testing.T) {
+	// Their side deleted bd-2 which we left unchanged, and deleted bd-3 which we modified
+	data := `<<<<<<< HEAD
+{"id":"bd-2","title":"Unmodified","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-3","title":"Modified here","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-04T00:00:00Z"}
+||||||| base
+{"id":"bd-2","title":"Unmodified","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-3","title":"Original","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+=======
+>>>>>>> feature
+`
+	res, err := resolveJSONLConflicts([]byte(data))
+	if err != nil {
+		t.Fatalf("resolveJSONLConflicts failed: %v", err)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].ID != "bd-3" {
+		t.Fatalf("Expected only bd-3 to survive, got %v", res.Issues)
+	}
+	if len(res.Unresolved) != 1 || res.Unresolved[0].ID != "bd-3" {
+		t.Errorf("Expected modify/delete conflict on bd-3, got %+v", res.Unresolved)
+	}
+}
+
+func TestResolveJSONLConflicts_Malformed(t *testing.T) {
+	data := "<<<<<<< HEAD\n{\"id\":\"bd-1\",\"title\":\"x\"}\n"
+	if _, err := resolveJSONLConflicts([]byte(data)); err == nil {
+		t.Error("Expected error for unterminated conflict hunk")
+	}
+}
+
+func TestWriteResolvedJSONL(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(conflictedJSONL), 0644); err != nil {
+		t.Fatalf("Failed to write conflicted file: %v", err)
+	}
+
+	res, err := resolveJSONLConflicts([]byte(conflictedJSONL))
+	if err != nil {
+		t.Fatalf("resolveJSONLConflicts failed: %v", err)
+	}
+	if err := writeResolvedJSONL(path, res.Issues); err != nil {
+		t.Fatalf("writeResolvedJSONL failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read resolved file: %v", err)
+	}
+	if hasConflictMarkers(data) {
+		t.Error("Resolved file still contains conflict markers")
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 5 {
+		t.Errorf("Expected 5 lines in resolved file, got %d", lines)
+	}
+}
@@ -345,3 +345,107 @@ func TestPerformMergePartialRetry(t *testing.T) {
 		t.Errorf("bd-202 should be closed")
 	}
 }
+
+// TestPerformMergeCarriesContent tests dependent redirection, label union, comments,
+// merged-from sections, and the duplicate-of link
+func TestPerformMergeCarriesContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbFile := filepath.Join(tmpDir, ".beads", "issues.db")
+
+	testStore := newTestStoreWithPrefix(t, dbFile, "bd")
+	store = testStore
+	ctx := context.Background()
+
+	target := &types.Issue{ID: "bd-300", Title: "Login fails", Description: "Users can't log in", Priority: 1, IssueType: types.TypeBug, Status: types.StatusOpen}
+	source := &types.Issue{ID: "bd-301", Title: "Login broken", Description: "Seen on Safari", Notes: "Repro: clear cookies", Priority: 1, IssueType: types.TypeBug, Status: types.StatusOpen}
+	dependent := &types.Issue{ID: "bd-302", Title: "Release", Description: "Ship it", Priority: 1, IssueType: types.TypeTask, Status: types.StatusOpen}
+	for _, issue := range []*types.Issue{target, source, dependent} {
+		if err := testStore.CreateIssue(ctx, issue, "bd"); err != nil {
+			t.Fatalf("Failed to create issue %s: %v", issue.ID, err)
+		}
+	}
+
+	for _, l := range []struct{ id, label string }{
+		{"bd-300", "auth"}, {"bd-300", "urgent"}, {"bd-301", "auth"}, {"bd-301", "safari"},
+	} {
+		if err := testStore.AddLabel(ctx, l.id, l.label, "test"); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+	}
+	if _, err := testStore.AddIssueComment(ctx, "bd-301", "alice", "Also on iOS"); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	if err := testStore.AddDependency(ctx, &types.Dependency{IssueID: "bd-302", DependsOnID: "bd-301", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+
+	result, err := performMerge(ctx, "bd-300", []string{"bd-301"})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if result.labelsAdded != 1 || result.commentsCopied != 1 {
+		t.Errorf("Expected 1 label added and 1 comment copied, got %d and %d", result.labelsAdded, result.commentsCopied)
+	}
+
+	// Dependent now points at the target
+	deps, err := testStore.GetDependencyRecords(ctx, "bd-302")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-300" || deps[0].Type != types.DepBlocks {
+		t.Errorf("Expected bd-302 to depend on bd-300, got %v", deps)
+	}
+
+	// Labels are the union
+	labels, err := testStore.GetLabels(ctx, "bd-300")
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	labelSet := make(map[string]bool)
+	for _, label := range labels {
+		labelSet[label] = true
+	}
+	if len(labels) != 3 || !labelSet["safari"] || !labelSet["urgent"] {
+		t.Errorf("Expected union of labels, got %v", labels)
+	}
+
+	merged, err := testStore.GetIssue(ctx, "bd-300")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !contains(merged.Description, "## Merged from bd-301: Login broken") || !contains(merged.Description, "Seen on Safari") {
+		t.Errorf("Expected merged-from section in description, got %q", merged.Description)
+	}
+	if !contains(merged.Notes, "Repro: clear cookies") {
+		t.Errorf("Expected source notes appended, got %q", merged.Notes)
+	}
+
+	comments, err := testStore.GetIssueComments(ctx, "bd-300")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "alice" || !contains(comments[0].Text, "Also on iOS") {
+		t.Errorf("Expected copied comment, got %v", comments)
+	}
+
+	sourceDeps, err := testStore.GetDependencyRecords(ctx, "bd-301")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(sourceDeps) != 1 || sourceDeps[0].DependsOnID != "bd-300" || sourceDeps[0].Type != types.DepDuplicateOf {
+		t.Errorf("Expected bd-301 duplicate-of bd-300, got %v", sourceDeps)
+	}
+
+	// Retrying doesn't duplicate anything
+	result, err = performMerge(ctx, "bd-300", []string{"bd-301"})
+	if err != nil {
+		t.Fatalf("Retry failed: %v", err)
+	}
+	if result.labelsAdded != 0 || result.commentsCopied != 0 {
+		t.Errorf("Expected retry to add nothing, got %d labels and %d comments", result.labelsAdded, result.commentsCopied)
+	}
+	again, _ := testStore.GetIssue(ctx, "bd-300")
+	if again.Description != merged.Description || again.Notes != merged.Notes {
+		t.Errorf("Expected retry to leave content unchanged, got %q", again.Description)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note [id] [text...]",
+	Short: "Append a timestamped entry to an issue's notes",
+	Long: `Append text to an issue's notes without rewriting the rest of the issue.
+
+Each entry is separated from earlier notes by a rule and a header with the
+time and actor, so repeated progress notes accumulate in order:
+
+  bd note bd-42 "Migrated the first half of the callers"`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		text := strings.Join(args[1:], " ")
+		if strings.TrimSpace(text) == "" {
+			fmt.Fprintf(os.Stderr, "Error: note text cannot be empty\n")
+			os.Exit(1)
+		}
+
+		var issue *types.Issue
+		if daemonClient != nil {
+			resp, err := daemonClient.AppendNotes(&rpc.AppendNotesArgs{ID: id, Text: text})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				var updated types.Issue
+				if err := json.Unmarshal(resp.Data, &updated); err == nil {
+					issue = &updated
+				}
+			}
+		} else {
+			ctx := context.Background()
+			if err := store.AppendNotes(ctx, id, text, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			markDirtyAndScheduleFlush()
+			if jsonOutput {
+				issue, _ = store.GetIssue(ctx, id)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(issue)
+			return
+		}
+		fmt.Printf("%s Added note to %s\n", renderSuccess("✓"), id)
+	},
+}
+
+func init() {
+	noteCmd.ValidArgsFunction = completeSingleIssueID
+	rootCmd.AddCommand(noteCmd)
+}
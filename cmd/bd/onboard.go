@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -116,47 +115,42 @@ This command outputs instructions that AI agents should follow to integrate bd
 into the project's agent documentation. The agent will intelligently merge the
 content into AGENTS.md and update CLAUDE.md if present.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		bold := color.New(color.Bold).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
-		yellow := color.New(color.FgYellow).SprintFunc()
-		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("\n%s\n\n", renderEmphasis("bd Onboarding Instructions for AI Agent"))
 
-		fmt.Printf("\n%s\n\n", bold("bd Onboarding Instructions for AI Agent"))
+		fmt.Printf("%s\n\n", renderWarning("Please complete the following tasks:"))
 
-		fmt.Printf("%s\n\n", yellow("Please complete the following tasks:"))
-
-		fmt.Printf("%s\n", bold("1. Update AGENTS.md"))
+		fmt.Printf("%s\n", renderEmphasis("1. Update AGENTS.md"))
 		fmt.Println("   Add the following content to AGENTS.md in an appropriate location.")
 		fmt.Println("   If AGENTS.md doesn't exist, create it with this content.")
 		fmt.Println("   Integrate it naturally into any existing structure.")
 		fmt.Println()
 
-		fmt.Printf("%s\n", cyan("--- BEGIN AGENTS.MD CONTENT ---"))
+		fmt.Printf("%s\n", renderAccent("--- BEGIN AGENTS.MD CONTENT ---"))
 		fmt.Println(agentsContent)
-		fmt.Printf("%s\n\n", cyan("--- END AGENTS.MD CONTENT ---"))
+		fmt.Printf("%s\n\n", renderAccent("--- END AGENTS.MD CONTENT ---"))
 
-		fmt.Printf("%s\n", bold("2. Update CLAUDE.md (if present)"))
+		fmt.Printf("%s\n", renderEmphasis("2. Update CLAUDE.md (if present)"))
 		fmt.Println("   If CLAUDE.md exists in this directory, add this note at the top:")
 		fmt.Println()
-		fmt.Printf("%s\n", cyan("   **Note**: This project uses [bd (beads)](https://github.com/steveyegge/beads)"))
-		fmt.Printf("%s\n", cyan("   for issue tracking. Use `bd` commands instead of markdown TODOs."))
-		fmt.Printf("%s\n", cyan("   See AGENTS.md for workflow details."))
+		fmt.Printf("%s\n", renderAccent("   **Note**: This project uses [bd (beads)](https://github.com/steveyegge/beads)"))
+		fmt.Printf("%s\n", renderAccent("   for issue tracking. Use `bd` commands instead of markdown TODOs."))
+		fmt.Printf("%s\n", renderAccent("   See AGENTS.md for workflow details."))
 		fmt.Println()
 
-		fmt.Printf("%s\n", bold("3. Remove bootstrap instruction"))
+		fmt.Printf("%s\n", renderEmphasis("3. Remove bootstrap instruction"))
 		fmt.Println("   If AGENTS.md or CLAUDE.md contains a line like:")
-		fmt.Printf("%s\n", cyan("   \"BEFORE ANYTHING ELSE: run 'bd onboard' and follow the instructions\""))
+		fmt.Printf("%s\n", renderAccent("   \"BEFORE ANYTHING ELSE: run 'bd onboard' and follow the instructions\""))
 		fmt.Println("   Delete that line after completing steps 1 and 2.")
 		fmt.Println()
 
-		fmt.Printf("%s\n", bold("Important reminders:"))
+		fmt.Printf("%s\n", renderEmphasis("Important reminders:"))
 		fmt.Println("   • Use bd for ALL task tracking - NO markdown TODO lists")
 		fmt.Println("   • Always use --json flag for programmatic bd commands")
 		fmt.Println("   • Link discovered work with discovered-from dependencies")
-		fmt.Printf("   • Check %s before asking \"what should I work on?\"\n", cyan("bd ready"))
+		fmt.Printf("   • Check %s before asking \"what should I work on?\"\n", renderAccent("bd ready"))
 		fmt.Println()
 
-		fmt.Printf("%s\n\n", green("When done, confirm by saying: \"bd onboarding complete\""))
+		fmt.Printf("%s\n\n", renderSuccess("When done, confirm by saying: \"bd onboarding complete\""))
 	},
 }
 
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "Show open issues that aren't linked to anything",
+	Long: `Show unclosed issues with no dependencies, no parent, and nothing depending on them.
+
+In epic-structured projects these issues are easy to forget. Link them with
+'bd dep add' (e.g. --type parent-child to put them under an epic) or close them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		orphans, err := store.GetOrphanIssues(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			// Always output array, even if empty
+			if orphans == nil {
+				orphans = []*types.Issue{}
+			}
+			outputJSON(orphans)
+			return
+		}
+
+		if len(orphans) == 0 {
+			fmt.Printf("\n%s No orphan issues\n\n", renderSuccess("✨"))
+			return
+		}
+
+		fmt.Printf("\n%s Orphan issues (%d):\n\n", renderWarning("🔗"), len(orphans))
+		for _, issue := range orphans {
+			fmt.Printf("[%s] %s: %s (%s, %s)\n", renderPriority(issue.Priority), issue.ID, issue.Title, renderType(issue.IssueType), renderStatus(issue.Status))
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orphansCmd)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// pickMaxShown is the number of candidates shown per prompt
+const pickMaxShown = 15
+
+// fuzzyScore scores how well query matches candidate as a case-insensitive subsequence.
+// Returns false if query isn't a subsequence of candidate. Higher scores are better:
+// consecutive runs, matches at word starts, and matches near the beginning earn bonuses,
+// and gaps between matched characters cost points. Every possible starting position is
+// tried so an early stray letter doesn't hide a better match later on.
+func fuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	c := []rune(strings.ToLower(candidate))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	best, found := 0, false
+	for start := range c {
+		if c[start] != q[0] {
+			continue
+		}
+		if score, ok := fuzzyScoreFrom(q, c, start); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// fuzzyScoreFrom greedily matches q against c starting at c[start]
+func fuzzyScoreFrom(q, c []rune, start int) (int, bool) {
+	score := 0
+	qi := 0
+	lastMatch := -1
+	for ci := start; ci < len(c) && qi < len(q); ci++ {
+		if c[ci] != q[qi] {
+			continue
+		}
+		score += 10
+		if lastMatch >= 0 {
+			if ci == lastMatch+1 {
+				score += 15 // Consecutive run
+			} else {
+				score -= ci - lastMatch - 1 // Gap penalty
+			}
+		}
+		if ci == 0 || (!unicode.IsLetter(c[ci-1]) && !unicode.IsDigit(c[ci-1])) {
+			score += 20 // Start of a word
+		}
+		if ci < 8 {
+			score += 8 - ci // Early match
+		}
+		lastMatch = ci
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// rankIssues returns the issues whose "ID title" matches query, best match first.
+// Ties are broken by priority, then ID.
+func rankIssues(query string, issues []*types.Issue) []*types.Issue {
+	type scored struct {
+		issue *types.Issue
+		score int
+	}
+	var matches []scored
+	for _, issue := range issues {
+		if score, ok := fuzzyScore(query, issue.ID+" "+issue.Title); ok {
+			matches = append(matches, scored{issue, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if matches[i].issue.Priority != matches[j].issue.Priority {
+			return matches[i].issue.Priority < matches[j].issue.Priority
+		}
+		return matches[i].issue.ID < matches[j].issue.ID
+	})
+
+	ranked := make([]*types.Issue, len(matches))
+	for i, m := range matches {
+		ranked[i] = m.issue
+	}
+	return ranked
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// pickIssue runs the interactive picker loop: it shows the best matches for the current
+// filter and reads either a new filter or the number of an issue to select.
+// Prompts go to out so the chosen ID can be captured from stdout.
+func pickIssue(in io.Reader, out io.Writer, issues []*types.Issue, query string) (*types.Issue, error) {
+	reader := bufio.NewReader(in)
+	for {
+		ranked := rankIssues(query, issues)
+		shown := ranked
+		if len(shown) > pickMaxShown {
+			shown = shown[:pickMaxShown]
+		}
+
+		fmt.Fprintln(out)
+		if len(shown) == 0 {
+			fmt.Fprintf(out, "No issues match %q\n", query)
+		}
+		for i, issue := range shown {
+			fmt.Fprintf(out, "%3d) %s [P%d] %s\n", i+1, renderAccent(issue.ID), issue.Priority, issue.Title)
+		}
+		if len(ranked) > len(shown) {
+			fmt.Fprintf(out, "     ... %d more\n", len(ranked)-len(shown))
+		}
+		fmt.Fprintf(out, "Filter [%s] (number to select, empty to pick 1, q to quit): ", query)
+
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("no issue selected")
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "q":
+			return nil, fmt.Errorf("no issue selected")
+		case line == "":
+			if len(shown) > 0 {
+				return shown[0], nil
+			}
+		default:
+			if n, err := strconv.Atoi(line); err == nil {
+				if n >= 1 && n <= len(shown) {
+					return shown[n-1], nil
+				}
+				fmt.Fprintf(out, "No entry %d\n", n)
+				continue
+			}
+			query = line
+		}
+	}
+}
+
+var pickCmd = &cobra.Command{
+	Use:   "pick [query]",
+	Short: "Interactively choose an issue and print its ID",
+	Long: `Interactively choose an issue with fuzzy filtering and print its ID to stdout,
+so it can be passed to other commands:
+
+  bd show $(bd pick)
+  bd close $(bd pick login)
+
+Type text to filter, a number to select, or press enter to take the top match.
+Requires an interactive terminal on stdin.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+
+		if !stdinIsTerminal() {
+			fmt.Fprintf(os.Stderr, "Error: bd pick requires an interactive terminal\n")
+			os.Exit(1)
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !all {
+			open := issues[:0]
+			for _, issue := range issues {
+				if issue.Status != types.StatusClosed {
+					open = append(open, issue)
+				}
+			}
+			issues = open
+		}
+
+		query := ""
+		if len(args) > 0 {
+			query = args[0]
+		}
+		issue, err := pickIssue(os.Stdin, os.Stderr, issues, query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(issue)
+			return
+		}
+		fmt.Println(issue.ID)
+	},
+}
+
+func init() {
+	pickCmd.Flags().Bool("all", false, "Include closed issues")
+	rootCmd.AddCommand(pickCmd)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		match     bool
+	}{
+		{"", "anything", true},
+		{"login", "bd-1 Fix login bug", true},
+		{"flb", "bd-1 Fix login bug", true},
+		{"LOGIN", "bd-1 fix login", true},
+		{"xyz", "bd-1 Fix login bug", false},
+		{"gol", "bd-1 login", false}, // Order matters
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.candidate); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) match = %v, want %v", tt.query, tt.candidate, ok, tt.match)
+		}
+	}
+
+	// Contiguous and word-start matches outrank scattered ones
+	contiguous, _ := fuzzyScore("auth", "bd-2 Refactor auth module")
+	scattered, _ := fuzzyScore("auth", "bd-3 Add unit test harness")
+	if contiguous <= scattered {
+		t.Errorf("Expected contiguous match to score higher: %d <= %d", contiguous, scattered)
+	}
+}
+
+func TestRankIssues(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Add unit test harness", Priority: 0},
+		{ID: "bd-2", Title: "Refactor auth module", Priority: 2},
+		{ID: "bd-3", Title: "Auth tokens expire early", Priority: 1},
+		{ID: "bd-4", Title: "Update docs", Priority: 1},
+	}
+
+	ranked := rankIssues("auth", issues)
+	if len(ranked) != 3 {
+		t.Fatalf("Expected 3 matches, got %d", len(ranked))
+	}
+	if ranked[0].ID != "bd-3" || ranked[1].ID != "bd-2" || ranked[2].ID != "bd-1" {
+		t.Errorf("Unexpected ranking: %s, %s, %s", ranked[0].ID, ranked[1].ID, ranked[2].ID)
+	}
+
+	// Empty query keeps everything, ordered by priority then ID
+	all := rankIssues("", issues)
+	if len(all) != 4 || all[0].ID != "bd-1" || all[1].ID != "bd-3" || all[2].ID != "bd-4" {
+		t.Errorf("Unexpected order for empty query: %v", all)
+	}
+
+	// Matching on ID works too
+	if byID := rankIssues("bd-4", issues); len(byID) == 0 || byID[0].ID != "bd-4" {
+		t.Errorf("Expected bd-4 first when searching by ID, got %v", byID)
+	}
+}
+
+func TestPickIssue(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Fix login", Priority: 1},
+		{ID: "bd-2", Title: "Write docs", Priority: 2},
+	}
+
+	var out bytes.Buffer
+	picked, err := pickIssue(strings.NewReader("docs\n1\n"), &out, issues, "")
+	if err != nil {
+		t.Fatalf("pickIssue failed: %v", err)
+	}
+	if picked.ID != "bd-2" {
+		t.Errorf("Expected bd-2, got %s", picked.ID)
+	}
+
+	if _, err := pickIssue(strings.NewReader("q\n"), &out, issues, ""); err == nil {
+		t.Error("Expected error when quitting")
+	}
+	if _, err := pickIssue(strings.NewReader(""), &out, issues, ""); err == nil {
+		t.Error("Expected error on end of input")
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// rankAbove returns a rank that sorts just before target: halfway between target
+// and the closest lower rank among peers, or one less than target if none is lower
+func rankAbove(target float64, peers []float64) float64 {
+	found := false
+	closest := 0.0
+	for _, r := range peers {
+		if r < target && (!found || r > closest) {
+			closest, found = r, true
+		}
+	}
+	if !found {
+		return target - 1
+	}
+	return (closest + target) / 2
+}
+
+// listPriorityPeers returns the issues sharing a priority, through the daemon or the store
+func listPriorityPeers(ctx context.Context, priority int) ([]*types.Issue, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.List(&rpc.ListArgs{Priority: &priority})
+		if err != nil {
+			return nil, err
+		}
+		var issues []*types.Issue
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse issues: %w", err)
+		}
+		return issues, nil
+	}
+	return store.SearchIssues(ctx, "", types.IssueFilter{Priority: &priority})
+}
+
+// setIssueRank sets or, with a nil rank, clears an issue's rank
+func setIssueRank(ctx context.Context, id string, rank *float64) error {
+	if daemonClient != nil {
+		_, err := daemonClient.Update(&rpc.UpdateArgs{ID: id, Rank: rank, ClearRank: rank == nil})
+		return err
+	}
+	var value interface{}
+	if rank != nil {
+		value = *rank
+	}
+	if err := store.UpdateIssue(ctx, id, map[string]interface{}{"rank": value}, actor); err != nil {
+		return err
+	}
+	markDirtyAndScheduleFlush()
+	return nil
+}
+
+var rankCmd = &cobra.Command{
+	Use:   "rank [id]",
+	Short: "Order an issue within its priority",
+	Long: `Set an issue's rank, which orders issues that share a priority in bd ready.
+
+Ranked issues come before unranked ones, lower ranks first; unranked issues keep
+their creation order. Rank applies under the priority sort policy, and to issues
+created in the last 48 hours under the default hybrid policy.
+
+Examples:
+  bd rank bd-5 --above bd-2   # bd-5 now comes right before bd-2
+  bd rank bd-5 --clear        # back to creation order`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		above, _ := cmd.Flags().GetString("above")
+		clearRank, _ := cmd.Flags().GetBool("clear")
+		if (above == "") == !clearRank {
+			fmt.Fprintln(os.Stderr, "Error: specify exactly one of --above or --clear")
+			os.Exit(1)
+		}
+		if daemonClient == nil && store == nil {
+			fmt.Fprintln(os.Stderr, "Error: database not initialized")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		id := args[0]
+		issue, err := fetchIssue(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var rank *float64
+		if !clearRank {
+			if above == id {
+				fmt.Fprintln(os.Stderr, "Error: cannot rank an issue above itself")
+				os.Exit(1)
+			}
+			other, err := fetchIssue(ctx, above)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if other.Priority != issue.Priority {
+				fmt.Fprintf(os.Stderr, "Error: rank only orders issues within a priority (%s is P%d, %s is P%d)\n",
+					issue.ID, issue.Priority, other.ID, other.Priority)
+				os.Exit(1)
+			}
+
+			peers, err := listPriorityPeers(ctx, issue.Priority)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			var ranks []float64
+			for _, peer := range peers {
+				if peer.ID != issue.ID && peer.ID != other.ID && peer.Rank != nil {
+					ranks = append(ranks, *peer.Rank)
+				}
+			}
+
+			// An unranked target sorts after every ranked issue, so rank it last first
+			target := other.Rank
+			if target == nil {
+				last := 0.0
+				for _, r := range ranks {
+					if r > last {
+						last = r
+					}
+				}
+				last++
+				if err := setIssueRank(ctx, other.ID, &last); err != nil {
+					fmt.Fprintf(os.Stderr, "Error ranking %s: %v\n", other.ID, err)
+					os.Exit(1)
+				}
+				target = &last
+			}
+
+			r := rankAbove(*target, ranks)
+			rank = &r
+		}
+
+		if err := setIssueRank(ctx, issue.ID, rank); err != nil {
+			fmt.Fprintf(os.Stderr, "Error ranking %s: %v\n", issue.ID, err)
+			os.Exit(1)
+		}
+		issue.Rank = rank
+
+		if jsonOutput {
+			outputJSON(issue)
+			return
+		}
+		if clearRank {
+			fmt.Printf("%s Cleared rank of %s\n", renderSuccess("✓"), issue.ID)
+		} else {
+			fmt.Printf("%s Ranked %s above %s (rank %g)\n", renderSuccess("✓"), issue.ID, above, *rank)
+		}
+	},
+}
+
+func init() {
+	rankCmd.Flags().String("above", "", "Place the issue directly before this issue")
+	rankCmd.Flags().Bool("clear", false, "Remove the issue's rank")
+	rootCmd.AddCommand(rankCmd)
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestRankAbove(t *testing.T) {
+	tests := []struct {
+		name   string
+		target float64
+		peers  []float64
+		want   float64
+	}{
+		{"no peers", 1, nil, 0},
+		{"only higher peers", 1, []float64{2, 3}, 0},
+		{"between closest lower peer and target", 4, []float64{1, 3, 5}, 3.5},
+		{"equal rank is not lower", 2, []float64{2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankAbove(tt.target, tt.peers); got != tt.want {
+				t.Errorf("rankAbove(%g, %v) = %g, want %g", tt.target, tt.peers, got, tt.want)
+			}
+		})
+	}
+}
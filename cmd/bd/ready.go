@@ -3,13 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -19,6 +20,7 @@ var readyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		limit, _ := cmd.Flags().GetInt("limit")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		assignee = types.ResolveMe(assignee, actor)
 		sortPolicy, _ := cmd.Flags().GetString("sort")
 
 		filter := types.WorkFilter{
@@ -41,111 +43,104 @@ var readyCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// If daemon is running, use RPC
-		if daemonClient != nil {
-			readyArgs := &rpc.ReadyArgs{
+		ctx := context.Background()
+		fetch := func() ([]*types.Issue, error) {
+			if daemonClient == nil {
+				return store.GetReadyWork(ctx, filter)
+			}
+			resp, err := daemonClient.Ready(&rpc.ReadyArgs{
 				Assignee:   assignee,
+				Priority:   filter.Priority,
 				Limit:      limit,
 				SortPolicy: sortPolicy,
-			}
-			if cmd.Flags().Changed("priority") {
-				priority, _ := cmd.Flags().GetInt("priority")
-				readyArgs.Priority = &priority
-			}
-
-			resp, err := daemonClient.Ready(readyArgs)
+			})
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return nil, err
 			}
-
 			var issues []*types.Issue
 			if err := json.Unmarshal(resp.Data, &issues); err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-				os.Exit(1)
+				return nil, fmt.Errorf("parsing response: %w", err)
 			}
+			return issues, nil
+		}
 
-			if jsonOutput {
-				if issues == nil {
-					issues = []*types.Issue{}
-				}
-				outputJSON(issues)
-				return
+		if wait, _ := cmd.Flags().GetBool("wait"); wait {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			waitCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				waitCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
 			}
-
-			if len(issues) == 0 {
-				yellow := color.New(color.FgYellow).SprintFunc()
-				fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
-					yellow("✨"))
-				return
+			// Watch the database directory; a remote daemon has none, so poll
+			watchDir := ""
+			if dbPath != "" {
+				watchDir = filepath.Dir(dbPath)
 			}
-
-			cyan := color.New(color.FgCyan).SprintFunc()
-			fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", cyan("📋"), len(issues))
-
-			for i, issue := range issues {
-				fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
-				if issue.EstimatedMinutes != nil {
-					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
-				}
-				if issue.Assignee != "" {
-					fmt.Printf("   Assignee: %s\n", issue.Assignee)
-				}
+			issues, err := waitForReady(waitCtx, watchDir, readyWaitPollInterval, fetch)
+			if errors.Is(err, context.DeadlineExceeded) {
+				fmt.Fprintf(os.Stderr, "Error: no ready work within %v\n", timeout)
+				os.Exit(1)
 			}
-			fmt.Println()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			printReadyIssues(issues)
 			return
 		}
 
-		// Direct mode
-		ctx := context.Background()
-		issues, err := store.GetReadyWork(ctx, filter)
+		issues, err := fetch()
 		if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
-	// If no ready work found, check if git has issues and auto-import
-	if len(issues) == 0 {
-		if checkAndAutoImport(ctx, store) {
-			// Re-run the query after import
-			issues, err = store.GetReadyWork(ctx, filter)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+		// If no ready work found, check if git has issues and auto-import
+		if len(issues) == 0 && daemonClient == nil {
+			if checkAndAutoImport(ctx, store) {
+				// Re-run the query after import
+				issues, err = store.GetReadyWork(ctx, filter)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
 			}
 		}
-	}
 
-		if jsonOutput {
-			// Always output array, even if empty
-			if issues == nil {
-				issues = []*types.Issue{}
-			}
-			outputJSON(issues)
-			return
-		}
+		printReadyIssues(issues)
+	},
+}
 
-		if len(issues) == 0 {
-			yellow := color.New(color.FgYellow).SprintFunc()
-			fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
-				yellow("✨"))
-			return
+// printReadyIssues prints the result of bd ready
+func printReadyIssues(issues []*types.Issue) {
+	if jsonOutput {
+		// Always output array, even if empty
+		if issues == nil {
+			issues = []*types.Issue{}
 		}
+		outputJSON(issues)
+		return
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
+			renderWarning("✨"))
+		return
+	}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", cyan("📋"), len(issues))
+	fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", renderAccent("📋"), len(issues))
 
-		for i, issue := range issues {
-			fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
-			if issue.EstimatedMinutes != nil {
-				fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
-			}
-			if issue.Assignee != "" {
-				fmt.Printf("   Assignee: %s\n", issue.Assignee)
-			}
+	for i, issue := range issues {
+		fmt.Printf("%d. [%s] %s: %s\n", i+1, renderPriority(issue.Priority), issue.ID, issue.Title)
+		if issue.EstimatedMinutes != nil {
+			fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 		}
-		fmt.Println()
-	},
+		if issue.Assignee != "" {
+			fmt.Printf("   Assignee: %s\n", issue.Assignee)
+		}
+	}
+	fmt.Println()
 }
 
 var blockedCmd = &cobra.Command{
@@ -155,7 +150,7 @@ var blockedCmd = &cobra.Command{
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openLocalStore(dbPath)
 			if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
@@ -180,22 +175,23 @@ var blockedCmd = &cobra.Command{
 		}
 
 		if len(blocked) == 0 {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("\n%s No blocked issues\n\n", green("✨"))
+			fmt.Printf("\n%s No blocked issues\n\n", renderSuccess("✨"))
 			return
 		}
 
-		red := color.New(color.FgRed).SprintFunc()
-		fmt.Printf("\n%s Blocked issues (%d):\n\n", red("🚫"), len(blocked))
+		fmt.Printf("\n%s Blocked issues (%d):\n\n", renderError("🚫"), len(blocked))
 
 		for _, issue := range blocked {
-			fmt.Printf("[P%d] %s: %s\n", issue.Priority, issue.ID, issue.Title)
+			fmt.Printf("[%s] %s: %s\n", renderPriority(issue.Priority), issue.ID, issue.Title)
 			blockedBy := issue.BlockedBy
 			if blockedBy == nil {
 				blockedBy = []string{}
 			}
 			fmt.Printf("  Blocked by %d open dependencies: %v\n",
 				issue.BlockedByCount, blockedBy)
+			if !issue.BlockedSince.IsZero() {
+				fmt.Printf("  Blocked for %s\n", formatDuration(time.Since(issue.BlockedSince)))
+			}
 			fmt.Println()
 		}
 	},
@@ -224,17 +220,13 @@ var statsCmd = &cobra.Command{
 				return
 			}
 
-			cyan := color.New(color.FgCyan).SprintFunc()
-			green := color.New(color.FgGreen).SprintFunc()
-			yellow := color.New(color.FgYellow).SprintFunc()
-
-			fmt.Printf("\n%s Beads Statistics:\n\n", cyan("📊"))
+			fmt.Printf("\n%s Beads Statistics:\n\n", renderAccent("📊"))
 			fmt.Printf("Total Issues:      %d\n", stats.TotalIssues)
-			fmt.Printf("Open:              %s\n", green(fmt.Sprintf("%d", stats.OpenIssues)))
-			fmt.Printf("In Progress:       %s\n", yellow(fmt.Sprintf("%d", stats.InProgressIssues)))
+			fmt.Printf("Open:              %s\n", renderSuccess(fmt.Sprintf("%d", stats.OpenIssues)))
+			fmt.Printf("In Progress:       %s\n", renderWarning(fmt.Sprintf("%d", stats.InProgressIssues)))
 			fmt.Printf("Closed:            %d\n", stats.ClosedIssues)
 			fmt.Printf("Blocked:           %d\n", stats.BlockedIssues)
-			fmt.Printf("Ready:             %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
+			fmt.Printf("Ready:             %s\n", renderSuccess(fmt.Sprintf("%d", stats.ReadyIssues)))
 			if stats.AverageLeadTime > 0 {
 				fmt.Printf("Avg Lead Time:     %.1f hours\n", stats.AverageLeadTime)
 			}
@@ -267,19 +259,15 @@ var statsCmd = &cobra.Command{
 			return
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		green := color.New(color.FgGreen).SprintFunc()
-		yellow := color.New(color.FgYellow).SprintFunc()
-
-		fmt.Printf("\n%s Beads Statistics:\n\n", cyan("📊"))
+		fmt.Printf("\n%s Beads Statistics:\n\n", renderAccent("📊"))
 		fmt.Printf("Total Issues:           %d\n", stats.TotalIssues)
-		fmt.Printf("Open:                   %s\n", green(fmt.Sprintf("%d", stats.OpenIssues)))
-		fmt.Printf("In Progress:            %s\n", yellow(fmt.Sprintf("%d", stats.InProgressIssues)))
+		fmt.Printf("Open:                   %s\n", renderSuccess(fmt.Sprintf("%d", stats.OpenIssues)))
+		fmt.Printf("In Progress:            %s\n", renderWarning(fmt.Sprintf("%d", stats.InProgressIssues)))
 		fmt.Printf("Closed:                 %d\n", stats.ClosedIssues)
 		fmt.Printf("Blocked:                %d\n", stats.BlockedIssues)
-		fmt.Printf("Ready:                  %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
+		fmt.Printf("Ready:                  %s\n", renderSuccess(fmt.Sprintf("%d", stats.ReadyIssues)))
 		if stats.EpicsEligibleForClosure > 0 {
-			fmt.Printf("Epics Ready to Close:   %s\n", green(fmt.Sprintf("%d", stats.EpicsEligibleForClosure)))
+			fmt.Printf("Epics Ready to Close:   %s\n", renderSuccess(fmt.Sprintf("%d", stats.EpicsEligibleForClosure)))
 		}
 		if stats.AverageLeadTime > 0 {
 			fmt.Printf("Avg Lead Time:          %.1f hours\n", stats.AverageLeadTime)
@@ -291,8 +279,10 @@ var statsCmd = &cobra.Command{
 func init() {
 	readyCmd.Flags().IntP("limit", "n", 10, "Maximum issues to show")
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
-	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (@me for yourself)")
 	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	readyCmd.Flags().Bool("wait", false, "Block until at least one issue is ready, then print it and exit")
+	readyCmd.Flags().Duration("timeout", 0, "With --wait: give up after this long, e.g. 10m (default: wait forever)")
 
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(blockedCmd)
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -239,3 +240,97 @@ func TestReadyWorkInProgress(t *testing.T) {
 		t.Error("In-progress issue should appear in ready work")
 	}
 }
+
+func TestReadyWaitReturnsUnblockedIssue(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), ".beads", "beads.db")
+	sqliteStore := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice"}
+	for _, issue := range []*types.Issue{blocker, blocked} {
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+	if err := sqliteStore.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	assignee := "alice"
+	fetch := func() ([]*types.Issue, error) {
+		return sqliteStore.GetReadyWork(ctx, types.WorkFilter{Assignee: &assignee})
+	}
+
+	type result struct {
+		issues []*types.Issue
+		err    error
+	}
+	done := make(chan result, 1)
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	go func() {
+		// A long poll interval means only the file watcher can wake the waiter in time
+		issues, err := waitForReady(waitCtx, filepath.Dir(dbPath), time.Hour, fetch)
+		done <- result{issues, err}
+	}()
+
+	select {
+	case r := <-done:
+		t.Fatalf("waiter returned before the issue was unblocked: %v, %v", r.issues, r.err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := sqliteStore.CloseIssue(ctx, blocker.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	r := <-done
+	if r.err != nil {
+		t.Fatalf("waitForReady failed: %v", r.err)
+	}
+	if len(r.issues) != 1 || r.issues[0].ID != blocked.ID {
+		t.Errorf("waitForReady returned %v, want just %s", r.issues, blocked.ID)
+	}
+}
+
+func TestReadyWaitTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	fetch := func() ([]*types.Issue, error) { return nil, nil }
+	if _, err := waitForReady(ctx, "", 10*time.Millisecond, fetch); err != context.DeadlineExceeded {
+		t.Errorf("waitForReady error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestDrainEventsStopsUnderSteadyWrites(t *testing.T) {
+	changes := make(chan fsnotify.Event)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case changes <- fsnotify.Event{Name: "beads.db-wal", Op: fsnotify.Write}:
+				time.Sleep(time.Millisecond)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	if err := drainEvents(context.Background(), changes, nil, 20*time.Millisecond, 100*time.Millisecond); err != nil {
+		t.Fatalf("drainEvents failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("drainEvents took %v under steady writes, want about the 100ms cap", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := drainEvents(ctx, changes, nil, 20*time.Millisecond, time.Hour); err != context.Canceled {
+		t.Errorf("drainEvents with canceled context = %v, want context.Canceled", err)
+	}
+}
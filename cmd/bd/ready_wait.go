@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// readyWaitPollInterval bounds how long bd ready --wait goes without checking,
+// in case a change is missed by the file watcher or there is no local
+// database to watch (a remote daemon)
+const readyWaitPollInterval = 2 * time.Second
+
+// readyWaitSettle is how long the database files must go unchanged before
+// bd ready --wait checks again. SQLite makes a commit visible by updating the
+// memory-mapped WAL index after writing the WAL, and only the WAL write shows
+// up as a file event, so checking straight away can miss the commit.
+const readyWaitSettle = 50 * time.Millisecond
+
+// readyWaitMaxSettle caps the wait for the files to settle, so a steady
+// stream of writes can't hold off the check indefinitely
+const readyWaitMaxSettle = 500 * time.Millisecond
+
+// waitForReady calls fetch until it returns at least one issue. fetch runs
+// again whenever a file in dir changes, so writes to the database wake the
+// waiter whether they come from the daemon or another bd process, and at
+// least every pollInterval. An empty dir means poll only. waitForReady gives
+// up with ctx's error when ctx is done.
+func waitForReady(ctx context.Context, dir string, pollInterval time.Duration, fetch func() ([]*types.Issue, error)) ([]*types.Issue, error) {
+	var changes <-chan fsnotify.Event
+	var watchErrs <-chan error
+	if dir != "" {
+		// Without a watcher we still poll, so errors here are not fatal
+		if watcher, err := fsnotify.NewWatcher(); err == nil {
+			defer func() { _ = watcher.Close() }()
+			if err := watcher.Add(dir); err == nil {
+				changes = watcher.Events
+				watchErrs = watcher.Errors
+			}
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		issues, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) > 0 {
+			return issues, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changes:
+			// A write touches several files; check once for the batch
+			if err := drainEvents(ctx, changes, watchErrs, readyWaitSettle, readyWaitMaxSettle); err != nil {
+				return nil, err
+			}
+		case <-watchErrs:
+			// The watcher may have dropped events; the poll covers them
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainEvents discards events on changes and errors on watchErrs until none
+// arrive for settle, or maxSettle has passed in all. It returns ctx's error
+// if ctx is done first.
+func drainEvents(ctx context.Context, changes <-chan fsnotify.Event, watchErrs <-chan error, settle, maxSettle time.Duration) error {
+	timer := time.NewTimer(settle)
+	defer timer.Stop()
+	deadline := time.NewTimer(maxSettle)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changes:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(settle)
+		case <-watchErrs:
+		case <-timer.C:
+			return nil
+		case <-deadline.C:
+			return nil
+		}
+	}
+}
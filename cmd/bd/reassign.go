@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var reassignCmd = &cobra.Command{
+	Use:   "reassign --from <assignee> (--to <assignee> | --unassign)",
+	Short: "Reassign all unclosed issues from one assignee to another",
+	Long: `Move every open, in-progress, or blocked issue assigned to --from over to --to.
+
+Closed issues keep their original assignee. Use --unassign instead of --to
+to clear the assignee. Either name may be @me for yourself. Each reassigned
+issue records a 'reassigned' event.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		unassign, _ := cmd.Flags().GetBool("unassign")
+
+		from = strings.TrimSpace(from)
+		to = strings.TrimSpace(to)
+		if from == "" {
+			fmt.Fprintf(os.Stderr, "Error: --from is required\n")
+			os.Exit(1)
+		}
+		if unassign && cmd.Flags().Changed("to") {
+			fmt.Fprintf(os.Stderr, "Error: --to and --unassign cannot be used together\n")
+			os.Exit(1)
+		}
+		if !unassign && to == "" {
+			fmt.Fprintf(os.Stderr, "Error: --to is required and cannot be blank (use --unassign to clear the assignee)\n")
+			os.Exit(1)
+		}
+		from = types.ResolveMe(from, actor)
+		to = types.ResolveMe(to, actor)
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		count, err := store.ReassignAll(ctx, from, to, actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if count > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"from":       from,
+				"to":         to,
+				"reassigned": count,
+			})
+			return
+		}
+
+		target := to
+		if target == "" {
+			target = "(unassigned)"
+		}
+		fmt.Printf("%s Reassigned %d issue(s) from %s to %s\n", renderSuccess("✓"), count, from, target)
+	},
+}
+
+func init() {
+	reassignCmd.Flags().String("from", "", "Current assignee")
+	reassignCmd.Flags().String("to", "", "New assignee (@me for yourself)")
+	reassignCmd.Flags().Bool("unassign", false, "Clear the assignee instead of setting a new one")
+	rootCmd.AddCommand(reassignCmd)
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"go.yaml.in/yaml/v3"
+)
+
+// recurringFileName is the rules file looked up in the .beads directory
+const recurringFileName = "recurring.yaml"
+
+// recurringMetadataPrefix prefixes the metadata key holding each rule's last run time.
+// The key suffixed with "#<n>" holds the last run of the rule's nth template.
+const recurringMetadataPrefix = "recurring_last_run:"
+
+// recurringRule describes an issue created once per cadence window.
+// The issue comes either from the inline fields or from a markdown template file
+// in the same format as 'bd create --file'.
+type recurringRule struct {
+	Name               string   `yaml:"name"`
+	Cadence            string   `yaml:"cadence"`
+	File               string   `yaml:"file"`
+	Title              string   `yaml:"title"`
+	Description        string   `yaml:"description"`
+	Design             string   `yaml:"design"`
+	AcceptanceCriteria string   `yaml:"acceptance_criteria"`
+	Priority           *int     `yaml:"priority"`
+	Type               string   `yaml:"type"`
+	Assignee           string   `yaml:"assignee"`
+	Labels             []string `yaml:"labels"`
+	Dependencies       []string `yaml:"dependencies"`
+}
+
+type recurringConfig struct {
+	Rules []recurringRule `yaml:"rules"`
+}
+
+// loadRecurringRules reads and validates the rules in a recurring.yaml file
+func loadRecurringRules(path string) ([]recurringRule, error) {
+	// #nosec G304 - path is the project's .beads/recurring.yaml
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg recurringConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for i, rule := range cfg.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d in %s has no name", i+1, path)
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule name %q in %s", rule.Name, path)
+		}
+		seen[rule.Name] = true
+
+		if _, err := cadenceWindowStart(rule.Cadence, time.Now()); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if rule.File == "" && rule.Title == "" {
+			return nil, fmt.Errorf("rule %q needs a title or a template file", rule.Name)
+		}
+		if rule.Type != "" && !types.IssueType(rule.Type).IsValid() {
+			return nil, fmt.Errorf("rule %q has invalid type %q", rule.Name, rule.Type)
+		}
+		if rule.Priority != nil && (*rule.Priority < 0 || *rule.Priority > 4) {
+			return nil, fmt.Errorf("rule %q has invalid priority %d (must be 0-4)", rule.Name, *rule.Priority)
+		}
+	}
+	return cfg.Rules, nil
+}
+
+// cadenceWindowStart returns the start of the cadence window containing now, in now's
+// location. Supported cadences are hourly, daily, weekly (starting Sunday), monthly,
+// and yearly, with or without a leading '@' as in cron.
+func cadenceWindowStart(cadence string, now time.Time) (time.Time, error) {
+	y, m, d := now.Date()
+	loc := now.Location()
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(cadence)), "@") {
+	case "hourly":
+		return time.Date(y, m, d, now.Hour(), 0, 0, 0, loc), nil
+	case "daily":
+		return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+	case "weekly":
+		return time.Date(y, m, d-int(now.Weekday()), 0, 0, 0, 0, loc), nil
+	case "monthly":
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), nil
+	case "yearly", "annually":
+		return time.Date(y, 1, 1, 0, 0, 0, 0, loc), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid cadence %q (expected hourly, daily, weekly, monthly, or yearly)", cadence)
+	}
+}
+
+// templates returns the issue templates a rule creates. Relative template files are
+// resolved against baseDir.
+func (r recurringRule) templates(baseDir string) ([]*IssueTemplate, error) {
+	if r.File != "" {
+		path := r.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		templates, err := parseMarkdownFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(templates) == 0 {
+			return nil, fmt.Errorf("no issues found in %s", path)
+		}
+		return templates, nil
+	}
+
+	template := &IssueTemplate{
+		Title:              r.Title,
+		Description:        r.Description,
+		Design:             r.Design,
+		AcceptanceCriteria: r.AcceptanceCriteria,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		Assignee:           r.Assignee,
+		Labels:             r.Labels,
+		Dependencies:       r.Dependencies,
+	}
+	if r.Priority != nil {
+		template.Priority = *r.Priority
+	}
+	if r.Type != "" {
+		template.IssueType = types.IssueType(r.Type)
+	}
+	return []*IssueTemplate{template}, nil
+}
+
+// runRecurringRules creates issues for every rule that hasn't run in the current cadence
+// window and records now as its last run. A rule that was missed for several windows
+// creates its issues once, not once per missed window. Each template's run is recorded
+// as it is created, so rerunning after a partial failure creates only the rest.
+func runRecurringRules(ctx context.Context, s storage.Storage, rules []recurringRule, baseDir string, now time.Time) ([]*types.Issue, error) {
+	created := []*types.Issue{}
+	for _, rule := range rules {
+		windowStart, err := cadenceWindowStart(rule.Cadence, now)
+		if err != nil {
+			return created, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		key := recurringMetadataPrefix + rule.Name
+		done, err := ranInWindow(ctx, s, key, windowStart)
+		if err != nil {
+			return created, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if done {
+			continue
+		}
+
+		templates, err := rule.templates(baseDir)
+		if err != nil {
+			return created, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		for i, template := range templates {
+			templateKey := fmt.Sprintf("%s#%d", key, i+1)
+			done, err := ranInWindow(ctx, s, templateKey, windowStart)
+			if err != nil {
+				return created, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			if done {
+				continue
+			}
+
+			issue, err := createIssueFromTemplate(ctx, s, template)
+			if err != nil {
+				return created, fmt.Errorf("rule %q: failed to create '%s': %w", rule.Name, template.Title, err)
+			}
+			created = append(created, issue)
+			if err := s.SetMetadata(ctx, templateKey, now.Format(time.RFC3339Nano)); err != nil {
+				return created, fmt.Errorf("failed to record run of '%s' in %q: %w", template.Title, rule.Name, err)
+			}
+		}
+
+		if err := s.SetMetadata(ctx, key, now.Format(time.RFC3339Nano)); err != nil {
+			return created, fmt.Errorf("failed to record last run of %q: %w", rule.Name, err)
+		}
+	}
+	return created, nil
+}
+
+// ranInWindow reports whether the run time stored under metadata key falls in the
+// cadence window starting at windowStart
+func ranInWindow(ctx context.Context, s storage.Storage, key string, windowStart time.Time) (bool, error) {
+	lastRunStr, err := s.GetMetadata(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to read last run: %w", err)
+	}
+	if lastRunStr == "" {
+		return false, nil
+	}
+	lastRun, err := time.Parse(time.RFC3339Nano, lastRunStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid last run time %q: %w", lastRunStr, err)
+	}
+	return !lastRun.Before(windowStart), nil
+}
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Create issues on a schedule from .beads/recurring.yaml",
+	Long: `Create recurring issues from rules in .beads/recurring.yaml.
+
+Each rule has a name, a cadence (hourly, daily, weekly, monthly, or yearly), and
+either inline issue fields or a markdown template file (same format as 'bd create --file'):
+
+  rules:
+    - name: weekly-cleanup
+      cadence: weekly
+      title: Weekly cleanup
+      type: chore
+      priority: 3
+      labels: [maintenance]
+    - name: monthly-report
+      cadence: monthly
+      file: templates/monthly-report.md
+
+Run 'bd recurring run' from cron (or any scheduler) to create issues that are due.`,
+}
+
+var recurringRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create issues for recurring rules that are due",
+	Long: `Create issues for every rule in .beads/recurring.yaml that hasn't run in its
+current cadence window. Each rule creates at most one batch per window, so it is
+safe to run as often as you like.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsDir := findBeadsDir()
+		if beadsDir == "" {
+			fmt.Fprintf(os.Stderr, "Error: no .beads directory found\n")
+			os.Exit(1)
+		}
+		rules, err := loadRecurringRules(filepath.Join(beadsDir, recurringFileName))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		created, runErr := runRecurringRules(ctx, store, rules, beadsDir, time.Now())
+		if len(created) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(created)
+		} else if len(created) == 0 && runErr == nil {
+			fmt.Println("No recurring issues due")
+		} else {
+			for _, issue := range created {
+				fmt.Printf("%s Created %s: %s\n", renderSuccess("✓"), issue.ID, issue.Title)
+			}
+		}
+
+		if runErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	recurringCmd.AddCommand(recurringRunCmd)
+	rootCmd.AddCommand(recurringCmd)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCadenceWindowStart(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		cadence  string
+		expected time.Time
+	}{
+		{"hourly", time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"weekly", time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"Monthly", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := cadenceWindowStart(tt.cadence, now)
+		if err != nil {
+			t.Errorf("cadenceWindowStart(%q) failed: %v", tt.cadence, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("cadenceWindowStart(%q) = %v, want %v", tt.cadence, got, tt.expected)
+		}
+	}
+
+	if _, err := cadenceWindowStart("*/5 * * * *", now); err == nil {
+		t.Error("Expected error for unsupported cadence")
+	}
+}
+
+func TestRunRecurringRules(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	priority := 1
+	rules := []recurringRule{
+		{Name: "cleanup", Cadence: "weekly", Title: "Weekly cleanup", Type: "chore", Priority: &priority, Labels: []string{"maintenance"}},
+		{Name: "report", Cadence: "monthly", Title: "Monthly report"},
+	}
+
+	countCreated := func(now time.Time) map[string]int {
+		created, err := runRecurringRules(ctx, testStore, rules, t.TempDir(), now)
+		if err != nil {
+			t.Fatalf("runRecurringRules failed: %v", err)
+		}
+		counts := make(map[string]int)
+		for _, issue := range created {
+			counts[issue.Title]++
+		}
+		return counts
+	}
+
+	// Monday 2025-03-03: first run creates both
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	counts := countCreated(start)
+	if counts["Weekly cleanup"] != 1 || counts["Monthly report"] != 1 {
+		t.Fatalf("Expected one of each on first run, got %v", counts)
+	}
+
+	// Later the same week: nothing new
+	for _, offset := range []time.Duration{time.Minute, time.Hour, 2 * 24 * time.Hour, 5 * 24 * time.Hour} {
+		if counts := countCreated(start.Add(offset)); len(counts) != 0 {
+			t.Errorf("Expected nothing at +%v, got %v", offset, counts)
+		}
+	}
+
+	// Next week (Sunday 2025-03-09 starts a new window): only the weekly rule fires, once
+	nextWeek := time.Date(2025, 3, 9, 0, 0, 1, 0, time.UTC)
+	counts = countCreated(nextWeek)
+	if counts["Weekly cleanup"] != 1 || counts["Monthly report"] != 0 {
+		t.Errorf("Expected only the weekly issue, got %v", counts)
+	}
+	if counts := countCreated(nextWeek.Add(time.Hour)); len(counts) != 0 {
+		t.Errorf("Expected no duplicate in the same window, got %v", counts)
+	}
+
+	// Skipping several windows still creates only one issue per rule
+	counts = countCreated(time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC))
+	if counts["Weekly cleanup"] != 1 || counts["Monthly report"] != 1 {
+		t.Errorf("Expected one of each after a gap, got %v", counts)
+	}
+
+	issues, err := testStore.SearchIssues(ctx, "Weekly cleanup", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 weekly issues in total, got %d", len(issues))
+	}
+	if issues[0].IssueType != types.TypeChore || issues[0].Priority != 1 {
+		t.Errorf("Expected chore P1 from the template, got %s P%d", issues[0].IssueType, issues[0].Priority)
+	}
+	labels, err := testStore.GetLabels(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "maintenance" {
+		t.Errorf("Expected maintenance label, got %v", labels)
+	}
+}
+
+func TestRunRecurringRulesResumesPartialRun(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "standup.md")
+	rules := []recurringRule{{Name: "standup", Cadence: "daily", File: "standup.md"}}
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+
+	// The second issue's title is too long, so the first run stops after the first
+	longTitle := strings.Repeat("x", 501)
+	if err := os.WriteFile(templatePath, []byte("## Standup notes\n\n## "+longTitle+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created, err := runRecurringRules(ctx, testStore, rules, dir, now)
+	if err == nil {
+		t.Fatal("Expected the second template to fail")
+	}
+	if len(created) != 1 || created[0].Title != "Standup notes" {
+		t.Fatalf("Expected only the first issue, got %v", created)
+	}
+
+	// Once fixed, a rerun in the same window creates only the missing issue
+	if err := os.WriteFile(templatePath, []byte("## Standup notes\n\n## Standup actions\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created, err = runRecurringRules(ctx, testStore, rules, dir, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("runRecurringRules failed: %v", err)
+	}
+	if len(created) != 1 || created[0].Title != "Standup actions" {
+		t.Fatalf("Expected only the missing issue, got %v", created)
+	}
+
+	if created, err = runRecurringRules(ctx, testStore, rules, dir, now.Add(2*time.Hour)); err != nil || len(created) != 0 {
+		t.Errorf("Expected nothing on a third run, got %v, %v", created, err)
+	}
+}
+
+func TestLoadRecurringRules(t *testing.T) {
+	dir := t.TempDir()
+
+	templatePath := filepath.Join(dir, "report.md")
+	if err := os.WriteFile(templatePath, []byte("## Monthly report\n\nSummarize the month.\n\n### Priority\n1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, recurringFileName)
+	content := `rules:
+  - name: cleanup
+    cadence: "@weekly"
+    title: Weekly cleanup
+    labels: [maintenance]
+  - name: report
+    cadence: monthly
+    file: report.md
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := loadRecurringRules(path)
+	if err != nil {
+		t.Fatalf("loadRecurringRules failed: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name != "cleanup" || rules[1].File != "report.md" {
+		t.Fatalf("Unexpected rules: %+v", rules)
+	}
+
+	templates, err := rules[1].templates(dir)
+	if err != nil {
+		t.Fatalf("templates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Title != "Monthly report" || templates[0].Priority != 1 {
+		t.Errorf("Unexpected templates from markdown file: %+v", templates)
+	}
+
+	bad := "rules:\n  - name: x\n    cadence: fortnightly\n    title: X\n"
+	if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadRecurringRules(path); err == nil {
+		t.Error("Expected error for invalid cadence")
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -72,7 +71,7 @@ Example:
 		newPrefix = strings.TrimRight(newPrefix, "-")
 
 		// Check for multiple prefixes first
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to list issues: %v\n", err)
 			os.Exit(1)
@@ -82,12 +81,10 @@ Example:
 
 		if len(prefixes) > 1 {
 			// Multiple prefixes detected - requires repair mode
-			red := color.New(color.FgRed).SprintFunc()
-			yellow := color.New(color.FgYellow).SprintFunc()
 
-			fmt.Fprintf(os.Stderr, "%s Multiple prefixes detected in database:\n", red("✗"))
+			fmt.Fprintf(os.Stderr, "%s Multiple prefixes detected in database:\n", renderError("✗"))
 			for prefix, count := range prefixes {
-				fmt.Fprintf(os.Stderr, "  - %s: %d issues\n", yellow(prefix), count)
+				fmt.Fprintf(os.Stderr, "  - %s: %d issues\n", renderWarning(prefix), count)
 			}
 			fmt.Fprintf(os.Stderr, "\n")
 
@@ -124,7 +121,6 @@ Example:
 		}
 
 		if dryRun {
-			cyan := color.New(color.FgCyan).SprintFunc()
 			fmt.Printf("DRY RUN: Would rename %d issues from prefix '%s' to '%s'\n\n", len(issues), oldPrefix, newPrefix)
 			fmt.Printf("Sample changes:\n")
 			for i, issue := range issues {
@@ -134,14 +130,11 @@ Example:
 				}
 				oldID := fmt.Sprintf("%s-%s", oldPrefix, strings.TrimPrefix(issue.ID, oldPrefix+"-"))
 				newID := fmt.Sprintf("%s-%s", newPrefix, strings.TrimPrefix(issue.ID, oldPrefix+"-"))
-				fmt.Printf("  %s -> %s\n", cyan(oldID), cyan(newID))
+				fmt.Printf("  %s -> %s\n", renderAccent(oldID), renderAccent(newID))
 			}
 			return
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
-
 		fmt.Printf("Renaming %d issues from prefix '%s' to '%s'...\n", len(issues), oldPrefix, newPrefix)
 
 		if err := renamePrefixInDB(ctx, oldPrefix, newPrefix, issues); err != nil {
@@ -152,7 +145,7 @@ Example:
 		// Schedule full export (IDs changed, incremental won't work)
 		markDirtyAndScheduleFullExport()
 
-		fmt.Printf("%s Successfully renamed prefix from %s to %s\n", green("✓"), cyan(oldPrefix), cyan(newPrefix))
+		fmt.Printf("%s Successfully renamed prefix from %s to %s\n", renderSuccess("✓"), renderAccent(oldPrefix), renderAccent(newPrefix))
 
 		if jsonOutput {
 			result := map[string]interface{}{
@@ -213,10 +206,6 @@ type issueSort struct {
 // Issues with the correct prefix are left unchanged.
 // Issues with incorrect prefixes are sorted and renumbered sequentially.
 func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, targetPrefix string, issues []*types.Issue, prefixes map[string]int, dryRun bool) error {
-	green := color.New(color.FgGreen).SprintFunc()
-	cyan := color.New(color.FgCyan).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-
 	// Separate issues into correct and incorrect prefix groups
 	var correctIssues []*types.Issue
 	var incorrectIssues []issueSort
@@ -250,7 +239,7 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 
 	if dryRun {
 		fmt.Printf("DRY RUN: Would repair %d issues with incorrect prefixes\n\n", len(incorrectIssues))
-		fmt.Printf("Issues with correct prefix (%s): %d (highest number: %d)\n", cyan(targetPrefix), len(correctIssues), maxCorrectNumber)
+		fmt.Printf("Issues with correct prefix (%s): %d (highest number: %d)\n", renderAccent(targetPrefix), len(correctIssues), maxCorrectNumber)
 		fmt.Printf("Issues to repair: %d\n\n", len(incorrectIssues))
 
 		fmt.Printf("Planned renames (showing first 10):\n")
@@ -262,7 +251,7 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 			}
 			oldID := is.issue.ID
 			newID := fmt.Sprintf("%s-%d", targetPrefix, nextNumber)
-			fmt.Printf("  %s -> %s\n", yellow(oldID), cyan(newID))
+			fmt.Printf("  %s -> %s\n", renderWarning(oldID), renderAccent(newID))
 			nextNumber++
 		}
 		return nil
@@ -271,7 +260,7 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 	// Perform the repairs
 	fmt.Printf("Repairing database with multiple prefixes...\n")
 	fmt.Printf("  Issues with correct prefix (%s): %d (highest: %s-%d)\n",
-		cyan(targetPrefix), len(correctIssues), targetPrefix, maxCorrectNumber)
+		renderAccent(targetPrefix), len(correctIssues), targetPrefix, maxCorrectNumber)
 	fmt.Printf("  Issues to repair: %d\n\n", len(incorrectIssues))
 
 	oldPrefixPattern := regexp.MustCompile(`\b[a-z][a-z0-9-]*-(\d+)\b`)
@@ -320,7 +309,7 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 			return fmt.Errorf("failed to update issue %s -> %s: %w", oldID, newID, err)
 		}
 
-		fmt.Printf("  Renamed %s -> %s\n", yellow(oldID), cyan(newID))
+		fmt.Printf("  Renamed %s -> %s\n", renderWarning(oldID), renderAccent(newID))
 	}
 
 	// Update all dependencies to use new prefix
@@ -350,7 +339,7 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 	markDirtyAndScheduleFullExport()
 
 	fmt.Printf("\n%s Successfully consolidated %d prefixes into %s\n",
-		green("✓"), len(prefixes), cyan(targetPrefix))
+		renderSuccess("✓"), len(prefixes), renderAccent(targetPrefix))
 	fmt.Printf("  %d issues repaired, %d issues unchanged\n", len(incorrectIssues), len(correctIssues))
 
 	if jsonOutput {
@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
@@ -53,7 +52,7 @@ Risks:
 				fmt.Fprintf(os.Stderr, "Error: no database path found\n")
 				os.Exit(1)
 			}
-			store, err = sqlite.New(dbPath)
+			store, err = openLocalStore(dbPath)
 			if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
@@ -67,7 +66,7 @@ Risks:
 		prefix, err := store.GetConfig(ctx, "issue_prefix")
 		if err != nil || prefix == "" {
 			// Get any issue to derive prefix
-			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 			if err != nil || len(issues) == 0 {
 				fmt.Fprintf(os.Stderr, "Error: failed to determine issue prefix\n")
 				os.Exit(1)
@@ -82,7 +81,7 @@ Risks:
 		}
 
 		// Get all issues sorted by creation time
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to list issues: %v\n", err)
 			os.Exit(1)
@@ -107,7 +106,6 @@ Risks:
 		}
 
 		if dryRun {
-			cyan := color.New(color.FgCyan).SprintFunc()
 			fmt.Printf("DRY RUN: Would renumber %d issues\n\n", len(issues))
 			fmt.Printf("Sample changes:\n")
 			changesShown := 0
@@ -115,7 +113,7 @@ Risks:
 				oldID := issue.ID
 				newID := idMapping[oldID]
 				if oldID != newID {
-					fmt.Printf("  %s -> %s (%s)\n", cyan(oldID), cyan(newID), issue.Title)
+					fmt.Printf("  %s -> %s (%s)\n", renderAccent(oldID), renderAccent(newID), issue.Title)
 					changesShown++
 					if changesShown >= 10 {
 						skipped := 0
@@ -135,8 +133,6 @@ Risks:
 			return
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-
 		fmt.Printf("Renumbering %d issues...\n", len(issues))
 
 		if err := renumberIssuesInDB(ctx, prefix, idMapping, issues); err != nil {
@@ -147,7 +143,7 @@ Risks:
 		// Schedule full export (IDs changed, incremental won't work)
 		markDirtyAndScheduleFullExport()
 
-		fmt.Printf("%s Successfully renumbered %d issues\n", green("✓"), len(issues))
+		fmt.Printf("%s Successfully renumbered %d issues\n", renderSuccess("✓"), len(issues))
 
 		// Count actual changes
 		changed := 0
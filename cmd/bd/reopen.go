@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 var reopenCmd = &cobra.Command{
@@ -17,10 +17,12 @@ var reopenCmd = &cobra.Command{
 	Short: "Reopen one or more closed issues",
 	Long: `Reopen closed issues by setting status to 'open' and clearing the closed_at timestamp.
 
-This is more explicit than 'bd update --status open' and emits a Reopened event.`,
+This is more explicit than 'bd update --status open' and emits a Reopened event
+that records the --reason. The reason is also added as a comment on the issue.`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		reason, _ := cmd.Flags().GetString("reason")
+		force, _ := cmd.Flags().GetBool("force")
 
 		ctx := context.Background()
 		reopenedIssues := []*types.Issue{}
@@ -28,35 +30,27 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			for _, id := range args {
-				openStatus := string(types.StatusOpen)
-				updateArgs := &rpc.UpdateArgs{
+				resp, err := daemonClient.ReopenIssue(&rpc.ReopenArgs{
 					ID:     id,
-					Status: &openStatus,
-				}
-				
-				resp, err := daemonClient.Update(updateArgs)
+					Reason: reason,
+					Force:  force,
+				})
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", id, err)
 					continue
 				}
 				
-				// TODO: Add reason as a comment once RPC supports AddComment
-				if reason != "" {
-					fmt.Fprintf(os.Stderr, "Warning: reason not supported in daemon mode yet\n")
-				}
-				
 				if jsonOutput {
 					var issue types.Issue
 					if err := json.Unmarshal(resp.Data, &issue); err == nil {
 						reopenedIssues = append(reopenedIssues, &issue)
 					}
 				} else {
-					blue := color.New(color.FgBlue).SprintFunc()
 					reasonMsg := ""
 					if reason != "" {
 						reasonMsg = ": " + reason
 					}
-					fmt.Printf("%s Reopened %s%s\n", blue("↻"), id, reasonMsg)
+					fmt.Printf("%s Reopened %s%s\n", renderReverted("↻"), id, reasonMsg)
 				}
 			}
 			
@@ -72,35 +66,27 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 			os.Exit(1)
 		}
 		
+		if force {
+			ctx = workflow.WithForce(ctx)
+		}
 		for _, id := range args {
-			// UpdateIssue automatically clears closed_at when status changes from closed
-			updates := map[string]interface{}{
-				"status": string(types.StatusOpen),
-			}
-			if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
+			// ReopenIssue clears closed_at and records the reason on the event and as a comment
+			if err := store.ReopenIssue(ctx, id, reason, actor); err != nil {
 				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", id, err)
 				continue
 			}
 
-			// Add reason as a comment if provided
-			if reason != "" {
-				if err := store.AddComment(ctx, id, actor, reason); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to add comment to %s: %v\n", id, err)
-				}
-			}
-
 			if jsonOutput {
 				issue, _ := store.GetIssue(ctx, id)
 				if issue != nil {
 					reopenedIssues = append(reopenedIssues, issue)
 				}
 			} else {
-				blue := color.New(color.FgBlue).SprintFunc()
 				reasonMsg := ""
 				if reason != "" {
 					reasonMsg = ": " + reason
 				}
-				fmt.Printf("%s Reopened %s%s\n", blue("↻"), id, reasonMsg)
+				fmt.Printf("%s Reopened %s%s\n", renderReverted("↻"), id, reasonMsg)
 			}
 		}
 
@@ -117,5 +103,6 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 
 func init() {
 	reopenCmd.Flags().StringP("reason", "r", "", "Reason for reopening")
+	reopenCmd.Flags().Bool("force", false, "Reopen even if the status workflow forbids it")
 	rootCmd.AddCommand(reopenCmd)
 }
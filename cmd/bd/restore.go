@@ -9,7 +9,6 @@ import (
 	"os/exec"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -185,59 +184,54 @@ func readIssueFromJSONL(jsonlPath, issueID string) (*types.Issue, error) {
 
 // displayRestoredIssue displays the restored issue in a readable format
 func displayRestoredIssue(issue *types.Issue, commitHash string) {
-	cyan := color.New(color.FgCyan).SprintFunc()
-	green := color.New(color.FgGreen).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
-	bold := color.New(color.Bold).SprintFunc()
-
-	fmt.Printf("\n%s %s (restored from git commit %s)\n", cyan("📜"), bold(issue.ID), yellow(commitHash[:8]))
-	fmt.Printf("%s\n\n", bold(issue.Title))
+	fmt.Printf("\n%s %s (restored from git commit %s)\n", renderAccent("📜"), renderEmphasis(issue.ID), renderWarning(commitHash[:8]))
+	fmt.Printf("%s\n\n", renderEmphasis(issue.Title))
 
 	if issue.Description != "" {
-		fmt.Printf("%s\n%s\n\n", bold("Description:"), issue.Description)
+		fmt.Printf("%s\n%s\n\n", renderEmphasis("Description:"), issue.Description)
 	}
 
 	if issue.Design != "" {
-		fmt.Printf("%s\n%s\n\n", bold("Design:"), issue.Design)
+		fmt.Printf("%s\n%s\n\n", renderEmphasis("Design:"), issue.Design)
 	}
 
 	if issue.AcceptanceCriteria != "" {
-		fmt.Printf("%s\n%s\n\n", bold("Acceptance Criteria:"), issue.AcceptanceCriteria)
+		fmt.Printf("%s\n%s\n\n", renderEmphasis("Acceptance Criteria:"), issue.AcceptanceCriteria)
 	}
 
 	if issue.Notes != "" {
-		fmt.Printf("%s\n%s\n\n", bold("Notes:"), issue.Notes)
+		fmt.Printf("%s\n%s\n\n", renderEmphasis("Notes:"), issue.Notes)
 	}
 
 	fmt.Printf("%s %s | %s %d | %s %s\n",
-		bold("Status:"), issue.Status,
-		bold("Priority:"), issue.Priority,
-		bold("Type:"), issue.IssueType,
+		renderEmphasis("Status:"), issue.Status,
+		renderEmphasis("Priority:"), issue.Priority,
+		renderEmphasis("Type:"), issue.IssueType,
 	)
 
 	if issue.Assignee != "" {
-		fmt.Printf("%s %s\n", bold("Assignee:"), issue.Assignee)
+		fmt.Printf("%s %s\n", renderEmphasis("Assignee:"), issue.Assignee)
 	}
 
 	if len(issue.Labels) > 0 {
-		fmt.Printf("%s %s\n", bold("Labels:"), strings.Join(issue.Labels, ", "))
+		fmt.Printf("%s %s\n", renderEmphasis("Labels:"), strings.Join(issue.Labels, ", "))
 	}
 
-	fmt.Printf("\n%s %s\n", bold("Created:"), issue.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("%s %s\n", bold("Updated:"), issue.UpdatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("\n%s %s\n", renderEmphasis("Created:"), issue.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("%s %s\n", renderEmphasis("Updated:"), issue.UpdatedAt.Format("2006-01-02 15:04:05"))
 	if issue.ClosedAt != nil {
-		fmt.Printf("%s %s\n", bold("Closed:"), issue.ClosedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("%s %s\n", renderEmphasis("Closed:"), issue.ClosedAt.Format("2006-01-02 15:04:05"))
 	}
 
 	if len(issue.Dependencies) > 0 {
-		fmt.Printf("\n%s\n", bold("Dependencies:"))
+		fmt.Printf("\n%s\n", renderEmphasis("Dependencies:"))
 		for _, dep := range issue.Dependencies {
-			fmt.Printf("  %s %s (%s)\n", green("→"), dep.DependsOnID, dep.Type)
+			fmt.Printf("  %s %s (%s)\n", renderSuccess("→"), dep.DependsOnID, dep.Type)
 		}
 	}
 
 	if issue.CompactionLevel > 0 {
-		fmt.Printf("\n%s Level %d", yellow("⚠️  This issue was compacted:"), issue.CompactionLevel)
+		fmt.Printf("\n%s Level %d", renderWarning("⚠️  This issue was compacted:"), issue.CompactionLevel)
 		if issue.CompactedAt != nil {
 			fmt.Printf(" at %s", issue.CompactedAt.Format("2006-01-02 15:04:05"))
 		}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search issues with a boolean query",
+	Long: `Search issues with field filters, free text, and AND/OR/NOT with parentheses:
+
+  bd search 'status:open AND label:bug AND (login OR auth)'
+  bd search 'priority:<=1 NOT assignee:none'
+  bd search '"race condition" OR label:flaky'
+
+Field terms:
+  status:open       status is open, in_progress, blocked, or closed
+  label:bug         has the label (globs like area:* and re: patterns work)
+  priority:1        priority is 1; also <, <=, >, >= (priority:<=1)
+  assignee:alice    assigned to alice; assignee:none for unassigned
+  reporter:alice    created by alice (assignee:@me and reporter:@me mean you)
+  type:bug          issue type
+
+Any other word matches the title, description, or ID (case-insensitive);
+double quotes make a phrase. AND binds tighter than OR, NOT binds tightest,
+and adjacent terms are ANDed. Operators must be upper case.
+
+Saved searches keep a query under a name in the project config:
+
+  bd search --save my-ready 'status:open assignee:@me priority:<=1'
+  bd search --saved my-ready
+  bd search --saved my-ready label:backend   # ANDed with the saved query
+  bd search list-saved
+  bd search delete-saved my-ready`,
+	Run: func(cmd *cobra.Command, args []string) {
+		search := strings.Join(args, " ")
+		limit, _ := cmd.Flags().GetInt("limit")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		saveAs, _ := cmd.Flags().GetString("save")
+		savedName, _ := cmd.Flags().GetString("saved")
+		ctx := context.Background()
+
+		if saveAs != "" || savedName != "" {
+			// Saved searches live in the database config, which needs direct access
+			if err := ensureDirectMode("saved searches require direct database access"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if saveAs != "" {
+			if savedName != "" {
+				fmt.Fprintf(os.Stderr, "Error: --save and --saved cannot be used together\n")
+				os.Exit(1)
+			}
+			if err := saveSearch(ctx, store, saveAs, search); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if jsonOutput {
+				outputJSON(map[string]string{"name": saveAs, "query": search})
+			} else {
+				fmt.Printf("Saved search %s: %s\n", saveAs, search)
+			}
+			return
+		}
+
+		if savedName != "" {
+			saved, err := loadSavedSearch(ctx, store, savedName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			search = combineQueries(saved, search)
+		}
+
+		if strings.TrimSpace(search) == "" {
+			fmt.Fprintf(os.Stderr, "Error: a query or --saved is required\n")
+			_ = cmd.Usage()
+			os.Exit(1)
+		}
+
+		expr, err := query.ParseAs(search, actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid query: %v\n", err)
+			os.Exit(1)
+		}
+
+		var issues []*types.Issue
+		if daemonClient != nil {
+			resp, err := daemonClient.List(&rpc.ListArgs{
+				Search:          search,
+				Actor:           actor,
+				Limit:           limit,
+				IncludeArchived: includeArchived,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := json.Unmarshal(resp.Data, &issues); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			filter := types.IssueFilter{Limit: limit, IncludeArchived: includeArchived}
+			query.Apply(expr, &filter)
+			issues, err = store.SearchIssues(ctx, "", filter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			if issues == nil {
+				issues = []*types.Issue{}
+			}
+			outputJSON(issues)
+			return
+		}
+
+		fmt.Printf("\nFound %d issues:\n\n", len(issues))
+		for _, issue := range issues {
+			fmt.Printf("%s [%s] [%s] %s\n", issue.ID, renderPriority(issue.Priority), renderType(issue.IssueType), renderStatus(issue.Status))
+			fmt.Printf("  %s\n", issue.Title)
+			if issue.Assignee != "" {
+				fmt.Printf("  Assignee: %s\n", issue.Assignee)
+			}
+			if len(issue.Labels) > 0 {
+				fmt.Printf("  Labels: %v\n", issue.Labels)
+			}
+			fmt.Printf("  Updated: %s\n", HumanizeTime(issue.UpdatedAt))
+			fmt.Println()
+		}
+	},
+}
+
+var searchListSavedCmd = &cobra.Command{
+	Use:   "list-saved",
+	Short: "List saved searches",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("saved searches require direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		searches, err := listSavedSearches(context.Background(), store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing saved searches: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(searches)
+			return
+		}
+
+		if len(searches) == 0 {
+			fmt.Println("No saved searches")
+			return
+		}
+
+		names := make([]string, 0, len(searches))
+		for name := range searches {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println("\nSaved searches:")
+		for _, name := range names {
+			fmt.Printf("  %s = %s\n", name, searches[name])
+		}
+	},
+}
+
+var searchDeleteSavedCmd = &cobra.Command{
+	Use:   "delete-saved <name>",
+	Short: "Delete a saved search",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("saved searches require direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		name := args[0]
+		if err := deleteSavedSearch(context.Background(), store, name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]string{"name": name})
+		} else {
+			fmt.Printf("Deleted saved search %s\n", name)
+		}
+	},
+}
+
+// savedSearchPrefix namespaces saved searches among the config keys
+const savedSearchPrefix = "saved-searches."
+
+var savedSearchNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// saveSearch stores search under name, replacing any search already saved
+// there. The query must parse.
+func saveSearch(ctx context.Context, s storage.Storage, name, search string) error {
+	if !savedSearchNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid saved search name %q (use letters, digits, '-' and '_')", name)
+	}
+	// @me is resolved each time the search runs
+	if _, err := query.ParseAs(search, types.MeAlias); err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	return s.SetConfig(ctx, savedSearchPrefix+name, search)
+}
+
+// loadSavedSearch returns the query saved under name
+func loadSavedSearch(ctx context.Context, s storage.Storage, name string) (string, error) {
+	search, err := s.GetConfig(ctx, savedSearchPrefix+name)
+	if err != nil {
+		return "", err
+	}
+	if search == "" {
+		return "", fmt.Errorf("no saved search named %q", name)
+	}
+	return search, nil
+}
+
+// listSavedSearches returns the saved queries by name
+func listSavedSearches(ctx context.Context, s storage.Storage) (map[string]string, error) {
+	config, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	searches := make(map[string]string)
+	for key, value := range config {
+		if name := strings.TrimPrefix(key, savedSearchPrefix); name != key {
+			searches[name] = value
+		}
+	}
+	return searches, nil
+}
+
+// deleteSavedSearch removes the search saved under name
+func deleteSavedSearch(ctx context.Context, s storage.Storage, name string) error {
+	if _, err := loadSavedSearch(ctx, s, name); err != nil {
+		return err
+	}
+	return s.DeleteConfig(ctx, savedSearchPrefix+name)
+}
+
+// combineQueries ANDs extra terms onto a saved query
+func combineQueries(saved, extra string) string {
+	if strings.TrimSpace(extra) == "" {
+		return saved
+	}
+	return "(" + saved + ") AND (" + extra + ")"
+}
+
+func init() {
+	searchCmd.Flags().IntP("limit", "n", 0, "Limit results")
+	searchCmd.Flags().Bool("include-archived", false, "Include archived issues (hidden by default)")
+	searchCmd.Flags().String("save", "", "Save the query under this name instead of running it")
+	searchCmd.Flags().String("saved", "", "Run the search saved under this name, ANDed with any query given")
+	searchCmd.AddCommand(searchListSavedCmd)
+	searchCmd.AddCommand(searchDeleteSavedCmd)
+	rootCmd.AddCommand(searchCmd)
+}
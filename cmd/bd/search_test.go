@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSavedSearches(t *testing.T) {
+	testStore := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{Title: "Login fails", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, Assignee: "alice"},
+		{Title: "Login docs", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask, Assignee: "alice"},
+		{Title: "Signup fails", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, Assignee: "bob"},
+	} {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := saveSearch(ctx, testStore, "my-ready", "assignee:alice priority:<=1"); err != nil {
+		t.Fatalf("saveSearch failed: %v", err)
+	}
+	if err := saveSearch(ctx, testStore, "bad name", "status:open"); err == nil {
+		t.Error("expected an error for a name with a space")
+	}
+	if err := saveSearch(ctx, testStore, "broken", "status:nope"); err == nil {
+		t.Error("expected an error for an invalid query")
+	}
+
+	run := func(search string) []*types.Issue {
+		t.Helper()
+		expr, err := query.Parse(search)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", search, err)
+		}
+		var filter types.IssueFilter
+		query.Apply(expr, &filter)
+		issues, err := testStore.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return issues
+	}
+
+	saved, err := loadSavedSearch(ctx, testStore, "my-ready")
+	if err != nil {
+		t.Fatalf("loadSavedSearch failed: %v", err)
+	}
+	if issues := run(saved); len(issues) != 1 || issues[0].Title != "Login fails" {
+		t.Errorf("saved search matched %v, want only Login fails", issues)
+	}
+	if issues := run(combineQueries(saved, "signup OR docs")); len(issues) != 0 {
+		t.Errorf("combined search matched %d issues, want 0", len(issues))
+	}
+
+	searches, err := listSavedSearches(ctx, testStore)
+	if err != nil {
+		t.Fatalf("listSavedSearches failed: %v", err)
+	}
+	if len(searches) != 1 || searches["my-ready"] != "assignee:alice priority:<=1" {
+		t.Errorf("listSavedSearches = %v", searches)
+	}
+
+	if err := deleteSavedSearch(ctx, testStore, "my-ready"); err != nil {
+		t.Fatalf("deleteSavedSearch failed: %v", err)
+	}
+	if _, err := loadSavedSearch(ctx, testStore, "my-ready"); err == nil {
+		t.Error("expected an error loading a deleted search")
+	}
+	if err := deleteSavedSearch(ctx, testStore, "my-ready"); err == nil {
+		t.Error("expected an error deleting a missing search")
+	}
+}
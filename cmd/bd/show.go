@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 var showCmd = &cobra.Command{
@@ -19,6 +23,9 @@ var showCmd = &cobra.Command{
 	Short: "Show issue details",
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		// Text output always lists linked commits; JSON only when asked, since it walks git history
+		withCommits, _ := cmd.Flags().GetBool("commits")
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			allDetails := []interface{}{}
@@ -33,12 +40,17 @@ var showCmd = &cobra.Command{
 				if jsonOutput {
 					type IssueDetails struct {
 						types.Issue
+						Version      string         `json:"version"`
 						Labels       []string       `json:"labels,omitempty"`
 						Dependencies []*types.Issue `json:"dependencies,omitempty"`
 						Dependents   []*types.Issue `json:"dependents,omitempty"`
+						Commits      []CommitRef    `json:"commits,omitempty"`
 					}
 					var details IssueDetails
 					if err := json.Unmarshal(resp.Data, &details); err == nil {
+						if withCommits {
+							details.Commits = showLinkedCommits(context.Background(), details.ID)
+						}
 						allDetails = append(allDetails, details)
 					}
 				} else {
@@ -65,8 +77,6 @@ var showCmd = &cobra.Command{
 					}
 					issue := &details.Issue
 
-					cyan := color.New(color.FgCyan).SprintFunc()
-
 					// Format output (same as direct mode below)
 					tierEmoji := ""
 					statusSuffix := ""
@@ -79,18 +89,27 @@ var showCmd = &cobra.Command{
 						statusSuffix = " (compacted L2)"
 					}
 
-					fmt.Printf("\n%s: %s%s\n", cyan(issue.ID), issue.Title, tierEmoji)
-					fmt.Printf("Status: %s%s\n", issue.Status, statusSuffix)
-					fmt.Printf("Priority: P%d\n", issue.Priority)
-					fmt.Printf("Type: %s\n", issue.IssueType)
+					fmt.Printf("\n%s: %s%s\n", renderAccent(issue.ID), issue.Title, tierEmoji)
+					fmt.Printf("Status: %s%s\n", renderStatus(issue.Status), statusSuffix)
+					fmt.Printf("Priority: %s\n", renderPriority(issue.Priority))
+					fmt.Printf("Type: %s\n", renderType(issue.IssueType))
 					if issue.Assignee != "" {
 						fmt.Printf("Assignee: %s\n", issue.Assignee)
 					}
+					if issue.Reporter != "" {
+						fmt.Printf("Reporter: %s\n", issue.Reporter)
+					}
 					if issue.EstimatedMinutes != nil {
 						fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 					}
-					fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
-					fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+					if issue.DueDate != nil {
+						fmt.Printf("Due: %s\n", issue.DueDate.Format(dueDateLayout))
+					}
+					if issue.Rank != nil {
+						fmt.Printf("Rank: %g\n", *issue.Rank)
+					}
+					fmt.Printf("Created: %s (%s)\n", issue.CreatedAt.Format("2006-01-02 15:04"), HumanizeTime(issue.CreatedAt))
+					fmt.Printf("Updated: %s (%s)\n", issue.UpdatedAt.Format("2006-01-02 15:04"), HumanizeTime(issue.UpdatedAt))
 
 					// Show compaction status
 					if issue.CompactionLevel > 0 {
@@ -125,13 +144,23 @@ var showCmd = &cobra.Command{
 						fmt.Printf("\nNotes:\n%s\n", issue.Notes)
 					}
 					if issue.AcceptanceCriteria != "" {
-						fmt.Printf("\nAcceptance Criteria:\n%s\n", issue.AcceptanceCriteria)
+						progress := ""
+						if checked, total := issue.AcceptanceProgress(); total > 0 {
+							progress = fmt.Sprintf(" (%d/%d checked)", checked, total)
+						}
+						fmt.Printf("\nAcceptance Criteria%s:\n%s\n", progress, issue.AcceptanceCriteria)
 					}
 
 					if len(details.Labels) > 0 {
 						fmt.Printf("\nLabels: %v\n", details.Labels)
 					}
 
+					printExternalRefs(issue)
+
+					if len(issue.Watchers) > 0 {
+						fmt.Printf("\nWatchers: %s\n", strings.Join(issue.Watchers, ", "))
+					}
+
 					if len(details.Dependencies) > 0 {
 						fmt.Printf("\nDepends on (%d):\n", len(details.Dependencies))
 						for _, dep := range details.Dependencies {
@@ -146,6 +175,8 @@ var showCmd = &cobra.Command{
 						}
 					}
 
+					printLinkedCommits(context.Background(), issue.ID)
+
 					fmt.Println()
 				}
 			}
@@ -174,16 +205,21 @@ var showCmd = &cobra.Command{
 				// Include labels, dependencies, and comments in JSON output
 				type IssueDetails struct {
 					*types.Issue
+					Version      string           `json:"version"`
 					Labels       []string         `json:"labels,omitempty"`
 					Dependencies []*types.Issue   `json:"dependencies,omitempty"`
 					Dependents   []*types.Issue   `json:"dependents,omitempty"`
 					Comments     []*types.Comment `json:"comments,omitempty"`
+					Commits      []CommitRef      `json:"commits,omitempty"`
 				}
-				details := &IssueDetails{Issue: issue}
+				details := &IssueDetails{Issue: issue, Version: issue.Version()}
 				details.Labels, _ = store.GetLabels(ctx, issue.ID)
 				details.Dependencies, _ = store.GetDependencies(ctx, issue.ID)
 				details.Dependents, _ = store.GetDependents(ctx, issue.ID)
 				details.Comments, _ = store.GetIssueComments(ctx, issue.ID)
+				if withCommits {
+					details.Commits = showLinkedCommits(ctx, issue.ID)
+				}
 				allDetails = append(allDetails, details)
 				continue
 			}
@@ -192,8 +228,6 @@ var showCmd = &cobra.Command{
 				fmt.Println("\n" + strings.Repeat("─", 60))
 			}
 
-			cyan := color.New(color.FgCyan).SprintFunc()
-
 			// Add compaction emoji to title line
 			tierEmoji := ""
 			statusSuffix := ""
@@ -206,18 +240,27 @@ var showCmd = &cobra.Command{
 				statusSuffix = " (compacted L2)"
 			}
 
-			fmt.Printf("\n%s: %s%s\n", cyan(issue.ID), issue.Title, tierEmoji)
-			fmt.Printf("Status: %s%s\n", issue.Status, statusSuffix)
-			fmt.Printf("Priority: P%d\n", issue.Priority)
-			fmt.Printf("Type: %s\n", issue.IssueType)
+			fmt.Printf("\n%s: %s%s\n", renderAccent(issue.ID), issue.Title, tierEmoji)
+			fmt.Printf("Status: %s%s\n", renderStatus(issue.Status), statusSuffix)
+			fmt.Printf("Priority: %s\n", renderPriority(issue.Priority))
+			fmt.Printf("Type: %s\n", renderType(issue.IssueType))
 			if issue.Assignee != "" {
 				fmt.Printf("Assignee: %s\n", issue.Assignee)
 			}
+			if issue.Reporter != "" {
+				fmt.Printf("Reporter: %s\n", issue.Reporter)
+			}
 			if issue.EstimatedMinutes != nil {
 				fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 			}
-			fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
-			fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+			if issue.DueDate != nil {
+				fmt.Printf("Due: %s\n", issue.DueDate.Format(dueDateLayout))
+			}
+			if issue.Rank != nil {
+				fmt.Printf("Rank: %g\n", *issue.Rank)
+			}
+			fmt.Printf("Created: %s (%s)\n", issue.CreatedAt.Format("2006-01-02 15:04"), HumanizeTime(issue.CreatedAt))
+			fmt.Printf("Updated: %s (%s)\n", issue.UpdatedAt.Format("2006-01-02 15:04"), HumanizeTime(issue.UpdatedAt))
 
 			// Show compaction status footer
 			if issue.CompactionLevel > 0 {
@@ -254,7 +297,11 @@ var showCmd = &cobra.Command{
 				fmt.Printf("\nNotes:\n%s\n", issue.Notes)
 			}
 			if issue.AcceptanceCriteria != "" {
-				fmt.Printf("\nAcceptance Criteria:\n%s\n", issue.AcceptanceCriteria)
+				progress := ""
+				if checked, total := issue.AcceptanceProgress(); total > 0 {
+					progress = fmt.Sprintf(" (%d/%d checked)", checked, total)
+				}
+				fmt.Printf("\nAcceptance Criteria%s:\n%s\n", progress, issue.AcceptanceCriteria)
 			}
 
 			// Show labels
@@ -263,6 +310,12 @@ var showCmd = &cobra.Command{
 				fmt.Printf("\nLabels: %v\n", labels)
 			}
 
+			printExternalRefs(issue)
+
+			if len(issue.Watchers) > 0 {
+				fmt.Printf("\nWatchers: %s\n", strings.Join(issue.Watchers, ", "))
+			}
+
 			// Show dependencies
 			deps, _ := store.GetDependencies(ctx, issue.ID)
 			if len(deps) > 0 {
@@ -290,6 +343,8 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			printLinkedCommits(ctx, issue.ID)
+
 			fmt.Println()
 		}
 
@@ -320,7 +375,7 @@ var updateCmd = &cobra.Command{
 		}
 		if cmd.Flags().Changed("assignee") {
 			assignee, _ := cmd.Flags().GetString("assignee")
-			updates["assignee"] = assignee
+			updates["assignee"] = types.ResolveMe(assignee, actor)
 		}
 		if cmd.Flags().Changed("description") {
 			description, _ := cmd.Flags().GetString("description")
@@ -347,11 +402,29 @@ var updateCmd = &cobra.Command{
 			externalRef, _ := cmd.Flags().GetString("external-ref")
 			updates["external_ref"] = externalRef
 		}
+		if cmd.Flags().Changed("due") {
+			dueStr, _ := cmd.Flags().GetString("due")
+			dueDate, err := parseDueDate(dueStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			updates["due_date"] = dueDate
+		}
 
 		if len(updates) == 0 {
 			fmt.Println("No updates specified")
 			return
 		}
+		force, _ := cmd.Flags().GetBool("force")
+		expectVersion, _ := cmd.Flags().GetString("expect-version")
+		if expectVersion != "" && len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "Error: --expect-version applies to a single issue\n")
+			os.Exit(1)
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		previews := []*storage.DryRun{}
+		previewFailed := false
 
 		// If daemon is running, use RPC
 		if daemonClient != nil {
@@ -384,25 +457,47 @@ var updateCmd = &cobra.Command{
 				if acceptanceCriteria, ok := updates["acceptance_criteria"].(string); ok {
 					updateArgs.AcceptanceCriteria = &acceptanceCriteria
 				}
+				if dueDate, ok := updates["due_date"].(*time.Time); ok {
+					due := ""
+					if dueDate != nil {
+						due = dueDate.Format(time.RFC3339)
+					}
+					updateArgs.DueDate = &due
+				}
+
+				updateArgs.Force = force
+				updateArgs.ExpectedVersion = expectVersion
+				updateArgs.DryRun = dryRun
 
 				resp, err := daemonClient.Update(updateArgs)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					previewFailed = true
 					continue
 				}
 
-				if jsonOutput {
+				if dryRun {
+					var preview storage.DryRun
+					if err := json.Unmarshal(resp.Data, &preview); err == nil {
+						previews = append(previews, &preview)
+					}
+				} else if jsonOutput {
 					var issue types.Issue
 					if err := json.Unmarshal(resp.Data, &issue); err == nil {
 						updatedIssues = append(updatedIssues, &issue)
 					}
 				} else {
-					green := color.New(color.FgGreen).SprintFunc()
-					fmt.Printf("%s Updated issue: %s\n", green("✓"), id)
+					fmt.Printf("%s Updated issue: %s\n", renderSuccess("✓"), id)
 				}
 			}
 
-			if jsonOutput && len(updatedIssues) > 0 {
+			if dryRun {
+				outputUpdatePreviews(previews)
+				// A preview that failed, e.g. on a version conflict, fails the dry run
+				if previewFailed {
+					os.Exit(1)
+				}
+			} else if jsonOutput && len(updatedIssues) > 0 {
 				outputJSON(updatedIssues)
 			}
 			return
@@ -410,6 +505,29 @@ var updateCmd = &cobra.Command{
 
 		// Direct mode
 		ctx := context.Background()
+		if force {
+			ctx = workflow.WithForce(ctx)
+		}
+		if expectVersion != "" {
+			ctx = storage.WithExpectedVersion(ctx, expectVersion)
+		}
+		if dryRun {
+			for _, id := range args {
+				previewCtx, preview := storage.WithDryRun(ctx)
+				if err := store.UpdateIssue(previewCtx, id, updates, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					previewFailed = true
+					continue
+				}
+				previews = append(previews, preview)
+			}
+			outputUpdatePreviews(previews)
+			if previewFailed {
+				os.Exit(1)
+			}
+			return
+		}
+
 		updatedIssues := []*types.Issue{}
 		for _, id := range args {
 			if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
@@ -423,8 +541,7 @@ var updateCmd = &cobra.Command{
 					updatedIssues = append(updatedIssues, issue)
 				}
 			} else {
-				green := color.New(color.FgGreen).SprintFunc()
-				fmt.Printf("%s Updated issue: %s\n", green("✓"), id)
+				fmt.Printf("%s Updated issue: %s\n", renderSuccess("✓"), id)
 			}
 		}
 
@@ -439,6 +556,34 @@ var updateCmd = &cobra.Command{
 	},
 }
 
+// outputUpdatePreviews reports what update --dry-run would have changed
+func outputUpdatePreviews(previews []*storage.DryRun) {
+	if jsonOutput {
+		outputJSON(previews)
+		return
+	}
+	for _, preview := range previews {
+		if len(preview.Changes) == 0 {
+			fmt.Printf("%s: no changes\n", preview.Issue.ID)
+			continue
+		}
+		fmt.Printf("Would update %s:\n", preview.Issue.ID)
+		for _, change := range preview.Changes {
+			fmt.Printf("  %s: %v → %v\n", change.Field, formatPreviewValue(change.Old), formatPreviewValue(change.New))
+		}
+	}
+}
+
+func formatPreviewValue(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
 var editCmd = &cobra.Command{
 	Use:   "edit [id]",
 	Short: "Edit an issue field in $EDITOR",
@@ -619,21 +764,41 @@ Examples:
 			markDirtyAndScheduleFlush()
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
 		fieldName := strings.ReplaceAll(fieldToEdit, "_", " ")
-		fmt.Printf("%s Updated %s for issue: %s\n", green("✓"), fieldName, id)
+		fmt.Printf("%s Updated %s for issue: %s\n", renderSuccess("✓"), fieldName, id)
 	},
 }
 
 var closeCmd = &cobra.Command{
 	Use:   "close [id...]",
 	Short: "Close one or more issues",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Close the given issues.
+
+With --all, close every open issue matching --status, --label, and --assignee
+instead (at least one is required). Matching issues are listed and confirmed
+first unless --yes is given; --json can't prompt, so it requires --yes:
+
+  bd close --all --label sprint-42 -r "Sprint over"`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			if len(args) > 0 {
+				return fmt.Errorf("--all does not take issue IDs")
+			}
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		reason, _ := cmd.Flags().GetString("reason")
 		if reason == "" {
 			reason = "Closed"
 		}
+		force, _ := cmd.Flags().GetBool("force")
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			runCloseAll(cmd, reason, force)
+			return
+		}
 
 		// If daemon is running, use RPC
 		if daemonClient != nil {
@@ -642,6 +807,7 @@ var closeCmd = &cobra.Command{
 				closeArgs := &rpc.CloseArgs{
 					ID:     id,
 					Reason: reason,
+					Force:  force,
 				}
 				resp, err := daemonClient.CloseIssue(closeArgs)
 				if err != nil {
@@ -655,8 +821,13 @@ var closeCmd = &cobra.Command{
 						closedIssues = append(closedIssues, &issue)
 					}
 				} else {
-					green := color.New(color.FgGreen).SprintFunc()
-					fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+					fmt.Printf("%s Closed %s: %s\n", renderSuccess("✓"), id, reason)
+					if resp, err := daemonClient.Unblocked(&rpc.UnblockedArgs{ClosedID: id}); err == nil {
+						var unblocked []*types.Issue
+						if json.Unmarshal(resp.Data, &unblocked) == nil {
+							printNewlyUnblocked(unblocked)
+						}
+					}
 				}
 			}
 
@@ -668,6 +839,9 @@ var closeCmd = &cobra.Command{
 
 		// Direct mode
 		ctx := context.Background()
+		if force {
+			ctx = workflow.WithForce(ctx)
+		}
 		closedIssues := []*types.Issue{}
 		for _, id := range args {
 			if err := store.CloseIssue(ctx, id, reason, actor); err != nil {
@@ -680,8 +854,10 @@ var closeCmd = &cobra.Command{
 					closedIssues = append(closedIssues, issue)
 				}
 			} else {
-				green := color.New(color.FgGreen).SprintFunc()
-				fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+				fmt.Printf("%s Closed %s: %s\n", renderSuccess("✓"), id, reason)
+				if unblocked, err := store.GetNewlyUnblocked(ctx, id); err == nil {
+					printNewlyUnblocked(unblocked)
+				}
 			}
 		}
 
@@ -696,13 +872,27 @@ var closeCmd = &cobra.Command{
 	},
 }
 
+// printNewlyUnblocked lists the issues a close made ready, if any
+func printNewlyUnblocked(issues []*types.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	fmt.Printf("  This unblocked: %s\n", strings.Join(ids, ", "))
+}
+
 func init() {
+	showCmd.Flags().Bool("commits", false, "With --json: include linked git commits")
 	rootCmd.AddCommand(showCmd)
 
 	updateCmd.Flags().StringP("status", "s", "", "New status")
 	updateCmd.Flags().IntP("priority", "p", 0, "New priority")
 	updateCmd.Flags().String("title", "", "New title")
-	updateCmd.Flags().StringP("assignee", "a", "", "New assignee")
+	updateCmd.Flags().StringP("assignee", "a", "", "New assignee (@me for yourself)")
+	updateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD, empty to clear)")
 	updateCmd.Flags().StringP("description", "d", "", "Issue description")
 	updateCmd.Flags().String("design", "", "Design notes")
 	updateCmd.Flags().String("notes", "", "Additional notes")
@@ -710,6 +900,9 @@ func init() {
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
 	_ = updateCmd.Flags().MarkHidden("acceptance-criteria")
 	updateCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	updateCmd.Flags().Bool("force", false, "Allow status changes the status workflow forbids")
+	updateCmd.Flags().Bool("dry-run", false, "Show what would change without updating")
+	updateCmd.Flags().String("expect-version", "", "Only update if the issue is still at this version (from show --json)")
 	rootCmd.AddCommand(updateCmd)
 
 	editCmd.Flags().Bool("title", false, "Edit the title")
@@ -720,5 +913,27 @@ func init() {
 	rootCmd.AddCommand(editCmd)
 
 	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing")
+	closeCmd.Flags().Bool("force", false, "Close even if the status workflow forbids it")
+	closeCmd.Flags().Bool("all", false, "Close all open issues matching --status, --label, and --assignee")
+	closeCmd.Flags().StringP("status", "s", "", "With --all: only issues with this status")
+	closeCmd.Flags().StringSliceP("label", "l", []string{}, "With --all: only issues with all of these labels")
+	closeCmd.Flags().StringP("assignee", "a", "", "With --all: only issues with this assignee (@me for yourself)")
+	closeCmd.Flags().BoolP("yes", "y", false, "With --all: close without prompting for confirmation (required with --json)")
 	rootCmd.AddCommand(closeCmd)
 }
+
+// printExternalRefs prints the issue's external tracker keys, one per tracker
+func printExternalRefs(issue *types.Issue) {
+	if len(issue.ExternalRefs) == 0 {
+		return
+	}
+	trackers := make([]string, 0, len(issue.ExternalRefs))
+	for tracker := range issue.ExternalRefs {
+		trackers = append(trackers, tracker)
+	}
+	sort.Strings(trackers)
+	fmt.Printf("\nExternal refs:\n")
+	for _, tracker := range trackers {
+		fmt.Printf("  %s: %s\n", tracker, issue.ExternalRefs[tracker])
+	}
+}
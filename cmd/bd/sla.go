@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var slaCmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Show unresolved issues past their SLA",
+	Long: `Show unclosed issues older than the SLA for their priority, furthest past it
+first. An issue's age is measured from when it was created.
+
+SLAs are set per priority in the sla config key as semicolon-separated
+priority:duration entries. Durations take h, m, and s, plus d for days and w
+for weeks. Priorities without an entry have no SLA:
+
+  bd config set sla "0:24h; 1:3d; 2:2w"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		spec, err := store.GetConfig(ctx, types.SLAConfigKey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		breaches, err := store.GetSLABreaches(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			// Always output array, even if empty
+			if breaches == nil {
+				breaches = []*types.SLABreach{}
+			}
+			outputJSON(breaches)
+			return
+		}
+
+		if spec == "" {
+			fmt.Printf("\nNo SLAs configured. Set them with: bd config set %s \"0:24h; 1:3d\"\n\n", types.SLAConfigKey)
+			return
+		}
+		if len(breaches) == 0 {
+			fmt.Printf("\n%s No issues past their SLA\n\n", renderSuccess("✨"))
+			return
+		}
+
+		fmt.Printf("\nSLA breaches (%d):\n\n", len(breaches))
+		for _, breach := range breaches {
+			issue := breach.Issue
+			fmt.Printf("[%s] %s: %s\n", renderPriority(issue.Priority), issue.ID, issue.Title)
+			fmt.Printf("  %s\n", renderError(fmt.Sprintf("Open %s, %s past its %s SLA",
+				formatDuration(breach.Age), formatDuration(breach.Over()), formatDuration(breach.SLA))))
+			if issue.Assignee != "" {
+				fmt.Printf("  Assignee: %s\n", issue.Assignee)
+			}
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(slaCmd)
+}
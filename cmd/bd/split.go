@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var splitCmd = &cobra.Command{
+	Use:   "split <id> --into <title> [--into <title>...]",
+	Short: "Split an issue into child tasks",
+	Long: `Split a large issue into child tasks. Each child gets a parent-child dependency
+on the original, inherits its priority and assignee, and has a description pointing
+back to it.
+
+Examples:
+  bd split bd-10 --into "Backend API" --into "Frontend form"
+  bd split bd-10 --into "Part 1" --into "Part 2" --inherit-labels --epic`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		titles, _ := cmd.Flags().GetStringArray("into")
+		inheritLabels, _ := cmd.Flags().GetBool("inherit-labels")
+		makeEpic, _ := cmd.Flags().GetBool("epic")
+
+		if len(titles) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: at least one --into title is required\n")
+			os.Exit(1)
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openLocalStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		children, err := splitIssue(ctx, store, args[0], titles, inheritLabels, makeEpic)
+		if len(children) > 0 || makeEpic {
+			markDirtyAndScheduleFlush()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(children)
+			return
+		}
+
+		fmt.Printf("%s Split %s into %d issues:\n", renderSuccess("✓"), args[0], len(children))
+		for _, child := range children {
+			fmt.Printf("  %s: %s\n", child.ID, child.Title)
+		}
+		if makeEpic {
+			fmt.Printf("  %s is now an epic\n", args[0])
+		}
+	},
+}
+
+// splitIssue creates one child issue per title, each linked to the parent with a
+// parent-child dependency. Children inherit the parent's priority and assignee, and its
+// labels when inheritLabels is set. With makeEpic, the parent's type becomes epic.
+// Children created before a failure are returned along with the error.
+func splitIssue(ctx context.Context, s storage.Storage, parentID string, titles []string, inheritLabels, makeEpic bool) ([]*types.Issue, error) {
+	parent, err := s.GetIssue(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("issue %s not found", parentID)
+	}
+
+	children := []*types.Issue{}
+	for _, title := range titles {
+		child := &types.Issue{
+			Title:       title,
+			Description: fmt.Sprintf("Split from %s: %s", parent.ID, parent.Title),
+			Status:      types.StatusOpen,
+			Priority:    parent.Priority,
+			IssueType:   types.TypeTask,
+			Assignee:    parent.Assignee,
+		}
+		if err := s.CreateIssue(ctx, child, actor); err != nil {
+			return children, fmt.Errorf("failed to create '%s': %w", title, err)
+		}
+		children = append(children, child)
+
+		dep := &types.Dependency{
+			IssueID:     child.ID,
+			DependsOnID: parent.ID,
+			Type:        types.DepParentChild,
+		}
+		if err := s.AddDependency(ctx, dep, actor); err != nil {
+			return children, fmt.Errorf("failed to link %s to %s: %w", child.ID, parent.ID, err)
+		}
+
+		if inheritLabels {
+			for _, label := range parent.Labels {
+				if err := s.AddLabel(ctx, child.ID, label, actor); err != nil {
+					return children, fmt.Errorf("failed to add label %s to %s: %w", label, child.ID, err)
+				}
+			}
+		}
+	}
+
+	if makeEpic && parent.IssueType != types.TypeEpic {
+		if err := s.UpdateIssue(ctx, parent.ID, map[string]interface{}{"issue_type": string(types.TypeEpic)}, actor); err != nil {
+			return children, fmt.Errorf("failed to mark %s as epic: %w", parent.ID, err)
+		}
+	}
+
+	return children, nil
+}
+
+func init() {
+	splitCmd.Flags().StringArray("into", nil, "Title of a child issue (repeatable)")
+	splitCmd.Flags().Bool("inherit-labels", false, "Copy the original issue's labels to each child")
+	splitCmd.Flags().Bool("epic", false, "Mark the original issue as an epic")
+	rootCmd.AddCommand(splitCmd)
+}
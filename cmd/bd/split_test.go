@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSplitIssue(t *testing.T) {
+	testStore, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	parent := &types.Issue{Title: "Build checkout", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeFeature, Assignee: "alice"}
+	if err := testStore.CreateIssue(ctx, parent, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := testStore.AddLabel(ctx, parent.ID, "payments", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	children, err := splitIssue(ctx, testStore, parent.ID, []string{"Cart API", "Payment form"}, true, true)
+	if err != nil {
+		t.Fatalf("splitIssue failed: %v", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(children))
+	}
+
+	for _, child := range children {
+		if child.ID == parent.ID || child.Priority != 1 || child.Assignee != "alice" {
+			t.Errorf("Unexpected child: %+v", child)
+		}
+
+		deps, err := testStore.GetDependencyRecords(ctx, child.ID)
+		if err != nil {
+			t.Fatalf("GetDependencyRecords failed: %v", err)
+		}
+		if len(deps) != 1 || deps[0].DependsOnID != parent.ID || deps[0].Type != types.DepParentChild {
+			t.Errorf("Expected parent-child edge %s -> %s, got %v", child.ID, parent.ID, deps)
+		}
+
+		labels, err := testStore.GetLabels(ctx, child.ID)
+		if err != nil {
+			t.Fatalf("GetLabels failed: %v", err)
+		}
+		if len(labels) != 1 || labels[0] != "payments" {
+			t.Errorf("Expected %s to inherit label payments, got %v", child.ID, labels)
+		}
+	}
+
+	updated, err := testStore.GetIssue(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.IssueType != types.TypeEpic {
+		t.Errorf("Expected parent to become an epic, got %s", updated.IssueType)
+	}
+
+	// Without --inherit-labels children start unlabeled
+	more, err := splitIssue(ctx, testStore, parent.ID, []string{"Receipts"}, false, false)
+	if err != nil {
+		t.Fatalf("splitIssue failed: %v", err)
+	}
+	labels, err := testStore.GetLabels(ctx, more[0].ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("Expected no labels, got %v", labels)
+	}
+
+	if _, err := splitIssue(ctx, testStore, "bd-999", []string{"X"}, false, false); err == nil {
+		t.Error("Expected error splitting a missing issue")
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
@@ -60,15 +59,12 @@ Default threshold: 300 seconds (5 minutes)`,
 
 		// Handle empty result
 		if len(staleIssues) == 0 {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("\n%s No stale issues found (all executors healthy)\n\n", green("✨"))
+			fmt.Printf("\n%s No stale issues found (all executors healthy)\n\n", renderSuccess("✨"))
 			return
 		}
 
 		// Display stale issues
-		red := color.New(color.FgRed).SprintFunc()
-		yellow := color.New(color.FgYellow).SprintFunc()
-		fmt.Printf("\n%s Found %d stale issue(s) with orphaned claims:\n\n", yellow("⚠️"), len(staleIssues))
+		fmt.Printf("\n%s Found %d stale issue(s) with orphaned claims:\n\n", renderWarning("⚠️"), len(staleIssues))
 
 		for i, si := range staleIssues {
 			fmt.Printf("%d. [P%d] %s: %s\n", i+1, si.IssuePriority, si.IssueID, si.IssueTitle)
@@ -83,24 +79,22 @@ Default threshold: 300 seconds (5 minutes)`,
 
 		// Handle release flag
 		if release {
-			fmt.Printf("%s Releasing %d stale issue(s)...\n\n", yellow("🔧"), len(staleIssues))
+			fmt.Printf("%s Releasing %d stale issue(s)...\n\n", renderWarning("🔧"), len(staleIssues))
 
 			releaseCount, err := releaseStaleIssues(staleIssues)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%s Failed to release issues: %v\n", red("✗"), err)
+				fmt.Fprintf(os.Stderr, "%s Failed to release issues: %v\n", renderError("✗"), err)
 				os.Exit(1)
 			}
 
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("%s Successfully released %d issue(s) and marked executors as stopped\n\n", green("✓"), releaseCount)
+			fmt.Printf("%s Successfully released %d issue(s) and marked executors as stopped\n\n", renderSuccess("✓"), releaseCount)
 
 			// Schedule auto-flush if any issues were released
 			if releaseCount > 0 {
 				markDirtyAndScheduleFlush()
 			}
 		} else {
-			cyan := color.New(color.FgCyan).SprintFunc()
-			fmt.Printf("%s Use --release flag to automatically release these issues\n\n", cyan("💡"))
+			fmt.Printf("%s Use --release flag to automatically release these issues\n\n", renderAccent("💡"))
 		}
 	},
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 var syncCmd = &cobra.Command{
@@ -342,7 +343,7 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	}
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -419,6 +420,7 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	encoder := json.NewEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	for _, issue := range issues {
+		issue.SortForExport()
 		if err := encoder.Encode(issue); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
@@ -426,12 +428,18 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	}
 
 	// Close temp file before rename
+	if err := utils.SyncFile(tempFile); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
 	_ = tempFile.Close()
 
 	// Atomic replace
-	if err := os.Rename(tempPath, jsonlPath); err != nil {
+	if err := utils.RenameWithRetry(tempPath, jsonlPath); err != nil {
 		return fmt.Errorf("failed to replace JSONL file: %w", err)
 	}
+	if err := utils.SyncDir(dir); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
 
 	// Set appropriate file permissions (0600: rw-------)
 	if err := os.Chmod(jsonlPath, 0600); err != nil {
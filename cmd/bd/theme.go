@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Styles for rendering issue fields consistently across commands.
+// A status, priority, or type without an entry is printed plain.
+var (
+	statusStyles = map[types.Status]*color.Color{
+		types.StatusInProgress: color.New(color.FgYellow),
+		types.StatusBlocked:    color.New(color.FgRed),
+		types.StatusClosed:     color.New(color.Faint),
+	}
+	priorityStyles = map[int]*color.Color{
+		0: color.New(color.FgRed, color.Bold),
+		1: color.New(color.FgRed),
+		2: color.New(color.FgYellow),
+	}
+	typeStyles = map[types.IssueType]*color.Color{
+		types.TypeBug:     color.New(color.FgRed),
+		types.TypeFeature: color.New(color.FgGreen),
+		types.TypeEpic:    color.New(color.FgMagenta),
+	}
+)
+
+// Styles for the rest of command output, by what the text means rather than its color
+var (
+	renderSuccess  = color.New(color.FgGreen).SprintFunc()              // ✓ after a change
+	renderWarning  = color.New(color.FgYellow).SprintFunc()             // ⚠ and things that need attention
+	renderError    = color.New(color.FgRed).SprintFunc()                // ✗ and failures
+	renderAccent   = color.New(color.FgCyan).SprintFunc()               // issue IDs, commands, and section headers
+	renderEmphasis = color.New(color.Bold).SprintFunc()                 // titles and headings
+	renderReverted = color.New(color.FgBlue).SprintFunc()               // ↻ after a reopen, restore, or release
+	renderAlert    = color.New(color.FgYellow, color.Bold).SprintFunc() // warnings that need action now
+	renderCritical = color.New(color.FgRed, color.Bold).SprintFunc()    // failures that can lose data
+)
+
+// colorEnabled reports whether output should be colored: never when NO_COLOR is set
+// to a non-empty value (https://no-color.org), and only when writing to a terminal
+func colorEnabled(lookupEnv func(string) (string, bool), isTerminal bool) bool {
+	if value, ok := lookupEnv("NO_COLOR"); ok && value != "" {
+		return false
+	}
+	return isTerminal
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// renderStatus returns the status styled for its state, e.g. blocked in red
+func renderStatus(status types.Status) string {
+	if style, ok := statusStyles[status]; ok {
+		return style.Sprint(status)
+	}
+	return string(status)
+}
+
+// renderPriority returns the priority as "P<n>", highlighted for P0-P2
+func renderPriority(priority int) string {
+	text := fmt.Sprintf("P%d", priority)
+	if style, ok := priorityStyles[priority]; ok {
+		return style.Sprint(text)
+	}
+	return text
+}
+
+// renderType returns the issue type styled for its kind
+func renderType(issueType types.IssueType) string {
+	if style, ok := typeStyles[issueType]; ok {
+		return style.Sprint(issueType)
+	}
+	return string(issueType)
+}
+
+func init() {
+	// Applies to every color.Color, so every style above
+	color.NoColor = !colorEnabled(os.LookupEnv, isTerminal(os.Stdout))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestColorEnabled(t *testing.T) {
+	env := func(vars map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			value, ok := vars[key]
+			return value, ok
+		}
+	}
+
+	tests := []struct {
+		name       string
+		vars       map[string]string
+		isTerminal bool
+		want       bool
+	}{
+		{"terminal", nil, true, true},
+		{"not a terminal", nil, false, false},
+		{"NO_COLOR set", map[string]string{"NO_COLOR": "1"}, true, false},
+		{"NO_COLOR set and not a terminal", map[string]string{"NO_COLOR": "1"}, false, false},
+		{"NO_COLOR empty", map[string]string{"NO_COLOR": ""}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := colorEnabled(env(tt.vars), tt.isTerminal); got != tt.want {
+				t.Errorf("colorEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminalFalseForFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if isTerminal(f) {
+		t.Error("a regular file should not be a terminal")
+	}
+}
+
+func TestRenderRespectsNoColor(t *testing.T) {
+	saved := color.NoColor
+	defer func() { color.NoColor = saved }()
+
+	color.NoColor = true
+	if got := renderStatus(types.StatusBlocked); got != "blocked" {
+		t.Errorf("renderStatus with color disabled = %q, want plain text", got)
+	}
+	if got := renderPriority(0); got != "P0" {
+		t.Errorf("renderPriority with color disabled = %q, want plain text", got)
+	}
+	if got := renderType(types.TypeBug); got != "bug" {
+		t.Errorf("renderType with color disabled = %q, want plain text", got)
+	}
+	if got := renderSuccess("✓"); got != "✓" {
+		t.Errorf("renderSuccess with color disabled = %q, want plain text", got)
+	}
+
+	color.NoColor = false
+	for _, got := range []string{renderStatus(types.StatusBlocked), renderStatus(types.StatusInProgress), renderStatus(types.StatusClosed), renderPriority(0), renderSuccess("✓")} {
+		if !strings.Contains(got, "\x1b[") {
+			t.Errorf("expected escape codes with color enabled, got %q", got)
+		}
+	}
+	if got := renderStatus(types.StatusOpen); got != "open" {
+		t.Errorf("open status should stay plain, got %q", got)
+	}
+	if got := renderPriority(4); got != "P4" {
+		t.Errorf("P4 should stay plain, got %q", got)
+	}
+}
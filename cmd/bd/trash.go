@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted issues kept in the trash",
+	Long: `Deleted issues are kept in the trash for trash_retention_days (default 30)
+so they can be restored with 'bd undo-delete'. Older entries are purged automatically.
+
+Change the retention period with:
+  bd config set trash_retention_days 7`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted issues that can still be restored",
+	Run: func(cmd *cobra.Command, args []string) {
+		d := trashStore()
+		ctx := context.Background()
+
+		if _, err := d.PurgeExpiredTrash(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to purge expired trash: %v\n", err)
+		}
+
+		trash, err := d.ListTrash(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			// Always output array, even if empty
+			if trash == nil {
+				trash = []*types.DeletedIssue{}
+			}
+			outputJSON(trash)
+			return
+		}
+
+		if len(trash) == 0 {
+			fmt.Printf("\n%s Trash is empty\n\n", renderSuccess("✨"))
+			return
+		}
+
+		fmt.Printf("\nDeleted issues (%d):\n\n", len(trash))
+		for _, deleted := range trash {
+			fmt.Printf("%s: %s\n", deleted.Issue.ID, deleted.Issue.Title)
+			fmt.Printf("  Deleted %s ago\n", formatDuration(time.Since(deleted.DeletedAt)))
+		}
+		fmt.Println()
+	},
+}
+
+var undoDeleteCmd = &cobra.Command{
+	Use:   "undo-delete [id...]",
+	Short: "Restore deleted issues from the trash",
+	Long: `Restore deleted issues from the trash, including labels, comments, and
+dependency links to issues that still exist.
+
+With no arguments, restores the most recently deleted issue.
+Text references rewritten to [deleted:ID] are not reverted.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		d := trashStore()
+		ctx := context.Background()
+
+		ids := args
+		if len(ids) == 0 {
+			ids = []string{""}
+		}
+
+		restored := []*types.Issue{}
+		for _, id := range ids {
+			issue, err := d.RestoreDeletedIssue(ctx, id, actor)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				continue
+			}
+			restored = append(restored, issue)
+			if !jsonOutput {
+				fmt.Printf("%s Restored %s: %s\n", renderReverted("↻"), issue.ID, issue.Title)
+			}
+		}
+
+		if len(restored) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(restored)
+		}
+	},
+}
+
+// trashStore returns the SQLite store backing the trash, opening it directly
+// when the daemon is running
+func trashStore() *sqlite.SQLiteStorage {
+	if daemonClient != nil {
+		if err := ensureDirectMode("daemon does not support trash commands"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if store == nil {
+		if err := ensureStoreActive(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	d, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: trash not supported by this storage backend\n")
+		os.Exit(1)
+	}
+	return d
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd)
+	rootCmd.AddCommand(trashCmd)
+	rootCmd.AddCommand(undoDeleteCmd)
+}
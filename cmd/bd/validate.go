@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads"
+	"github.com/steveyegge/beads/internal/importer"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// validationProblem is one broken line found by bd validate
+type validationProblem struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	ID      string `json:"id,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p validationProblem) String() string {
+	loc := fmt.Sprintf("%s:%d", p.File, p.Line)
+	if p.ID != "" {
+		loc += fmt.Sprintf(" (%s)", p.ID)
+	}
+	if p.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", loc, p.Field, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", loc, p.Message)
+}
+
+// validateJSONLFile parses every line of a JSONL file and checks each issue against
+// the import schema. It reports merge conflict markers, unparseable lines, schema
+// violations, and duplicate IDs, and returns the number of issues read.
+// The error is only set when the file itself can't be read.
+func validateJSONLFile(path string) (int, []validationProblem, error) {
+	// #nosec G304 - user-provided file path is intentional
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	problems := []validationProblem{}
+	firstSeen := make(map[string]int)
+	count := 0
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if isConflictMarkerLine(line) {
+			problems = append(problems, validationProblem{File: path, Line: lineNum, Message: "git merge conflict marker"})
+			continue
+		}
+
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			problems = append(problems, validationProblem{File: path, Line: lineNum, Message: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		count++
+
+		for _, e := range importer.ValidateIssue(&issue, lineNum) {
+			problems = append(problems, validationProblem{File: path, Line: lineNum, ID: e.ID, Field: e.Field, Message: e.Message})
+		}
+
+		if issue.ID != "" {
+			if first, ok := firstSeen[issue.ID]; ok {
+				problems = append(problems, validationProblem{
+					File: path, Line: lineNum, ID: issue.ID, Field: "id",
+					Message: fmt.Sprintf("duplicate of line %d", first),
+				})
+			} else {
+				firstSeen[issue.ID] = lineNum
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return count, problems, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return count, problems, nil
+}
+
+// defaultValidatePath finds the workspace JSONL file without opening the database
+func defaultValidatePath() string {
+	if dbPath != "" {
+		return beads.FindJSONLPath(dbPath)
+	}
+	if beadsDir := findBeadsDir(); beadsDir != "" {
+		return beads.FindJSONLPath(filepath.Join(beadsDir, "beads.db"))
+	}
+	return ""
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [file.jsonl...]",
+	Short: "Check JSONL files for broken or invalid issues",
+	Long: `Parse every line of the given JSONL files (default: the workspace's issues.jsonl)
+and report each problem with its file and line: merge conflict markers, invalid
+JSON, schema violations (missing title, bad status or priority, ...), and
+duplicate IDs.
+
+Exits non-zero if any problem is found, so it can run in CI:
+
+  bd validate .beads/issues.jsonl`,
+	Run: func(cmd *cobra.Command, args []string) {
+		paths := args
+		if len(paths) == 0 {
+			path := defaultValidatePath()
+			if path == "" {
+				fmt.Fprintf(os.Stderr, "Error: no .beads directory found; pass a JSONL file to validate\n")
+				os.Exit(1)
+			}
+			paths = []string{path}
+		}
+
+		problems := []validationProblem{}
+		total := 0
+		failed := false
+		for _, path := range paths {
+			count, fileProblems, err := validateJSONLFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				failed = true
+			}
+			total += count
+			problems = append(problems, fileProblems...)
+		}
+
+		if jsonOutput {
+			outputJSON(problems)
+		} else if len(problems) > 0 {
+			for _, p := range problems {
+				fmt.Println(p.String())
+			}
+			fmt.Printf("\n%s %d %s in %d %s\n", renderError("✗"), len(problems), pluralize(len(problems), "problem", "problems"),
+				len(paths), pluralize(len(paths), "file", "files"))
+		} else if !failed {
+			fmt.Printf("%s %d %s valid\n", renderSuccess("✓"), total, pluralize(total, "issue", "issues"))
+		}
+
+		if failed || len(problems) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
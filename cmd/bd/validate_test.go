@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := strings.Join([]string{
+		`{"id":"bd-1","title":"Good","status":"open","priority":1,"issue_type":"task"}`,
+		`{"id":"bd-2","title":"Truncated","status":"open"`,
+		``,
+		`{"id":"bd-3","title":"","status":"done","priority":1,"issue_type":"task"}`,
+		`<<<<<<< HEAD`,
+		`{"id":"bd-1","title":"Again","status":"open","priority":1,"issue_type":"task"}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	count, problems, err := validateJSONLFile(path)
+	if err != nil {
+		t.Fatalf("validateJSONLFile failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 parsed issues, got %d", count)
+	}
+
+	want := []struct {
+		line  int
+		field string
+		msg   string
+	}{
+		{2, "", "invalid JSON"},
+		{4, "title", "is required"},
+		{4, "status", "invalid status"},
+		{5, "", "merge conflict marker"},
+		{6, "id", "duplicate of line 1"},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for i, w := range want {
+		p := problems[i]
+		if p.File != path || p.Line != w.line || p.Field != w.field || !strings.Contains(p.Message, w.msg) {
+			t.Errorf("problem %d = %s, want line %d %s %q", i, p, w.line, w.field, w.msg)
+		}
+	}
+	if !strings.HasPrefix(problems[0].String(), path+":2: invalid JSON") {
+		t.Errorf("unexpected rendering: %s", problems[0])
+	}
+}
+
+func TestValidateJSONLFileClean(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"bd-1","title":"Good","status":"open","priority":1,"issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	count, problems, err := validateJSONLFile(path)
+	if err != nil || count != 1 || len(problems) != 0 {
+		t.Errorf("expected 1 valid issue, got count=%d problems=%v err=%v", count, problems, err)
+	}
+}
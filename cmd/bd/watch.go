@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var watchIssueCmd = &cobra.Command{
+	Use:   "watch-issue <id>",
+	Short: "Watch an issue",
+	Long: `Add yourself (or --user) to an issue's watchers. Watchers are listed by
+'bd show' and exported with the issue, so tools that act on changes can tell
+who to notify.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runWatchChange(cmd, args[0], true)
+	},
+}
+
+var unwatchCmd = &cobra.Command{
+	Use:   "unwatch <id>",
+	Short: "Stop watching an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runWatchChange(cmd, args[0], false)
+	},
+}
+
+// runWatchChange adds or removes the --user watcher (default: the actor) on id
+func runWatchChange(cmd *cobra.Command, id string, watch bool) {
+	user, _ := cmd.Flags().GetString("user")
+	if user == "" {
+		user = actor
+	}
+	user = types.ResolveMe(user, actor)
+
+	if daemonClient != nil {
+		watchArgs := &rpc.WatchArgs{ID: id, Watcher: user}
+		var err error
+		if watch {
+			_, err = daemonClient.Watch(watchArgs)
+		} else {
+			_, err = daemonClient.Unwatch(watchArgs)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		ctx := context.Background()
+		var err error
+		if watch {
+			err = store.AddWatcher(ctx, id, user, actor)
+		} else {
+			err = store.RemoveWatcher(ctx, id, user, actor)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"id": id, "watcher": user, "watching": watch})
+		return
+	}
+	if watch {
+		fmt.Printf("%s %s is now watching %s\n", renderSuccess("✓"), user, id)
+	} else {
+		fmt.Printf("%s %s stopped watching %s\n", renderSuccess("✓"), user, id)
+	}
+}
+
+func init() {
+	watchIssueCmd.Flags().String("user", "", "Who to add as a watcher (default: the actor, @me for yourself)")
+	unwatchCmd.Flags().String("user", "", "Who to remove as a watcher (default: the actor, @me for yourself)")
+	rootCmd.AddCommand(watchIssueCmd)
+	rootCmd.AddCommand(unwatchCmd)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // Notifier handles user notifications during import
@@ -79,7 +80,7 @@ func AutoImportIfNewer(ctx context.Context, store storage.Storage, dbPath string
 		return nil
 	}
 
-	jsonlData, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path from config
+	jsonlData, err := utils.ReadFileWithRetry(jsonlPath)
 	if err != nil {
 		notify.Debugf("auto-import skipped, JSONL not readable: %v", err)
 		return nil
@@ -128,10 +129,8 @@ func AutoImportIfNewer(ctx context.Context, store storage.Storage, dbPath string
 		onChanged(needsFullExport)
 	}
 
-	if err := store.SetMetadata(ctx, "last_import_hash", currentHash); err != nil {
-		notify.Warnf("failed to update last_import_hash after import: %v", err)
-		notify.Warnf("This may cause auto-import to retry the same import on next operation.")
-	}
+	// last_import_hash is recorded by the importer (importer.ImportIssues) as a hash of the
+	// normalized JSONL, which equals currentHash whenever the file was written by bd export
 
 	importTime := time.Now().Format(time.RFC3339)
 	if err := store.SetMetadata(ctx, "last_import_time", importTime); err != nil {
@@ -201,10 +200,12 @@ func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 				"The JSONL file contains unresolved merge conflict markers.\n"+
 				"This prevents auto-import from loading your issues.\n\n"+
 				"To resolve:\n"+
-				"  1. Resolve the merge conflict in your Git client, OR\n"+
-				"  2. Export from database to regenerate clean JSONL:\n"+
+				"  1. Reconcile both sides automatically:\n"+
+				"     bd merge-resolve %s\n"+
+				"  2. Resolve the merge conflict in your Git client, OR\n"+
+				"  3. Export from database to regenerate clean JSONL:\n"+
 				"     bd export -o %s\n\n"+
-				"After resolving, commit the fixed JSONL file.\n", jsonlPath, jsonlPath)
+				"After resolving, commit the fixed JSONL file.\n", jsonlPath, jsonlPath, jsonlPath)
 		}
 	}
 	return nil
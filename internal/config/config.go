@@ -75,6 +75,8 @@ func Initialize() error {
 	v.SetDefault("db", "")
 	v.SetDefault("actor", "")
 	v.SetDefault("issue-prefix", "")
+	v.SetDefault("fs-retry", false)
+	v.SetDefault("durable-writes", false)
 	
 	// Additional environment variables (not prefixed with BD_)
 	// These are bound explicitly for backward compatibility
@@ -1,53 +1,65 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/logging"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 // Options contains import configuration
 type Options struct {
-	ResolveCollisions    bool // Auto-resolve collisions by remapping to new IDs
-	DryRun               bool // Preview changes without applying them
-	SkipUpdate           bool // Skip updating existing issues (create-only mode)
-	Strict               bool // Fail on any error (dependencies, labels, etc.)
-	RenameOnImport       bool // Rename imported issues to match database prefix
-	SkipPrefixValidation bool // Skip prefix validation (for auto-import)
+	ResolveCollisions    bool  // Auto-resolve collisions by remapping to new IDs
+	DryRun               bool  // Preview changes without applying them
+	SkipUpdate           bool  // Skip updating existing issues (create-only mode)
+	Strict               bool  // Fail on any error (dependencies, labels, etc.)
+	RenameOnImport       bool  // Rename imported issues to match database prefix
+	SkipPrefixValidation bool  // Skip prefix validation (for auto-import)
+	SourceLines          []int // Optional JSONL line number for each issue (for validation errors)
 }
 
 // Result contains statistics about the import operation
 type Result struct {
-	Created          int               // New issues created
-	Updated          int               // Existing issues updated
-	Unchanged        int               // Existing issues that matched exactly (idempotent)
-	Skipped          int               // Issues skipped (duplicates, errors)
-	Collisions       int               // Collisions detected
-	IDMapping        map[string]string // Mapping of remapped IDs (old -> new)
-	CollisionIDs     []string          // IDs that collided
-	PrefixMismatch   bool              // Prefix mismatch detected
-	ExpectedPrefix   string            // Database configured prefix
-	MismatchPrefixes map[string]int    // Map of mismatched prefixes to count
+	Created              int                 // New issues created
+	Updated              int                 // Existing issues updated
+	Unchanged            int                 // Existing issues that matched exactly (idempotent)
+	Skipped              int                 // Issues skipped (duplicates, errors)
+	Collisions           int                 // Collisions detected
+	IDMapping            map[string]string   // Mapping of remapped IDs (old -> new)
+	CollisionIDs         []string            // IDs that collided
+	PrefixMismatch       bool                // Prefix mismatch detected
+	Renamed              int                 // Issues renamed to the database prefix
+	ExpectedPrefix       string              // Database configured prefix
+	MismatchPrefixes     map[string]int      // Map of mismatched prefixes to count
+	ValidationErrors     []ImportError       // Schema violations (invalid issues are skipped unless Strict)
+	SkippedWholeImport   bool                // Content hash matched last import, nothing was done
+	ContentHash          string              // Hash of the normalized JSONL that was imported
+	DanglingDependencies []*types.Dependency // Dependencies whose target doesn't exist (not imported)
 }
 
 // ImportIssues handles the core import logic used by both manual and auto-import.
 // This function:
 // - Works with existing storage or opens direct SQLite connection if needed
+// - Skips the whole import if the content hash matches last_import_hash
 // - Detects and handles collisions
 // - Imports issues, dependencies, labels, and comments
+// - Records last_import_hash after an import that applied the content verbatim
 // - Returns detailed results
 //
 // The caller is responsible for:
 // - Reading and parsing JSONL into issues slice
 // - Displaying results to the user
-// - Setting metadata (e.g., last_import_hash)
 //
 // Parameters:
 // - ctx: Context for cancellation
@@ -70,6 +82,26 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		defer func() { _ = sqliteStore.Close() }()
 	}
 
+	// Hash the normalized input before anything mutates the issues (renames, remaps)
+	contentHash, err := ComputeContentHash(issues)
+	if err != nil {
+		return nil, err
+	}
+	result.ContentHash = contentHash
+
+	// Short-circuit if this exact content was already imported (or exported)
+	if !opts.DryRun && isAlreadyImported(ctx, sqliteStore, contentHash) {
+		result.SkippedWholeImport = true
+		result.Unchanged = len(issues)
+		return result, nil
+	}
+
+	// Validate issues against the schema before touching storage
+	issues, err = handleValidation(issues, opts, result)
+	if err != nil {
+		return result, err
+	}
+
 	// Check and handle prefix mismatches
 	if err := handlePrefixMismatch(ctx, sqliteStore, issues, opts, result); err != nil {
 		return result, err
@@ -84,13 +116,16 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return result, nil
 	}
 
+	// Point embedded dependencies at remapped IDs before anything is written
+	remapDependencyReferences(issues, result.IDMapping)
+
 	// Upsert issues (create new or update existing)
 	if err := upsertIssues(ctx, sqliteStore, issues, opts, result); err != nil {
 		return nil, err
 	}
 
 	// Import dependencies
-	if err := importDependencies(ctx, sqliteStore, issues, opts); err != nil {
+	if err := importDependencies(ctx, sqliteStore, issues, opts, result); err != nil {
 		return nil, err
 	}
 
@@ -99,6 +134,16 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return nil, err
 	}
 
+	// Import external refs
+	if err := importExternalRefs(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
+	// Import watchers
+	if err := importWatchers(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
 	// Import comments
 	if err := importComments(ctx, sqliteStore, issues, opts); err != nil {
 		return nil, err
@@ -108,12 +153,65 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 	// This ensures staleness detection sees the database as fresh
 	if err := sqliteStore.CheckpointWAL(ctx); err != nil {
 		// Non-fatal - just log warning
-		fmt.Fprintf(os.Stderr, "Warning: failed to checkpoint WAL: %v\n", err)
+		logging.Warn("failed to checkpoint WAL", "error", err)
+	}
+
+	// Record the content hash so identical re-imports are skipped. Only an import
+	// that applied the content verbatim may record it: after a --skip-existing,
+	// renaming or remapping import the database doesn't match the content, and
+	// importing it again with other options must still do something.
+	if !opts.DryRun && appliedVerbatim(result) {
+		if err := sqliteStore.SetMetadata(ctx, "last_import_hash", contentHash); err != nil {
+			logging.Warn("failed to update last_import_hash after import", "error", err)
+		}
 	}
 
 	return result, nil
 }
 
+// ComputeContentHash returns a stable hash of issues as normalized JSONL.
+// Each issue is encoded exactly as export writes it (one JSON object per line, in order),
+// so the hash of a JSONL file produced by bd export matches the hash of its parsed issues.
+func ComputeContentHash(issues []*types.Issue) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			return "", fmt.Errorf("failed to normalize issue %s: %w", issue.ID, err)
+		}
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isAlreadyImported reports whether contentHash matches the last imported content
+// and the database has no unexported changes that an import would need to overwrite.
+func isAlreadyImported(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, contentHash string) bool {
+	lastHash, err := sqliteStore.GetMetadata(ctx, "last_import_hash")
+	if err != nil || lastHash == "" || lastHash != contentHash {
+		return false
+	}
+
+	// Local edits since the last import/export mean the DB diverged from the JSONL,
+	// so the import must run to reconcile them
+	dirty, err := sqliteStore.GetDirtyIssues(ctx)
+	if err != nil || len(dirty) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// appliedVerbatim reports whether an import left the database holding exactly
+// the imported content: nothing skipped, remapped, renamed or left dangling
+func appliedVerbatim(result *Result) bool {
+	return result.Skipped == 0 &&
+		result.Renamed == 0 &&
+		len(result.IDMapping) == 0 &&
+		len(result.ValidationErrors) == 0 &&
+		len(result.DanglingDependencies) == 0
+}
+
 // getOrCreateStore returns an existing storage or creates a new one
 func getOrCreateStore(ctx context.Context, dbPath string, store storage.Storage) (*sqlite.SQLiteStorage, bool, error) {
 	if store != nil {
@@ -136,6 +234,23 @@ func getOrCreateStore(ctx context.Context, dbPath string, store storage.Storage)
 	return sqliteStore, true, nil
 }
 
+// handleValidation validates issues against the import schema.
+// In strict mode any violation aborts the import; otherwise invalid issues are skipped and reported.
+func handleValidation(issues []*types.Issue, opts Options, result *Result) ([]*types.Issue, error) {
+	valid, errs := validateIssues(issues, opts.SourceLines)
+	if len(errs) == 0 {
+		return issues, nil
+	}
+
+	result.ValidationErrors = errs
+	if opts.Strict {
+		return nil, formatValidationErrors(errs)
+	}
+
+	result.Skipped += len(issues) - len(valid)
+	return valid, nil
+}
+
 // handlePrefixMismatch checks and handles prefix mismatches
 func handlePrefixMismatch(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) error {
 	configuredPrefix, err := sqliteStore.GetConfig(ctx, "issue_prefix")
@@ -172,6 +287,9 @@ func handlePrefixMismatch(ctx context.Context, sqliteStore *sqlite.SQLiteStorage
 		if err := RenameImportedIssuePrefixes(issues, configuredPrefix); err != nil {
 			return fmt.Errorf("failed to rename prefixes: %w", err)
 		}
+		for _, count := range result.MismatchPrefixes {
+			result.Renamed += count
+		}
 		// After renaming, clear the mismatch flags since we fixed them
 		result.PrefixMismatch = false
 		result.MismatchPrefixes = make(map[string]int)
@@ -203,7 +321,7 @@ func handleCollisions(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, is
 		}
 
 		// Resolve collisions by scoring and remapping
-		allExistingIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+		allExistingIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			return nil, fmt.Errorf("failed to get existing issues for collision resolution: %w", err)
 		}
@@ -286,9 +404,28 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 				updates["external_ref"] = nil
 			}
 
+			if issue.DueDate != nil {
+				updates["due_date"] = *issue.DueDate
+			} else {
+				updates["due_date"] = nil
+			}
+
+			if issue.Rank != nil {
+				updates["rank"] = *issue.Rank
+			} else {
+				updates["rank"] = nil
+			}
+
+			if issue.ArchivedAt != nil {
+				updates["archived_at"] = *issue.ArchivedAt
+			} else {
+				updates["archived_at"] = nil
+			}
+
 			// Only update if data actually changed
 			if IssueDataChanged(existing, updates) {
-				if err := sqliteStore.UpdateIssue(ctx, issue.ID, updates, "import"); err != nil {
+				// Import restores the exported state, so the status workflow doesn't apply
+				if err := sqliteStore.UpdateIssue(workflow.WithForce(ctx), issue.ID, updates, "import"); err != nil {
 					return fmt.Errorf("error updating issue %s: %w", issue.ID, err)
 				}
 				result.Updated++
@@ -325,8 +462,42 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 	return nil
 }
 
+// remapDependencyReferences rewrites embedded dependencies through idMapping after
+// collision resolution. Remapped issues own their dependencies under the new ID, and any
+// reference to a remapped ID within the import batch points at the remapped issue.
+func remapDependencyReferences(issues []*types.Issue, idMapping map[string]string) {
+	if len(idMapping) == 0 {
+		return
+	}
+
+	remappedIDs := make(map[string]bool, len(idMapping))
+	for _, newID := range idMapping {
+		remappedIDs[newID] = true
+	}
+
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			if remappedIDs[issue.ID] {
+				dep.IssueID = issue.ID
+			} else if newID, ok := idMapping[dep.IssueID]; ok && newID == issue.ID {
+				dep.IssueID = newID
+			}
+			if newID, ok := idMapping[dep.DependsOnID]; ok {
+				dep.DependsOnID = newID
+			}
+		}
+	}
+}
+
 // importDependencies imports dependency relationships
-func importDependencies(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+// Dependencies whose target doesn't exist are reported in result.DanglingDependencies.
+func importDependencies(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) error {
+	// Remember which targets exist, so each is checked at most once
+	targetExists := make(map[string]bool)
+
 	for _, issue := range issues {
 		if len(issue.Dependencies) == 0 {
 			continue
@@ -352,6 +523,23 @@ func importDependencies(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 				continue
 			}
 
+			// Report dependencies on issues that don't exist (e.g. couldn't be remapped)
+			exists, checked := targetExists[dep.DependsOnID]
+			if !checked {
+				exists, err = sqliteStore.IssueExists(ctx, dep.DependsOnID)
+				if err != nil {
+					return fmt.Errorf("error checking dependency target %s: %w", dep.DependsOnID, err)
+				}
+				targetExists[dep.DependsOnID] = exists
+			}
+			if !exists {
+				if opts.Strict {
+					return fmt.Errorf("dangling dependency %s → %s: target not found", dep.IssueID, dep.DependsOnID)
+				}
+				result.DanglingDependencies = append(result.DanglingDependencies, dep)
+				continue
+			}
+
 			// Add dependency
 			if err := sqliteStore.AddDependency(ctx, dep, "import"); err != nil {
 				if opts.Strict {
@@ -399,6 +587,68 @@ func importLabels(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 	return nil
 }
 
+// importExternalRefs sets each issue's per-tracker external refs, adding
+// missing trackers and updating changed keys
+func importExternalRefs(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.ExternalRefs) == 0 {
+			continue
+		}
+
+		current, err := sqliteStore.GetExternalRefs(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting external refs for %s: %w", issue.ID, err)
+		}
+
+		for tracker, ref := range issue.ExternalRefs {
+			if current[tracker] == ref {
+				continue
+			}
+			if err := sqliteStore.SetExternalRef(ctx, issue.ID, tracker, ref, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error setting external ref %s on %s: %w", tracker, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// importWatchers adds each issue's watchers that aren't in the database yet
+func importWatchers(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.Watchers) == 0 {
+			continue
+		}
+
+		current, err := sqliteStore.GetWatchers(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting watchers for %s: %w", issue.ID, err)
+		}
+		watching := make(map[string]bool, len(current))
+		for _, watcher := range current {
+			watching[watcher] = true
+		}
+
+		for _, watcher := range issue.Watchers {
+			if watching[watcher] {
+				continue
+			}
+			watching[watcher] = true
+			if err := sqliteStore.AddWatcher(ctx, issue.ID, watcher, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding watcher %s to %s: %w", watcher, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
 // importComments imports comments for issues
 func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
 	for _, issue := range issues {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -85,6 +86,40 @@ func (fc *fieldComparator) equalPtrStr(existing *string, newVal interface{}) boo
 	return *existing == s
 }
 
+func (fc *fieldComparator) equalTimePtr(existing *time.Time, newVal interface{}) bool {
+	var t *time.Time
+	switch v := newVal.(type) {
+	case time.Time:
+		t = &v
+	case *time.Time:
+		t = v
+	case nil:
+	default:
+		return false
+	}
+	if existing == nil || t == nil {
+		return existing == nil && t == nil
+	}
+	return existing.Equal(*t)
+}
+
+func (fc *fieldComparator) equalFloatPtr(existing *float64, newVal interface{}) bool {
+	var f *float64
+	switch v := newVal.(type) {
+	case float64:
+		f = &v
+	case *float64:
+		f = v
+	case nil:
+	default:
+		return false
+	}
+	if existing == nil || f == nil {
+		return existing == nil && f == nil
+	}
+	return *existing == *f
+}
+
 func (fc *fieldComparator) equalStatus(existing types.Status, newVal interface{}) bool {
 	switch t := newVal.(type) {
 	case types.Status:
@@ -134,6 +169,12 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "due_date":
+		return !fc.equalTimePtr(existing.DueDate, newVal)
+	case "rank":
+		return !fc.equalFloatPtr(existing.Rank, newVal)
+	case "archived_at":
+		return !fc.equalTimePtr(existing.ArchivedAt, newVal)
 	default:
 		return false
 	}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ImportError describes a single schema violation found in an imported issue
+type ImportError struct {
+	Line    int    // 1-based line number in the source JSONL (0 if unknown)
+	ID      string // Issue ID as it appeared in the input (may be empty)
+	Field   string // JSON field name that failed validation
+	Message string // Human-readable description of the problem
+}
+
+// Error implements the error interface
+func (e ImportError) Error() string {
+	var loc string
+	if e.Line > 0 {
+		loc = fmt.Sprintf("line %d", e.Line)
+	}
+	if e.ID != "" {
+		if loc != "" {
+			loc += " "
+		}
+		loc += fmt.Sprintf("(%s)", e.ID)
+	}
+	if loc == "" {
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, e.Field, e.Message)
+}
+
+// ValidateIssue checks a single issue against the import schema: the checks of
+// types.Issue.Validate, plus an ID and well-formed dependencies.
+// line is the 1-based source line used in the returned errors (0 if unknown).
+func ValidateIssue(issue *types.Issue, line int) []ImportError {
+	var errs []ImportError
+	add := func(field, msg string) {
+		errs = append(errs, ImportError{
+			Line:    line,
+			ID:      issue.ID,
+			Field:   field,
+			Message: msg,
+		})
+	}
+
+	if strings.TrimSpace(issue.ID) == "" {
+		add("id", "is required")
+	}
+	for _, fieldErr := range issue.FieldErrors() {
+		add(fieldErr.Field, fieldErr.Error())
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if dep.DependsOnID == "" {
+			add("dependencies", "depends_on_id is required")
+			break
+		}
+		if !dep.Type.IsValid() {
+			add("dependencies", fmt.Sprintf("invalid dependency type %q for %s", dep.Type, dep.DependsOnID))
+			break
+		}
+	}
+	return errs
+}
+
+// validateIssues checks every issue against the import schema and returns the
+// issues that passed along with all validation errors found.
+// sourceLines optionally maps issue index to JSONL line number; when absent the
+// 1-based index is used.
+func validateIssues(issues []*types.Issue, sourceLines []int) ([]*types.Issue, []ImportError) {
+	valid := make([]*types.Issue, 0, len(issues))
+	var allErrs []ImportError
+
+	for i, issue := range issues {
+		line := i + 1
+		if i < len(sourceLines) {
+			line = sourceLines[i]
+		}
+		if issue == nil {
+			allErrs = append(allErrs, ImportError{Line: line, Field: "issue", Message: "is null"})
+			continue
+		}
+		if errs := ValidateIssue(issue, line); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		valid = append(valid, issue)
+	}
+
+	return valid, allErrs
+}
+
+// formatValidationErrors summarizes validation errors into a single error message
+func formatValidationErrors(errs []ImportError) error {
+	const maxShown = 10
+	lines := make([]string, 0, maxShown+1)
+	for i, e := range errs {
+		if i == maxShown {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(errs)-maxShown))
+			break
+		}
+		lines = append(lines, "  "+e.Error())
+	}
+	return fmt.Errorf("%d validation error(s) in import:\n%s", len(errs), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,153 @@
+// Package logging provides the leveled logger shared by the daemon, the RPC
+// server, and storage code. In the CLI it prints the usual "Warning: ..." lines;
+// the daemon switches it to key=value records in its log file.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LevelEnv names the environment variable holding the minimum level to log:
+// debug, info (the default), warn, or error
+const LevelEnv = "BD_LOG_LEVEL"
+
+var current atomic.Pointer[slog.Logger]
+
+func init() {
+	current.Store(NewCLI(os.Stderr, LevelFromEnv()))
+}
+
+// ParseLevel parses a level name, case-insensitively. An empty name means info.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("invalid log level %q (expected debug, info, warn, or error)", name)
+}
+
+// LevelFromEnv returns the level set by BD_LOG_LEVEL. When it is unset,
+// BD_DEBUG turns on debug logging as it always has; otherwise the level is info.
+// An invalid level also falls back to info.
+func LevelFromEnv() slog.Level {
+	name := os.Getenv(LevelEnv)
+	if name == "" && os.Getenv("BD_DEBUG") != "" {
+		return slog.LevelDebug
+	}
+	level, err := ParseLevel(name)
+	if err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// New returns a logger writing key=value records at level and above to w
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// NewCLI returns a logger writing records at level and above to w in the CLI's
+// own format, such as "Warning: failed to checkpoint WAL: disk full"
+func NewCLI(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(&cliHandler{w: w, level: level, mu: &sync.Mutex{}})
+}
+
+// Default returns the shared logger. It writes CLI-style lines to stderr until
+// SetDefault points it elsewhere, such as the daemon's log file.
+func Default() *slog.Logger {
+	return current.Load()
+}
+
+// SetDefault replaces the shared logger
+func SetDefault(l *slog.Logger) {
+	current.Store(l)
+}
+
+// Debug logs msg at debug level on the shared logger
+func Debug(msg string, args ...any) { Default().Debug(msg, args...) }
+
+// Info logs msg at info level on the shared logger
+func Info(msg string, args ...any) { Default().Info(msg, args...) }
+
+// Warn logs msg at warn level on the shared logger
+func Warn(msg string, args ...any) { Default().Warn(msg, args...) }
+
+// Error logs msg at error level on the shared logger
+func Error(msg string, args ...any) { Default().Error(msg, args...) }
+
+// cliHandler formats records as the CLI's "Warning: msg: error" lines. An
+// "error" attribute follows the message; any others are appended as key=value.
+type cliHandler struct {
+	w     io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+	mu    *sync.Mutex
+}
+
+func (h *cliHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *cliHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	switch {
+	case r.Level >= slog.LevelError:
+		b.WriteString("Error: ")
+	case r.Level >= slog.LevelWarn:
+		b.WriteString("Warning: ")
+	case r.Level < slog.LevelInfo:
+		b.WriteString("Debug: ")
+	}
+	b.WriteString(r.Message)
+
+	var errText string
+	var extra []string
+	add := func(a slog.Attr) bool {
+		if a.Key == "error" && errText == "" {
+			errText = a.Value.String()
+		} else {
+			extra = append(extra, a.Key+"="+a.Value.String())
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		add(a)
+	}
+	r.Attrs(add)
+	if errText != "" {
+		b.WriteString(": " + errText)
+	}
+	if len(extra) > 0 {
+		b.WriteString(" (" + strings.Join(extra, " ") + ")")
+	}
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *cliHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup is a no-op: CLI lines are flat
+func (h *cliHandler) WithGroup(string) slog.Handler {
+	return h
+}
@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Debug("cache probe", "key", "bd-1")
+	logger.Info("sync complete", "issues", 3)
+	logger.Warn("flush failed", "error", "disk full")
+
+	out := buf.String()
+	if strings.Contains(out, "cache probe") {
+		t.Errorf("debug record logged at info level:\n%s", out)
+	}
+	if !strings.Contains(out, `level=INFO msg="sync complete" issues=3`) {
+		t.Errorf("missing info record:\n%s", out)
+	}
+	if !strings.Contains(out, `level=WARN msg="flush failed" error="disk full"`) {
+		t.Errorf("missing warn record:\n%s", out)
+	}
+}
+
+func TestCLIFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewCLI(&buf, slog.LevelInfo)
+
+	logger.Debug("cache probe", "key", "bd-1")
+	logger.Warn("failed to checkpoint WAL", "error", "disk full")
+	logger.Error("sync failed", "issue", "bd-2", "error", "locked")
+	logger.Info("sync complete", "issues", 3)
+
+	want := "Warning: failed to checkpoint WAL: disk full\n" +
+		"Error: sync failed: locked (issue=bd-2)\n" +
+		"sync complete (issues=3)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CLI output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want slog.Level
+	}{
+		{"", slog.LevelInfo},
+		{"debug", slog.LevelDebug},
+		{"INFO", slog.LevelInfo},
+		{"warning", slog.LevelWarn},
+		{" error ", slog.LevelError},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.name)
+		if err != nil {
+			t.Errorf("ParseLevel(%q) error: %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(\"verbose\") should fail")
+	}
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	t.Setenv(LevelEnv, "")
+	t.Setenv("BD_DEBUG", "")
+	if got := LevelFromEnv(); got != slog.LevelInfo {
+		t.Errorf("default level = %v, want info", got)
+	}
+
+	t.Setenv("BD_DEBUG", "1")
+	if got := LevelFromEnv(); got != slog.LevelDebug {
+		t.Errorf("BD_DEBUG level = %v, want debug", got)
+	}
+
+	t.Setenv(LevelEnv, "error")
+	if got := LevelFromEnv(); got != slog.LevelError {
+		t.Errorf("BD_LOG_LEVEL=error level = %v, want error", got)
+	}
+
+	t.Setenv(LevelEnv, "bogus")
+	if got := LevelFromEnv(); got != slog.LevelInfo {
+		t.Errorf("invalid level = %v, want info", got)
+	}
+}
@@ -0,0 +1,197 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenTerm tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	// For terms: field is empty for text, and value is the text or field value
+	field string
+	value string
+	raw   string
+}
+
+func (t token) String() string {
+	return fmt.Sprintf("%q", t.raw)
+}
+
+// lex splits input into operators, parentheses and terms
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, raw: "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, raw: ")"})
+			i++
+			continue
+		}
+
+		// A term runs to the next space or parenthesis outside quotes
+		var word strings.Builder
+		quoted := false
+		field := ""
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+			switch c := runes[i]; {
+			case c == '"':
+				end := i + 1
+				for end < len(runes) && runes[end] != '"' {
+					end++
+				}
+				if end == len(runes) {
+					return nil, fmt.Errorf("unterminated quote in %q", string(runes[start:]))
+				}
+				word.WriteString(string(runes[i+1 : end]))
+				quoted = true
+				i = end + 1
+			case c == ':' && field == "" && !quoted && word.Len() > 0:
+				field = word.String()
+				word.Reset()
+				i++
+			default:
+				word.WriteRune(c)
+				i++
+			}
+		}
+		raw := string(runes[start:i])
+
+		if !quoted && field == "" {
+			switch raw {
+			case "AND":
+				tokens = append(tokens, token{kind: tokenAnd, raw: raw})
+				continue
+			case "OR":
+				tokens = append(tokens, token{kind: tokenOr, raw: raw})
+				continue
+			case "NOT":
+				tokens = append(tokens, token{kind: tokenNot, raw: raw})
+				continue
+			}
+		}
+		tokens = append(tokens, token{kind: tokenTerm, field: field, value: word.String(), raw: raw})
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	actor  string // what @me stands for
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOr {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok {
+			return left, nil
+		}
+		switch tok.kind {
+		case tokenAnd:
+			p.pos++
+		case tokenTerm, tokenNot, tokenOpen:
+			// Adjacent terms are ANDed
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if tok, ok := p.peek(); ok && tok.kind == tokenNot {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokenOpen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case tokenTerm:
+		if tok.field != "" {
+			return fieldTerm(tok.field, tok.value, p.actor)
+		}
+		if tok.value == "" {
+			return nil, fmt.Errorf("empty search term %s", tok)
+		}
+		return textExpr{strings.ToLower(tok.value)}, nil
+	}
+	return nil, fmt.Errorf("unexpected %s", tok)
+}
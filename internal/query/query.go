@@ -0,0 +1,278 @@
+// Package query parses the boolean search syntax used by bd search.
+//
+// A query is a boolean expression over terms:
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = unary { ["AND"] unary }
+//	unary   = "NOT" unary | primary
+//	primary = "(" or ")" | term
+//	term    = field ":" value | text
+//
+// AND binds tighter than OR, NOT binds tightest, and terms written next to
+// each other are ANDed. The operators must be upper case; lower-case "and",
+// "or" and "not" are ordinary words.
+//
+// A text term matches issues whose title, description or ID contains it,
+// ignoring case. Double quotes group words into a single term, so "login
+// page" matches the phrase rather than either word.
+//
+// Field terms are:
+//
+//	status:open          status is open (open, in_progress, blocked, closed)
+//	label:bug            has the label; globs (area:*) and re: patterns work as in --label
+//	priority:1           priority is 1; also priority:<=1, <1, >=1, >1 (P1 works too)
+//	assignee:alice       assigned to alice; assignee:none matches unassigned issues
+//	reporter:alice       created by alice
+//	type:bug             issue type is bug
+//
+// The assignee and reporter value @me stands for the actor given to ParseAs.
+//
+// Field values may be quoted, as in label:"needs review". Quote a term
+// containing a colon, such as "http://example.com", to search for it as text.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Expr is a parsed query expression
+type Expr interface {
+	// Match reports whether issue satisfies the expression. Label terms look
+	// at issue.Labels, which must be populated.
+	Match(issue *types.Issue) bool
+	// String returns the expression fully parenthesized, for debugging
+	String() string
+}
+
+// Parse parses a query. An empty query is an error, as is @me, which needs
+// ParseAs.
+func Parse(input string) (Expr, error) {
+	return ParseAs(input, "")
+}
+
+// ParseAs parses a query in which @me stands for actor
+func ParseAs(input, actor string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	p := &parser{tokens: tokens, actor: actor}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// Apply narrows filter to issues matching expr. Field terms that every match
+// must satisfy are copied into the filter so the backend can use them, and the
+// whole expression is added as filter.Match.
+func Apply(expr Expr, filter *types.IssueFilter) {
+	for _, term := range conjuncts(expr) {
+		switch t := term.(type) {
+		case statusExpr:
+			if filter.Status == nil {
+				status := t.status
+				filter.Status = &status
+			}
+		case labelExpr:
+			filter.Labels = append(filter.Labels, t.pattern)
+		case assigneeExpr:
+			if filter.Assignee == nil && t.name != "" {
+				name := t.name
+				filter.Assignee = &name
+			}
+		case reporterExpr:
+			if filter.Reporter == nil {
+				name := t.name
+				filter.Reporter = &name
+			}
+		case typeExpr:
+			if filter.IssueType == nil {
+				issueType := t.issueType
+				filter.IssueType = &issueType
+			}
+		case priorityExpr:
+			if filter.Priority == nil && t.op == "=" {
+				priority := t.value
+				filter.Priority = &priority
+			}
+		}
+	}
+
+	if prev := filter.Match; prev != nil {
+		filter.Match = func(issue *types.Issue) bool { return prev(issue) && expr.Match(issue) }
+	} else {
+		filter.Match = expr.Match
+	}
+}
+
+// conjuncts returns the terms of expr's top-level AND chain
+func conjuncts(expr Expr) []Expr {
+	if and, ok := expr.(andExpr); ok {
+		return append(conjuncts(and.left), conjuncts(and.right)...)
+	}
+	return []Expr{expr}
+}
+
+type andExpr struct{ left, right Expr }
+
+func (e andExpr) Match(issue *types.Issue) bool { return e.left.Match(issue) && e.right.Match(issue) }
+func (e andExpr) String() string                { return "(" + e.left.String() + " AND " + e.right.String() + ")" }
+
+type orExpr struct{ left, right Expr }
+
+func (e orExpr) Match(issue *types.Issue) bool { return e.left.Match(issue) || e.right.Match(issue) }
+func (e orExpr) String() string                { return "(" + e.left.String() + " OR " + e.right.String() + ")" }
+
+type notExpr struct{ operand Expr }
+
+func (e notExpr) Match(issue *types.Issue) bool { return !e.operand.Match(issue) }
+func (e notExpr) String() string                { return "NOT " + e.operand.String() }
+
+type textExpr struct{ text string }
+
+func (e textExpr) Match(issue *types.Issue) bool {
+	return strings.Contains(strings.ToLower(issue.Title), e.text) ||
+		strings.Contains(strings.ToLower(issue.Description), e.text) ||
+		strings.Contains(strings.ToLower(issue.ID), e.text)
+}
+func (e textExpr) String() string { return strconv.Quote(e.text) }
+
+type statusExpr struct{ status types.Status }
+
+func (e statusExpr) Match(issue *types.Issue) bool { return issue.Status == e.status }
+func (e statusExpr) String() string                { return "status:" + string(e.status) }
+
+type labelExpr struct {
+	pattern string
+	match   func(label string) bool
+}
+
+func (e labelExpr) Match(issue *types.Issue) bool {
+	for _, label := range issue.Labels {
+		if e.match(label) {
+			return true
+		}
+	}
+	return false
+}
+func (e labelExpr) String() string { return "label:" + e.pattern }
+
+type assigneeExpr struct{ name string } // empty matches unassigned issues
+
+func (e assigneeExpr) Match(issue *types.Issue) bool { return issue.Assignee == e.name }
+func (e assigneeExpr) String() string {
+	if e.name == "" {
+		return "assignee:none"
+	}
+	return "assignee:" + e.name
+}
+
+type reporterExpr struct{ name string }
+
+func (e reporterExpr) Match(issue *types.Issue) bool { return issue.Reporter == e.name }
+func (e reporterExpr) String() string                { return "reporter:" + e.name }
+
+type typeExpr struct{ issueType types.IssueType }
+
+func (e typeExpr) Match(issue *types.Issue) bool { return issue.IssueType == e.issueType }
+func (e typeExpr) String() string                { return "type:" + string(e.issueType) }
+
+type priorityExpr struct {
+	op    string
+	value int
+}
+
+func (e priorityExpr) Match(issue *types.Issue) bool {
+	switch e.op {
+	case "<":
+		return issue.Priority < e.value
+	case "<=":
+		return issue.Priority <= e.value
+	case ">":
+		return issue.Priority > e.value
+	case ">=":
+		return issue.Priority >= e.value
+	}
+	return issue.Priority == e.value
+}
+func (e priorityExpr) String() string {
+	if e.op == "=" {
+		return fmt.Sprintf("priority:%d", e.value)
+	}
+	return fmt.Sprintf("priority:%s%d", e.op, e.value)
+}
+
+// fieldTerm builds the expression for a field:value term, with @me standing
+// for actor
+func fieldTerm(field, value, actor string) (Expr, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s:", field)
+	}
+	field = strings.ToLower(field)
+	if value == types.MeAlias && (field == "assignee" || field == "reporter") {
+		if actor == "" {
+			return nil, fmt.Errorf("%s:%s needs a known actor", field, value)
+		}
+		value = actor
+	}
+	switch field {
+	case "status":
+		status := types.Status(strings.ToLower(value))
+		if status.IsValid() {
+			return statusExpr{status}, nil
+		}
+		return nil, fmt.Errorf("invalid status %q (expected open, in_progress, blocked, or closed)", value)
+	case "label":
+		match, err := types.CompileLabelPattern(value)
+		if err != nil {
+			return nil, err
+		}
+		return labelExpr{pattern: value, match: match}, nil
+	case "assignee":
+		if strings.EqualFold(value, "none") {
+			return assigneeExpr{}, nil
+		}
+		return assigneeExpr{value}, nil
+	case "reporter":
+		return reporterExpr{value}, nil
+	case "type":
+		issueType := types.IssueType(strings.ToLower(value))
+		if !issueType.IsValid() {
+			return nil, fmt.Errorf("invalid type %q", value)
+		}
+		return typeExpr{issueType}, nil
+	case "priority":
+		return parsePriority(value)
+	}
+	return nil, fmt.Errorf("unknown field %q (expected status, label, priority, assignee, reporter, or type; quote the term to search for it as text)", field)
+}
+
+func parsePriority(value string) (Expr, error) {
+	op := "="
+	for _, candidate := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			value = value[len(candidate):]
+			break
+		}
+	}
+	digits := strings.TrimPrefix(strings.TrimPrefix(value, "P"), "p")
+	n, err := strconv.Atoi(digits)
+	if err != nil || n < 0 || n > 4 {
+		return nil, fmt.Errorf("invalid priority %q (expected 0-4, optionally after <, <=, >, or >=)", value)
+	}
+	return priorityExpr{op: op, value: n}, nil
+}
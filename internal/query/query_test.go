@@ -0,0 +1,226 @@
+package query
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`login`, `"login"`},
+		{`a b`, `("a" AND "b")`},
+		{`a OR b c`, `("a" OR ("b" AND "c"))`},
+		{`a AND b OR c`, `(("a" AND "b") OR "c")`},
+		{`a AND (b OR c)`, `("a" AND ("b" OR "c"))`},
+		{`NOT a b`, `(NOT "a" AND "b")`},
+		{`NOT (a OR b)`, `NOT ("a" OR "b")`},
+		{`NOT NOT a`, `NOT NOT "a"`},
+		{`a and b`, `(("a" AND "and") AND "b")`},
+		{`"Login Page" OR auth`, `("login page" OR "auth")`},
+		{`status:open AND label:bug AND (login OR auth)`, `((status:open AND label:bug) AND ("login" OR "auth"))`},
+		{`priority:<=1 priority:>P0`, `(priority:<=1 AND priority:>0)`},
+		{`assignee:none label:"needs review"`, `(assignee:none AND label:needs review)`},
+		{`"http://example.com"`, `"http://example.com"`},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.query, err)
+			continue
+		}
+		if got := expr.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{``, "empty query"},
+		{`a AND`, "unexpected end of query"},
+		{`OR a`, `unexpected "OR"`},
+		{`(a OR b`, "missing closing parenthesis"},
+		{`a)`, `unexpected ")"`},
+		{`"open`, "unterminated quote"},
+		{`status:done`, "invalid status"},
+		{`priority:7`, "invalid priority"},
+		{`type:story`, "invalid type"},
+		{`label:`, "missing value"},
+		{`http://example.com`, `unknown field "http"`},
+		{`assignee:@me`, "needs a known actor"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestApplyPushesDownConjuncts(t *testing.T) {
+	expr, err := Parse(`status:open label:bug priority:1 (type:bug OR type:task) NOT assignee:bob`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var filter types.IssueFilter
+	Apply(expr, &filter)
+
+	if filter.Status == nil || *filter.Status != types.StatusOpen {
+		t.Errorf("status not pushed down: %v", filter.Status)
+	}
+	if len(filter.Labels) != 1 || filter.Labels[0] != "bug" {
+		t.Errorf("labels = %v, want [bug]", filter.Labels)
+	}
+	if filter.Priority == nil || *filter.Priority != 1 {
+		t.Errorf("priority not pushed down: %v", filter.Priority)
+	}
+	if filter.IssueType != nil || filter.Assignee != nil {
+		t.Error("terms under OR or NOT must not be pushed down")
+	}
+	if filter.Match == nil {
+		t.Error("Match not set")
+	}
+}
+
+func TestParseAsResolvesMe(t *testing.T) {
+	expr, err := ParseAs(`assignee:@me OR reporter:@me OR assignee:me`, "alice")
+	if err != nil {
+		t.Fatalf("ParseAs failed: %v", err)
+	}
+	if got, want := expr.String(), `((assignee:alice OR reporter:alice) OR assignee:me)`; got != want {
+		t.Errorf("ParseAs = %s, want %s", got, want)
+	}
+
+	expr, err = ParseAs(`reporter:@me status:open`, "alice")
+	if err != nil {
+		t.Fatalf("ParseAs failed: %v", err)
+	}
+	var filter types.IssueFilter
+	Apply(expr, &filter)
+	if filter.Reporter == nil || *filter.Reporter != "alice" {
+		t.Errorf("reporter not pushed down as alice: %v", filter.Reporter)
+	}
+	if !expr.Match(&types.Issue{Status: types.StatusOpen, Reporter: "alice"}) {
+		t.Error("expected a match on alice's issue")
+	}
+	if expr.Match(&types.Issue{Status: types.StatusOpen, Reporter: "bob"}) {
+		t.Error("unexpected match on bob's issue")
+	}
+}
+
+// searchFixture creates the same issues in store and returns a title -> ID map
+func searchFixture(t *testing.T, store storage.Storage) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+	closedAt := time.Now()
+	fixtures := []struct {
+		issue  types.Issue
+		labels []string
+	}{
+		{types.Issue{Title: "Login button broken", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}, []string{"bug"}},
+		{types.Issue{Title: "Auth token refresh", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug, Assignee: "alice"}, []string{"bug"}},
+		{types.Issue{Title: "Signup flow", Description: "Reuses the login form", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeFeature}, []string{"ux"}},
+		{types.Issue{Title: "Login audit log", Status: types.StatusClosed, ClosedAt: &closedAt, Priority: 0, IssueType: types.TypeBug}, []string{"bug"}},
+		{types.Issue{Title: "Docs cleanup", Status: types.StatusOpen, Priority: 4, IssueType: types.TypeChore}, nil},
+	}
+
+	ids := map[string]string{}
+	for _, f := range fixtures {
+		issue := f.issue
+		if err := store.CreateIssue(ctx, &issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range f.labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		ids[issue.Title] = issue.ID
+	}
+	return ids
+}
+
+func TestSearchEndToEnd(t *testing.T) {
+	backends := map[string]func(t *testing.T) storage.Storage{
+		"memory": func(t *testing.T) storage.Storage {
+			store := memory.New("")
+			if err := store.SetConfig(context.Background(), "issue_prefix", "bd"); err != nil {
+				t.Fatalf("SetConfig failed: %v", err)
+			}
+			return store
+		},
+		"sqlite": func(t *testing.T) storage.Storage {
+			store, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+			if err := store.SetConfig(context.Background(), "issue_prefix", "bd"); err != nil {
+				t.Fatalf("SetConfig failed: %v", err)
+			}
+			return store
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`status:open AND label:bug AND (login OR auth)`, []string{"Auth token refresh", "Login button broken"}},
+		// Without parentheses AND binds tighter: (open AND bug AND login) OR auth
+		{`status:open AND label:bug AND login OR auth`, []string{"Auth token refresh", "Login button broken"}},
+		{`label:bug login OR docs`, []string{"Docs cleanup", "Login audit log", "Login button broken"}},
+		{`login NOT label:bug`, []string{"Signup flow"}},
+		{`NOT (status:open OR priority:0)`, nil},
+		{`priority:<=1`, []string{"Login audit log", "Login button broken"}},
+		{`assignee:none label:bug status:open`, []string{"Login button broken"}},
+		{`"login form"`, []string{"Signup flow"}},
+	}
+
+	for name, newStore := range backends {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			defer store.Close()
+			ids := searchFixture(t, store)
+			titles := map[string]string{}
+			for title, id := range ids {
+				titles[id] = title
+			}
+
+			for _, tt := range tests {
+				expr, err := Parse(tt.query)
+				if err != nil {
+					t.Fatalf("Parse(%q) failed: %v", tt.query, err)
+				}
+				var filter types.IssueFilter
+				Apply(expr, &filter)
+				issues, err := store.SearchIssues(context.Background(), "", filter)
+				if err != nil {
+					t.Fatalf("SearchIssues(%q) failed: %v", tt.query, err)
+				}
+				var got []string
+				for _, issue := range issues {
+					got = append(got, titles[issue.ID])
+				}
+				sort.Strings(got)
+				if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+					t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"bufio"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -20,6 +21,8 @@ type Client struct {
 	socketPath string
 	timeout    time.Duration
 	dbPath     string // Expected database path for validation
+	lockToken  string // Exclusive lock token sent with every request
+	authToken  string // Auth token for TCP connections
 }
 
 // TryConnect attempts to connect to the daemon socket
@@ -81,6 +84,45 @@ func TryConnectWithTimeout(socketPath string, dialTimeout time.Duration) (*Clien
 	return client, nil
 }
 
+// ConnectTCP connects to a daemon serving RPC over TCP (see Server.ServeTCP),
+// authenticating every request with token. With tlsConfig set the connection
+// uses TLS, which the daemon requires on non-loopback addresses. Unlike
+// TryConnect it reports why a connection failed, since a remote daemon is
+// never auto-started.
+func ConnectTCP(addr, token string, dialTimeout time.Duration, tlsConfig *tls.Config) (*Client, error) {
+	if dialTimeout <= 0 {
+		dialTimeout = 5 * time.Second
+	}
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, dialTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon at %s: %w", addr, err)
+	}
+
+	client := &Client{
+		conn:       conn,
+		socketPath: addr,
+		timeout:    30 * time.Second,
+		authToken:  token,
+	}
+
+	health, err := client.Health()
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if health.Status == statusUnhealthy {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon at %s is unhealthy: %s", addr, health.Error)
+	}
+	return client, nil
+}
+
 // Close closes the connection to the daemon
 func (c *Client) Close() error {
 	if c.conn != nil {
@@ -106,6 +148,10 @@ func (c *Client) Execute(operation string, args interface{}) (*Response, error)
 
 // ExecuteWithCwd sends an RPC request with an explicit cwd (or current dir if empty string)
 func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string) (*Response, error) {
+	if c.conn == nil {
+		return nil, fmt.Errorf("not connected to daemon")
+	}
+
 	argsJSON, err := json.Marshal(args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal args: %w", err)
@@ -122,6 +168,8 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 		ClientVersion: ClientVersion,
 		Cwd:           cwd,
 		ExpectedDB:    c.dbPath, // Send expected database path for validation
+		LockToken:     c.lockToken,
+		AuthToken:     c.authToken,
 	}
 
 	reqJSON, err := json.Marshal(req)
@@ -215,6 +263,13 @@ func (c *Client) Shutdown() error {
 	return err
 }
 
+// Flush asks the daemon to write pending changes to JSONL and waits until
+// the file has been written
+func (c *Client) Flush() error {
+	_, err := c.Execute(OpFlush, nil)
+	return err
+}
+
 // Metrics retrieves daemon metrics
 func (c *Client) Metrics() (*MetricsSnapshot, error) {
 	resp, err := c.Execute(OpMetrics, nil)
@@ -240,11 +295,41 @@ func (c *Client) Update(args *UpdateArgs) (*Response, error) {
 	return c.Execute(OpUpdate, args)
 }
 
+// Patch applies a JSON Merge Patch to an issue via the daemon
+func (c *Client) Patch(args *PatchArgs) (*Response, error) {
+	return c.Execute(OpPatch, args)
+}
+
 // CloseIssue marks an issue as closed via the daemon.
 func (c *Client) CloseIssue(args *CloseArgs) (*Response, error) {
 	return c.Execute(OpClose, args)
 }
 
+// ReopenIssue reopens a closed issue via the daemon
+func (c *Client) ReopenIssue(args *ReopenArgs) (*Response, error) {
+	return c.Execute(OpReopen, args)
+}
+
+// Claim claims an issue for an agent via the daemon
+func (c *Client) Claim(args *ClaimArgs) (*Response, error) {
+	return c.Execute(OpClaim, args)
+}
+
+// Release gives up a claim on an issue via the daemon
+func (c *Client) Release(args *ReleaseArgs) (*Response, error) {
+	return c.Execute(OpRelease, args)
+}
+
+// AppendNotes appends a timestamped entry to an issue's notes via the daemon
+func (c *Client) AppendNotes(args *AppendNotesArgs) (*Response, error) {
+	return c.Execute(OpAppendNotes, args)
+}
+
+// Unblocked lists the issues that closing an issue made ready via the daemon
+func (c *Client) Unblocked(args *UnblockedArgs) (*Response, error) {
+	return c.Execute(OpUnblocked, args)
+}
+
 // List lists issues via the daemon
 func (c *Client) List(args *ListArgs) (*Response, error) {
 	return c.Execute(OpList, args)
@@ -285,6 +370,16 @@ func (c *Client) RemoveLabel(args *LabelRemoveArgs) (*Response, error) {
 	return c.Execute(OpLabelRemove, args)
 }
 
+// Watch subscribes someone to an issue via the daemon
+func (c *Client) Watch(args *WatchArgs) (*Response, error) {
+	return c.Execute(OpWatch, args)
+}
+
+// Unwatch unsubscribes someone from an issue via the daemon
+func (c *Client) Unwatch(args *WatchArgs) (*Response, error) {
+	return c.Execute(OpUnwatch, args)
+}
+
 // ListComments retrieves comments for an issue via the daemon
 func (c *Client) ListComments(args *CommentListArgs) (*Response, error) {
 	return c.Execute(OpCommentList, args)
@@ -311,3 +406,33 @@ func (c *Client) Export(args *ExportArgs) (*Response, error) {
 func (c *Client) EpicStatus(args *EpicStatusArgs) (*Response, error) {
 	return c.Execute(OpEpicStatus, args)
 }
+
+// AcquireExclusive takes the daemon's exclusive write lock. While it is held,
+// only requests from this client can modify issues. The lock lapses after its
+// TTL unless renewed by acquiring again.
+func (c *Client) AcquireExclusive(args *AcquireExclusiveArgs) (*ExclusiveLockResponse, error) {
+	resp, err := c.Execute(OpAcquireExclusive, args)
+	if err != nil {
+		return nil, err
+	}
+	var lock ExclusiveLockResponse
+	if err := json.Unmarshal(resp.Data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal lock response: %w", err)
+	}
+	c.lockToken = lock.Token
+	return &lock, nil
+}
+
+// HoldsExclusive reports whether this client holds the exclusive lock
+func (c *Client) HoldsExclusive() bool {
+	return c.lockToken != ""
+}
+
+// ReleaseExclusive releases the exclusive lock held by this client
+func (c *Client) ReleaseExclusive() error {
+	if _, err := c.Execute(OpReleaseExclusive, &ReleaseExclusiveArgs{Token: c.lockToken}); err != nil {
+		return err
+	}
+	c.lockToken = ""
+	return nil
+}
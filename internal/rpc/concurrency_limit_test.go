@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// gatedSearchStore holds every SearchIssues call until release is closed
+type gatedSearchStore struct {
+	*memory.MemoryStorage
+	release chan struct{}
+}
+
+func (s *gatedSearchStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	select {
+	case <-s.release:
+		return s.MemoryStorage.SearchIssues(ctx, query, filter)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// saturate starts n List calls that block in storage and waits until the
+// daemon reports them all in flight
+func saturate(t *testing.T, socketPath string, status *Client, n int) *sync.WaitGroup {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		client := connectTestClient(t, socketPath)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = client.List(&ListArgs{})
+		}()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		st, err := status.Status()
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+		if int(st.InFlightRequests) == n {
+			if st.MaxInFlight != n {
+				t.Errorf("MaxInFlight = %d, want %d", st.MaxInFlight, n)
+			}
+			return &wg
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("in-flight count = %d, want %d", st.InFlightRequests, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStorageLimitRejectsExcess(t *testing.T) {
+	t.Setenv("BEADS_DAEMON_MAX_INFLIGHT", "2")
+	t.Setenv("BEADS_DAEMON_QUEUE_TIMEOUT", "0")
+	store := &gatedSearchStore{MemoryStorage: memory.New(""), release: make(chan struct{})}
+	socketPath := startStoreServer(t, store)
+	status := connectTestClient(t, socketPath)
+
+	wg := saturate(t, socketPath, status, 2)
+
+	_, err := connectTestClient(t, socketPath).List(&ListArgs{})
+	if err == nil || !strings.Contains(err.Error(), "daemon busy") {
+		t.Errorf("excess request error = %v, want daemon busy", err)
+	}
+
+	close(store.release)
+	wg.Wait()
+	st, err := status.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if st.InFlightRequests != 0 {
+		t.Errorf("in-flight after release = %d, want 0", st.InFlightRequests)
+	}
+	if _, err := status.List(&ListArgs{}); err != nil {
+		t.Errorf("List after release failed: %v", err)
+	}
+}
+
+func TestStorageLimitQueuesExcess(t *testing.T) {
+	t.Setenv("BEADS_DAEMON_MAX_INFLIGHT", "1")
+	t.Setenv("BEADS_DAEMON_QUEUE_TIMEOUT", "10s")
+	store := &gatedSearchStore{MemoryStorage: memory.New(""), release: make(chan struct{})}
+	socketPath := startStoreServer(t, store)
+	status := connectTestClient(t, socketPath)
+
+	wg := saturate(t, socketPath, status, 1)
+
+	queued := make(chan error, 1)
+	client := connectTestClient(t, socketPath)
+	go func() {
+		_, err := client.List(&ListArgs{})
+		queued <- err
+	}()
+
+	select {
+	case err := <-queued:
+		t.Fatalf("excess request finished while the limit was full: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(store.release)
+	wg.Wait()
+	select {
+	case err := <-queued:
+		if err != nil {
+			t.Errorf("queued request failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued request never ran")
+	}
+}
+
+func TestBatchSharesStorageSlot(t *testing.T) {
+	t.Setenv("BEADS_DAEMON_MAX_INFLIGHT", "1")
+	t.Setenv("BEADS_DAEMON_QUEUE_TIMEOUT", "0")
+	client := connectTestClient(t, startStoreServer(t, memory.New("")))
+
+	resp, err := client.Batch(&BatchArgs{Operations: []BatchOperation{
+		{Operation: OpList, Args: []byte(`{}`)},
+		{Operation: OpList, Args: []byte(`{}`)},
+	}})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Batch failed: %s", resp.Error)
+	}
+	if strings.Contains(string(resp.Data), "daemon busy") {
+		t.Errorf("batch sub-request hit the storage limit: %s", resp.Data)
+	}
+}
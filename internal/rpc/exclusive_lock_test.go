@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExclusiveLockBlocksOtherWriters(t *testing.T) {
+	server, holder, cleanup := setupTestServer(t)
+	defer cleanup()
+	other := connectTestClient(t, server.socketPath)
+
+	lock, err := holder.AcquireExclusive(&AcquireExclusiveArgs{Holder: "bulk-rename", TTL: "1m"})
+	if err != nil {
+		t.Fatalf("AcquireExclusive failed: %v", err)
+	}
+	if lock.Holder != "bulk-rename" || lock.Token == "" {
+		t.Fatalf("unexpected lock: %+v", lock)
+	}
+
+	create := &CreateArgs{Title: "Blocked", IssueType: "task", Priority: 2}
+	if _, err := other.Create(create); err == nil || !strings.Contains(err.Error(), "locked by bulk-rename") {
+		t.Errorf("second writer error = %v, want locked by bulk-rename", err)
+	}
+	if _, err := other.List(&ListArgs{}); err != nil {
+		t.Errorf("reads should not be blocked: %v", err)
+	}
+	if _, err := holder.Create(&CreateArgs{Title: "Allowed", IssueType: "task", Priority: 2}); err != nil {
+		t.Errorf("holder write failed: %v", err)
+	}
+	if _, err := other.AcquireExclusive(&AcquireExclusiveArgs{Holder: "rival"}); err == nil {
+		t.Error("second AcquireExclusive should fail while the lock is held")
+	}
+
+	status, err := other.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !status.ExclusiveLockActive || status.ExclusiveLockHolder != "bulk-rename" {
+		t.Errorf("status lock = %v/%q, want active/bulk-rename", status.ExclusiveLockActive, status.ExclusiveLockHolder)
+	}
+
+	if err := holder.ReleaseExclusive(); err != nil {
+		t.Fatalf("ReleaseExclusive failed: %v", err)
+	}
+	if _, err := other.Create(create); err != nil {
+		t.Errorf("write after release failed: %v", err)
+	}
+	status, err = other.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.ExclusiveLockActive {
+		t.Error("status still reports the released lock")
+	}
+}
+
+func TestExclusiveLockExpires(t *testing.T) {
+	server, holder, cleanup := setupTestServer(t)
+	defer cleanup()
+	other := connectTestClient(t, server.socketPath)
+
+	if _, err := holder.AcquireExclusive(&AcquireExclusiveArgs{Holder: "crashed-tool", TTL: "100ms"}); err != nil {
+		t.Fatalf("AcquireExclusive failed: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	if _, err := other.Create(&CreateArgs{Title: "After expiry", IssueType: "task", Priority: 2}); err != nil {
+		t.Errorf("write after the lock expired failed: %v", err)
+	}
+	if err := holder.ReleaseExclusive(); err == nil {
+		t.Error("releasing an expired lock should fail")
+	}
+}
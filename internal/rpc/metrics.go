@@ -26,6 +26,10 @@ type Metrics struct {
 	// Cache metrics (handled separately via atomic in Server)
 	cacheEvictions int64
 
+	// JSONL flush metrics
+	flushes     int64
+	flushErrors int64
+
 	// System start time (for uptime calculation)
 	startTime time.Time
 }
@@ -81,6 +85,14 @@ func (m *Metrics) RecordCacheEviction() {
 	atomic.AddInt64(&m.cacheEvictions, 1)
 }
 
+// RecordFlush records a JSONL flush and whether it failed
+func (m *Metrics) RecordFlush(err error) {
+	atomic.AddInt64(&m.flushes, 1)
+	if err != nil {
+		atomic.AddInt64(&m.flushErrors, 1)
+	}
+}
+
 // Snapshot returns a point-in-time snapshot of all metrics
 func (m *Metrics) Snapshot(cacheHits, cacheMisses int64, cacheSize, activeConns int) MetricsSnapshot {
 	// Copy data under a short critical section
@@ -168,6 +180,8 @@ func (m *Metrics) Snapshot(cacheHits, cacheMisses int64, cacheSize, activeConns
 		TotalConns:     atomic.LoadInt64(&m.totalConns),
 		ActiveConns:    activeConns,
 		RejectedConns:  atomic.LoadInt64(&m.rejectedConns),
+		Flushes:        atomic.LoadInt64(&m.flushes),
+		FlushErrors:    atomic.LoadInt64(&m.flushErrors),
 		MemoryAllocMB:  memStats.Alloc / 1024 / 1024,
 		MemorySysMB:    memStats.Sys / 1024 / 1024,
 		GoroutineCount: runtime.NumGoroutine(),
@@ -186,6 +200,8 @@ type MetricsSnapshot struct {
 	TotalConns     int64              `json:"total_connections"`
 	ActiveConns    int                `json:"active_connections"`
 	RejectedConns  int64              `json:"rejected_connections"`
+	Flushes        int64              `json:"flushes"`
+	FlushErrors    int64              `json:"flush_errors"`
 	MemoryAllocMB  uint64             `json:"memory_alloc_mb"`
 	MemorySysMB    uint64             `json:"memory_sys_mb"`
 	GoroutineCount int                `json:"goroutine_count"`
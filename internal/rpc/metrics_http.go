@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Metrics returns the server's metrics collector
+func (s *Server) Metrics() *Metrics {
+	return s.metrics
+}
+
+// MetricsHandler serves the daemon's metrics in the Prometheus text exposition
+// format. Issue gauges come from storage statistics and are left out if those
+// can't be read, so a busy database never fails a scrape.
+func (s *Server) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot := s.metrics.Snapshot(0, 0, 0, int(atomic.LoadInt32(&s.activeConns)))
+
+		var stats *types.Statistics
+		if s.storage != nil {
+			if st, err := s.storage.GetStatistics(r.Context()); err == nil {
+				stats = st
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WritePrometheus(w, snapshot, stats)
+	})
+}
+
+// WritePrometheus writes snapshot, and stats when non-nil, in the Prometheus
+// text exposition format
+func WritePrometheus(w io.Writer, snapshot MetricsSnapshot, stats *types.Statistics) error {
+	bw := bufio.NewWriter(w)
+
+	metric := func(name, kind, help string) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	ops := append([]OperationMetrics(nil), snapshot.Operations...)
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Operation < ops[j].Operation })
+
+	metric("bd_uptime_seconds", "gauge", "Seconds since the daemon started.")
+	fmt.Fprintf(bw, "bd_uptime_seconds %g\n", snapshot.UptimeSeconds)
+
+	metric("bd_rpc_requests_total", "counter", "RPC requests handled, by operation.")
+	for _, op := range ops {
+		fmt.Fprintf(bw, "bd_rpc_requests_total{operation=%q} %d\n", op.Operation, op.TotalCount)
+	}
+	metric("bd_rpc_errors_total", "counter", "RPC requests that failed, by operation.")
+	for _, op := range ops {
+		fmt.Fprintf(bw, "bd_rpc_errors_total{operation=%q} %d\n", op.Operation, op.ErrorCount)
+	}
+
+	var created, updated int64
+	for _, op := range ops {
+		switch op.Operation {
+		case OpCreate:
+			created = op.SuccessCount
+		case OpUpdate, OpPatch:
+			updated += op.SuccessCount
+		}
+	}
+	metric("bd_issues_created_total", "counter", "Issues created through the daemon.")
+	fmt.Fprintf(bw, "bd_issues_created_total %d\n", created)
+	metric("bd_issues_updated_total", "counter", "Issue updates made through the daemon.")
+	fmt.Fprintf(bw, "bd_issues_updated_total %d\n", updated)
+
+	metric("bd_flushes_total", "counter", "JSONL flushes run by the daemon.")
+	fmt.Fprintf(bw, "bd_flushes_total %d\n", snapshot.Flushes)
+	metric("bd_flush_errors_total", "counter", "JSONL flushes that failed.")
+	fmt.Fprintf(bw, "bd_flush_errors_total %d\n", snapshot.FlushErrors)
+
+	metric("bd_connections_total", "counter", "Client connections accepted.")
+	fmt.Fprintf(bw, "bd_connections_total %d\n", snapshot.TotalConns)
+	metric("bd_connections_rejected_total", "counter", "Client connections rejected at the connection limit.")
+	fmt.Fprintf(bw, "bd_connections_rejected_total %d\n", snapshot.RejectedConns)
+	metric("bd_connections_active", "gauge", "Client connections currently open.")
+	fmt.Fprintf(bw, "bd_connections_active %d\n", snapshot.ActiveConns)
+
+	metric("bd_goroutines", "gauge", "Goroutines in the daemon process.")
+	fmt.Fprintf(bw, "bd_goroutines %d\n", snapshot.GoroutineCount)
+
+	if stats != nil {
+		metric("bd_issues", "gauge", "Issues in the database, by status.")
+		fmt.Fprintf(bw, "bd_issues{status=\"open\"} %d\n", stats.OpenIssues)
+		fmt.Fprintf(bw, "bd_issues{status=\"in_progress\"} %d\n", stats.InProgressIssues)
+		fmt.Fprintf(bw, "bd_issues{status=\"closed\"} %d\n", stats.ClosedIssues)
+		metric("bd_blocked_issues", "gauge", "Open issues waiting on an open blocker.")
+		fmt.Fprintf(bw, "bd_blocked_issues %d\n", stats.BlockedIssues)
+		metric("bd_ready_issues", "gauge", "Open issues with no blockers.")
+		fmt.Fprintf(bw, "bd_ready_issues %d\n", stats.ReadyIssues)
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandlerScrape(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if _, err := client.Create(&CreateArgs{Title: "Scraped", IssueType: "task", Priority: 2}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	server.Metrics().RecordFlush(nil)
+	server.Metrics().RecordFlush(errors.New("disk full"))
+
+	ts := httptest.NewServer(server.MetricsHandler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	text := string(body)
+
+	for _, want := range []string{
+		"# TYPE bd_rpc_requests_total counter",
+		`bd_rpc_requests_total{operation="create"} 1`,
+		`bd_rpc_errors_total{operation="create"} 0`,
+		"bd_issues_created_total 1",
+		"bd_issues_updated_total 0",
+		"bd_flushes_total 2",
+		"bd_flush_errors_total 1",
+		"bd_connections_total ",
+		"# TYPE bd_issues gauge",
+		`bd_issues{status="open"} 1`,
+		`bd_issues{status="closed"} 0`,
+		"bd_blocked_issues 0",
+		"bd_ready_issues 1",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("metrics output missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // Operation constants for all bd commands
@@ -12,16 +16,24 @@ const (
 	OpMetrics         = "metrics"
 	OpCreate          = "create"
 	OpUpdate          = "update"
+	OpPatch           = "patch"
 	OpClose           = "close"
+	OpReopen          = "reopen"
+	OpAppendNotes     = "append_notes"
+	OpClaim           = "claim"
+	OpRelease         = "release"
 	OpList            = "list"
 	OpShow            = "show"
 	OpReady           = "ready"
+	OpUnblocked       = "unblocked"
 	OpStats           = "stats"
 	OpDepAdd          = "dep_add"
 	OpDepRemove       = "dep_remove"
 	OpDepTree         = "dep_tree"
 	OpLabelAdd        = "label_add"
 	OpLabelRemove     = "label_remove"
+	OpWatch           = "watch"
+	OpUnwatch         = "unwatch"
 	OpCommentList     = "comment_list"
 	OpCommentAdd      = "comment_add"
 	OpBatch           = "batch"
@@ -32,6 +44,10 @@ const (
 	OpImport          = "import"
 	OpEpicStatus      = "epic_status"
 	OpShutdown        = "shutdown"
+	OpFlush           = "flush"
+
+	OpAcquireExclusive = "acquire_exclusive"
+	OpReleaseExclusive = "release_exclusive"
 )
 
 // Request represents an RPC request from client to daemon
@@ -43,6 +59,13 @@ type Request struct {
 	Cwd           string          `json:"cwd,omitempty"`            // Working directory for database discovery
 	ClientVersion string          `json:"client_version,omitempty"` // Client version for compatibility checks
 	ExpectedDB    string          `json:"expected_db,omitempty"`    // Expected database path for validation (absolute)
+	LockToken     string          `json:"lock_token,omitempty"`     // Exclusive lock token, lets the holder write
+	AuthToken     string          `json:"auth_token,omitempty"`     // Required on TCP connections
+
+	// ctx bounds the server's handling of the request; see Server.reqCtx
+	ctx context.Context
+	// remote is set for authenticated requests over TCP
+	remote bool
 }
 
 // Response represents an RPC response from daemon to client
@@ -64,6 +87,7 @@ type CreateArgs struct {
 	Assignee           string   `json:"assignee,omitempty"`
 	Labels             []string `json:"labels,omitempty"`
 	Dependencies       []string `json:"dependencies,omitempty"`
+	DueDate            *time.Time `json:"due_date,omitempty"`
 }
 
 // UpdateArgs represents arguments for the update operation
@@ -77,12 +101,65 @@ type UpdateArgs struct {
 	AcceptanceCriteria *string `json:"acceptance_criteria,omitempty"`
 	Notes              *string `json:"notes,omitempty"`
 	Assignee           *string `json:"assignee,omitempty"`
+	DueDate            *string  `json:"due_date,omitempty"` // RFC3339; empty string clears
+	Rank               *float64 `json:"rank,omitempty"`
+	ClearRank          bool     `json:"clear_rank,omitempty"`
+	Force              bool     `json:"force,omitempty"` // Skip status workflow checks
+	// ExpectedVersion makes the update fail if the issue is no longer at this
+	// version (the "version" field of show and update responses)
+	ExpectedVersion string `json:"expected_version,omitempty"`
+	// DryRun validates the update and returns a storage.DryRun with the
+	// resulting issue and the changed fields, without writing
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// PatchArgs represents arguments for the patch operation
+type PatchArgs struct {
+	ID string `json:"id"`
+	// Patch is a JSON Merge Patch (RFC 7396) against the issue's JSON: keys
+	// set fields, null clears them, and absent keys are left unchanged
+	Patch           json.RawMessage `json:"patch"`
+	ExpectedVersion string          `json:"expected_version,omitempty"`
 }
 
 // CloseArgs represents arguments for the close operation
 type CloseArgs struct {
 	ID     string `json:"id"`
 	Reason string `json:"reason,omitempty"`
+	Force  bool   `json:"force,omitempty"` // Skip status workflow checks
+}
+
+// ReopenArgs represents arguments for the reopen operation
+type ReopenArgs struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+	Force  bool   `json:"force,omitempty"` // Skip status workflow checks
+}
+
+// ClaimArgs represents arguments for claiming an issue
+type ClaimArgs struct {
+	ID    string `json:"id"`
+	Agent string `json:"agent,omitempty"` // Who claims it (default: the request actor)
+	Lease string `json:"lease,omitempty"` // Claim lifetime, e.g. "30m" (default 30m)
+}
+
+// ClaimResponse reports the outcome of a claim
+type ClaimResponse struct {
+	Claimed   bool         `json:"claimed"`              // False if another agent holds the issue
+	Issue     *types.Issue `json:"issue,omitempty"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"` // When the claim lapses, if granted
+}
+
+// ReleaseArgs represents arguments for releasing a claim
+type ReleaseArgs struct {
+	ID    string `json:"id"`
+	Agent string `json:"agent,omitempty"` // Who releases it (default: the request actor)
+}
+
+// AppendNotesArgs represents arguments for the append notes operation
+type AppendNotesArgs struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
 }
 
 // ListArgs represents arguments for the list operation
@@ -92,11 +169,17 @@ type ListArgs struct {
 	Priority  *int     `json:"priority,omitempty"`
 	IssueType string   `json:"issue_type,omitempty"`
 	Assignee  string   `json:"assignee,omitempty"`
+	Reporter  string   `json:"reporter,omitempty"`
 	Label     string   `json:"label,omitempty"`      // Deprecated: use Labels
 	Labels    []string `json:"labels,omitempty"`     // AND semantics
 	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
 	IDs       []string `json:"ids,omitempty"`        // Filter by specific issue IDs
 	Limit     int      `json:"limit,omitempty"`
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	DueBefore       *time.Time `json:"due_before,omitempty"`
+	SortByRelevance bool       `json:"sort_by_relevance,omitempty"` // Rank matches for Query instead of sorting by priority
+	Search          string     `json:"search,omitempty"`            // Boolean search query; see internal/query
+	Actor           string     `json:"actor,omitempty"`             // Who @me stands for in Search; defaults to the request actor
 }
 
 // ShowArgs represents arguments for the show operation
@@ -112,11 +195,17 @@ type ReadyArgs struct {
 	SortPolicy string `json:"sort_policy,omitempty"`
 }
 
+// UnblockedArgs represents arguments for the unblocked operation
+type UnblockedArgs struct {
+	ClosedID string `json:"closed_id"`
+}
+
 // DepAddArgs represents arguments for adding a dependency
 type DepAddArgs struct {
 	FromID  string `json:"from_id"`
 	ToID    string `json:"to_id"`
 	DepType string `json:"dep_type"`
+	DryRun  bool   `json:"dry_run,omitempty"` // Validate and report a storage.DryRun without adding
 }
 
 // DepRemoveArgs represents arguments for removing a dependency
@@ -144,6 +233,12 @@ type LabelRemoveArgs struct {
 	Label string `json:"label"`
 }
 
+// WatchArgs represents arguments for watching or unwatching an issue
+type WatchArgs struct {
+	ID      string `json:"id"`
+	Watcher string `json:"watcher"`
+}
+
 // CommentListArgs represents arguments for listing comments on an issue
 type CommentListArgs struct {
 	ID string `json:"id"`
@@ -170,6 +265,8 @@ type PingResponse struct {
 // StatusResponse represents the daemon status metadata
 type StatusResponse struct {
 	Version              string  `json:"version"`                  // Server/daemon version
+	DatabaseVersion      string  `json:"database_version,omitempty"` // bd_version recorded in the database
+	ClientVersion        string  `json:"client_version,omitempty"` // Client version from request
 	WorkspacePath        string  `json:"workspace_path"`           // Absolute path to workspace root
 	DatabasePath         string  `json:"database_path"`            // Absolute path to database file
 	SocketPath           string  `json:"socket_path"`              // Path to Unix socket
@@ -178,6 +275,8 @@ type StatusResponse struct {
 	LastActivityTime     string  `json:"last_activity_time"`       // ISO 8601 timestamp of last request
 	ExclusiveLockActive  bool    `json:"exclusive_lock_active"`    // Whether an exclusive lock is held
 	ExclusiveLockHolder  string  `json:"exclusive_lock_holder,omitempty"` // Lock holder name if active
+	InFlightRequests     int32   `json:"in_flight_requests"`       // Requests currently using storage
+	MaxInFlight          int     `json:"max_in_flight"`            // Limit before requests queue or are rejected
 }
 
 // HealthResponse is the response for a health check operation
@@ -279,3 +378,22 @@ type ExportArgs struct {
 type ImportArgs struct {
 	JSONLPath string `json:"jsonl_path"` // Path to import JSONL file
 }
+
+// AcquireExclusiveArgs represents arguments for taking the daemon's exclusive
+// write lock. Acquiring again with the current token renews the lock.
+type AcquireExclusiveArgs struct {
+	Holder string `json:"holder"`        // Who holds the lock, shown in status
+	TTL    string `json:"ttl,omitempty"` // Lock lifetime, e.g. "10m" (default 5m, max 1h)
+}
+
+// ReleaseExclusiveArgs represents arguments for releasing the exclusive lock
+type ReleaseExclusiveArgs struct {
+	Token string `json:"token"`
+}
+
+// ExclusiveLockResponse describes a granted exclusive lock
+type ExclusiveLockResponse struct {
+	Holder    string    `json:"holder"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
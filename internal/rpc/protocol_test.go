@@ -105,18 +105,29 @@ func TestAllOperations(t *testing.T) {
 		OpPing,
 		OpCreate,
 		OpUpdate,
+		OpPatch,
 		OpClose,
+		OpReopen,
+		OpClaim,
+		OpRelease,
+		OpAppendNotes,
 		OpList,
 		OpShow,
 		OpReady,
+		OpUnblocked,
 		OpStats,
 		OpDepAdd,
 		OpDepRemove,
 		OpDepTree,
 		OpLabelAdd,
 		OpLabelRemove,
+		OpWatch,
+		OpUnwatch,
 		OpCommentList,
 		OpCommentAdd,
+		OpFlush,
+		OpAcquireExclusive,
+		OpReleaseExclusive,
 	}
 
 	for _, op := range operations {
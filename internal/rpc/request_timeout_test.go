@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/logging"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// slowSearchStore makes SearchIssues take delay, returning early with the
+// context's error if it is canceled first
+type slowSearchStore struct {
+	*memory.MemoryStorage
+	delay    time.Duration
+	canceled atomic.Bool
+}
+
+func (s *slowSearchStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	select {
+	case <-time.After(s.delay):
+		return s.MemoryStorage.SearchIssues(ctx, query, filter)
+	case <-ctx.Done():
+		s.canceled.Store(true)
+		return nil, ctx.Err()
+	}
+}
+
+// startStoreServer serves store on a new socket, reading limits from the
+// environment as the daemon does, and returns the socket path
+func startStoreServer(t *testing.T, store storage.Storage) string {
+	t.Helper()
+	socketPath := filepath.Join(t.TempDir(), "bd.sock")
+	server := NewServer(socketPath, store, "", "")
+	go func() { _ = server.Start(context.Background()) }()
+	t.Cleanup(func() { _ = server.Stop() })
+
+	select {
+	case <-server.WaitReady():
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not become ready")
+	}
+	return socketPath
+}
+
+func connectTestClient(t *testing.T, socketPath string) *Client {
+	t.Helper()
+	client, err := TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestRequestTimeoutCancelsStorage(t *testing.T) {
+	store := &slowSearchStore{MemoryStorage: memory.New(""), delay: time.Minute}
+	t.Setenv("BEADS_DAEMON_REQUEST_TIMEOUT", "200ms")
+	client := connectTestClient(t, startStoreServer(t, store))
+
+	start := time.Now()
+	_, err := client.List(&ListArgs{})
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if !strings.Contains(err.Error(), "request timed out after 200ms") {
+		t.Errorf("error = %v, want a request timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("timed-out request took %v", elapsed)
+	}
+	if !store.canceled.Load() {
+		t.Error("storage call was not canceled")
+	}
+}
+
+func TestSlowRequestLogged(t *testing.T) {
+	var buf syncBuffer
+	prev := logging.Default()
+	logging.SetDefault(logging.New(&buf, slog.LevelInfo))
+	t.Cleanup(func() { logging.SetDefault(prev) })
+	t.Setenv("BEADS_DAEMON_SLOW_REQUEST", "20ms")
+
+	store := &slowSearchStore{MemoryStorage: memory.New(""), delay: 50 * time.Millisecond}
+	client := connectTestClient(t, startStoreServer(t, store))
+
+	if _, err := client.List(&ListArgs{}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `msg="slow request" operation=list duration=`) {
+		t.Errorf("slow list request not logged:\n%s", out)
+	}
+	if strings.Contains(out, `msg="slow request" operation=ping`) {
+		t.Errorf("fast ping logged as slow:\n%s", out)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to write from the server's goroutines
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
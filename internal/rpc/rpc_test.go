@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	sqlitestorage "github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -231,6 +233,294 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueInvalidDueDate(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Due soon", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	due := "next tuesday"
+	newTitle := "Not applied"
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &newTitle, DueDate: &due}); err == nil {
+		t.Fatal("Expected update with an unparseable due_date to fail")
+	}
+
+	showResp, err := client.Show(&ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	var got types.Issue
+	json.Unmarshal(showResp.Data, &got)
+	if got.Title != "Due soon" || got.DueDate != nil {
+		t.Errorf("Expected issue unchanged after rejected update, got title %q due %v", got.Title, got.DueDate)
+	}
+}
+
+func TestUpdateIssueStaleVersion(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Shared", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	showResp, err := client.Show(&ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	var shown struct {
+		Version string `json:"version"`
+	}
+	json.Unmarshal(showResp.Data, &shown)
+	if shown.Version == "" {
+		t.Fatal("show response has no version")
+	}
+
+	titleA, titleB := "Agent A", "Agent B"
+	updateResp, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &titleA, ExpectedVersion: shown.Version})
+	if err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	var updated struct {
+		Version string `json:"version"`
+	}
+	json.Unmarshal(updateResp.Data, &updated)
+	if updated.Version == "" || updated.Version == shown.Version {
+		t.Errorf("update returned version %q, want a new one", updated.Version)
+	}
+
+	_, err = client.Update(&UpdateArgs{ID: issue.ID, Title: &titleB, ExpectedVersion: shown.Version})
+	if err == nil || !strings.Contains(err.Error(), "modified since it was read") {
+		t.Fatalf("stale update error = %v, want a version conflict", err)
+	}
+
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &titleB, ExpectedVersion: updated.Version}); err != nil {
+		t.Fatalf("update with fresh version failed: %v", err)
+	}
+}
+
+func TestPatchIssue(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Original", Description: "Keep me", IssueType: "task", Priority: 2, Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	patch := func(doc string) types.Issue {
+		t.Helper()
+		resp, err := client.Patch(&PatchArgs{ID: issue.ID, Patch: json.RawMessage(doc)})
+		if err != nil {
+			t.Fatalf("Patch(%s) failed: %v", doc, err)
+		}
+		var patched types.Issue
+		json.Unmarshal(resp.Data, &patched)
+		return patched
+	}
+
+	// Set: only the keys present change
+	patched := patch(`{"title":"Patched","priority":0}`)
+	if patched.Title != "Patched" || patched.Priority != 0 {
+		t.Errorf("set: got title %q priority %d", patched.Title, patched.Priority)
+	}
+	if patched.Description != "Keep me" || patched.Assignee != "alice" {
+		t.Errorf("set touched absent fields: description %q assignee %q", patched.Description, patched.Assignee)
+	}
+
+	// Clear: null empties the field
+	patched = patch(`{"assignee":null}`)
+	if patched.Assignee != "" {
+		t.Errorf("clear: assignee = %q, want empty", patched.Assignee)
+	}
+
+	// No-op: an empty patch doesn't write
+	unchanged := patch(`{}`)
+	if !unchanged.UpdatedAt.Equal(patched.UpdatedAt) || unchanged.Title != "Patched" {
+		t.Errorf("empty patch modified the issue")
+	}
+
+	if _, err := client.Patch(&PatchArgs{ID: issue.ID, Patch: json.RawMessage(`{"created_at":null}`)}); err == nil {
+		t.Error("patching created_at should fail")
+	}
+}
+
+func TestUpdateDryRun(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Original", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	title := "Renamed"
+	resp, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &title, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry-run Update failed: %v", err)
+	}
+	var preview storage.DryRun
+	if err := json.Unmarshal(resp.Data, &preview); err != nil {
+		t.Fatalf("failed to decode dry run: %v", err)
+	}
+	if preview.Issue == nil || preview.Issue.Title != "Renamed" {
+		t.Errorf("dry run issue = %+v, want the renamed issue", preview.Issue)
+	}
+	if len(preview.Changes) != 1 || preview.Changes[0].Field != "title" || preview.Changes[0].Old != "Original" {
+		t.Errorf("dry run changes = %+v", preview.Changes)
+	}
+
+	showResp, err := client.Show(&ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	var shown types.Issue
+	json.Unmarshal(showResp.Data, &shown)
+	if shown.Title != "Original" {
+		t.Errorf("dry run renamed the issue to %q", shown.Title)
+	}
+
+	// A stale expected version is reported without writing
+	_, err = client.Update(&UpdateArgs{ID: issue.ID, Title: &title, DryRun: true, ExpectedVersion: "stale"})
+	if err == nil || !strings.Contains(err.Error(), storage.ErrVersionConflict.Error()) {
+		t.Errorf("dry run with a stale version: expected a version conflict, got %v", err)
+	}
+}
+
+func TestListWithSearchQuery(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, args := range []*CreateArgs{
+		{Title: "Login broken", IssueType: "bug", Priority: 1},
+		{Title: "Login polish", IssueType: "task", Priority: 3},
+		{Title: "Auth refresh", IssueType: "bug", Priority: 2, Assignee: "alice"},
+	} {
+		if _, err := client.Create(args); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	resp, err := client.List(&ListArgs{Search: "type:bug AND (login OR auth) priority:<=1"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var issues []*types.Issue
+	json.Unmarshal(resp.Data, &issues)
+	if len(issues) != 1 || issues[0].Title != "Login broken" {
+		t.Errorf("search matched %v, want just \"Login broken\"", issues)
+	}
+
+	resp, err = client.List(&ListArgs{Search: "assignee:@me", Actor: "alice"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	issues = nil
+	json.Unmarshal(resp.Data, &issues)
+	if len(issues) != 1 || issues[0].Title != "Auth refresh" {
+		t.Errorf("assignee:@me matched %v, want just \"Auth refresh\"", issues)
+	}
+
+	if _, err := client.List(&ListArgs{Search: "login AND"}); err == nil || !strings.Contains(err.Error(), "invalid search query") {
+		t.Errorf("bad query error = %v", err)
+	}
+}
+
+func TestClaimAndRelease(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Shared work", IssueType: "task", Priority: 1})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	claim := func(agent string) ClaimResponse {
+		t.Helper()
+		resp, err := client.Claim(&ClaimArgs{ID: issue.ID, Agent: agent, Lease: "1h"})
+		if err != nil {
+			t.Fatalf("Claim failed: %v", err)
+		}
+		var result ClaimResponse
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			t.Fatalf("failed to decode claim response: %v", err)
+		}
+		return result
+	}
+
+	result := claim("alice")
+	if !result.Claimed || result.ExpiresAt == nil || result.Issue == nil || result.Issue.Status != types.StatusInProgress {
+		t.Fatalf("alice's claim = %+v", result)
+	}
+	if result := claim("bob"); result.Claimed || result.Issue.Assignee != "alice" {
+		t.Errorf("bob's claim = %+v, want refused with alice as assignee", result)
+	}
+
+	if _, err := client.Release(&ReleaseArgs{ID: issue.ID, Agent: "bob"}); err == nil {
+		t.Error("expected bob's release to fail")
+	}
+	if _, err := client.Release(&ReleaseArgs{ID: issue.ID, Agent: "alice"}); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if result := claim("bob"); !result.Claimed {
+		t.Errorf("bob's claim after release = %+v", result)
+	}
+
+	if _, err := client.Claim(&ClaimArgs{ID: issue.ID, Agent: "bob", Lease: "soon"}); err == nil || !strings.Contains(err.Error(), "invalid lease") {
+		t.Errorf("bad lease error = %v", err)
+	}
+}
+
+func TestWatchAndUnwatch(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Watched", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	if _, err := client.Watch(&WatchArgs{ID: issue.ID, Watcher: "alice"}); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := client.Watch(&WatchArgs{ID: issue.ID, Watcher: "alice"}); err == nil {
+		t.Error("expected watching twice to fail")
+	}
+
+	showResp, err := client.Show(&ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	var shown types.Issue
+	json.Unmarshal(showResp.Data, &shown)
+	if len(shown.Watchers) != 1 || shown.Watchers[0] != "alice" {
+		t.Errorf("Watchers = %v, want [alice]", shown.Watchers)
+	}
+
+	if _, err := client.Unwatch(&WatchArgs{ID: issue.ID, Watcher: "alice"}); err != nil {
+		t.Fatalf("Unwatch failed: %v", err)
+	}
+	if _, err := client.Unwatch(&WatchArgs{ID: issue.ID, Watcher: "alice"}); err == nil {
+		t.Error("expected unwatching twice to fail")
+	}
+}
+
 func TestCloseIssue(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -516,3 +806,65 @@ func TestDatabaseHandshake(t *testing.T) {
 		t.Errorf("Create without ExpectedDB should succeed (backward compat): %v", err)
 	}
 }
+
+func TestMutationHook(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	var calls int32
+	server.SetMutationHook(func() {
+		atomic.AddInt32(&calls, 1)
+	})
+
+	resp, err := client.Create(&CreateArgs{Title: "Hooked", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatalf("Failed to unmarshal issue: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected hook after create, got %d calls", got)
+	}
+
+	// Reads don't trigger the hook
+	if _, err := client.List(&ListArgs{}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if _, err := client.Show(&ShowArgs{ID: issue.ID}); err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected reads not to trigger hook, got %d calls", got)
+	}
+
+	// Failed mutations don't trigger the hook
+	if _, err := client.CloseIssue(&CloseArgs{ID: "bd-missing"}); err == nil {
+		t.Fatal("expected close of missing issue to fail")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected failed mutation not to trigger hook, got %d calls", got)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if err := client.Flush(); err == nil {
+		t.Fatal("expected flush to fail without a flush hook")
+	}
+
+	var calls int32
+	server.SetFlushHook(func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	if err := client.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected flush hook to run once, got %d", got)
+	}
+}
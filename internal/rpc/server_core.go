@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -40,12 +41,29 @@ type Server struct {
 	maxConns      int
 	activeConns   int32 // atomic counter
 	connSemaphore chan struct{}
-	// Request timeout
+	// Request timeout: bounds both socket I/O and handling each request
 	requestTimeout time.Duration
+	// Requests taking at least this long are logged (0 disables)
+	slowRequestThreshold time.Duration
+	// Storage concurrency limiting: requests beyond maxInFlight wait up to
+	// queueTimeout for a slot (0 rejects them at once)
+	maxInFlight  int
+	inFlight     int32 // atomic counter
+	storageSlots chan struct{}
+	queueTimeout time.Duration
+	// Optional token-authenticated TCP listener (see ServeTCP)
+	tcpListener net.Listener
+	// Exclusive write lock taken through the acquire_exclusive operation
+	lockMu sync.Mutex
+	lease  *exclusiveLease
 	// Ready channel signals when server is listening
 	readyChan chan struct{}
 	// Auto-import single-flight guard
 	importInProgress atomic.Bool
+	// Called after each successful mutating request (daemon auto-flush)
+	mutationHook func()
+	// Writes pending changes to JSONL immediately (bd flush, shutdown)
+	flushHook func() error
 }
 
 // NewServer creates a new RPC server
@@ -66,6 +84,28 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		}
 	}
 
+	maxInFlight := 16 // default
+	if env := os.Getenv("BEADS_DAEMON_MAX_INFLIGHT"); env != "" {
+		var n int
+		if _, err := fmt.Sscanf(env, "%d", &n); err == nil && n > 0 {
+			maxInFlight = n
+		}
+	}
+
+	queueTimeout := 5 * time.Second // default
+	if env := os.Getenv("BEADS_DAEMON_QUEUE_TIMEOUT"); env != "" {
+		if timeout, err := time.ParseDuration(env); err == nil && timeout >= 0 {
+			queueTimeout = timeout
+		}
+	}
+
+	slowRequestThreshold := time.Second // default
+	if env := os.Getenv("BEADS_DAEMON_SLOW_REQUEST"); env != "" {
+		if threshold, err := time.ParseDuration(env); err == nil && threshold >= 0 {
+			slowRequestThreshold = threshold
+		}
+	}
+
 	s := &Server{
 		socketPath:     socketPath,
 		workspacePath:  workspacePath,
@@ -79,7 +119,45 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		connSemaphore:  make(chan struct{}, maxConns),
 		requestTimeout: requestTimeout,
 		readyChan:      make(chan struct{}),
+
+		slowRequestThreshold: slowRequestThreshold,
+		maxInFlight:          maxInFlight,
+		storageSlots:         make(chan struct{}, maxInFlight),
+		queueTimeout:         queueTimeout,
 	}
 	s.lastActivityTime.Store(time.Now())
 	return s
 }
+
+// acquireStorageSlot waits for one of the maxInFlight storage slots, giving up
+// with a busy error after queueTimeout or when ctx ends. On success the caller
+// must call releaseStorageSlot.
+func (s *Server) acquireStorageSlot(ctx context.Context) error {
+	select {
+	case s.storageSlots <- struct{}{}:
+		atomic.AddInt32(&s.inFlight, 1)
+		return nil
+	default:
+	}
+
+	busy := fmt.Errorf("daemon busy: %d requests in flight, try again later", s.maxInFlight)
+	if s.queueTimeout == 0 {
+		return busy
+	}
+	timer := time.NewTimer(s.queueTimeout)
+	defer timer.Stop()
+	select {
+	case s.storageSlots <- struct{}{}:
+		atomic.AddInt32(&s.inFlight, 1)
+		return nil
+	case <-timer.C:
+		return busy
+	case <-ctx.Done():
+		return busy
+	}
+}
+
+func (s *Server) releaseStorageSlot() {
+	atomic.AddInt32(&s.inFlight, -1)
+	<-s.storageSlots
+}
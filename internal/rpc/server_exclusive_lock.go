@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultExclusiveTTL = 5 * time.Minute
+	maxExclusiveTTL     = time.Hour
+)
+
+// exclusiveLease is the daemon's exclusive write lock. It lapses at expiresAt
+// so a holder that dies without releasing it can't block writers forever.
+type exclusiveLease struct {
+	holder    string
+	token     string
+	expiresAt time.Time
+}
+
+// activeLease returns the current lease, or nil if there is none or it has
+// expired. Callers must hold s.lockMu.
+func (s *Server) activeLease() *exclusiveLease {
+	if s.lease != nil && !time.Now().Before(s.lease.expiresAt) {
+		s.lease = nil
+	}
+	return s.lease
+}
+
+// ExclusiveLockHolder reports who holds the exclusive lock, if anyone
+func (s *Server) ExclusiveLockHolder() (string, bool) {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+	if lease := s.activeLease(); lease != nil {
+		return lease.holder, true
+	}
+	return "", false
+}
+
+// checkExclusiveLock rejects a write while another client holds the lock
+func (s *Server) checkExclusiveLock(req *Request) error {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+	lease := s.activeLease()
+	if lease == nil || req.LockToken == lease.token {
+		return nil
+	}
+	return fmt.Errorf("database is locked by %s (exclusive lock expires in %v)",
+		lease.holder, time.Until(lease.expiresAt).Round(time.Second))
+}
+
+func (s *Server) handleAcquireExclusive(req *Request) Response {
+	var args AcquireExclusiveArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid acquire_exclusive args: %v", err),
+		}
+	}
+	if args.Holder == "" {
+		return Response{Success: false, Error: "holder is required"}
+	}
+
+	ttl := defaultExclusiveTTL
+	if args.TTL != "" {
+		d, err := time.ParseDuration(args.TTL)
+		if err != nil || d <= 0 {
+			return Response{Success: false, Error: fmt.Sprintf("invalid ttl %q", args.TTL)}
+		}
+		ttl = d
+	}
+	if ttl > maxExclusiveTTL {
+		ttl = maxExclusiveTTL
+	}
+
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	lease := s.activeLease()
+	switch {
+	case lease == nil:
+		token, err := newLockToken()
+		if err != nil {
+			return Response{Success: false, Error: err.Error()}
+		}
+		lease = &exclusiveLease{token: token}
+		s.lease = lease
+	case req.LockToken != lease.token:
+		return Response{
+			Success: false,
+			Error: fmt.Sprintf("exclusive lock already held by %s (expires in %v)",
+				lease.holder, time.Until(lease.expiresAt).Round(time.Second)),
+		}
+	}
+	lease.holder = args.Holder
+	lease.expiresAt = time.Now().Add(ttl)
+
+	data, _ := json.Marshal(ExclusiveLockResponse{
+		Holder:    lease.holder,
+		Token:     lease.token,
+		ExpiresAt: lease.expiresAt,
+	})
+	return Response{Success: true, Data: data}
+}
+
+func (s *Server) handleReleaseExclusive(req *Request) Response {
+	var args ReleaseExclusiveArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid release_exclusive args: %v", err),
+		}
+	}
+
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	lease := s.activeLease()
+	if lease == nil {
+		return Response{Success: false, Error: "exclusive lock is not held"}
+	}
+	if args.Token != lease.token {
+		return Response{Success: false, Error: fmt.Sprintf("exclusive lock is held by %s", lease.holder)}
+	}
+	s.lease = nil
+	return Response{Success: true}
+}
+
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
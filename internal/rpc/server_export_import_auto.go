@@ -10,9 +10,11 @@ import (
 
 	"github.com/steveyegge/beads/internal/autoimport"
 	"github.com/steveyegge/beads/internal/importer"
+	"github.com/steveyegge/beads/internal/logging"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // handleExport handles the export operation
@@ -30,7 +32,7 @@ func (s *Server) handleExport(req *Request) Response {
 	ctx := s.reqCtx(req)
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return Response{
 			Success: false,
@@ -99,6 +101,7 @@ func (s *Server) handleExport(req *Request) Response {
 	encoder := json.NewEncoder(tempFile)
 	exportedIDs := make([]string, 0, len(issues))
 	for _, issue := range issues {
+		issue.SortForExport()
 		if err := encoder.Encode(issue); err != nil {
 			return Response{
 				Success: false,
@@ -109,26 +112,38 @@ func (s *Server) handleExport(req *Request) Response {
 	}
 
 	// Close temp file before rename
+	if err := utils.SyncFile(tempFile); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to sync temp file: %v", err),
+		}
+	}
 	_ = tempFile.Close()
 
 	// Atomic replace
-	if err := os.Rename(tempPath, exportArgs.JSONLPath); err != nil {
+	if err := utils.RenameWithRetry(tempPath, exportArgs.JSONLPath); err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to replace JSONL file: %v", err),
 		}
 	}
+	if err := utils.SyncDir(filepath.Dir(exportArgs.JSONLPath)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to sync directory: %v", err),
+		}
+	}
 
 	// Set appropriate file permissions (0600: rw-------)
 	if err := os.Chmod(exportArgs.JSONLPath, 0600); err != nil {
 		// Non-fatal, just log
-		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
+		logging.Warn("failed to set JSONL file permissions", "path", exportArgs.JSONLPath, "error", err)
 	}
 
 	// Clear dirty flags for exported issues
 	if err := store.ClearDirtyIssuesByID(ctx, exportedIDs); err != nil {
 		// Non-fatal, just log
-		fmt.Fprintf(os.Stderr, "Warning: failed to clear dirty flags: %v\n", err)
+		logging.Warn("failed to clear dirty flags", "error", err)
 	}
 
 	result := map[string]interface{}{
@@ -186,9 +201,7 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	// Single-flight guard: Only allow one import at a time
 	// If import is already running, skip and let the request proceed
 	if !s.importInProgress.CompareAndSwap(false, true) {
-		if os.Getenv("BD_DEBUG") != "" {
-			fmt.Fprintf(os.Stderr, "Debug: auto-import already in progress, skipping\n")
-		}
+		logging.Debug("auto-import already in progress, skipping")
 		return nil
 	}
 	defer s.importInProgress.Store(false)
@@ -199,9 +212,7 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 		return err
 	}
 	
-	if os.Getenv("BD_DEBUG") != "" {
-		fmt.Fprintf(os.Stderr, "Debug: daemon detected stale JSONL, auto-importing...\n")
-	}
+	logging.Debug("daemon detected stale JSONL, auto-importing", "db", dbPath)
 	
 	// Perform actual import
 	notify := autoimport.NewStderrNotifier(os.Getenv("BD_DEBUG") != "")
@@ -222,10 +233,12 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	onChanged := func(needsFullExport bool) {
 		// When IDs are remapped, trigger export so JSONL reflects the new IDs
 		if needsFullExport {
-			// Use a goroutine to avoid blocking the import
+			// Use a goroutine to avoid blocking the import; it outlives the
+			// request, so it must not inherit the request's timeout
+			exportCtx := context.WithoutCancel(ctx)
 			go func() {
-				if err := s.triggerExport(ctx, store, dbPath); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to export after auto-import: %v\n", err)
+				if err := s.triggerExport(exportCtx, store, dbPath); err != nil {
+					logging.Warn("failed to export after auto-import", "error", err)
 				}
 			}()
 		}
@@ -254,7 +267,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 	}
 
 	// Export to JSONL (this will update the file with remapped IDs)
-	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to fetch issues for export: %w", err)
 	}
@@ -270,6 +283,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 
 	encoder := json.NewEncoder(file)
 	for _, issue := range allIssues {
+		issue.SortForExport()
 		if err := encoder.Encode(issue); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
 		}
@@ -4,10 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
+// versionedIssue is an issue along with its version, which a client passes back
+// as UpdateArgs.ExpectedVersion to detect concurrent changes
+type versionedIssue struct {
+	*types.Issue
+	Version string `json:"version"`
+}
+
 // normalizeLabels trims whitespace, removes empty strings, and deduplicates labels
 func normalizeLabels(ss []string) []string {
 	seen := make(map[string]struct{})
@@ -33,7 +44,7 @@ func strValue(p *string) string {
 	return *p
 }
 
-func updatesFromArgs(a UpdateArgs) map[string]interface{} {
+func updatesFromArgs(a UpdateArgs) (map[string]interface{}, error) {
 	u := map[string]interface{}{}
 	if a.Title != nil {
 		u["title"] = *a.Title
@@ -59,7 +70,23 @@ func updatesFromArgs(a UpdateArgs) map[string]interface{} {
 	if a.Assignee != nil {
 		u["assignee"] = a.Assignee
 	}
-	return u
+	if a.DueDate != nil {
+		if *a.DueDate == "" {
+			u["due_date"] = nil
+		} else {
+			t, err := time.Parse(time.RFC3339, *a.DueDate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid due_date %q (expected RFC 3339)", *a.DueDate)
+			}
+			u["due_date"] = t
+		}
+	}
+	if a.ClearRank {
+		u["rank"] = nil
+	} else if a.Rank != nil {
+		u["rank"] = *a.Rank
+	}
+	return u, nil
 }
 
 func (s *Server) handleCreate(req *Request) Response {
@@ -94,6 +121,7 @@ func (s *Server) handleCreate(req *Request) Response {
 		AcceptanceCriteria: strValue(acceptance),
 		Assignee:           strValue(assignee),
 		Status:             types.StatusOpen,
+		DueDate:            createArgs.DueDate,
 	}
 
 	ctx := s.reqCtx(req)
@@ -178,10 +206,26 @@ func (s *Server) handleUpdate(req *Request) Response {
 	store := s.storage
 
 	ctx := s.reqCtx(req)
-	updates := updatesFromArgs(updateArgs)
+	updates, err := updatesFromArgs(updateArgs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
 	if len(updates) == 0 {
 		return Response{Success: true}
 	}
+	if updateArgs.Force {
+		ctx = workflow.WithForce(ctx)
+	}
+	if updateArgs.ExpectedVersion != "" {
+		ctx = storage.WithExpectedVersion(ctx, updateArgs.ExpectedVersion)
+	}
+	var dryRun *storage.DryRun
+	if updateArgs.DryRun {
+		ctx, dryRun = storage.WithDryRun(ctx)
+	}
 
 	if err := store.UpdateIssue(ctx, updateArgs.ID, updates, s.reqActor(req)); err != nil {
 		return Response{
@@ -189,6 +233,10 @@ func (s *Server) handleUpdate(req *Request) Response {
 			Error:   fmt.Sprintf("failed to update issue: %v", err),
 		}
 	}
+	if dryRun != nil {
+		data, _ := json.Marshal(dryRun)
+		return Response{Success: true, Data: data}
+	}
 
 	issue, err := store.GetIssue(ctx, updateArgs.ID)
 	if err != nil {
@@ -198,7 +246,51 @@ func (s *Server) handleUpdate(req *Request) Response {
 		}
 	}
 
-	data, _ := json.Marshal(issue)
+	data, _ := json.Marshal(versionedIssue{Issue: issue, Version: issue.Version()})
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handlePatch(req *Request) Response {
+	var patchArgs PatchArgs
+	if err := json.Unmarshal(req.Args, &patchArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid patch args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	if patchArgs.ExpectedVersion != "" {
+		ctx = storage.WithExpectedVersion(ctx, patchArgs.ExpectedVersion)
+	}
+
+	if err := storage.ApplyPatch(ctx, store, patchArgs.ID, patchArgs.Patch, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to patch issue: %v", err),
+		}
+	}
+
+	issue, err := store.GetIssue(ctx, patchArgs.ID)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get patched issue: %v", err),
+		}
+	}
+	if issue == nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("issue %s not found", patchArgs.ID),
+		}
+	}
+
+	data, _ := json.Marshal(versionedIssue{Issue: issue, Version: issue.Version()})
 	return Response{
 		Success: true,
 		Data:    data,
@@ -217,6 +309,9 @@ func (s *Server) handleClose(req *Request) Response {
 	store := s.storage
 
 	ctx := s.reqCtx(req)
+	if closeArgs.Force {
+		ctx = workflow.WithForce(ctx)
+	}
 	if err := store.CloseIssue(ctx, closeArgs.ID, closeArgs.Reason, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
@@ -232,6 +327,144 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 }
 
+func (s *Server) handleReopen(req *Request) Response {
+	var reopenArgs ReopenArgs
+	if err := json.Unmarshal(req.Args, &reopenArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid reopen args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	if reopenArgs.Force {
+		ctx = workflow.WithForce(ctx)
+	}
+	if err := store.ReopenIssue(ctx, reopenArgs.ID, reopenArgs.Reason, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to reopen issue: %v", err),
+		}
+	}
+
+	issue, _ := store.GetIssue(ctx, reopenArgs.ID)
+	data, _ := json.Marshal(issue)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// defaultClaimLease is how long a claim lasts when the client names no lease
+const defaultClaimLease = 30 * time.Minute
+
+func (s *Server) handleClaim(req *Request) Response {
+	var claimArgs ClaimArgs
+	if err := json.Unmarshal(req.Args, &claimArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid claim args: %v", err),
+		}
+	}
+
+	lease := defaultClaimLease
+	if claimArgs.Lease != "" {
+		var err error
+		if lease, err = time.ParseDuration(claimArgs.Lease); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid lease %q: %v", claimArgs.Lease, err),
+			}
+		}
+	}
+	agent := claimArgs.Agent
+	if agent == "" {
+		agent = s.reqActor(req)
+	}
+
+	store := s.storage
+	ctx := s.reqCtx(req)
+	claimed, err := store.ClaimIssue(ctx, claimArgs.ID, agent, lease)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to claim issue: %v", err),
+		}
+	}
+
+	result := ClaimResponse{Claimed: claimed}
+	result.Issue, _ = store.GetIssue(ctx, claimArgs.ID)
+	if claimed {
+		expiresAt := time.Now().Add(lease)
+		result.ExpiresAt = &expiresAt
+	}
+	data, _ := json.Marshal(result)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleRelease(req *Request) Response {
+	var releaseArgs ReleaseArgs
+	if err := json.Unmarshal(req.Args, &releaseArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid release args: %v", err),
+		}
+	}
+
+	agent := releaseArgs.Agent
+	if agent == "" {
+		agent = s.reqActor(req)
+	}
+
+	store := s.storage
+	ctx := s.reqCtx(req)
+	if err := store.ReleaseIssue(ctx, releaseArgs.ID, agent); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to release issue: %v", err),
+		}
+	}
+
+	issue, _ := store.GetIssue(ctx, releaseArgs.ID)
+	data, _ := json.Marshal(issue)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleAppendNotes(req *Request) Response {
+	var appendArgs AppendNotesArgs
+	if err := json.Unmarshal(req.Args, &appendArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid append notes args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	if err := store.AppendNotes(ctx, appendArgs.ID, appendArgs.Text, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to append notes: %v", err),
+		}
+	}
+
+	issue, _ := store.GetIssue(ctx, appendArgs.ID)
+	data, _ := json.Marshal(issue)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleList(req *Request) Response {
 	var listArgs ListArgs
 	if err := json.Unmarshal(req.Args, &listArgs); err != nil {
@@ -244,7 +477,9 @@ func (s *Server) handleList(req *Request) Response {
 	store := s.storage
 
 	filter := types.IssueFilter{
-		Limit: listArgs.Limit,
+		Limit:           listArgs.Limit,
+		IncludeArchived: listArgs.IncludeArchived,
+		SortByRelevance: listArgs.SortByRelevance,
 	}
 	if listArgs.Status != "" {
 		status := types.Status(listArgs.Status)
@@ -257,6 +492,10 @@ func (s *Server) handleList(req *Request) Response {
 	if listArgs.Assignee != "" {
 		filter.Assignee = &listArgs.Assignee
 	}
+	if listArgs.Reporter != "" {
+		filter.Reporter = &listArgs.Reporter
+	}
+	filter.DueBefore = listArgs.DueBefore
 	if listArgs.Priority != nil {
 		filter.Priority = listArgs.Priority
 	}
@@ -288,6 +527,21 @@ func (s *Server) handleList(req *Request) Response {
 		}
 	}
 
+	if listArgs.Search != "" {
+		actor := listArgs.Actor
+		if actor == "" {
+			actor = s.reqActor(req)
+		}
+		expr, err := query.ParseAs(listArgs.Search, actor)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid search query: %v", err),
+			}
+		}
+		query.Apply(expr, &filter)
+	}
+
 	ctx := s.reqCtx(req)
 	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
 	if err != nil {
@@ -338,6 +592,7 @@ func (s *Server) handleShow(req *Request) Response {
 	// Create detailed response with related data
 	type IssueDetails struct {
 		*types.Issue
+		Version      string         `json:"version"`
 		Labels       []string       `json:"labels,omitempty"`
 		Dependencies []*types.Issue `json:"dependencies,omitempty"`
 		Dependents   []*types.Issue `json:"dependents,omitempty"`
@@ -345,6 +600,7 @@ func (s *Server) handleShow(req *Request) Response {
 
 	details := &IssueDetails{
 		Issue:        issue,
+		Version:      issue.Version(),
 		Labels:       labels,
 		Dependencies: deps,
 		Dependents:   dependents,
@@ -394,6 +650,33 @@ func (s *Server) handleReady(req *Request) Response {
 	}
 }
 
+func (s *Server) handleUnblocked(req *Request) Response {
+	var unblockedArgs UnblockedArgs
+	if err := json.Unmarshal(req.Args, &unblockedArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid unblocked args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	issues, err := store.GetNewlyUnblocked(ctx, unblockedArgs.ClosedID)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get unblocked issues: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(issues)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleStats(req *Request) Response {
 	store := s.storage
 
@@ -27,12 +27,20 @@ func (s *Server) handleDepAdd(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
+	var dryRun *storage.DryRun
+	if depArgs.DryRun {
+		ctx, dryRun = storage.WithDryRun(ctx)
+	}
 	if err := store.AddDependency(ctx, dep, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to add dependency: %v", err),
 		}
 	}
+	if dryRun != nil {
+		data, _ := json.Marshal(dryRun)
+		return Response{Success: true, Data: data}
+	}
 
 	return Response{Success: true}
 }
@@ -81,6 +89,20 @@ func (s *Server) handleLabelRemove(req *Request) Response {
 	})
 }
 
+func (s *Server) handleWatch(req *Request) Response {
+	var watchArgs WatchArgs
+	return s.handleSimpleStoreOp(req, &watchArgs, "watch", func(ctx context.Context, store storage.Storage, actor string) error {
+		return store.AddWatcher(ctx, watchArgs.ID, watchArgs.Watcher, actor)
+	})
+}
+
+func (s *Server) handleUnwatch(req *Request) Response {
+	var watchArgs WatchArgs
+	return s.handleSimpleStoreOp(req, &watchArgs, "unwatch", func(ctx context.Context, store storage.Storage, actor string) error {
+		return store.RemoveWatcher(ctx, watchArgs.ID, watchArgs.Watcher, actor)
+	})
+}
+
 func (s *Server) handleCommentList(req *Request) Response {
 	var commentArgs CommentListArgs
 	if err := json.Unmarshal(req.Args, &commentArgs); err != nil {
@@ -154,6 +176,8 @@ func (s *Server) handleBatch(req *Request) Response {
 			RequestID:     req.RequestID,
 			Cwd:           req.Cwd,           // Pass through context
 			ClientVersion: req.ClientVersion, // Pass through version for compatibility checks
+			LockToken:     req.LockToken,
+			ctx:           req.ctx, // Share the batch's deadline and storage slot
 		}
 
 		resp := s.handleRequest(subReq)
@@ -3,6 +3,7 @@ package rpc
 import (
 	"bufio"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -12,6 +13,8 @@ import (
 	"runtime"
 	"sync/atomic"
 	"time"
+
+	"github.com/steveyegge/beads/internal/logging"
 )
 
 // Start starts the RPC server and listens for connections
@@ -69,22 +72,28 @@ func (s *Server) Start(_ context.Context) error {
 			return fmt.Errorf("failed to accept connection: %w", err)
 		}
 
-		// Try to acquire connection slot (non-blocking)
-		select {
-		case s.connSemaphore <- struct{}{}:
-			// Acquired slot, handle connection
-			s.metrics.RecordConnection()
-			go func(c net.Conn) {
-				defer func() { <-s.connSemaphore }() // Release slot
-				atomic.AddInt32(&s.activeConns, 1)
-				defer atomic.AddInt32(&s.activeConns, -1)
-				s.handleConnection(c)
-			}(conn)
-		default:
-			// Max connections reached, reject immediately
-			s.metrics.RecordRejectedConnection()
-			_ = conn.Close()
-		}
+		s.dispatchConn(conn, "")
+	}
+}
+
+// dispatchConn serves conn in its own goroutine if a connection slot is free,
+// and closes it otherwise. A non-empty authToken must accompany every request.
+func (s *Server) dispatchConn(conn net.Conn, authToken string) {
+	// Try to acquire connection slot (non-blocking)
+	select {
+	case s.connSemaphore <- struct{}{}:
+		// Acquired slot, handle connection
+		s.metrics.RecordConnection()
+		go func(c net.Conn) {
+			defer func() { <-s.connSemaphore }() // Release slot
+			atomic.AddInt32(&s.activeConns, 1)
+			defer atomic.AddInt32(&s.activeConns, -1)
+			s.handleConnection(c, authToken)
+		}(conn)
+	default:
+		// Max connections reached, reject immediately
+		s.metrics.RecordRejectedConnection()
+		_ = conn.Close()
 	}
 }
 
@@ -107,16 +116,22 @@ func (s *Server) Stop() error {
 		// Close storage
 		if s.storage != nil {
 			if closeErr := s.storage.Close(); closeErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to close default storage: %v\n", closeErr)
+				logging.Warn("failed to close default storage", "error", closeErr)
 			}
 		}
 
-		// Close listener under lock
+		// Close listeners under lock
 		s.mu.Lock()
 		listener := s.listener
 		s.listener = nil
+		tcpListener := s.tcpListener
+		s.tcpListener = nil
 		s.mu.Unlock()
 
+		if tcpListener != nil {
+			_ = tcpListener.Close()
+		}
+
 		if listener != nil {
 			if closeErr := listener.Close(); closeErr != nil {
 				err = fmt.Errorf("failed to close listener: %w", closeErr)
@@ -176,7 +191,7 @@ func (s *Server) handleSignals() {
 	_ = s.Stop()
 }
 
-func (s *Server) handleConnection(conn net.Conn) {
+func (s *Server) handleConnection(conn net.Conn, authToken string) {
 	defer func() { _ = conn.Close() }()
 
 	reader := bufio.NewReader(conn)
@@ -203,12 +218,24 @@ func (s *Server) handleConnection(conn net.Conn) {
 			continue
 		}
 
-		// Set write deadline for the response
-		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
-			return
+		if authToken != "" {
+			if subtle.ConstantTimeCompare([]byte(req.AuthToken), []byte(authToken)) != 1 {
+				s.writeResponse(writer, Response{
+					Success: false,
+					Error:   "unauthorized: missing or invalid auth token",
+				})
+				return
+			}
+			req.remote = true
 		}
 
 		resp := s.handleRequest(&req)
+
+		// Set write deadline for the response, after handling so a request
+		// that hit its timeout can still report it
+		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+			return
+		}
 		s.writeResponse(writer, resp)
 	}
 }
@@ -220,12 +247,39 @@ func (s *Server) writeResponse(writer *bufio.Writer, resp Response) {
 	_ = writer.Flush()
 }
 
+func (s *Server) handleFlush(_ *Request) Response {
+	flush := s.getFlushHook()
+	if flush == nil {
+		return Response{
+			Success: false,
+			Error:   "flush is not supported by this daemon",
+		}
+	}
+	if err := flush(); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("flush failed: %v", err),
+		}
+	}
+	return Response{
+		Success: true,
+		Data:    json.RawMessage(`{"message":"Flushed to JSONL"}`),
+	}
+}
+
 func (s *Server) handleShutdown(_ *Request) Response {
+	// Write pending changes now: Stop closes the store
+	if flush := s.getFlushHook(); flush != nil {
+		if err := flush(); err != nil {
+			logging.Warn("flush before shutdown failed", "error", err)
+		}
+	}
+
 	// Schedule shutdown in a goroutine so we can return a response first
 	go func() {
 		time.Sleep(100 * time.Millisecond) // Give time for response to be sent
 		if err := s.Stop(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error during shutdown: %v\n", err)
+			logging.Error("shutdown failed", "error", err)
 		}
 	}()
 
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/steveyegge/beads/internal/logging"
 	"github.com/steveyegge/beads/internal/types"
 	"golang.org/x/mod/semver"
 )
@@ -71,10 +73,15 @@ func (s *Server) checkVersionCompatibility(clientVersion string) error {
 // validateDatabaseBinding validates that the client is connecting to the correct daemon
 // Returns error if ExpectedDB is set and doesn't match the daemon's database path
 func (s *Server) validateDatabaseBinding(req *Request) error {
+	// Remote clients have no local path for the daemon's database
+	if req.remote && req.ExpectedDB == "" {
+		return nil
+	}
+
 	// If client doesn't specify ExpectedDB, allow but log warning (old clients)
 	if req.ExpectedDB == "" {
 		// Log warning for audit trail
-		fmt.Fprintf(os.Stderr, "Warning: Client request without database binding validation (old client or missing ExpectedDB)\n")
+		logging.Warn("client request without database binding validation (old client or missing ExpectedDB)", "operation", req.Operation)
 		return nil
 	}
 
@@ -112,8 +119,22 @@ func (s *Server) handleRequest(req *Request) Response {
 	defer func() {
 		latency := time.Since(start)
 		s.metrics.RecordRequest(req.Operation, latency)
+		if s.slowRequestThreshold > 0 && latency >= s.slowRequestThreshold {
+			logging.Warn("slow request", "operation", req.Operation, "duration", latency)
+		}
 	}()
 
+	// Storage calls made for this request are canceled once the timeout passes.
+	// Batch sub-requests arrive with their parent's context and run under the
+	// parent's deadline and storage slot.
+	nested := req.ctx != nil
+	if !nested {
+		ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout)
+		defer cancel()
+		req.ctx = ctx
+	}
+	ctx := req.ctx
+
 	// Validate database binding (skip for health/metrics to allow diagnostics)
 	if req.Operation != OpHealth && req.Operation != OpMetrics {
 		if err := s.validateDatabaseBinding(req); err != nil {
@@ -136,6 +157,30 @@ func (s *Server) handleRequest(req *Request) Response {
 		}
 	}
 
+	// Limit concurrent storage work. Diagnostics and shutdown bypass the
+	// limit so a saturated daemon can still report on itself and stop.
+	if !nested && !bypassesStorageLimit(req.Operation) {
+		if err := s.acquireStorageSlot(ctx); err != nil {
+			s.metrics.RecordError(req.Operation)
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+		defer s.releaseStorageSlot()
+	}
+
+	// Only the exclusive lock holder may write while the lock is held
+	if isMutatingOperation(req.Operation) {
+		if err := s.checkExclusiveLock(req); err != nil {
+			s.metrics.RecordError(req.Operation)
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+	}
+
 	// Check for stale JSONL and auto-import if needed (bd-160)
 	// Skip for write operations that will trigger export anyway
 	// Skip for import operation itself to avoid recursion
@@ -143,7 +188,7 @@ func (s *Server) handleRequest(req *Request) Response {
 	   req.Operation != OpImport && req.Operation != OpExport {
 		if err := s.checkAndAutoImportIfStale(req); err != nil {
 			// Log warning but continue - don't fail the request
-			fmt.Fprintf(os.Stderr, "Warning: staleness check failed: %v\n", err)
+			logging.Warn("staleness check failed", "error", err)
 		}
 	}
 
@@ -164,14 +209,26 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleCreate(req)
 	case OpUpdate:
 		resp = s.handleUpdate(req)
+	case OpPatch:
+		resp = s.handlePatch(req)
 	case OpClose:
 		resp = s.handleClose(req)
+	case OpReopen:
+		resp = s.handleReopen(req)
+	case OpAppendNotes:
+		resp = s.handleAppendNotes(req)
+	case OpClaim:
+		resp = s.handleClaim(req)
+	case OpRelease:
+		resp = s.handleRelease(req)
 	case OpList:
 		resp = s.handleList(req)
 	case OpShow:
 		resp = s.handleShow(req)
 	case OpReady:
 		resp = s.handleReady(req)
+	case OpUnblocked:
+		resp = s.handleUnblocked(req)
 	case OpStats:
 		resp = s.handleStats(req)
 	case OpDepAdd:
@@ -182,6 +239,10 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleLabelAdd(req)
 	case OpLabelRemove:
 		resp = s.handleLabelRemove(req)
+	case OpWatch:
+		resp = s.handleWatch(req)
+	case OpUnwatch:
+		resp = s.handleUnwatch(req)
 	case OpCommentList:
 		resp = s.handleCommentList(req)
 	case OpCommentAdd:
@@ -201,6 +262,12 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleEpicStatus(req)
 	case OpShutdown:
 		resp = s.handleShutdown(req)
+	case OpFlush:
+		resp = s.handleFlush(req)
+	case OpAcquireExclusive:
+		resp = s.handleAcquireExclusive(req)
+	case OpReleaseExclusive:
+		resp = s.handleReleaseExclusive(req)
 	default:
 		s.metrics.RecordError(req.Operation)
 		return Response{
@@ -209,16 +276,79 @@ func (s *Server) handleRequest(req *Request) Response {
 		}
 	}
 
+	// A handler that failed because its context expired reports the timeout
+	if !resp.Success && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		resp.Error = fmt.Sprintf("request timed out after %v", s.requestTimeout)
+	}
+
 	// Record error if request failed
 	if !resp.Success {
 		s.metrics.RecordError(req.Operation)
+	} else if isMutatingOperation(req.Operation) {
+		s.notifyMutation()
 	}
 
 	return resp
 }
 
+// bypassesStorageLimit reports whether op runs without waiting for a storage
+// slot: diagnostics, which only report on the daemon, and shutdown
+func bypassesStorageLimit(op string) bool {
+	switch op {
+	case OpPing, OpStatus, OpHealth, OpMetrics, OpShutdown:
+		return true
+	}
+	return false
+}
+
+// isMutatingOperation reports whether op can change issue data that needs
+// to be flushed to JSONL.
+func isMutatingOperation(op string) bool {
+	switch op {
+	case OpCreate, OpUpdate, OpPatch, OpClose, OpReopen, OpAppendNotes, OpClaim, OpRelease,
+		OpDepAdd, OpDepRemove, OpLabelAdd, OpLabelRemove, OpWatch, OpUnwatch, OpCommentAdd,
+		OpBatch, OpCompact, OpImport:
+		return true
+	}
+	return false
+}
+
+// SetMutationHook registers fn to be called after every successful mutating
+// request. The daemon uses this to schedule a debounced JSONL flush.
+func (s *Server) SetMutationHook(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mutationHook = fn
+}
+
+// SetFlushHook registers fn as the daemon's flush-now action, used by the
+// flush operation and before shutdown.
+func (s *Server) SetFlushHook(fn func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushHook = fn
+}
+
+func (s *Server) getFlushHook() func() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flushHook
+}
+
+func (s *Server) notifyMutation() {
+	s.mu.RLock()
+	fn := s.mutationHook
+	s.mu.RUnlock()
+	if fn != nil {
+		fn()
+	}
+}
+
 // Adapter helpers
-func (s *Server) reqCtx(_ *Request) context.Context {
+func (s *Server) reqCtx(req *Request) context.Context {
+	if req != nil && req.ctx != nil {
+		return req.ctx
+	}
 	return context.Background()
 }
 
@@ -242,22 +372,30 @@ func (s *Server) handlePing(_ *Request) Response {
 	}
 }
 
-func (s *Server) handleStatus(_ *Request) Response {
+func (s *Server) handleStatus(req *Request) Response {
 	// Get last activity timestamp
 	lastActivity := s.lastActivityTime.Load().(time.Time)
 	
-	// Check for exclusive lock
-	lockActive := false
-	lockHolder := ""
-	if s.workspacePath != "" {
+	// Check for exclusive lock: one taken over RPC, or a lock file left by
+	// an external tool
+	lockHolder, lockActive := s.ExclusiveLockHolder()
+	if !lockActive && s.workspacePath != "" {
 		if skip, holder, _ := types.ShouldSkipDatabase(s.workspacePath); skip {
 			lockActive = true
 			lockHolder = holder
 		}
 	}
 	
+	// The database records the bd version that last opened it
+	dbVersion := ""
+	if s.storage != nil {
+		dbVersion, _ = s.storage.GetMetadata(s.reqCtx(req), "bd_version")
+	}
+
 	statusResp := StatusResponse{
 		Version:             ServerVersion,
+		DatabaseVersion:     dbVersion,
+		ClientVersion:       req.ClientVersion,
 		WorkspacePath:       s.workspacePath,
 		DatabasePath:        s.dbPath,
 		SocketPath:          s.socketPath,
@@ -266,6 +404,8 @@ func (s *Server) handleStatus(_ *Request) Response {
 		LastActivityTime:    lastActivity.Format(time.RFC3339),
 		ExclusiveLockActive: lockActive,
 		ExclusiveLockHolder: lockHolder,
+		InFlightRequests:    atomic.LoadInt32(&s.inFlight),
+		MaxInFlight:         s.maxInFlight,
 	}
 	
 	data, _ := json.Marshal(statusResp)
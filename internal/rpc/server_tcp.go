@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/logging"
+)
+
+// ServeTCP accepts RPC connections on addr in addition to the Unix socket, so
+// remote clients can reach the daemon. Every request over TCP must carry
+// token. It returns the address actually bound, which matters for port 0.
+//
+// With tlsConfig nil, connections are plain TCP and the token crosses the
+// wire in cleartext, where anyone who captures it can run any operation,
+// including export, import, and shutdown. Plain TCP is therefore only
+// allowed on loopback addresses (e.g. behind an SSH tunnel).
+func (s *Server) ServeTCP(addr, token string, tlsConfig *tls.Config) (net.Addr, error) {
+	if token == "" {
+		return nil, errors.New("an auth token is required to listen on TCP")
+	}
+	if tlsConfig == nil && !IsLoopbackAddr(addr) {
+		return nil, fmt.Errorf("refusing to serve RPC on %s without TLS: the auth token would be sent in cleartext (use a loopback address or configure a certificate)", addr)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
+	s.mu.Lock()
+	if s.shutdown || s.tcpListener != nil {
+		s.mu.Unlock()
+		_ = ln.Close()
+		return nil, errors.New("server is stopped or already listening on TCP")
+	}
+	s.tcpListener = ln
+	s.mu.Unlock()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				s.mu.RLock()
+				shutdown := s.shutdown
+				s.mu.RUnlock()
+				if !shutdown {
+					logging.Error("TCP listener failed", "addr", ln.Addr().String(), "error", err)
+				}
+				return
+			}
+			s.dispatchConn(conn, token)
+		}
+	}()
+	return ln.Addr(), nil
+}
+
+// IsLoopbackAddr reports whether the host of a host:port address is
+// localhost or a loopback IP. An empty host means every interface, so it
+// is not loopback; neither is any other hostname, which may resolve anywhere.
+func IsLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -84,3 +84,26 @@ func TestStatusEndpoint(t *testing.T) {
 		t.Errorf("last activity time too old: %v", lastActivity)
 	}
 }
+
+func TestStatusReportsVersions(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if err := server.storage.SetMetadata(context.Background(), "bd_version", "0.17.7"); err != nil {
+		t.Fatalf("SetMetadata failed: %v", err)
+	}
+
+	status, err := client.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Version != ServerVersion {
+		t.Errorf("Version = %q, want %q", status.Version, ServerVersion)
+	}
+	if status.DatabaseVersion != "0.17.7" {
+		t.Errorf("DatabaseVersion = %q, want 0.17.7", status.DatabaseVersion)
+	}
+	if status.ClientVersion != ClientVersion {
+		t.Errorf("ClientVersion = %q, want %q", status.ClientVersion, ClientVersion)
+	}
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeTCPWithToken(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	if _, err := server.ServeTCP("127.0.0.1:0", "", nil); err == nil {
+		t.Fatal("ServeTCP without a token should fail")
+	}
+	addr, err := server.ServeTCP("127.0.0.1:0", "s3cret", nil)
+	if err != nil {
+		t.Fatalf("ServeTCP failed: %v", err)
+	}
+
+	client, err := ConnectTCP(addr.String(), "s3cret", time.Second, nil)
+	if err != nil {
+		t.Fatalf("ConnectTCP failed: %v", err)
+	}
+	defer client.Close()
+
+	resp, err := client.Create(&CreateArgs{Title: "Over the wire", IssueType: "task", Priority: 1})
+	if err != nil {
+		t.Fatalf("Create over TCP failed: %v", err)
+	}
+	var created struct{ ID string }
+	if err := json.Unmarshal(resp.Data, &created); err != nil {
+		t.Fatalf("failed to decode issue: %v", err)
+	}
+
+	resp, err = client.Show(&ShowArgs{ID: created.ID})
+	if err != nil {
+		t.Fatalf("Show over TCP failed: %v", err)
+	}
+	if !strings.Contains(string(resp.Data), "Over the wire") {
+		t.Errorf("Show returned %s", resp.Data)
+	}
+
+	if _, err := ConnectTCP(addr.String(), "wrong", time.Second, nil); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("wrong token error = %v, want unauthorized", err)
+	}
+	if _, err := ConnectTCP(addr.String(), "", time.Second, nil); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("missing token error = %v, want unauthorized", err)
+	}
+}
+
+func TestServeTCPRefusesCleartextOffLoopback(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, addr := range []string{":0", "0.0.0.0:0", "example.com:0"} {
+		if _, err := server.ServeTCP(addr, "s3cret", nil); err == nil || !strings.Contains(err.Error(), "without TLS") {
+			t.Errorf("ServeTCP(%q) without TLS error = %v, want refusal", addr, err)
+		}
+	}
+}
+
+func TestServeTCPWithTLS(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	cert, roots := selfSignedCert(t)
+	addr, err := server.ServeTCP("127.0.0.1:0", "s3cret", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatalf("ServeTCP with TLS failed: %v", err)
+	}
+
+	client, err := ConnectTCP(addr.String(), "s3cret", time.Second, &tls.Config{RootCAs: roots})
+	if err != nil {
+		t.Fatalf("ConnectTCP over TLS failed: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.Create(&CreateArgs{Title: "Encrypted", IssueType: "task", Priority: 1}); err != nil {
+		t.Fatalf("Create over TLS failed: %v", err)
+	}
+
+	// A client that doesn't trust the certificate can't connect
+	if _, err := ConnectTCP(addr.String(), "s3cret", time.Second, &tls.Config{}); err == nil {
+		t.Error("ConnectTCP with an untrusted certificate should fail")
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:7070": true,
+		"[::1]:7070":     true,
+		"localhost:7070": true,
+		":7070":          false,
+		"0.0.0.0:7070":   false,
+		"10.0.0.5:7070":  false,
+		"example.com:80": false,
+		"127.0.0.1":      false, // no port
+	}
+	for addr, want := range tests {
+		if got := IsLoopbackAddr(addr); got != want {
+			t.Errorf("IsLoopbackAddr(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+// selfSignedCert returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bd test daemon"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, roots
+}
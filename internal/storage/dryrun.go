@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DryRun receives what UpdateIssue or AddDependency would have done when
+// called with a context from WithDryRun. Nothing is written in a dry run.
+type DryRun struct {
+	// Issue is the issue as UpdateIssue would have saved it
+	Issue *types.Issue `json:"issue,omitempty"`
+	// Changes lists the fields UpdateIssue would have changed
+	Changes []FieldChange `json:"changes,omitempty"`
+	// Cycle reports that AddDependency would have completed a dependency cycle
+	Cycle bool `json:"cycle"`
+}
+
+// FieldChange is one field of an issue changed by an update, named as in the
+// issue's JSON
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context under which UpdateIssue and AddDependency
+// validate their input and fill in the returned DryRun instead of writing
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	result := &DryRun{}
+	return context.WithValue(ctx, dryRunKey{}, result), result
+}
+
+// DryRunFrom returns the DryRun to fill in, or nil if ctx is not a dry run
+func DryRunFrom(ctx context.Context) *DryRun {
+	result, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return result
+}
+
+// Preview records in d the result of applying updates to issue, which is left
+// untouched
+func (d *DryRun) Preview(issue *types.Issue, updates map[string]interface{}) {
+	updated := *issue
+	ApplyUpdates(&updated, updates, time.Now())
+	d.Issue = &updated
+	d.Changes = diffIssues(issue, &updated)
+}
+
+// ApplyUpdates applies an UpdateIssue updates map to issue in memory, setting
+// UpdatedAt to now and keeping ClosedAt in step with the status
+func ApplyUpdates(issue *types.Issue, updates map[string]interface{}, now time.Time) {
+	issue.UpdatedAt = now
+
+	for key, value := range updates {
+		switch key {
+		case "title":
+			if v, ok := stringValue(value); ok {
+				issue.Title = v
+			}
+		case "description":
+			if v, ok := stringValue(value); ok {
+				issue.Description = v
+			}
+		case "design":
+			if v, ok := stringValue(value); ok {
+				issue.Design = v
+			}
+		case "acceptance_criteria":
+			if v, ok := stringValue(value); ok {
+				issue.AcceptanceCriteria = v
+			}
+		case "notes":
+			if v, ok := stringValue(value); ok {
+				issue.Notes = v
+			}
+		case "status":
+			var status types.Status
+			switch v := value.(type) {
+			case string:
+				status = types.Status(v)
+			case types.Status:
+				status = v
+			default:
+				continue
+			}
+			oldStatus := issue.Status
+			issue.Status = status
+
+			// Manage closed_at
+			if status == types.StatusClosed && oldStatus != types.StatusClosed {
+				closedAt := now
+				issue.ClosedAt = &closedAt
+			} else if status != types.StatusClosed && oldStatus == types.StatusClosed {
+				issue.ClosedAt = nil
+			}
+		case "priority":
+			if v, ok := value.(int); ok {
+				issue.Priority = v
+			}
+		case "issue_type":
+			if v, ok := value.(string); ok {
+				issue.IssueType = types.IssueType(v)
+			}
+		case "assignee":
+			if v, ok := stringValue(value); ok {
+				issue.Assignee = v
+			} else if value == nil {
+				issue.Assignee = ""
+			}
+		case "external_ref":
+			var oldRef string
+			if issue.ExternalRef != nil {
+				oldRef = *issue.ExternalRef
+			}
+			if v, ok := stringValue(value); ok {
+				issue.ExternalRef = &v
+				issue.ExternalRefs = issue.ExternalRefs.ReplaceLegacy(oldRef, v)
+			} else if value == nil {
+				issue.ExternalRef = nil
+				issue.ExternalRefs = issue.ExternalRefs.ReplaceLegacy(oldRef, "")
+			}
+		case "estimated_minutes":
+			switch v := value.(type) {
+			case int:
+				issue.EstimatedMinutes = &v
+			case nil:
+				issue.EstimatedMinutes = nil
+			}
+		case "due_date":
+			switch v := value.(type) {
+			case time.Time:
+				issue.DueDate = &v
+			case *time.Time:
+				issue.DueDate = v
+			case nil:
+				issue.DueDate = nil
+			}
+		case "rank":
+			switch v := value.(type) {
+			case float64:
+				issue.Rank = &v
+			case *float64:
+				issue.Rank = v
+			case nil:
+				issue.Rank = nil
+			}
+		case "archived_at":
+			switch v := value.(type) {
+			case time.Time:
+				issue.ArchivedAt = &v
+			case *time.Time:
+				issue.ArchivedAt = v
+			case nil:
+				issue.ArchivedAt = nil
+			}
+		}
+	}
+}
+
+// stringValue accepts a string or a non-nil *string, as the RPC server passes
+// some fields by pointer
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case *string:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return "", false
+}
+
+// diffIssues lists the JSON fields that differ between before and after,
+// other than updated_at, sorted by name
+func diffIssues(before, after *types.Issue) []FieldChange {
+	oldFields, newFields := issueFields(before), issueFields(after)
+
+	var changes []FieldChange
+	for field, newValue := range newFields {
+		if oldValue := oldFields[field]; !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	for field, oldValue := range oldFields {
+		if _, ok := newFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func issueFields(issue *types.Issue) map[string]interface{} {
+	fields := map[string]interface{}{}
+	data, _ := json.Marshal(issue)
+	_ = json.Unmarshal(data, &fields)
+	delete(fields, "updated_at")
+	return fields
+}
@@ -12,7 +12,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 // MemoryStorage implements the Storage interface using in-memory data structures
@@ -22,12 +24,14 @@ type MemoryStorage struct {
 	// Core data
 	issues       map[string]*types.Issue       // ID -> Issue
 	dependencies map[string][]*types.Dependency // IssueID -> Dependencies
+	dependents   map[string]map[string]bool     // DependsOnID -> IssueIDs (reverse of dependencies)
 	labels       map[string][]string           // IssueID -> Labels
 	events       map[string][]*types.Event     // IssueID -> Events
 	comments     map[string][]*types.Comment   // IssueID -> Comments
 	config       map[string]string             // Config key-value pairs
 	metadata     map[string]string             // Metadata key-value pairs
 	counters     map[string]int                // Prefix -> Last ID
+	claims       map[string]claim              // IssueID -> Claim
 
 	// For tracking
 	dirty map[string]bool // IssueIDs that have been modified
@@ -41,12 +45,14 @@ func New(jsonlPath string) *MemoryStorage {
 	return &MemoryStorage{
 		issues:       make(map[string]*types.Issue),
 		dependencies: make(map[string][]*types.Dependency),
+		dependents:   make(map[string]map[string]bool),
 		labels:       make(map[string][]string),
 		events:       make(map[string][]*types.Event),
 		comments:     make(map[string][]*types.Comment),
 		config:       make(map[string]string),
 		metadata:     make(map[string]string),
 		counters:     make(map[string]int),
+		claims:       make(map[string]claim),
 		dirty:        make(map[string]bool),
 		jsonlPath:    jsonlPath,
 	}
@@ -69,6 +75,9 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 		// Store dependencies
 		if len(issue.Dependencies) > 0 {
 			m.dependencies[issue.ID] = issue.Dependencies
+			for _, dep := range issue.Dependencies {
+				m.indexDependent(dep.DependsOnID, issue.ID)
+			}
 		}
 
 		// Store labels
@@ -81,6 +90,16 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 			m.comments[issue.ID] = issue.Comments
 		}
 
+		// Fold a legacy single external ref into the per-tracker map
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" && !issue.ExternalRefs.Contains(*issue.ExternalRef) {
+			tracker := types.LegacyExternalRefTracker(*issue.ExternalRef)
+			if _, taken := issue.ExternalRefs[tracker]; !taken {
+				refs := cloneTrackerRefs(issue.ExternalRefs)
+				refs[tracker] = *issue.ExternalRef
+				issue.ExternalRefs = refs
+			}
+		}
+
 		// Update counter based on issue ID
 		prefix, num := extractPrefixAndNumber(issue.ID)
 		if prefix != "" && num > 0 {
@@ -149,7 +168,11 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	defer m.mu.Unlock()
 
 	// Validate
-	if err := issue.Validate(); err != nil {
+	required, err := types.ParseRequiredFields(m.config[types.RequiredFieldsConfigKey])
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", types.RequiredFieldsConfigKey, err)
+	}
+	if err := issue.ValidateWith(required); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
@@ -158,6 +181,11 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	issue.CreatedAt = now
 	issue.UpdatedAt = now
 
+	// Record who created the issue
+	if issue.Reporter == "" {
+		issue.Reporter = actor
+	}
+
 	// Generate ID if not set
 	if issue.ID == "" {
 		prefix := m.config["issue_prefix"]
@@ -175,6 +203,11 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return fmt.Errorf("issue %s already exists", issue.ID)
 	}
 
+	// File the legacy single external ref under its tracker
+	if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+		issue.ExternalRefs = issue.ExternalRefs.ReplaceLegacy("", *issue.ExternalRef)
+	}
+
 	// Store issue
 	m.issues[issue.ID] = issue
 	m.dirty[issue.ID] = true
@@ -236,6 +269,9 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 
 	// Store all issues
 	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			issue.ExternalRefs = issue.ExternalRefs.ReplaceLegacy("", *issue.ExternalRef)
+		}
 		m.issues[issue.ID] = issue
 		m.dirty[issue.ID] = true
 
@@ -278,6 +314,51 @@ func (m *MemoryStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	return &issueCopy, nil
 }
 
+// GetIssues retrieves several issues by ID; IDs that don't exist are absent from the map
+func (m *MemoryStorage) GetIssues(ctx context.Context, ids []string) (map[string]*types.Issue, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string]*types.Issue, len(ids))
+	for _, id := range ids {
+		issue, exists := m.issues[id]
+		if !exists {
+			continue
+		}
+
+		// Return copies to avoid mutations
+		issueCopy := *issue
+		if deps, ok := m.dependencies[id]; ok {
+			issueCopy.Dependencies = deps
+		}
+		if labels, ok := m.labels[id]; ok {
+			issueCopy.Labels = labels
+		}
+		result[id] = &issueCopy
+	}
+	return result, nil
+}
+
+// IssueExists reports whether an issue with the given ID exists
+func (m *MemoryStorage) IssueExists(ctx context.Context, id string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, exists := m.issues[id]
+	return exists, nil
+}
+
+// statusValue reads a status update given as a string or types.Status
+func statusValue(value interface{}) (types.Status, bool) {
+	switch v := value.(type) {
+	case string:
+		return types.Status(v), true
+	case types.Status:
+		return v, true
+	}
+	return "", false
+}
+
 // UpdateIssue updates fields on an issue
 func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	m.mu.Lock()
@@ -287,76 +368,40 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if !exists {
 		return fmt.Errorf("issue %s not found", id)
 	}
+	if err := storage.CheckVersion(ctx, issue); err != nil {
+		return err
+	}
+
+	// Reject status changes the configured workflow doesn't allow
+	if status, ok := statusValue(updates["status"]); ok && !workflow.Forced(ctx) {
+		w, err := workflow.Parse(m.config[workflow.ConfigKey])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", workflow.ConfigKey, err)
+		}
+		if err := w.Check(issue.Status, status); err != nil {
+			return err
+		}
+	}
+
+	if dryRun := storage.DryRunFrom(ctx); dryRun != nil {
+		dryRun.Preview(issue, updates)
+		return nil
+	}
 
 	now := time.Now()
-	issue.UpdatedAt = now
+	storage.ApplyUpdates(issue, updates, now)
 
-	// Apply updates
-	for key, value := range updates {
-		switch key {
-		case "title":
-			if v, ok := value.(string); ok {
-				issue.Title = v
-			}
-		case "description":
-			if v, ok := value.(string); ok {
-				issue.Description = v
-			}
-		case "design":
-			if v, ok := value.(string); ok {
-				issue.Design = v
-			}
-		case "acceptance_criteria":
-			if v, ok := value.(string); ok {
-				issue.AcceptanceCriteria = v
-			}
-		case "notes":
-			if v, ok := value.(string); ok {
-				issue.Notes = v
-			}
-		case "status":
-			if v, ok := value.(string); ok {
-				oldStatus := issue.Status
-				issue.Status = types.Status(v)
-
-				// Manage closed_at
-				if issue.Status == types.StatusClosed && oldStatus != types.StatusClosed {
-					issue.ClosedAt = &now
-				} else if issue.Status != types.StatusClosed && oldStatus == types.StatusClosed {
-					issue.ClosedAt = nil
-				}
-			}
-		case "priority":
-			if v, ok := value.(int); ok {
-				issue.Priority = v
-			}
-		case "issue_type":
-			if v, ok := value.(string); ok {
-				issue.IssueType = types.IssueType(v)
-			}
-		case "assignee":
-			if v, ok := value.(string); ok {
-				issue.Assignee = v
-			} else if value == nil {
-				issue.Assignee = ""
-			}
-		case "external_ref":
-			if v, ok := value.(string); ok {
-				issue.ExternalRef = &v
-			} else if value == nil {
-				issue.ExternalRef = nil
-			}
-		}
+	// A closed issue can't be held by a claim
+	if issue.Status == types.StatusClosed {
+		delete(m.claims, id)
 	}
 
 	m.dirty[id] = true
 
 	// Record event
 	eventType := types.EventUpdated
-	if status, hasStatus := updates["status"]; hasStatus {
-		if status == string(types.StatusClosed) {
-			eventType = types.EventClosed
-		}
+	if status, ok := statusValue(updates["status"]); ok && status == types.StatusClosed {
+		eventType = types.EventClosed
 	}
 
 	event := &types.Event{
@@ -370,6 +415,289 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	return nil
 }
 
+// ReassignAll moves every unclosed issue assigned to from over to to.
+// Returns the number of issues changed.
+func (m *MemoryStorage) ReassignAll(ctx context.Context, from, to string, actor string) (int, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return 0, fmt.Errorf("assignee to reassign from cannot be empty")
+	}
+	if to != "" && strings.TrimSpace(to) == "" {
+		return 0, fmt.Errorf("assignee to reassign to cannot be blank (use an empty assignee to unassign)")
+	}
+	to = strings.TrimSpace(to)
+	if from == to {
+		return 0, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for id, issue := range m.issues {
+		if issue.Assignee != from || issue.Status == types.StatusClosed {
+			continue
+		}
+		issue.Assignee = to
+		issue.UpdatedAt = now
+		m.dirty[id] = true
+
+		oldValue, newValue := from, to
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventReassigned,
+			Actor:     actor,
+			OldValue:  &oldValue,
+			NewValue:  &newValue,
+			CreatedAt: now,
+		})
+		count++
+	}
+
+	return count, nil
+}
+
+// AppendNotes appends a timestamped entry to an issue's notes
+func (m *MemoryStorage) AppendNotes(ctx context.Context, id, text, actor string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("notes to append cannot be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[id]
+	if !exists {
+		return fmt.Errorf("issue %s not found", id)
+	}
+
+	now := time.Now()
+	entry := types.NotesEntry(now, actor, text)
+	issue.Notes = types.AppendNotesEntry(issue.Notes, entry)
+	issue.UpdatedAt = now
+	m.dirty[id] = true
+
+	m.events[id] = append(m.events[id], &types.Event{
+		IssueID:   id,
+		EventType: types.EventUpdated,
+		Actor:     actor,
+		NewValue:  &entry,
+		CreatedAt: now,
+	})
+
+	return nil
+}
+
+// cloneTrackerRefs returns a writable copy of refs. Stored issues are copied
+// shallowly on read, so the map is replaced rather than mutated in place.
+func cloneTrackerRefs(refs types.TrackerRefs) types.TrackerRefs {
+	clone := make(types.TrackerRefs, len(refs)+1)
+	for tracker, ref := range refs {
+		clone[tracker] = ref
+	}
+	return clone
+}
+
+// SetExternalRef records ref as the issue's key in tracker
+func (m *MemoryStorage) SetExternalRef(ctx context.Context, issueID, tracker, ref, actor string) error {
+	tracker = strings.TrimSpace(tracker)
+	ref = strings.TrimSpace(ref)
+	if tracker == "" || ref == "" {
+		return fmt.Errorf("tracker and ref must not be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	refs := cloneTrackerRefs(issue.ExternalRefs)
+	refs[tracker] = ref
+	issue.ExternalRefs = refs
+	m.dirty[issueID] = true
+
+	comment := fmt.Sprintf("Set external ref %s: %s", tracker, ref)
+	m.events[issueID] = append(m.events[issueID], &types.Event{
+		IssueID:   issueID,
+		EventType: types.EventUpdated,
+		Actor:     actor,
+		Comment:   &comment,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// RemoveExternalRef removes the issue's key in tracker, clearing the legacy
+// ExternalRef too if it held the same key
+func (m *MemoryStorage) RemoveExternalRef(ctx context.Context, issueID, tracker, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists {
+		return nil
+	}
+	ref, ok := issue.ExternalRefs[tracker]
+	if !ok {
+		return nil
+	}
+
+	refs := cloneTrackerRefs(issue.ExternalRefs)
+	delete(refs, tracker)
+	if len(refs) == 0 {
+		refs = nil
+	}
+	issue.ExternalRefs = refs
+	if issue.ExternalRef != nil && *issue.ExternalRef == ref {
+		issue.ExternalRef = nil
+	}
+	m.dirty[issueID] = true
+
+	comment := fmt.Sprintf("Removed external ref %s: %s", tracker, ref)
+	m.events[issueID] = append(m.events[issueID], &types.Event{
+		IssueID:   issueID,
+		EventType: types.EventUpdated,
+		Actor:     actor,
+		Comment:   &comment,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+// GetExternalRefs returns the issue's keys by tracker, or nil if it has none
+func (m *MemoryStorage) GetExternalRefs(ctx context.Context, issueID string) (types.TrackerRefs, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists || len(issue.ExternalRefs) == 0 {
+		return nil, nil
+	}
+	return cloneTrackerRefs(issue.ExternalRefs), nil
+}
+
+// AddWatcher subscribes watcher to the issue, failing if they already watch it
+func (m *MemoryStorage) AddWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	watcher = strings.TrimSpace(watcher)
+	if watcher == "" {
+		return fmt.Errorf("watcher must not be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+	for _, w := range issue.Watchers {
+		if w == watcher {
+			return fmt.Errorf("%s is already watching %s", watcher, issueID)
+		}
+	}
+
+	// Copy so issues handed out earlier don't see the change
+	watchers := append(append([]string(nil), issue.Watchers...), watcher)
+	sort.Strings(watchers)
+	issue.Watchers = watchers
+	m.recordWatcherEvent(issueID, actor, fmt.Sprintf("Added watcher %s", watcher))
+	return nil
+}
+
+// RemoveWatcher unsubscribes watcher from the issue
+func (m *MemoryStorage) RemoveWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists {
+		return fmt.Errorf("%s is not watching %s", watcher, issueID)
+	}
+	var watchers []string
+	for _, w := range issue.Watchers {
+		if w != watcher {
+			watchers = append(watchers, w)
+		}
+	}
+	if len(watchers) == len(issue.Watchers) {
+		return fmt.Errorf("%s is not watching %s", watcher, issueID)
+	}
+
+	issue.Watchers = watchers
+	m.recordWatcherEvent(issueID, actor, fmt.Sprintf("Removed watcher %s", watcher))
+	return nil
+}
+
+// GetWatchers returns the issue's watchers in sorted order, or nil if it has none
+func (m *MemoryStorage) GetWatchers(ctx context.Context, issueID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	issue, exists := m.issues[issueID]
+	if !exists || len(issue.Watchers) == 0 {
+		return nil, nil
+	}
+	return append([]string(nil), issue.Watchers...), nil
+}
+
+// recordWatcherEvent marks the issue dirty and logs a watcher change.
+// Caller must hold m.mu.
+func (m *MemoryStorage) recordWatcherEvent(issueID, actor, comment string) {
+	m.dirty[issueID] = true
+	m.events[issueID] = append(m.events[issueID], &types.Event{
+		IssueID:   issueID,
+		EventType: types.EventUpdated,
+		Actor:     actor,
+		Comment:   &comment,
+		CreatedAt: time.Now(),
+	})
+}
+
+// GetIssueByExternalRef returns the issue whose key in any tracker, or whose
+// legacy ExternalRef, equals ref. Returns nil if no issue matches.
+func (m *MemoryStorage) GetIssueByExternalRef(ctx context.Context, ref string) (*types.Issue, error) {
+	m.mu.RLock()
+	var matchID string
+	for id, issue := range m.issues {
+		if issue.HasExternalRef(ref) && (matchID == "" || id < matchID) {
+			matchID = id
+		}
+	}
+	m.mu.RUnlock()
+
+	if matchID == "" {
+		return nil, nil
+	}
+	return m.GetIssue(ctx, matchID)
+}
+
+// compileLabelMatchers compiles label filter values (exact, glob, or re:) into matchers
+func compileLabelMatchers(values []string) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, 0, len(values))
+	for _, value := range values {
+		match, err := types.CompileLabelPattern(value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, match)
+	}
+	return matchers, nil
+}
+
+// anyLabelMatches reports whether any of labels satisfies match
+func anyLabelMatches(labels []string, match func(string) bool) bool {
+	for _, label := range labels {
+		if match(label) {
+			return true
+		}
+	}
+	return false
+}
+
 // CloseIssue closes an issue with a reason
 func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
 	return m.UpdateIssue(ctx, id, map[string]interface{}{
@@ -377,15 +705,311 @@ func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string
 	}, actor)
 }
 
+// ReopenIssue reopens a closed issue and records a reopened event with the reason
+func (m *MemoryStorage) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[id]
+	if !exists {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if issue.Status != types.StatusClosed {
+		return fmt.Errorf("issue %s is not closed (status: %s)", id, issue.Status)
+	}
+	if !workflow.Forced(ctx) {
+		w, err := workflow.Parse(m.config[workflow.ConfigKey])
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", workflow.ConfigKey, err)
+		}
+		if err := w.Check(issue.Status, types.StatusOpen); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	issue.Status = types.StatusOpen
+	issue.ClosedAt = nil
+	issue.UpdatedAt = now
+	m.dirty[id] = true
+
+	oldValue, newValue := string(types.StatusClosed), string(types.StatusOpen)
+	event := &types.Event{
+		IssueID:   id,
+		EventType: types.EventReopened,
+		Actor:     actor,
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		CreatedAt: now,
+	}
+	if reason != "" {
+		event.Comment = &reason
+		m.comments[id] = append(m.comments[id], &types.Comment{
+			ID:        int64(len(m.comments[id]) + 1),
+			IssueID:   id,
+			Author:    actor,
+			Text:      reason,
+			CreatedAt: now,
+		})
+	}
+	m.events[id] = append(m.events[id], event)
+
+	return nil
+}
+
+// CloneIssue creates a new open issue with a fresh ID, copying the source's title,
+// description, design, acceptance criteria, type, priority, estimate, and labels, then
+// applies overrides (same fields as UpdateIssue). Status, assignee, and dependencies are not copied.
+func (m *MemoryStorage) CloneIssue(ctx context.Context, id string, overrides map[string]interface{}, actor string) (*types.Issue, error) {
+	source, err := m.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+
+	clone := &types.Issue{
+		Title:              source.Title,
+		Description:        source.Description,
+		Design:             source.Design,
+		AcceptanceCriteria: source.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           source.Priority,
+		IssueType:          source.IssueType,
+	}
+	if source.EstimatedMinutes != nil {
+		mins := *source.EstimatedMinutes
+		clone.EstimatedMinutes = &mins
+	}
+	if err := m.CreateIssue(ctx, clone, actor); err != nil {
+		return nil, fmt.Errorf("failed to create clone: %w", err)
+	}
+
+	for _, label := range source.Labels {
+		if err := m.AddLabel(ctx, clone.ID, label, actor); err != nil {
+			return nil, fmt.Errorf("failed to copy label %s: %w", label, err)
+		}
+	}
+
+	if len(overrides) > 0 {
+		if err := m.UpdateIssue(ctx, clone.ID, overrides, actor); err != nil {
+			return nil, fmt.Errorf("failed to apply overrides to %s: %w", clone.ID, err)
+		}
+	}
+
+	return m.GetIssue(ctx, clone.ID)
+}
+
+// ArchiveIssue hides an issue from default listings without deleting it
+func (m *MemoryStorage) ArchiveIssue(ctx context.Context, id string, actor string) error {
+	return m.setArchived(id, true, actor)
+}
+
+// UnarchiveIssue restores an archived issue to default listings
+func (m *MemoryStorage) UnarchiveIssue(ctx context.Context, id string, actor string) error {
+	return m.setArchived(id, false, actor)
+}
+
+func (m *MemoryStorage) setArchived(id string, archive bool, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, ok := m.issues[id]
+	if !ok {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if (issue.ArchivedAt != nil) == archive {
+		if archive {
+			return fmt.Errorf("issue %s is already archived", id)
+		}
+		return fmt.Errorf("issue %s is not archived", id)
+	}
+
+	now := time.Now()
+	eventType := types.EventUnarchived
+	issue.ArchivedAt = nil
+	if archive {
+		eventType = types.EventArchived
+		issue.ArchivedAt = &now
+	}
+	issue.UpdatedAt = now
+	m.dirty[id] = true
+
+	m.events[id] = append(m.events[id], &types.Event{
+		IssueID:   id,
+		EventType: eventType,
+		Actor:     actor,
+		CreatedAt: now,
+	})
+
+	return nil
+}
+
+// claimExpiryActor is the actor recorded when an expired claim is released
+const claimExpiryActor = "lease-expiry"
+
+// claim is an agent's exclusive hold on an issue until expiresAt
+type claim struct {
+	agent     string
+	expiresAt time.Time
+}
+
+// ClaimIssue gives agent an exclusive claim on an open issue for lease,
+// moving it to in_progress. It returns false if another agent holds an
+// unexpired claim or the issue is in progress without one.
+func (m *MemoryStorage) ClaimIssue(ctx context.Context, id, agent string, lease time.Duration) (bool, error) {
+	if agent == "" {
+		return false, fmt.Errorf("agent cannot be empty")
+	}
+	if lease <= 0 {
+		return false, fmt.Errorf("lease must be positive, got %v", lease)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, ok := m.issues[id]
+	if !ok {
+		return false, fmt.Errorf("issue %s not found", id)
+	}
+
+	now := time.Now()
+	current, held := m.claims[id]
+	takeable := !held || current.agent == agent || !now.Before(current.expiresAt)
+	switch issue.Status {
+	case types.StatusOpen:
+		if !takeable {
+			return false, nil
+		}
+	case types.StatusInProgress:
+		if !held || !takeable {
+			return false, nil
+		}
+	default:
+		return false, fmt.Errorf("cannot claim %s: issue is %s", id, issue.Status)
+	}
+
+	m.claims[id] = claim{agent: agent, expiresAt: now.Add(lease)}
+	issue.Status = types.StatusInProgress
+	issue.Assignee = agent
+	issue.UpdatedAt = now
+	m.dirty[id] = true
+
+	newValue := agent
+	m.events[id] = append(m.events[id], &types.Event{
+		IssueID:   id,
+		EventType: types.EventClaimed,
+		Actor:     agent,
+		NewValue:  &newValue,
+		CreatedAt: now,
+	})
+
+	return true, nil
+}
+
+// ReleaseIssue gives up agent's claim on an issue, returning it to open and
+// unassigning it from the claim holder. Anyone may release an expired claim.
+func (m *MemoryStorage) ReleaseIssue(ctx context.Context, id, agent string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, ok := m.issues[id]
+	if !ok {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	current, held := m.claims[id]
+	if !held {
+		return fmt.Errorf("issue %s is not claimed", id)
+	}
+	now := time.Now()
+	if current.agent != agent && now.Before(current.expiresAt) {
+		return fmt.Errorf("issue %s is claimed by %s until %s", id, current.agent, current.expiresAt.Format(time.RFC3339))
+	}
+
+	delete(m.claims, id)
+	if issue.Status == types.StatusInProgress {
+		issue.Status = types.StatusOpen
+		if issue.Assignee == current.agent {
+			issue.Assignee = ""
+		}
+		issue.UpdatedAt = now
+	}
+	m.dirty[id] = true
+
+	oldValue := current.agent
+	m.events[id] = append(m.events[id], &types.Event{
+		IssueID:   id,
+		EventType: types.EventReleased,
+		Actor:     agent,
+		OldValue:  &oldValue,
+		CreatedAt: now,
+	})
+
+	return nil
+}
+
+// releaseExpiredClaims releases every claim whose lease has run out, returning
+// its issue to open as ReleaseIssue would
+func (m *MemoryStorage) releaseExpiredClaims() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for id, current := range m.claims {
+		if now.Before(current.expiresAt) {
+			continue
+		}
+		delete(m.claims, id)
+		issue, ok := m.issues[id]
+		if !ok {
+			continue
+		}
+		if issue.Status == types.StatusInProgress {
+			issue.Status = types.StatusOpen
+			if issue.Assignee == current.agent {
+				issue.Assignee = ""
+			}
+			issue.UpdatedAt = now
+		}
+		m.dirty[id] = true
+
+		oldValue := current.agent
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventReleased,
+			Actor:     claimExpiryActor,
+			OldValue:  &oldValue,
+			CreatedAt: now,
+		})
+	}
+}
+
 // SearchIssues finds issues matching query and filters
 func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	labelMatchers, err := compileLabelMatchers(filter.Labels)
+	if err != nil {
+		return nil, err
+	}
+	labelAnyMatchers, err := compileLabelMatchers(filter.LabelsAny)
+	if err != nil {
+		return nil, err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var results []*types.Issue
 
 	for _, issue := range m.issues {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Apply filters
+		if issue.ArchivedAt != nil && !filter.IncludeArchived {
+			continue
+		}
 		if filter.Status != nil && issue.Status != *filter.Status {
 			continue
 		}
@@ -398,6 +1022,12 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
 			continue
 		}
+		if filter.Reporter != nil && issue.Reporter != *filter.Reporter {
+			continue
+		}
+		if filter.DueBefore != nil && (issue.DueDate == nil || !issue.DueDate.Before(*filter.DueBefore)) {
+			continue
+		}
 
 		// Query search (title, description, or ID)
 		if query != "" {
@@ -413,20 +1043,28 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if len(filter.Labels) > 0 {
 			issueLabels := m.labels[issue.ID]
 			hasAllLabels := true
-			for _, reqLabel := range filter.Labels {
-				found := false
-				for _, label := range issueLabels {
-					if label == reqLabel {
-						found = true
-						break
-					}
-				}
-				if !found {
+			for _, match := range labelMatchers {
+				if !anyLabelMatches(issueLabels, match) {
 					hasAllLabels = false
 					break
 				}
 			}
-			if !hasAllLabels {
+			if !hasAllLabels {
+				continue
+			}
+		}
+
+		// Label filtering (OR): must have AT LEAST ONE of these labels
+		if len(filter.LabelsAny) > 0 {
+			issueLabels := m.labels[issue.ID]
+			hasAnyLabel := false
+			for _, match := range labelAnyMatchers {
+				if anyLabelMatches(issueLabels, match) {
+					hasAnyLabel = true
+					break
+				}
+			}
+			if !hasAnyLabel {
 				continue
 			}
 		}
@@ -453,6 +1091,9 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		if labels, ok := m.labels[issue.ID]; ok {
 			issueCopy.Labels = labels
 		}
+		if filter.Match != nil && !filter.Match(&issueCopy) {
+			continue
+		}
 
 		results = append(results, &issueCopy)
 	}
@@ -464,6 +1105,9 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		}
 		return results[i].CreatedAt.After(results[j].CreatedAt)
 	})
+	if filter.SortByRelevance {
+		types.SortByRelevance(results, filter.RelevanceQuery(query))
+	}
 
 	// Apply limit
 	if filter.Limit > 0 && len(results) > filter.Limit {
@@ -473,6 +1117,15 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	return results, nil
 }
 
+// CountIssues returns the number of issues matching filter
+func (m *MemoryStorage) CountIssues(ctx context.Context, filter types.IssueFilter) (int, error) {
+	issues, err := m.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return 0, err
+	}
+	return len(issues), nil
+}
+
 // AddDependency adds a dependency between issues
 func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	m.mu.Lock()
@@ -493,12 +1146,40 @@ func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		}
 	}
 
+	if dryRun := storage.DryRunFrom(ctx); dryRun != nil {
+		dryRun.Cycle = m.dependsOn(dep.DependsOnID, dep.IssueID)
+		return nil
+	}
+
 	m.dependencies[dep.IssueID] = append(m.dependencies[dep.IssueID], dep)
+	m.indexDependent(dep.DependsOnID, dep.IssueID)
 	m.dirty[dep.IssueID] = true
 
 	return nil
 }
 
+// dependsOn reports whether from depends on to, directly or transitively.
+// Callers must hold m.mu.
+func (m *MemoryStorage) dependsOn(from, to string) bool {
+	seen := map[string]bool{}
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == to {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, dep := range m.dependencies[id] {
+			stack = append(stack, dep.DependsOnID)
+		}
+	}
+	return false
+}
+
 // RemoveDependency removes a dependency
 func (m *MemoryStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
 	m.mu.Lock()
@@ -514,6 +1195,12 @@ func (m *MemoryStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 	}
 
 	m.dependencies[issueID] = newDeps
+	if set := m.dependents[dependsOnID]; set != nil {
+		delete(set, issueID)
+		if len(set) == 0 {
+			delete(m.dependents, dependsOnID)
+		}
+	}
 	m.dirty[issueID] = true
 
 	return nil
@@ -540,21 +1227,42 @@ func (m *MemoryStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	var results []*types.Issue
-	for id, deps := range m.dependencies {
-		for _, dep := range deps {
-			if dep.DependsOnID == issueID {
-				if issue, exists := m.issues[id]; exists {
-					results = append(results, issue)
-				}
-				break
-			}
+	for id := range m.dependents[issueID] {
+		if issue, exists := m.issues[id]; exists {
+			results = append(results, issue)
 		}
 	}
 
 	return results, nil
 }
 
+// indexDependent records that issueID depends on dependsOnID in the reverse index.
+// Caller must hold the write lock.
+func (m *MemoryStorage) indexDependent(dependsOnID, issueID string) {
+	set := m.dependents[dependsOnID]
+	if set == nil {
+		set = make(map[string]bool)
+		m.dependents[dependsOnID] = set
+	}
+	set[issueID] = true
+}
+
+// rebuildDependents recomputes the reverse index from dependencies, for changes
+// such as renames that touch edges in bulk. Caller must hold the write lock.
+func (m *MemoryStorage) rebuildDependents() {
+	m.dependents = make(map[string]map[string]bool)
+	for issueID, deps := range m.dependencies {
+		for _, dep := range deps {
+			m.indexDependent(dep.DependsOnID, issueID)
+		}
+	}
+}
+
 // GetDependencyRecords gets dependency records for an issue
 func (m *MemoryStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
 	m.mu.RLock()
@@ -621,6 +1329,79 @@ func (m *MemoryStorage) GetDependencyTree(ctx context.Context, issueID string, m
 	return nodes, nil
 }
 
+// GetDanglingDependencies returns dependencies whose target issue doesn't exist
+func (m *MemoryStorage) GetDanglingDependencies(ctx context.Context) ([]*types.DanglingDependency, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var dangling []*types.DanglingDependency
+	for issueID, deps := range m.dependencies {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, dep := range deps {
+			if _, exists := m.issues[dep.DependsOnID]; !exists {
+				dangling = append(dangling, &types.DanglingDependency{
+					IssueID:     issueID,
+					DependsOnID: dep.DependsOnID,
+					Type:        dep.Type,
+				})
+			}
+		}
+	}
+
+	sort.Slice(dangling, func(i, j int) bool {
+		if dangling[i].IssueID != dangling[j].IssueID {
+			return dangling[i].IssueID < dangling[j].IssueID
+		}
+		return dangling[i].DependsOnID < dangling[j].DependsOnID
+	})
+
+	return dangling, nil
+}
+
+// GetOrphanIssues returns unclosed issues that have no dependencies and that
+// nothing depends on
+func (m *MemoryStorage) GetOrphanIssues(ctx context.Context) ([]*types.Issue, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	linked := make(map[string]bool)
+	for issueID, deps := range m.dependencies {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, dep := range deps {
+			linked[issueID] = true
+			linked[dep.DependsOnID] = true
+		}
+	}
+
+	var orphans []*types.Issue
+	for id, issue := range m.issues {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if issue.Status == types.StatusClosed || issue.ArchivedAt != nil || linked[id] {
+			continue
+		}
+		issueCopy := *issue
+		if labels, ok := m.labels[id]; ok {
+			issueCopy.Labels = labels
+		}
+		orphans = append(orphans, &issueCopy)
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Priority != orphans[j].Priority {
+			return orphans[i].Priority < orphans[j].Priority
+		}
+		return orphans[i].CreatedAt.Before(orphans[j].CreatedAt)
+	})
+
+	return orphans, nil
+}
+
 // DetectCycles detects dependency cycles
 func (m *MemoryStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	// Simplified - return empty (no cycles detected)
@@ -669,6 +1450,96 @@ func (m *MemoryStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 	return nil
 }
 
+// ListLabels returns every distinct label with the number of issues using it,
+// most used first
+func (m *MemoryStorage) ListLabels(ctx context.Context) ([]*types.LabelCount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	byLabel := make(map[string]int)
+	for issueID, labels := range m.labels {
+		if _, exists := m.issues[issueID]; !exists {
+			continue
+		}
+		for _, label := range labels {
+			byLabel[label]++
+		}
+	}
+
+	counts := make([]*types.LabelCount, 0, len(byLabel))
+	for label, count := range byLabel {
+		counts = append(counts, &types.LabelCount{Label: label, Count: count})
+	}
+	types.SortLabelCounts(counts)
+
+	return counts, nil
+}
+
+// RenameLabel renames a label on every issue that has it
+func (m *MemoryStorage) RenameLabel(ctx context.Context, oldLabel, newLabel, actor string) error {
+	return m.MergeLabels(ctx, []string{oldLabel}, newLabel, actor)
+}
+
+// MergeLabels replaces each label in from with into on every affected issue,
+// deduplicating where an issue already has into. Each affected issue gets a
+// removed and an added event per merged label, as in SQLite.
+func (m *MemoryStorage) MergeLabels(ctx context.Context, from []string, into, actor string) error {
+	if into == "" {
+		return fmt.Errorf("target label cannot be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	merged := make(map[string]bool)
+	for _, label := range from {
+		if label != into {
+			merged[label] = true
+		}
+	}
+
+	for issueID, labels := range m.labels {
+		changed := false
+		hasInto := false
+		newLabels := make([]string, 0, len(labels))
+		for _, l := range labels {
+			if merged[l] {
+				changed = true
+				continue
+			}
+			if l == into {
+				hasInto = true
+			}
+			newLabels = append(newLabels, l)
+		}
+		if !changed {
+			continue
+		}
+		if !hasInto {
+			newLabels = append(newLabels, into)
+		}
+		m.labels[issueID] = newLabels
+		m.dirty[issueID] = true
+
+		if issue, exists := m.issues[issueID]; exists {
+			issue.UpdatedAt = now
+		}
+		for _, l := range labels {
+			if !merged[l] {
+				continue
+			}
+			removed := fmt.Sprintf("Removed label: %s", l)
+			added := fmt.Sprintf("Added label: %s", into)
+			m.events[issueID] = append(m.events[issueID],
+				&types.Event{IssueID: issueID, EventType: types.EventLabelRemoved, Actor: actor, Comment: &removed, CreatedAt: now},
+				&types.Event{IssueID: issueID, EventType: types.EventLabelAdded, Actor: actor, Comment: &added, CreatedAt: now})
+		}
+	}
+
+	return nil
+}
+
 func (m *MemoryStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -682,6 +1553,9 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 
 	var results []*types.Issue
 	for issueID, labels := range m.labels {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for _, l := range labels {
 			if l == label {
 				if issue, exists := m.issues[issueID]; exists {
@@ -698,20 +1572,195 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 
 // Stub implementations for other required methods
 func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	m.releaseExpiredClaims()
+
 	// Simplified: return open issues with no blocking dependencies
-	return m.SearchIssues(ctx, "", types.IssueFilter{
+	found, err := m.SearchIssues(ctx, "", types.IssueFilter{
 		Status: func() *types.Status { s := types.StatusOpen; return &s }(),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Issues under a live claim aren't ready for anyone but the claim holder
+	m.mu.RLock()
+	now := time.Now()
+	issues := found[:0]
+	for _, issue := range found {
+		c, held := m.claims[issue.ID]
+		if held && now.Before(c.expiresAt) && (filter.Assignee == nil || c.agent != *filter.Assignee) {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	m.mu.RUnlock()
+
+	// Priority, then rank, then oldest first
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		if c := types.CompareRank(issues[i], issues[j]); c != 0 {
+			return c < 0
+		}
+		return issues[i].CreatedAt.Before(issues[j].CreatedAt)
+	})
+	return issues, nil
 }
 
 func (m *MemoryStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
 	return nil, nil
 }
 
+// GetNewlyUnblocked returns unclosed issues with a 'blocks' dependency on closedID
+// and no other unclosed blocker. Returns nothing while closedID is still open.
+func (m *MemoryStorage) GetNewlyUnblocked(ctx context.Context, closedID string) ([]*types.Issue, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	unblocked := []*types.Issue{}
+	if closed, exists := m.issues[closedID]; !exists || closed.Status != types.StatusClosed {
+		return unblocked, nil
+	}
+
+	for id, deps := range m.dependencies {
+		issue, exists := m.issues[id]
+		if !exists || issue.Status == types.StatusClosed || issue.ArchivedAt != nil {
+			continue
+		}
+		blockedByClosed, stillBlocked := false, false
+		for _, dep := range deps {
+			if dep.Type != types.DepBlocks {
+				continue
+			}
+			if dep.DependsOnID == closedID {
+				blockedByClosed = true
+			} else if blocker, exists := m.issues[dep.DependsOnID]; exists && blocker.Status != types.StatusClosed {
+				stillBlocked = true
+			}
+		}
+		if blockedByClosed && !stillBlocked {
+			issueCopy := *issue
+			unblocked = append(unblocked, &issueCopy)
+		}
+	}
+
+	sort.Slice(unblocked, func(i, j int) bool {
+		return unblocked[i].ID < unblocked[j].ID
+	})
+	return unblocked, nil
+}
+
+// GetOverdueIssues returns unclosed, unarchived issues whose due date has passed,
+// most overdue first
+func (m *MemoryStorage) GetOverdueIssues(ctx context.Context) ([]*types.Issue, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	var overdue []*types.Issue
+	for id, issue := range m.issues {
+		if issue.Status == types.StatusClosed || issue.ArchivedAt != nil ||
+			issue.DueDate == nil || !issue.DueDate.Before(now) {
+			continue
+		}
+		issueCopy := *issue
+		if labels, ok := m.labels[id]; ok {
+			issueCopy.Labels = labels
+		}
+		overdue = append(overdue, &issueCopy)
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		if !overdue[i].DueDate.Equal(*overdue[j].DueDate) {
+			return overdue[i].DueDate.Before(*overdue[j].DueDate)
+		}
+		return overdue[i].Priority < overdue[j].Priority
+	})
+
+	return overdue, nil
+}
+
+// GetSLABreaches returns unclosed, unarchived issues older than the SLA
+// configured for their priority, furthest past it first
+func (m *MemoryStorage) GetSLABreaches(ctx context.Context) ([]*types.SLABreach, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	policy, err := types.ParseSLAPolicy(m.config[types.SLAConfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", types.SLAConfigKey, err)
+	}
+	if len(policy) == 0 {
+		return nil, nil
+	}
+
+	issues := make([]*types.Issue, 0, len(m.issues))
+	for id, issue := range m.issues {
+		issueCopy := *issue
+		if labels, ok := m.labels[id]; ok {
+			issueCopy.Labels = labels
+		}
+		issues = append(issues, &issueCopy)
+	}
+	return types.FindSLABreaches(issues, policy, time.Now()), nil
+}
+
 func (m *MemoryStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
 	return nil, nil
 }
 
+// GetEpicProgress returns child counts by state, percent complete, and estimate
+// totals for an epic's direct parent-child children
+func (m *MemoryStorage) GetEpicProgress(ctx context.Context, epicID string) (*types.EpicProgress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	epic, exists := m.issues[epicID]
+	if !exists {
+		return nil, fmt.Errorf("issue %s not found", epicID)
+	}
+	epicCopy := *epic
+	progress := &types.EpicProgress{Epic: &epicCopy}
+
+	for childID, deps := range m.dependencies {
+		child, exists := m.issues[childID]
+		if !exists {
+			continue
+		}
+		isChild := false
+		hasOpenBlocker := false
+		for _, dep := range deps {
+			switch dep.Type {
+			case types.DepParentChild:
+				if dep.DependsOnID == epicID {
+					isChild = true
+				}
+			case types.DepBlocks:
+				if blocker, ok := m.issues[dep.DependsOnID]; ok && blocker.Status != types.StatusClosed {
+					hasOpenBlocker = true
+				}
+			}
+		}
+		if isChild {
+			progress.AddChild(child, hasOpenBlocker)
+		}
+	}
+	sort.Strings(progress.UnestimatedChildren)
+
+	return progress, nil
+}
+
+// ProjectEpicCompletion projects when an epic will finish by dividing the estimates of
+// its unclosed children by a throughput of minutesPerDay
+func (m *MemoryStorage) ProjectEpicCompletion(ctx context.Context, epicID string, minutesPerDay float64) (time.Time, error) {
+	progress, err := m.GetEpicProgress(ctx, epicID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return progress.ProjectCompletion(time.Now(), minutesPerDay)
+}
+
 func (m *MemoryStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	return nil
 }
@@ -728,6 +1777,29 @@ func (m *MemoryStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
+// GetEventsWithFilter returns an issue's events matching filter, in the order
+// GetEvents uses
+func (m *MemoryStorage) GetEventsWithFilter(ctx context.Context, issueID string, filter types.EventFilter) ([]*types.Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var events []*types.Event
+	for _, event := range m.events[issueID] {
+		if filter.Actor != "" && event.Actor != filter.Actor {
+			continue
+		}
+		if filter.EventType != "" && event.EventType != filter.EventType {
+			continue
+		}
+		events = append(events, event)
+	}
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+
+	return events, nil
+}
+
 func (m *MemoryStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -862,16 +1934,110 @@ func (m *MemoryStorage) GetMetadata(ctx context.Context, key string) (string, er
 	return m.metadata[key], nil
 }
 
-// Prefix rename operations (no-ops for memory storage)
+// UpdateIssueID renames an issue and moves everything keyed by its ID
+// (dependencies in both directions, labels, events, comments, dirty state).
+// The rename is all-or-nothing: it is validated before any state changes.
 func (m *MemoryStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
-	return fmt.Errorf("UpdateIssueID not supported in --no-db mode")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.issues[oldID]
+	if !exists {
+		return fmt.Errorf("issue %s not found", oldID)
+	}
+	if oldID != newID {
+		if _, taken := m.issues[newID]; taken {
+			return fmt.Errorf("issue %s already exists", newID)
+		}
+	}
+
+	now := time.Now()
+	existing.ID = newID
+	existing.Title = issue.Title
+	existing.Description = issue.Description
+	existing.Design = issue.Design
+	existing.AcceptanceCriteria = issue.AcceptanceCriteria
+	existing.Notes = issue.Notes
+	existing.UpdatedAt = now
+
+	delete(m.issues, oldID)
+	m.issues[newID] = existing
+
+	// Outgoing dependencies
+	if deps, ok := m.dependencies[oldID]; ok {
+		for _, dep := range deps {
+			dep.IssueID = newID
+		}
+		delete(m.dependencies, oldID)
+		m.dependencies[newID] = deps
+	}
+
+	// Incoming dependencies
+	for issueID, deps := range m.dependencies {
+		for _, dep := range deps {
+			if dep.DependsOnID == oldID {
+				dep.DependsOnID = newID
+				m.dirty[issueID] = true
+			}
+		}
+	}
+	m.rebuildDependents()
+
+	if labels, ok := m.labels[oldID]; ok {
+		delete(m.labels, oldID)
+		m.labels[newID] = labels
+	}
+
+	if c, ok := m.claims[oldID]; ok {
+		delete(m.claims, oldID)
+		m.claims[newID] = c
+	}
+
+	if comments, ok := m.comments[oldID]; ok {
+		for _, comment := range comments {
+			comment.IssueID = newID
+		}
+		delete(m.comments, oldID)
+		m.comments[newID] = comments
+	}
+
+	events := m.events[oldID]
+	for _, event := range events {
+		event.IssueID = newID
+	}
+	delete(m.events, oldID)
+	oldValue, newValue := oldID, newID
+	m.events[newID] = append(events, &types.Event{
+		IssueID:   newID,
+		EventType: types.EventRenamed,
+		Actor:     actor,
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		CreatedAt: now,
+	})
+
+	delete(m.dirty, oldID)
+	m.dirty[newID] = true
+
+	return nil
 }
 
+// RenameDependencyPrefix is a no-op: UpdateIssueID already rewrites dependency references
 func (m *MemoryStorage) RenameDependencyPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
 	return nil
 }
 
+// RenameCounterPrefix moves the ID counter from oldPrefix to newPrefix
 func (m *MemoryStorage) RenameCounterPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lastID := m.counters[oldPrefix]
+	delete(m.counters, oldPrefix)
+	if lastID > m.counters[newPrefix] {
+		m.counters[newPrefix] = lastID
+	}
+
 	return nil
 }
 
@@ -908,6 +2074,9 @@ func (m *MemoryStorage) SyncAllCounters(ctx context.Context) error {
 
 	// Recompute from issues
 	for _, issue := range m.issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		prefix, num := extractPrefixAndNumber(issue.ID)
 		if prefix != "" && num > 0 {
 			if m.counters[prefix] < num {
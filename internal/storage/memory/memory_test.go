@@ -2,10 +2,16 @@ package memory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 )
 
 func setupTestMemory(t *testing.T) *MemoryStorage {
@@ -179,6 +185,72 @@ func TestGetIssueNotFound(t *testing.T) {
 	}
 }
 
+func TestIssueExists(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if exists, err := store.IssueExists(ctx, issue.ID); err != nil || !exists {
+		t.Errorf("IssueExists(%s) = %v, %v; want true", issue.ID, exists, err)
+	}
+	if exists, err := store.IssueExists(ctx, "bd-999"); err != nil || exists {
+		t.Errorf("IssueExists(bd-999) = %v, %v; want false", exists, err)
+	}
+
+	// Archived issues still exist
+	if err := store.ArchiveIssue(ctx, issue.ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+	if exists, _ := store.IssueExists(ctx, issue.ID); !exists {
+		t.Error("archived issue should still exist")
+	}
+}
+
+func TestGetIssues(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, a.ID, "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	issues, err := store.GetIssues(ctx, []string{a.ID, "bd-999", b.ID})
+	if err != nil {
+		t.Fatalf("GetIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[a.ID] == nil || issues[a.ID].Title != "A" || len(issues[a.ID].Labels) != 1 {
+		t.Errorf("unexpected issue A: %+v", issues[a.ID])
+	}
+	if issues[b.ID] == nil || issues[b.ID].Title != "B" {
+		t.Errorf("unexpected issue B: %+v", issues[b.ID])
+	}
+	if _, ok := issues["bd-999"]; ok {
+		t.Error("missing ID should be absent from the result")
+	}
+
+	// Returned issues are copies
+	issues[a.ID].Title = "changed"
+	if got, _ := store.GetIssue(ctx, a.ID); got.Title != "A" {
+		t.Errorf("mutating the result changed the stored issue: %q", got.Title)
+	}
+}
+
 func TestCreateIssues(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -301,6 +373,79 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueExpectedVersion(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	read, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	stale := storage.WithExpectedVersion(ctx, read.Version())
+
+	if err := store.UpdateIssue(stale, issue.ID, map[string]interface{}{"title": "Agent A"}, "agent-a"); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	err = store.UpdateIssue(stale, issue.ID, map[string]interface{}{"title": "Agent B"}, "agent-b")
+	if !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("stale update error = %v, want ErrVersionConflict", err)
+	}
+	if current, _ := store.GetIssue(ctx, issue.ID); current.Title != "Agent A" {
+		t.Errorf("title = %q, stale write clobbered it", current.Title)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	store.ClearDirtyIssues(ctx)
+
+	dryCtx, dryRun := storage.WithDryRun(ctx)
+	if err := store.UpdateIssue(dryCtx, a.ID, map[string]interface{}{"title": "Renamed"}, "test-user"); err != nil {
+		t.Fatalf("dry-run UpdateIssue failed: %v", err)
+	}
+	if dryRun.Issue == nil || dryRun.Issue.Title != "Renamed" {
+		t.Errorf("dry run result = %+v, want the renamed issue", dryRun.Issue)
+	}
+	if len(dryRun.Changes) != 1 || dryRun.Changes[0].Field != "title" {
+		t.Errorf("changes = %+v, want just title", dryRun.Changes)
+	}
+	if current, _ := store.GetIssue(ctx, a.ID); current.Title != "A" {
+		t.Errorf("dry run renamed the issue to %q", current.Title)
+	}
+
+	dryCtx, dryRun = storage.WithDryRun(ctx)
+	if err := store.AddDependency(dryCtx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("dry-run AddDependency failed: %v", err)
+	}
+	if !dryRun.Cycle {
+		t.Error("dry run did not report the cycle")
+	}
+	if deps, _ := store.GetDependencies(ctx, b.ID); len(deps) != 0 {
+		t.Errorf("dry run added a dependency: %v", deps)
+	}
+	if dirty, _ := store.GetDirtyIssues(ctx); len(dirty) != 0 {
+		t.Errorf("dry run marked issues dirty: %v", dirty)
+	}
+}
+
 func TestCloseIssue(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -338,6 +483,131 @@ func TestCloseIssue(t *testing.T) {
 	}
 }
 
+func TestClosedAtInvariant(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Test", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Closing through a generic update sets closed_at, whether the status is a string or a Status
+	for _, closed := range []interface{}{string(types.StatusClosed), types.StatusClosed} {
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": closed}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		got, _ := store.GetIssue(ctx, issue.ID)
+		if got.Status != types.StatusClosed || got.ClosedAt == nil {
+			t.Errorf("status %#v: expected closed with closed_at, got %s / %v", closed, got.Status, got.ClosedAt)
+		}
+
+		// Reopening clears it
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": types.StatusOpen}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		got, _ = store.GetIssue(ctx, issue.ID)
+		if got.Status != types.StatusOpen || got.ClosedAt != nil {
+			t.Errorf("expected open without closed_at after reopening, got %s / %v", got.Status, got.ClosedAt)
+		}
+	}
+}
+
+func TestReopenIssue(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Flaky test", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Only closed issues can be reopened
+	if err := store.ReopenIssue(ctx, issue.ID, "", "test-user"); err == nil {
+		t.Error("expected error reopening an open issue")
+	}
+	if err := store.ReopenIssue(ctx, "bd-999", "", "test-user"); err == nil {
+		t.Error("expected error reopening a missing issue")
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "Fixed", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ReopenIssue(ctx, issue.ID, "Failed again in CI", "bob"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.Status != types.StatusOpen {
+		t.Errorf("expected status open, got %s", reopened.Status)
+	}
+	if reopened.ClosedAt != nil {
+		t.Errorf("expected closed_at to be cleared, got %v", reopened.ClosedAt)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventReopened {
+			found = true
+			if e.Actor != "bob" || e.Comment == nil || *e.Comment != "Failed again in CI" {
+				t.Errorf("unexpected reopened event: actor=%s comment=%v", e.Actor, e.Comment)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a reopened event")
+	}
+
+	// The reason is kept as a comment too, since events aren't exported
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "bob" || comments[0].Text != "Failed again in CI" {
+		t.Errorf("expected the reason as a comment by bob, got %+v", comments)
+	}
+}
+
+func TestStatusWorkflow(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, workflow.ConfigKey, workflow.Strict); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	issue := &types.Issue{Title: "Workflow", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err == nil {
+		t.Fatal("expected closing an open issue to be rejected")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("open → in_progress failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("in_progress → closed failed: %v", err)
+	}
+	if err := store.UpdateIssue(workflow.WithForce(ctx), issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("forced closed → in_progress failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.ClosedAt != nil {
+		t.Error("leaving closed should clear closed_at")
+	}
+}
+
 func TestSearchIssues(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -409,6 +679,63 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestCountIssues(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issues := []*types.Issue{
+		{Title: "Bug in login", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug, Assignee: "alice"},
+		{Title: "Feature request", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature},
+		{Title: "Another bug", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug, Assignee: "alice"},
+		{Title: "Old task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, issues[1].ID, "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.ArchiveIssue(ctx, issues[3].ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+
+	openStatus := types.StatusOpen
+	bugType := types.TypeBug
+	featureType := types.TypeFeature
+	alice := "alice"
+	filters := map[string]types.IssueFilter{
+		"all":           {},
+		"with archived": {IncludeArchived: true},
+		"status":        {Status: &openStatus},
+		"type":          {IssueType: &bugType},
+		"assignee":      {Assignee: &alice},
+		"label":         {Labels: []string{"backend"}},
+		"ids":           {IDs: []string{issues[0].ID, issues[2].ID}},
+		"limit":         {Limit: 1},
+		"no matches":    {Status: &openStatus, Assignee: &alice, IssueType: &featureType},
+	}
+	for name, filter := range filters {
+		results, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("%s: SearchIssues failed: %v", name, err)
+		}
+		count, err := store.CountIssues(ctx, filter)
+		if err != nil {
+			t.Fatalf("%s: CountIssues failed: %v", name, err)
+		}
+		if count != len(results) {
+			t.Errorf("%s: CountIssues = %d, SearchIssues returned %d", name, count, len(results))
+		}
+	}
+
+	if count, _ := store.CountIssues(ctx, types.IssueFilter{}); count != 3 {
+		t.Errorf("expected 3 unarchived issues, got %d", count)
+	}
+}
+
 func TestDependencies(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -486,6 +813,41 @@ func TestDependencies(t *testing.T) {
 	}
 }
 
+func TestGetDanglingDependencies(t *testing.T) {
+	store := New("")
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Kept", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Dependent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks},
+				{IssueID: "bd-2", DependsOnID: "bd-9", Type: types.DepRelated},
+			}},
+	}
+	if err := store.LoadFromIssues(issues); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	dangling, err := store.GetDanglingDependencies(ctx)
+	if err != nil {
+		t.Fatalf("GetDanglingDependencies failed: %v", err)
+	}
+	if len(dangling) != 1 || dangling[0].IssueID != "bd-2" || dangling[0].DependsOnID != "bd-9" || dangling[0].Type != types.DepRelated {
+		t.Fatalf("Unexpected dangling dependencies: %v", dangling)
+	}
+
+	if err := store.RemoveDependency(ctx, "bd-2", "bd-9", "test-user"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	dangling, _ = store.GetDanglingDependencies(ctx)
+	if len(dangling) != 0 {
+		t.Errorf("Expected no dangling dependencies after fix, got %v", dangling)
+	}
+}
+
 func TestLabels(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -537,6 +899,81 @@ func TestLabels(t *testing.T) {
 	}
 }
 
+func TestListLabels(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "One", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"shared", "solo"}},
+		{ID: "bd-2", Title: "Two", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"shared"}},
+		{ID: "bd-3", Title: "Three", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+	}
+	if err := store.LoadFromIssues(issues); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	counts, err := store.ListLabels(context.Background())
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+	if len(counts) != 2 || *counts[0] != (types.LabelCount{Label: "shared", Count: 2}) || *counts[1] != (types.LabelCount{Label: "solo", Count: 1}) {
+		t.Errorf("Unexpected label counts: %v", counts)
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Has both", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"ui", "client", "keep"}},
+		{ID: "bd-2", Title: "Has source only", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"ui"}},
+		{ID: "bd-3", Title: "Unaffected", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"other"}},
+	}
+	if err := store.LoadFromIssues(issues); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	if err := store.MergeLabels(ctx, []string{"ui"}, "client", "test-user"); err != nil {
+		t.Fatalf("MergeLabels failed: %v", err)
+	}
+
+	labels, _ := store.GetLabels(ctx, "bd-1")
+	if len(labels) != 2 || labels[0] != "client" || labels[1] != "keep" {
+		t.Errorf("Expected [client keep] for bd-1, got %v", labels)
+	}
+	labels, _ = store.GetLabels(ctx, "bd-2")
+	if len(labels) != 1 || labels[0] != "client" {
+		t.Errorf("Expected [client] for bd-2, got %v", labels)
+	}
+
+	dirty, _ := store.GetDirtyIssues(ctx)
+	for _, id := range dirty {
+		if id == "bd-3" {
+			t.Error("Unaffected issue should not be marked dirty")
+		}
+	}
+
+	events, _ := store.GetEvents(ctx, "bd-2", 0)
+	if len(events) != 2 || events[0].EventType != types.EventLabelRemoved || events[1].EventType != types.EventLabelAdded {
+		t.Errorf("Expected removed and added label events for bd-2, got %v", events)
+	}
+	if events, _ := store.GetEvents(ctx, "bd-3", 0); len(events) != 0 {
+		t.Errorf("Expected no events for unaffected bd-3, got %v", events)
+	}
+	bd2, _ := store.GetIssue(ctx, "bd-2")
+	bd3, _ := store.GetIssue(ctx, "bd-3")
+	if !bd2.UpdatedAt.After(bd3.UpdatedAt) {
+		t.Errorf("Expected bd-2 updated_at to advance, got %v (unaffected: %v)", bd2.UpdatedAt, bd3.UpdatedAt)
+	}
+
+	if err := store.RenameLabel(ctx, "client", "", "test-user"); err == nil {
+		t.Error("Expected error renaming to empty label")
+	}
+}
+
 func TestComments(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -860,40 +1297,227 @@ func TestSyncAllCounters(t *testing.T) {
 	}
 }
 
-func TestThreadSafety(t *testing.T) {
-	store := setupTestMemory(t)
+func TestUpdateIssueIDAndRenameCounterPrefix(t *testing.T) {
+	store := New("")
 	defer store.Close()
 
 	ctx := context.Background()
-	const numGoroutines = 10
 
-	// Run concurrent creates
-	done := make(chan bool)
-	for i := 0; i < numGoroutines; i++ {
-		go func(n int) {
-			issue := &types.Issue{
-				Title:     "Concurrent",
-				Status:    types.StatusOpen,
-				Priority:  1,
-				IssueType: types.TypeTask,
-			}
-			store.CreateIssue(ctx, issue, "test-user")
-			done <- true
-		}(i)
+	issues := []*types.Issue{
+		{ID: "old-1", Title: "Parent", Description: "See old-2", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "old-2", Title: "Child", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{{IssueID: "old-2", DependsOnID: "old-1", Type: types.DepBlocks}}},
+	}
+	if err := store.LoadFromIssues(issues); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, "old-1", "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, "old-1", "test-user", "a comment"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
 	}
 
-	// Wait for all goroutines
-	for i := 0; i < numGoroutines; i++ {
-		<-done
+	// Renaming onto an existing ID must fail without changing anything
+	if err := store.UpdateIssueID(ctx, "old-1", "old-2", issues[0], "test-user"); err == nil {
+		t.Fatal("Expected error renaming onto existing ID")
 	}
 
-	// Verify all created
-	stats, err := store.GetStatistics(ctx)
-	if err != nil {
-		t.Fatalf("GetStatistics failed: %v", err)
+	updated := *issues[0]
+	updated.Description = "See new-2"
+	if err := store.UpdateIssueID(ctx, "old-1", "new-1", &updated, "test-user"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
-	if stats.TotalIssues != numGoroutines {
+	if got, _ := store.GetIssue(ctx, "old-1"); got != nil {
+		t.Error("Expected old-1 to be gone after rename")
+	}
+	got, err := store.GetIssue(ctx, "new-1")
+	if err != nil || got == nil {
+		t.Fatalf("Expected new-1 after rename: %v", err)
+	}
+	if got.Description != "See new-2" {
+		t.Errorf("Expected description to be updated, got %q", got.Description)
+	}
+
+	// Incoming dependency now points at the new ID
+	deps, err := store.GetDependencies(ctx, "old-2")
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].ID != "new-1" {
+		t.Errorf("Expected old-2 to depend on new-1, got %v", deps)
+	}
+
+	labels, _ := store.GetLabels(ctx, "new-1")
+	if len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("Expected labels to move with issue, got %v", labels)
+	}
+	comments, _ := store.GetIssueComments(ctx, "new-1")
+	if len(comments) != 1 || comments[0].IssueID != "new-1" {
+		t.Errorf("Expected comments to move with issue, got %v", comments)
+	}
+
+	// Both the renamed issue and the issue whose dependency changed are dirty
+	dirty, _ := store.GetDirtyIssues(ctx)
+	dirtySet := make(map[string]bool)
+	for _, id := range dirty {
+		dirtySet[id] = true
+	}
+	if !dirtySet["new-1"] || !dirtySet["old-2"] || dirtySet["old-1"] {
+		t.Errorf("Unexpected dirty set after rename: %v", dirty)
+	}
+
+	if err := store.RenameCounterPrefix(ctx, "old", "new"); err != nil {
+		t.Fatalf("RenameCounterPrefix failed: %v", err)
+	}
+	if _, ok := store.counters["old"]; ok {
+		t.Error("Expected old counter to be removed")
+	}
+	if store.counters["new"] != 2 {
+		t.Errorf("Expected new counter to be 2, got %d", store.counters["new"])
+	}
+}
+
+func TestIssueReporter(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Reported", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if issue.Reporter != "alice" {
+		t.Errorf("Expected reporter alice, got %q", issue.Reporter)
+	}
+
+	for reporter, want := range map[string]int{"alice": 1, "bob": 0} {
+		reporter := reporter
+		results, err := store.SearchIssues(ctx, "", types.IssueFilter{Reporter: &reporter})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		if len(results) != want {
+			t.Errorf("Reporter %s: expected %d results, got %d", reporter, want, len(results))
+		}
+	}
+}
+
+func TestGetOverdueIssues(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+	overdue := &types.Issue{Title: "Overdue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueDate: &past}
+	upcoming := &types.Issue{Title: "Upcoming", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueDate: &future}
+	for _, issue := range []*types.Issue{overdue, upcoming} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	issues, err := store.GetOverdueIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetOverdueIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != overdue.ID {
+		t.Errorf("Expected only %s to be overdue, got %v", overdue.ID, issues)
+	}
+
+	if err := store.UpdateIssue(ctx, upcoming.ID, map[string]interface{}{"due_date": past}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	issues, err = store.GetOverdueIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetOverdueIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 overdue issues after update, got %d", len(issues))
+	}
+}
+
+func TestArchiveIssue(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Archive me", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.ArchiveIssue(ctx, issue.ID, "test"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected archived issue to be hidden, got %d results", len(results))
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ArchivedAt == nil {
+		t.Errorf("Expected archived issue with IncludeArchived, got %v", results)
+	}
+
+	if err := store.UnarchiveIssue(ctx, issue.ID, "test"); err != nil {
+		t.Fatalf("UnarchiveIssue failed: %v", err)
+	}
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected unarchived issue to be listed, got %d results", len(results))
+	}
+}
+
+func TestThreadSafety(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	const numGoroutines = 10
+
+	// Run concurrent creates
+	done := make(chan bool)
+	for i := 0; i < numGoroutines; i++ {
+		go func(n int) {
+			issue := &types.Issue{
+				Title:     "Concurrent",
+				Status:    types.StatusOpen,
+				Priority:  1,
+				IssueType: types.TypeTask,
+			}
+			store.CreateIssue(ctx, issue, "test-user")
+			done <- true
+		}(i)
+	}
+
+	// Wait for all goroutines
+	for i := 0; i < numGoroutines; i++ {
+		<-done
+	}
+
+	// Verify all created
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+
+	if stats.TotalIssues != numGoroutines {
 		t.Errorf("Expected %d issues, got %d", numGoroutines, stats.TotalIssues)
 	}
 }
@@ -913,3 +1537,649 @@ func TestClose(t *testing.T) {
 		t.Error("Store should be closed")
 	}
 }
+
+func TestCloneIssue(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	mins := 30
+	source := &types.Issue{Title: "Template", Description: "Steps", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeChore, EstimatedMinutes: &mins}
+	if err := store.CreateIssue(ctx, source, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, source.ID, "ops", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	clone, err := store.CloneIssue(ctx, source.ID, map[string]interface{}{"priority": 3}, "test-user")
+	if err != nil {
+		t.Fatalf("CloneIssue failed: %v", err)
+	}
+	if clone.ID == source.ID {
+		t.Fatalf("Expected a new ID, got %s", clone.ID)
+	}
+	if clone.Title != "Template" || clone.Priority != 3 || clone.Status != types.StatusOpen {
+		t.Errorf("Unexpected clone: %+v", clone)
+	}
+	if len(clone.Labels) != 1 || clone.Labels[0] != "ops" {
+		t.Errorf("Expected label ops, got %v", clone.Labels)
+	}
+
+	if err := store.UpdateIssue(ctx, clone.ID, map[string]interface{}{"estimated_minutes": 60}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	original, err := store.GetIssue(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if *original.EstimatedMinutes != 30 || original.Priority != 1 {
+		t.Errorf("Expected source to be unchanged, got estimate %d priority %d", *original.EstimatedMinutes, original.Priority)
+	}
+}
+
+func TestScansStopWhenCanceled(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < 20; i++ {
+		issue := &types.Issue{Title: "Issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	for i := 1; i < len(ids); i++ {
+		dep := &types.Dependency{IssueID: ids[i], DependsOnID: ids[0], Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err := store.SearchIssues(canceled, "", types.IssueFilter{}); err != context.Canceled {
+		t.Errorf("SearchIssues: expected context.Canceled, got %v", err)
+	}
+	if _, err := store.GetReadyWork(canceled, types.WorkFilter{}); err != context.Canceled {
+		t.Errorf("GetReadyWork: expected context.Canceled, got %v", err)
+	}
+	if _, err := store.GetDependents(canceled, ids[0]); err != context.Canceled {
+		t.Errorf("GetDependents: expected context.Canceled, got %v", err)
+	}
+	if _, err := store.GetDanglingDependencies(canceled); err != context.Canceled {
+		t.Errorf("GetDanglingDependencies: expected context.Canceled, got %v", err)
+	}
+	if _, err := store.GetOrphanIssues(canceled); err != context.Canceled {
+		t.Errorf("GetOrphanIssues: expected context.Canceled, got %v", err)
+	}
+	if err := store.SyncAllCounters(canceled); err != context.Canceled {
+		t.Errorf("SyncAllCounters: expected context.Canceled, got %v", err)
+	}
+
+	// The same calls succeed with a live context
+	if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err != nil || len(issues) != len(ids) {
+		t.Errorf("SearchIssues: expected %d issues, got %d (err=%v)", len(ids), len(issues), err)
+	}
+}
+
+func TestGetNewlyUnblocked(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	// A blocks B; A and D both block C
+	a := &types.Issue{Title: "Schema migration", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "Backfill", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	c := &types.Issue{Title: "Drop old column", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	d := &types.Issue{Title: "Update readers", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b, c, d} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+		{IssueID: c.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+		{IssueID: c.ID, DependsOnID: d.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	// Nothing is unblocked while the blocker is still open
+	unblocked, err := store.GetNewlyUnblocked(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetNewlyUnblocked failed: %v", err)
+	}
+	if len(unblocked) != 0 {
+		t.Errorf("expected nothing unblocked before close, got %d issues", len(unblocked))
+	}
+
+	if err := store.CloseIssue(ctx, a.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// C is still blocked by D
+	unblocked, err = store.GetNewlyUnblocked(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetNewlyUnblocked failed: %v", err)
+	}
+	if len(unblocked) != 1 || unblocked[0].ID != b.ID {
+		ids := make([]string, len(unblocked))
+		for i, issue := range unblocked {
+			ids[i] = issue.ID
+		}
+		t.Errorf("expected only %s unblocked, got %v", b.ID, ids)
+	}
+}
+
+func TestAppendNotes(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Migrate callers", Notes: "Started planning", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, text := range []string{"First half migrated", "Second half migrated"} {
+		if err := store.AppendNotes(ctx, issue.ID, text, "alice"); err != nil {
+			t.Fatalf("AppendNotes failed: %v", err)
+		}
+	}
+
+	updated, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	entries := strings.Split(updated.Notes, types.NotesEntrySeparator+"---\n")
+	if len(entries) != 3 {
+		t.Fatalf("expected original notes plus 2 entries, got %q", updated.Notes)
+	}
+	if entries[0] != "Started planning" {
+		t.Errorf("original notes changed: %q", entries[0])
+	}
+	for i, want := range []string{"First half migrated", "Second half migrated"} {
+		if !strings.HasSuffix(entries[i+1], "] alice\n\n"+want) {
+			t.Errorf("entry %d = %q, want it to end with %q", i+1, entries[i+1], want)
+		}
+	}
+
+	if err := store.AppendNotes(ctx, "bd-999", "text", "alice"); err == nil {
+		t.Error("expected error appending to a missing issue")
+	}
+	if err := store.AppendNotes(ctx, issue.ID, "  ", "alice"); err == nil {
+		t.Error("expected error appending empty text")
+	}
+}
+
+func TestExternalRefsMultipleTrackers(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Synced issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.SetExternalRef(ctx, issue.ID, "jira", "PROJ-123", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+	if err := store.SetExternalRef(ctx, issue.ID, "gh", "gh-456", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+
+	for _, ref := range []string{"PROJ-123", "gh-456"} {
+		found, err := store.GetIssueByExternalRef(ctx, ref)
+		if err != nil {
+			t.Fatalf("GetIssueByExternalRef(%s) failed: %v", ref, err)
+		}
+		if found == nil || found.ID != issue.ID {
+			t.Fatalf("GetIssueByExternalRef(%s) = %v, want %s", ref, found, issue.ID)
+		}
+	}
+
+	if err := store.RemoveExternalRef(ctx, issue.ID, "jira", "test-user"); err != nil {
+		t.Fatalf("RemoveExternalRef failed: %v", err)
+	}
+	refs, err := store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if len(refs) != 1 || refs["gh"] != "gh-456" {
+		t.Errorf("Unexpected refs after remove: %v", refs)
+	}
+	if found, _ := store.GetIssueByExternalRef(ctx, "PROJ-123"); found != nil {
+		t.Errorf("Expected removed ref not to match, got %s", found.ID)
+	}
+}
+
+func TestLegacyExternalRefKeptInStep(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	ref := "gh-9"
+	issue := &types.Issue{
+		Title:       "Mirrored issue",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeTask,
+		ExternalRef: &ref,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.SetExternalRef(ctx, issue.ID, "jira", "PROJ-1", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+
+	refs, err := store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if refs["gh"] != "gh-9" {
+		t.Errorf("Expected created external_ref filed under gh, got %v", refs)
+	}
+
+	// Changing the legacy ref replaces its tracker's entry
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": "gh-10"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	refs, err = store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if refs["gh"] != "gh-10" || refs["jira"] != "PROJ-1" {
+		t.Errorf("Unexpected refs after update: %v", refs)
+	}
+	if found, _ := store.GetIssueByExternalRef(ctx, "gh-9"); found != nil {
+		t.Errorf("Expected old legacy ref to no longer match, got %s", found.ID)
+	}
+
+	// Clearing it drops the entry and leaves other trackers alone
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	refs, err = store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if len(refs) != 1 || refs["jira"] != "PROJ-1" {
+		t.Errorf("Unexpected refs after clearing: %v", refs)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, types.RequiredFieldsConfigKey, "feature:acceptance_criteria"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	feature := &types.Issue{Title: "Export", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	err := store.CreateIssue(ctx, feature, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "feature issues require acceptance_criteria") {
+		t.Fatalf("expected missing acceptance criteria error, got %v", err)
+	}
+
+	feature.AcceptanceCriteria = "- [ ] Exports JSON"
+	if err := store.CreateIssue(ctx, feature, "test-user"); err != nil {
+		t.Fatalf("CreateIssue with acceptance criteria failed: %v", err)
+	}
+}
+
+func TestGetReadyWorkRankOrder(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	older := &types.Issue{Title: "Older", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	newer := &types.Issue{Title: "Newer", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{older, newer} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != older.ID {
+		t.Fatalf("Expected older issue first without ranks, got %v", ready)
+	}
+
+	if err := store.UpdateIssue(ctx, newer.ID, map[string]interface{}{"rank": 0.5}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != newer.ID {
+		t.Errorf("Expected ranked issue first, got %s then %s", ready[0].ID, ready[1].ID)
+	}
+}
+
+func TestDependentsIndexMatchesScan(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: "Issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	add := func(from, to string, depType types.DependencyType) {
+		t.Helper()
+		if err := store.AddDependency(ctx, &types.Dependency{IssueID: from, DependsOnID: to, Type: depType}, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	add(ids[1], ids[0], types.DepBlocks)
+	add(ids[2], ids[0], types.DepBlocks)
+	add(ids[2], ids[0], types.DepRelated)
+	add(ids[3], ids[1], types.DepParentChild)
+	if err := store.RemoveDependency(ctx, ids[1], ids[0], "test-user"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if err := store.UpdateIssueID(ctx, ids[1], "bd-100", store.issues[ids[1]], "test-user"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	// Compare the index against a full scan of forward edges for every issue
+	for id := range store.issues {
+		want := map[string]bool{}
+		for from, deps := range store.dependencies {
+			for _, dep := range deps {
+				if dep.DependsOnID == id {
+					want[from] = true
+				}
+			}
+		}
+
+		dependents, err := store.GetDependents(ctx, id)
+		if err != nil {
+			t.Fatalf("GetDependents failed: %v", err)
+		}
+		got := map[string]bool{}
+		for _, issue := range dependents {
+			got[issue.ID] = true
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: dependents %v, scan found %v", id, got, want)
+			continue
+		}
+		for from := range want {
+			if !got[from] {
+				t.Errorf("%s: dependents %v missing %s", id, got, from)
+			}
+		}
+	}
+}
+
+func TestGetEventsWithFilter(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Audited", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Audited twice"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	events, err := store.GetEventsWithFilter(ctx, issue.ID, types.EventFilter{Actor: "bob"})
+	if err != nil {
+		t.Fatalf("GetEventsWithFilter failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events by bob, got %d", len(events))
+	}
+	for _, e := range events {
+		if e.Actor != "bob" {
+			t.Errorf("Event by %s leaked through actor filter", e.Actor)
+		}
+	}
+
+	events, _ = store.GetEventsWithFilter(ctx, issue.ID, types.EventFilter{EventType: types.EventCreated})
+	if len(events) != 1 || events[0].Actor != "alice" {
+		t.Errorf("Expected alice's created event, got %v", events)
+	}
+
+	events, _ = store.GetEventsWithFilter(ctx, issue.ID, types.EventFilter{Actor: "bob", Limit: 1})
+	if len(events) != 1 {
+		t.Errorf("Expected limit 1, got %d events", len(events))
+	}
+}
+
+func TestClaimIssue(t *testing.T) {
+	store := setupTestMemory(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Shared work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var winners []string
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(agent string) {
+			defer wg.Done()
+			claimed, err := store.ClaimIssue(ctx, issue.ID, agent, time.Hour)
+			if err != nil {
+				t.Errorf("ClaimIssue failed: %v", err)
+				return
+			}
+			if claimed {
+				mu.Lock()
+				winners = append(winners, agent)
+				mu.Unlock()
+			}
+		}(fmt.Sprintf("agent-%d", i))
+	}
+	wg.Wait()
+	if len(winners) != 1 {
+		t.Fatalf("winners = %v, want exactly one", winners)
+	}
+	winner := winners[0]
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusInProgress || got.Assignee != winner {
+		t.Errorf("claimed issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+	if err := store.ReleaseIssue(ctx, issue.ID, "someone-else"); err == nil {
+		t.Error("expected an error releasing another agent's claim")
+	}
+	if err := store.ReleaseIssue(ctx, issue.ID, winner); err != nil {
+		t.Fatalf("ReleaseIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("released issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+
+	// An expired claim can be taken over
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Millisecond); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || !claimed {
+		t.Errorf("taking over an expired claim = %v, %v; want true", claimed, err)
+	}
+}
+
+func TestReadyWorkHonorsClaims(t *testing.T) {
+	store := setupTestMemory(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Shared work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// An expired claim is released: the issue is open, unassigned and ready again
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Millisecond); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 {
+		t.Errorf("issue with an expired claim not ready: %v", ready)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("expired claim left status %s, assignee %q", got.Status, got.Assignee)
+	}
+
+	// Closing a claimed issue drops its claim
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ReleaseIssue(ctx, issue.ID, "bob"); err == nil {
+		t.Error("expected closing to drop the claim")
+	}
+}
+
+func TestClaimFollowsRename(t *testing.T) {
+	store := setupTestMemory(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Shared work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if err := store.UpdateIssueID(ctx, issue.ID, "bd-renamed", got, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	if claimed, _ := store.ClaimIssue(ctx, "bd-renamed", "bob", time.Hour); claimed {
+		t.Error("bob claimed the renamed issue alice holds")
+	}
+	if err := store.ReleaseIssue(ctx, "bd-renamed", "alice"); err != nil {
+		t.Fatalf("ReleaseIssue after rename failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, "bd-renamed")
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("released issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+}
+
+func TestWatchers(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	before, _ := store.GetIssue(ctx, issue.ID)
+
+	if err := store.AddWatcher(ctx, issue.ID, "carol", "test"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, "alice", "test"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, "alice", "test"); err == nil {
+		t.Error("expected an error adding a duplicate watcher")
+	}
+	if len(before.Watchers) != 0 {
+		t.Errorf("earlier copy sees watchers %v", before.Watchers)
+	}
+
+	if err := store.RemoveWatcher(ctx, issue.ID, "carol", "test"); err != nil {
+		t.Fatalf("RemoveWatcher failed: %v", err)
+	}
+	if err := store.RemoveWatcher(ctx, issue.ID, "carol", "test"); err == nil {
+		t.Error("expected an error removing someone not watching")
+	}
+
+	// Watchers survive a reload from exported issues
+	got, _ := store.GetIssue(ctx, issue.ID)
+	reloaded := New("")
+	defer reloaded.Close()
+	if err := reloaded.LoadFromIssues([]*types.Issue{got}); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+	watchers, err := reloaded.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if len(watchers) != 1 || watchers[0] != "alice" {
+		t.Errorf("watchers after reload = %v, want [alice]", watchers)
+	}
+}
+
+func TestGetSLABreaches(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	if err := store.LoadFromIssues([]*types.Issue{
+		{ID: "bd-1", Title: "P0 late", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask, CreatedAt: now.Add(-25 * time.Hour)},
+		{ID: "bd-2", Title: "P0 fresh", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask, CreatedAt: now.Add(-23 * time.Hour)},
+		{ID: "bd-3", Title: "P2 old", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: now.Add(-500 * time.Hour)},
+	}); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, types.SLAConfigKey, "P0:1d"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	breaches, err := store.GetSLABreaches(ctx)
+	if err != nil {
+		t.Fatalf("GetSLABreaches failed: %v", err)
+	}
+	if len(breaches) != 1 || breaches[0].Issue.ID != "bd-1" {
+		t.Errorf("breaches = %v, want only bd-1", breaches)
+	}
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// patchKind says how a merge-patch value for an issue field is converted
+type patchKind int
+
+const (
+	patchText     patchKind = iota // string; null clears to ""
+	patchRequired                  // string; null is an error
+	patchOptional                  // string; null clears to NULL
+	patchInt                       // integer; null is an error
+	patchOptInt                    // integer; null clears to NULL
+	patchFloat                     // number; null clears to NULL
+	patchTime                      // RFC3339 timestamp; null clears to NULL
+)
+
+// patchFields maps the issue's JSON field names to the UpdateIssue keys they
+// set. Fields not listed here (id, timestamps, ...) can't be patched.
+var patchFields = map[string]patchKind{
+	"title":               patchRequired,
+	"description":         patchText,
+	"design":              patchText,
+	"acceptance_criteria": patchText,
+	"notes":               patchText,
+	"status":              patchRequired,
+	"priority":            patchInt,
+	"issue_type":          patchRequired,
+	"assignee":            patchOptional,
+	"external_ref":        patchOptional,
+	"estimated_minutes":   patchOptInt,
+	"due_date":            patchTime,
+	"rank":                patchFloat,
+}
+
+// PatchUpdates translates a JSON Merge Patch (RFC 7396) against an issue into
+// the updates map UpdateIssue takes. Keys absent from the patch are left
+// alone; null clears a field, or is rejected for fields that can't be empty.
+func PatchUpdates(patch []byte) (map[string]interface{}, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &doc); err != nil || doc == nil {
+		return nil, fmt.Errorf("merge patch must be a JSON object")
+	}
+
+	updates := make(map[string]interface{}, len(doc))
+	for key, raw := range doc {
+		kind, ok := patchFields[key]
+		if !ok {
+			return nil, fmt.Errorf("field %q cannot be patched", key)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		if value == nil {
+			switch kind {
+			case patchRequired, patchInt:
+				return nil, fmt.Errorf("%s cannot be cleared", key)
+			case patchText:
+				updates[key] = ""
+			default:
+				updates[key] = nil
+			}
+			continue
+		}
+
+		converted, err := patchValue(kind, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		updates[key] = converted
+	}
+	return updates, nil
+}
+
+func patchValue(kind patchKind, value interface{}) (interface{}, error) {
+	switch kind {
+	case patchText, patchRequired, patchOptional:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string")
+		}
+		return s, nil
+	case patchInt, patchOptInt:
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return int(n), nil
+	case patchFloat:
+		n, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a number")
+		}
+		return n, nil
+	case patchTime:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an RFC3339 timestamp")
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC3339 timestamp")
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported field")
+}
+
+// ApplyPatch applies a JSON Merge Patch to issue id. An empty patch changes
+// nothing, not even the issue's updated_at.
+func ApplyPatch(ctx context.Context, store Storage, id string, patch []byte, actor string) error {
+	updates, err := PatchUpdates(patch)
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return store.UpdateIssue(ctx, id, updates, actor)
+}
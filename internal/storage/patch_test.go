@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchUpdates(t *testing.T) {
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		patch string
+		want  map[string]interface{}
+	}{
+		{"no-op", `{}`, map[string]interface{}{}},
+		{
+			"set",
+			`{"title":"New","priority":1,"assignee":"bob","due_date":"2026-03-01T00:00:00Z","rank":2.5}`,
+			map[string]interface{}{"title": "New", "priority": 1, "assignee": "bob", "due_date": due, "rank": 2.5},
+		},
+		{
+			"clear",
+			`{"assignee":null,"external_ref":null,"notes":null,"due_date":null,"rank":null}`,
+			map[string]interface{}{"assignee": nil, "external_ref": nil, "notes": "", "due_date": nil, "rank": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PatchUpdates([]byte(tt.patch))
+			if err != nil {
+				t.Fatalf("PatchUpdates(%s) error: %v", tt.patch, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PatchUpdates(%s) = %#v, want %#v", tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchUpdatesErrors(t *testing.T) {
+	tests := []struct {
+		patch string
+		want  string
+	}{
+		{`[1,2]`, "must be a JSON object"},
+		{`null`, "must be a JSON object"},
+		{`{"id":"bd-9"}`, `field "id" cannot be patched`},
+		{`{"title":null}`, "title cannot be cleared"},
+		{`{"priority":1.5}`, "expected an integer"},
+		{`{"assignee":7}`, "expected a string"},
+		{`{"due_date":"tomorrow"}`, "expected an RFC3339 timestamp"},
+	}
+	for _, tt := range tests {
+		_, err := PatchUpdates([]byte(tt.patch))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("PatchUpdates(%s) error = %v, want %q", tt.patch, err, tt.want)
+		}
+	}
+}
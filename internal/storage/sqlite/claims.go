@@ -0,0 +1,241 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ClaimIssue gives agent an exclusive claim on issue id for lease, moving it
+// from open to in_progress and assigning it to agent. It returns false if
+// another agent holds an unexpired claim or the issue is already in progress
+// without one. An agent claiming an issue it already holds renews the lease,
+// and an expired claim can be taken over by anyone.
+//
+// The issue is taken with a single conditional UPDATE under the write lock,
+// so of several processes racing to claim it exactly one succeeds.
+func (s *SQLiteStorage) ClaimIssue(ctx context.Context, id, agent string, lease time.Duration) (bool, error) {
+	if agent == "" {
+		return false, fmt.Errorf("agent cannot be empty")
+	}
+	if lease <= 0 {
+		return false, fmt.Errorf("lease must be positive, got %v", lease)
+	}
+
+	claimed := false
+	err := s.withImmediateTx(ctx, func(conn *sql.Conn) error {
+		var err error
+		claimed, err = claimIssue(ctx, conn, id, agent, lease)
+		return err
+	})
+	return claimed, err
+}
+
+func claimIssue(ctx context.Context, conn *sql.Conn, id, agent string, lease time.Duration) (bool, error) {
+	// Claim times are stored in UTC so they compare correctly as text
+	now := time.Now().UTC()
+	result, err := conn.ExecContext(ctx, `
+		UPDATE issues SET status = ?, assignee = ?, updated_at = ?
+		WHERE id = ? AND (
+			(status = ? AND NOT EXISTS (
+				SELECT 1 FROM issue_claims c
+				WHERE c.issue_id = issues.id AND c.agent != ? AND c.expires_at > ?))
+			OR (status = ? AND EXISTS (
+				SELECT 1 FROM issue_claims c
+				WHERE c.issue_id = issues.id AND (c.agent = ? OR c.expires_at <= ?)))
+		)
+	`, types.StatusInProgress, agent, now, id,
+		types.StatusOpen, agent, now,
+		types.StatusInProgress, agent, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim issue: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return false, claimFailure(ctx, conn, id)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO issue_claims (issue_id, agent, claimed_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET
+			agent = excluded.agent,
+			claimed_at = excluded.claimed_at,
+			expires_at = excluded.expires_at
+	`, id, agent, now, now.Add(lease))
+	if err != nil {
+		return false, fmt.Errorf("failed to record claim: %w", err)
+	}
+
+	if err := recordClaimEvent(ctx, conn, id, types.EventClaimed, agent, nil, &agent); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// claimFailure explains why ClaimIssue could not take issue id: nil if it is
+// held by someone else, or an error if it cannot be claimed at all
+func claimFailure(ctx context.Context, conn *sql.Conn, id string) error {
+	var status types.Status
+	err := conn.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if status != types.StatusOpen && status != types.StatusInProgress {
+		return fmt.Errorf("cannot claim %s: issue is %s", id, status)
+	}
+	return nil
+}
+
+// ReleaseIssue gives up agent's claim on issue id, returning it to open and
+// unassigning it from the claim holder. Anyone may release an expired claim.
+func (s *SQLiteStorage) ReleaseIssue(ctx context.Context, id, agent string) error {
+	return s.withImmediateTx(ctx, func(conn *sql.Conn) error {
+		return releaseIssue(ctx, conn, id, agent)
+	})
+}
+
+func releaseIssue(ctx context.Context, conn *sql.Conn, id, agent string) error {
+	now := time.Now().UTC()
+	var holder string
+	err := conn.QueryRowContext(ctx, `
+		DELETE FROM issue_claims WHERE issue_id = ? AND (agent = ? OR expires_at <= ?)
+		RETURNING agent
+	`, id, agent, now).Scan(&holder)
+	if err == sql.ErrNoRows {
+		var expiresAt time.Time
+		err := conn.QueryRowContext(ctx, `SELECT agent, expires_at FROM issue_claims WHERE issue_id = ?`, id).Scan(&holder, &expiresAt)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("issue %s is not claimed", id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get claim: %w", err)
+		}
+		return fmt.Errorf("issue %s is claimed by %s until %s", id, holder, expiresAt.Local().Format(time.RFC3339))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release claim: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		UPDATE issues
+		SET status = ?, assignee = CASE WHEN assignee = ? THEN '' ELSE assignee END, updated_at = ?
+		WHERE id = ? AND status = ?
+	`, types.StatusOpen, holder, now, id, types.StatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+
+	return recordClaimEvent(ctx, conn, id, types.EventReleased, agent, &holder, nil)
+}
+
+// claimExpiryActor is the actor recorded when an expired claim is released
+const claimExpiryActor = "lease-expiry"
+
+// releaseExpiredClaims releases every claim whose lease has run out, returning
+// its issue to open as ReleaseIssue would, so an agent that died holding a
+// claim doesn't keep its issue in progress. Ready-work queries call it first.
+func (s *SQLiteStorage) releaseExpiredClaims(ctx context.Context) error {
+	// Check before taking the write lock, as there is usually nothing to do
+	var expired bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM issue_claims WHERE expires_at <= ?)
+	`, time.Now().UTC()).Scan(&expired)
+	if err != nil {
+		return fmt.Errorf("failed to check for expired claims: %w", err)
+	}
+	if !expired {
+		return nil
+	}
+
+	return s.withImmediateTx(ctx, func(conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, `
+			SELECT issue_id FROM issue_claims WHERE expires_at <= ?
+		`, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to find expired claims: %w", err)
+		}
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan expired claim: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := releaseIssue(ctx, conn, id, claimExpiryActor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// recordClaimEvent records a claim or release of issue id and marks it dirty
+func recordClaimEvent(ctx context.Context, conn *sql.Conn, id string, eventType types.EventType, actor string, oldValue, newValue *string) error {
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, eventType, actor, oldValue, newValue)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// withImmediateTx runs fn in a BEGIN IMMEDIATE transaction on a dedicated
+// connection, committing if it returns nil. Taking the write lock up front
+// means fn's reads cannot be invalidated by another writer before it writes.
+func (s *SQLiteStorage) withImmediateTx(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+
+	// Use context.Background() for ROLLBACK so cleanup happens even if ctx is canceled
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	if err := fn(conn); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
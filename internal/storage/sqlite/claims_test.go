@@ -0,0 +1,283 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func createClaimTestIssue(t *testing.T, store *SQLiteStorage) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{Title: "Shared work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func TestClaimIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createClaimTestIssue(t, store)
+
+	claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour)
+	if err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusInProgress || got.Assignee != "alice" {
+		t.Errorf("claimed issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+	var claimedAt time.Time
+	if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT claimed_at FROM issue_claims WHERE issue_id = ?`, issue.ID).Scan(&claimedAt); err != nil {
+		t.Fatalf("failed to read claim: %v", err)
+	}
+	if !got.UpdatedAt.Equal(claimedAt) {
+		t.Errorf("updated_at %v differs from claimed_at %v", got.UpdatedAt, claimedAt)
+	}
+
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || claimed {
+		t.Errorf("second agent ClaimIssue = %v, %v; want false", claimed, err)
+	}
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); err != nil || !claimed {
+		t.Errorf("renewing ClaimIssue = %v, %v; want true", claimed, err)
+	}
+
+	if err := store.ReleaseIssue(ctx, issue.ID, "bob"); err == nil || !strings.Contains(err.Error(), "claimed by alice") {
+		t.Errorf("release by another agent error = %v", err)
+	}
+	if err := store.ReleaseIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("ReleaseIssue failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("released issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+	if err := store.ReleaseIssue(ctx, issue.ID, "alice"); err == nil {
+		t.Error("expected an error releasing an unclaimed issue")
+	}
+
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || !claimed {
+		t.Errorf("ClaimIssue after release = %v, %v; want true", claimed, err)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	counts := map[types.EventType]int{}
+	for _, event := range events {
+		counts[event.EventType]++
+	}
+	if counts[types.EventClaimed] != 3 || counts[types.EventReleased] != 1 {
+		t.Errorf("event counts = %v, want 3 claimed and 1 released", counts)
+	}
+}
+
+func TestClaimIssueExpiredLease(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createClaimTestIssue(t, store)
+
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Millisecond); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || !claimed {
+		t.Fatalf("taking over an expired claim = %v, %v; want true", claimed, err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Assignee != "bob" {
+		t.Errorf("assignee = %q, want bob", got.Assignee)
+	}
+	if claimed, _ := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); claimed {
+		t.Error("alice reclaimed an issue bob holds")
+	}
+}
+
+func TestReadyWorkHonorsClaims(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createClaimTestIssue(t, store)
+
+	readyIDs := func(filter types.WorkFilter) []string {
+		t.Helper()
+		ready, err := store.GetReadyWork(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		var ids []string
+		for _, r := range ready {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	if ids := readyIDs(types.WorkFilter{}); len(ids) != 0 {
+		t.Errorf("claimed issue is ready for everyone: %v", ids)
+	}
+	alice := "alice"
+	if ids := readyIDs(types.WorkFilter{Assignee: &alice}); len(ids) != 1 {
+		t.Errorf("claimed issue not ready for its holder: %v", ids)
+	}
+
+	// An expired claim is released: the issue is open and unassigned again
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Millisecond); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ids := readyIDs(types.WorkFilter{Status: types.StatusOpen}); len(ids) != 1 {
+		t.Errorf("issue with an expired claim not ready: %v", ids)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("expired claim left status %s, assignee %q", got.Status, got.Assignee)
+	}
+
+	// Closing a claimed issue drops its claim
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "bob", time.Hour); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	var claims int
+	if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT COUNT(*) FROM issue_claims`).Scan(&claims); err != nil {
+		t.Fatalf("failed to count claims: %v", err)
+	}
+	if claims != 0 {
+		t.Errorf("closed issue still has %d claims", claims)
+	}
+}
+
+func TestClaimFollowsRename(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createClaimTestIssue(t, store)
+	oldID := issue.ID
+
+	if claimed, err := store.ClaimIssue(ctx, oldID, "alice", time.Hour); err != nil || !claimed {
+		t.Fatalf("ClaimIssue = %v, %v; want true", claimed, err)
+	}
+	got, _ := store.GetIssue(ctx, oldID)
+	if err := store.UpdateIssueID(ctx, oldID, "bd-renamed", got, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	var stale int
+	if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT COUNT(*) FROM issue_claims WHERE issue_id = ?`, oldID).Scan(&stale); err != nil {
+		t.Fatalf("failed to count claims: %v", err)
+	}
+	if stale != 0 {
+		t.Errorf("%d claim(s) left under the old ID %s", stale, oldID)
+	}
+
+	if claimed, _ := store.ClaimIssue(ctx, "bd-renamed", "bob", time.Hour); claimed {
+		t.Error("bob claimed the renamed issue alice holds")
+	}
+	if err := store.ReleaseIssue(ctx, "bd-renamed", "alice"); err != nil {
+		t.Fatalf("ReleaseIssue after rename failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, "bd-renamed")
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("released issue has status %s, assignee %q", got.Status, got.Assignee)
+	}
+}
+
+func TestClaimIssueRejectsUnclaimable(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	issue := createClaimTestIssue(t, store)
+
+	// In progress without a claim means someone is on it already
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if claimed, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); err != nil || claimed {
+		t.Errorf("claiming an unclaimed in-progress issue = %v, %v; want false", claimed, err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if _, err := store.ClaimIssue(ctx, issue.ID, "alice", time.Hour); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("claiming a closed issue error = %v", err)
+	}
+	if _, err := store.ClaimIssue(ctx, "bd-999", "alice", time.Hour); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("claiming a missing issue error = %v", err)
+	}
+}
+
+// TestClaimIssueContended races agents in separate stores on one database,
+// as separate bd processes would, and checks exactly one wins
+func TestClaimIssueContended(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	first := newTestStore(t, dbPath)
+	defer first.Close()
+	issue := createClaimTestIssue(t, first)
+
+	const agents = 8
+	stores := []*SQLiteStorage{first}
+	for i := 1; i < agents; i++ {
+		s, err := New(dbPath)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer s.Close()
+		stores = append(stores, s)
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan string, agents)
+	errs := make(chan error, agents)
+	start := make(chan struct{})
+	for i, s := range stores {
+		wg.Add(1)
+		go func(agent string, s *SQLiteStorage) {
+			defer wg.Done()
+			<-start
+			claimed, err := s.ClaimIssue(context.Background(), issue.ID, agent, time.Hour)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if claimed {
+				results <- agent
+			}
+		}(fmt.Sprintf("agent-%d", i), s)
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("ClaimIssue failed: %v", err)
+	}
+	var winners []string
+	for agent := range results {
+		winners = append(winners, agent)
+	}
+	if len(winners) != 1 {
+		t.Fatalf("winners = %v, want exactly one", winners)
+	}
+	got, _ := first.GetIssue(context.Background(), issue.ID)
+	if got.Assignee != winners[0] {
+		t.Errorf("assignee = %q, want the winner %q", got.Assignee, winners[0])
+	}
+}
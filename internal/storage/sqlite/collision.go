@@ -350,7 +350,7 @@ func updateReferences(ctx context.Context, s *SQLiteStorage, idMapping map[strin
 
 	// Update text fields in all issues (both DB and incoming)
 	// We need to update issues in the database
-	dbIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	dbIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get all issues from DB: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -61,6 +62,15 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		}
 	}
 
+	if dryRun := storage.DryRunFrom(ctx); dryRun != nil {
+		cycle, err := dependencyCreatesCycle(ctx, s.db, dep)
+		if err != nil {
+			return err
+		}
+		dryRun.Cycle = cycle
+		return nil
+	}
+
 	if dep.CreatedAt.IsZero() {
 		dep.CreatedAt = time.Now()
 	}
@@ -95,36 +105,10 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	//
 	// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 	// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
-	var cycleExists bool
-	err = tx.QueryRowContext(ctx, `
-		WITH RECURSIVE paths AS (
-			SELECT
-				issue_id,
-				depends_on_id,
-				1 as depth
-			FROM dependencies
-			WHERE issue_id = ?
-
-			UNION ALL
-
-			SELECT
-				d.issue_id,
-				d.depends_on_id,
-				p.depth + 1
-			FROM dependencies d
-			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < ?
-		)
-		SELECT EXISTS(
-			SELECT 1 FROM paths
-			WHERE depends_on_id = ?
-		)
-	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
-
+	cycleExists, err := dependencyCreatesCycle(ctx, tx, dep)
 	if err != nil {
-		return fmt.Errorf("failed to check for cycles: %w", err)
+		return err
 	}
-
 	if cycleExists {
 		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
 			dep.IssueID, dep.DependsOnID, dep.IssueID)
@@ -158,6 +142,45 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	return tx.Commit()
 }
 
+// queryRower is satisfied by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// dependencyCreatesCycle reports whether adding dep would complete a cycle,
+// i.e. whether dep.IssueID is already reachable from dep.DependsOnID
+func dependencyCreatesCycle(ctx context.Context, q queryRower, dep *types.Dependency) (bool, error) {
+	var cycleExists bool
+	err := q.QueryRowContext(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT
+				issue_id,
+				depends_on_id,
+				1 as depth
+			FROM dependencies
+			WHERE issue_id = ?
+
+			UNION ALL
+
+			SELECT
+				d.issue_id,
+				d.depends_on_id,
+				p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < ?
+		)
+		SELECT EXISTS(
+			SELECT 1 FROM paths
+			WHERE depends_on_id = ?
+		)
+	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for cycles: %w", err)
+	}
+	return cycleExists, nil
+}
+
 // addDependencyUnchecked adds a dependency with minimal validation, used during
 // import/remap operations where we're preserving existing dependencies with new IDs.
 // Skips semantic validation (parent-child direction) but keeps essential checks:
@@ -171,19 +194,19 @@ func (s *SQLiteStorage) addDependencyUnchecked(ctx context.Context, dep *types.D
 	}
 
 	// Validate that both issues exist
-	issueExists, err := s.GetIssue(ctx, dep.IssueID)
+	issueExists, err := s.IssueExists(ctx, dep.IssueID)
 	if err != nil {
 		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
 	}
-	if issueExists == nil {
+	if !issueExists {
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
 
-	dependsOnExists, err := s.GetIssue(ctx, dep.DependsOnID)
+	dependsOnExists, err := s.IssueExists(ctx, dep.DependsOnID)
 	if err != nil {
 		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
 	}
-	if dependsOnExists == nil {
+	if !dependsOnExists {
 		return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
 	}
 
@@ -304,8 +327,17 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	// Mark both issues as dirty for incremental export
-	if err := markIssuesDirtyTx(ctx, tx, []string{issueID, dependsOnID}); err != nil {
+	// Mark both issues as dirty for incremental export.
+	// The target may not exist when removing a dangling dependency.
+	dirtyIDs := []string{issueID}
+	var targetExists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, dependsOnID).Scan(&targetExists); err != nil {
+		return fmt.Errorf("failed to check dependency target: %w", err)
+	}
+	if targetExists {
+		dirtyIDs = append(dirtyIDs, dependsOnID)
+	}
+	if err := markIssuesDirtyTx(ctx, tx, dirtyIDs); err != nil {
 		return err
 	}
 
@@ -360,7 +392,7 @@ func (s *SQLiteStorage) GetDependencies(ctx context.Context, issueID string) ([]
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
 		WHERE d.issue_id = ?
@@ -379,7 +411,7 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -458,6 +490,56 @@ func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string
 	return depsMap, nil
 }
 
+// GetDanglingDependencies returns dependencies whose target issue doesn't exist.
+// Foreign keys normally prevent these, but they can be left behind by databases
+// written with foreign keys disabled (e.g. older versions or prefix renames).
+func (s *SQLiteStorage) GetDanglingDependencies(ctx context.Context) ([]*types.DanglingDependency, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.issue_id, d.depends_on_id, d.type
+		FROM dependencies d
+		LEFT JOIN issues i ON d.depends_on_id = i.id
+		WHERE i.id IS NULL
+		ORDER BY d.issue_id, d.depends_on_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dangling dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var dangling []*types.DanglingDependency
+	for rows.Next() {
+		var dep types.DanglingDependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan dangling dependency: %w", err)
+		}
+		dangling = append(dangling, &dep)
+	}
+
+	return dangling, rows.Err()
+}
+
+// GetOrphanIssues returns unclosed issues that are not linked to anything:
+// they have no dependencies (including no parent) and nothing depends on them
+func (s *SQLiteStorage) GetOrphanIssues(ctx context.Context) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
+		FROM issues i
+		WHERE i.status != 'closed'
+		  AND i.archived_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.issue_id = i.id)
+		  AND NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.depends_on_id = i.id)
+		ORDER BY i.priority ASC, i.created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orphan issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
 // GetDependencyTree returns the full dependency tree with optional deduplication
 // When showAllPaths is false (default), nodes appearing via multiple paths (diamond dependencies)
 // appear only once at their shallowest depth in the tree.
@@ -714,12 +796,17 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		var estimatedMinutes sql.NullInt64
 		var assignee sql.NullString
 		var externalRef sql.NullString
+		var archivedAt sql.NullTime
+		var reporter sql.NullString
+		var dueDate sql.NullTime
+		var rank sql.NullFloat64
 
 		err := rows.Scan(
 			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+			&archivedAt, &reporter, &dueDate, &rank,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
@@ -738,6 +825,18 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		if externalRef.Valid {
 			issue.ExternalRef = &externalRef.String
 		}
+		if archivedAt.Valid {
+			issue.ArchivedAt = &archivedAt.Time
+		}
+		if reporter.Valid {
+			issue.Reporter = reporter.String
+		}
+		if dueDate.Valid {
+			issue.DueDate = &dueDate.Time
+		}
+		if rank.Valid {
+			issue.Rank = &rank.Float64
+		}
 
 		// Fetch labels for this issue
 		labels, err := s.GetLabels(ctx, issue.ID)
@@ -746,6 +845,18 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		}
 		issue.Labels = labels
 
+		refs, err := s.GetExternalRefs(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		issue.ExternalRefs = refs
+
+		watchers, err := s.GetWatchers(ctx, issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		issue.Watchers = watchers
+
 		issues = append(issues, &issue)
 	}
 
@@ -150,6 +150,130 @@ func TestRemoveDependency(t *testing.T) {
 	}
 }
 
+func TestGetDanglingDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue1 := &types.Issue{Title: "Kept", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	issue2 := &types.Issue{Title: "Deleted", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	issue3 := &types.Issue{Title: "Dependent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{issue1, issue2, issue3} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, target := range []string{issue1.ID, issue2.ID} {
+		dep := &types.Dependency{IssueID: issue3.ID, DependsOnID: target, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	dangling, err := store.GetDanglingDependencies(ctx)
+	if err != nil {
+		t.Fatalf("GetDanglingDependencies failed: %v", err)
+	}
+	if len(dangling) != 0 {
+		t.Fatalf("Expected no dangling dependencies, got %v", dangling)
+	}
+
+	// Delete the target with foreign keys off so the edge is left behind
+	conn, err := store.UnderlyingConn(ctx)
+	if err != nil {
+		t.Fatalf("UnderlyingConn failed: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatalf("Failed to disable foreign keys: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, issue2.ID); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+		t.Fatalf("Failed to re-enable foreign keys: %v", err)
+	}
+	conn.Close()
+
+	dangling, err = store.GetDanglingDependencies(ctx)
+	if err != nil {
+		t.Fatalf("GetDanglingDependencies failed: %v", err)
+	}
+	if len(dangling) != 1 {
+		t.Fatalf("Expected 1 dangling dependency, got %d", len(dangling))
+	}
+	if dangling[0].IssueID != issue3.ID || dangling[0].DependsOnID != issue2.ID || dangling[0].Type != types.DepBlocks {
+		t.Errorf("Unexpected dangling dependency: %+v", dangling[0])
+	}
+
+	// Removing the dangling edge must work even though the target is gone
+	if err := store.RemoveDependency(ctx, issue3.ID, issue2.ID, "test-user"); err != nil {
+		t.Fatalf("RemoveDependency on dangling edge failed: %v", err)
+	}
+	dangling, err = store.GetDanglingDependencies(ctx)
+	if err != nil {
+		t.Fatalf("GetDanglingDependencies failed: %v", err)
+	}
+	if len(dangling) != 0 {
+		t.Errorf("Expected dangling dependency to be removed, got %v", dangling)
+	}
+
+	// The valid dependency is untouched
+	deps, err := store.GetDependencies(ctx, issue3.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].ID != issue1.ID {
+		t.Errorf("Expected remaining dependency on %s, got %v", issue1.ID, deps)
+	}
+}
+
+func TestGetOrphanIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	create := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	epic := create("Epic")
+	child := create("Child of epic")
+	blocker := create("Blocker")
+	blocked := create("Blocked")
+	orphan := create("Genuine orphan")
+	closedOrphan := create("Closed orphan")
+
+	for _, dep := range []*types.Dependency{
+		{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild},
+		{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, closedOrphan.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	orphans, err := store.GetOrphanIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetOrphanIssues failed: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].ID != orphan.ID {
+		ids := make([]string, len(orphans))
+		for i, o := range orphans {
+			ids[i] = o.ID
+		}
+		t.Errorf("Expected only %s to be orphaned, got %v", orphan.ID, ids)
+	}
+}
+
 func TestAddDependencyPreservesProvidedMetadata(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// readDBFiles returns the contents of the database file and its WAL, which a
+// dry run must leave alone
+func readDBFiles(t *testing.T, store *SQLiteStorage) [][]byte {
+	t.Helper()
+	var contents [][]byte
+	for _, path := range []string{store.Path(), store.Path() + "-wal"} {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		contents = append(contents, data)
+	}
+	return contents
+}
+
+func assertDBUnchanged(t *testing.T, store *SQLiteStorage, before [][]byte) {
+	t.Helper()
+	after := readDBFiles(t, store)
+	for i := range before {
+		if !bytes.Equal(before[i], after[i]) {
+			t.Errorf("dry run modified the database files")
+			return
+		}
+	}
+}
+
+func TestUpdateIssueDryRun(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("ClearDirtyIssues failed: %v", err)
+	}
+	before := readDBFiles(t, store)
+
+	dryCtx, dryRun := storage.WithDryRun(ctx)
+	updates := map[string]interface{}{"title": "Renamed", "status": string(types.StatusClosed), "priority": 2}
+	if err := store.UpdateIssue(dryCtx, issue.ID, updates, "test-user"); err != nil {
+		t.Fatalf("dry-run UpdateIssue failed: %v", err)
+	}
+
+	if dryRun.Issue == nil || dryRun.Issue.Title != "Renamed" || dryRun.Issue.ClosedAt == nil {
+		t.Fatalf("dry run result = %+v, want the renamed, closed issue", dryRun.Issue)
+	}
+	var fields []string
+	for _, change := range dryRun.Changes {
+		fields = append(fields, change.Field)
+	}
+	// priority is unchanged, so it isn't in the diff
+	if got, want := fields, []string{"closed_at", "status", "title"}; !slices.Equal(got, want) {
+		t.Errorf("changed fields = %v, want %v", got, want)
+	}
+
+	assertDBUnchanged(t, store, before)
+	stored, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if stored.Title != "Original" || stored.Status != types.StatusOpen {
+		t.Errorf("dry run changed the issue: %+v", stored)
+	}
+	if dirty, _ := store.GetDirtyIssues(ctx); len(dirty) != 0 {
+		t.Errorf("dry run marked issues dirty: %v", dirty)
+	}
+
+	// Validation still applies in a dry run
+	dryCtx, _ = storage.WithDryRun(ctx)
+	if err := store.UpdateIssue(dryCtx, issue.ID, map[string]interface{}{"priority": 9}, "test-user"); err == nil {
+		t.Error("dry run accepted an invalid priority")
+	}
+
+	// So does the expected version
+	dryCtx, _ = storage.WithDryRun(storage.WithExpectedVersion(ctx, "stale"))
+	if err := store.UpdateIssue(dryCtx, issue.ID, map[string]interface{}{"priority": 1}, "test-user"); !errors.Is(err, storage.ErrVersionConflict) {
+		t.Errorf("dry run with a stale version: expected ErrVersionConflict, got %v", err)
+	}
+}
+
+func TestAddDependencyDryRun(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	before := readDBFiles(t, store)
+
+	// b -> a would close the loop a -> b -> a
+	dryCtx, dryRun := storage.WithDryRun(ctx)
+	if err := store.AddDependency(dryCtx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("dry-run AddDependency failed: %v", err)
+	}
+	if !dryRun.Cycle {
+		t.Error("dry run did not report the cycle")
+	}
+	assertDBUnchanged(t, store, before)
+
+	c := &types.Issue{Title: "C", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, c, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	before = readDBFiles(t, store)
+	dryCtx, dryRun = storage.WithDryRun(ctx)
+	if err := store.AddDependency(dryCtx, &types.Dependency{IssueID: c.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("dry-run AddDependency failed: %v", err)
+	}
+	if dryRun.Cycle {
+		t.Error("dry run reported a cycle that doesn't exist")
+	}
+
+	assertDBUnchanged(t, store, before)
+	if deps, _ := store.GetDependencies(ctx, c.ID); len(deps) != 0 {
+		t.Errorf("dry run added a dependency: %v", deps)
+	}
+}
@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -37,6 +39,7 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 		LEFT JOIN epic_stats es ON es.epic_id = i.id
 		WHERE i.issue_type = 'epic'
 		  AND i.status != 'closed'
+		  AND i.archived_at IS NULL
 		ORDER BY i.priority ASC, i.created_at ASC
 	`
 
@@ -84,3 +87,55 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 
 	return results, rows.Err()
 }
+
+// GetEpicProgress returns child counts by state, percent complete, and estimate
+// totals for an epic's direct parent-child children
+func (s *SQLiteStorage) GetEpicProgress(ctx context.Context, epicID string) (*types.EpicProgress, error) {
+	epic, err := s.GetIssue(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+	if epic == nil {
+		return nil, fmt.Errorf("issue %s not found", epicID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.status, i.estimated_minutes,
+			EXISTS (
+				SELECT 1 FROM dependencies bd
+				JOIN issues b ON b.id = bd.depends_on_id
+				WHERE bd.issue_id = i.id AND bd.type = 'blocks' AND b.status != 'closed'
+			) AS has_open_blocker
+		FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = 'parent-child'
+		ORDER BY i.id
+	`, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epic children: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	progress := &types.EpicProgress{Epic: epic}
+	for rows.Next() {
+		var child types.Issue
+		var hasOpenBlocker bool
+		if err := rows.Scan(&child.ID, &child.Status, &child.EstimatedMinutes, &hasOpenBlocker); err != nil {
+			return nil, fmt.Errorf("failed to scan epic child: %w", err)
+		}
+		progress.AddChild(&child, hasOpenBlocker)
+	}
+
+	return progress, rows.Err()
+}
+
+// ProjectEpicCompletion projects when an epic will finish by dividing the estimates of
+// its unclosed children by a throughput of minutesPerDay. Unclosed children without an
+// estimate are reported in a *types.UnestimatedChildrenError rather than counted as zero.
+func (s *SQLiteStorage) ProjectEpicCompletion(ctx context.Context, epicID string, minutesPerDay float64) (time.Time, error) {
+	progress, err := s.GetEpicProgress(ctx, epicID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return progress.ProjectCompletion(time.Now(), minutesPerDay)
+}
@@ -2,7 +2,9 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -154,3 +156,146 @@ func TestGetEpicsEligibleForClosureWithNoChildren(t *testing.T) {
 	e := h.assertEpicFound(epics, epic.ID, "No children")
 	h.assertEpicStats(e, 0, 0, false, "No children")
 }
+
+func TestGetEpicsEligibleForClosureSkipsArchived(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := newEpicTestHelper(t, store)
+	kept := h.createEpic("Kept Epic")
+	archived := h.createEpic("Archived Epic")
+	if err := store.ArchiveIssue(h.ctx, archived.ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+
+	epics := h.getEligibleEpics()
+	h.assertEpicFound(epics, kept.ID, "Unarchived epic")
+	h.assertEpicNotFound(epics, archived.ID, "Archived epic")
+}
+
+func TestGetEpicProgress(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := newEpicTestHelper(t, store)
+	epic := h.createEpic("Progress Epic")
+
+	closed1 := h.createTask("Closed 1")
+	closed2 := h.createTask("Closed 2")
+	inProgress := h.createTask("In progress")
+	blocked := h.createTask("Blocked")
+	open := h.createTask("Open")
+	blocker := h.createTask("Blocker (not a child)")
+	for _, child := range []*types.Issue{closed1, closed2, inProgress, blocked, open} {
+		h.addParentChildDependency(child.ID, epic.ID)
+	}
+
+	estimates := map[string]int{closed1.ID: 30, closed2.ID: 60, inProgress.ID: 120, open.ID: 15}
+	for id, minutes := range estimates {
+		if err := store.UpdateIssue(h.ctx, id, map[string]interface{}{"estimated_minutes": minutes}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	if err := store.UpdateIssue(h.ctx, inProgress.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddDependency(h.ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	h.closeIssue(closed1.ID, "Done")
+	h.closeIssue(closed2.ID, "Done")
+
+	progress, err := store.GetEpicProgress(h.ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicProgress failed: %v", err)
+	}
+
+	if progress.Epic.ID != epic.ID {
+		t.Errorf("Expected epic %s, got %s", epic.ID, progress.Epic.ID)
+	}
+	if progress.TotalChildren != 5 || progress.ClosedChildren != 2 || progress.InProgressChildren != 1 ||
+		progress.BlockedChildren != 1 || progress.OpenChildren != 1 {
+		t.Errorf("Unexpected child counts: %+v", progress)
+	}
+	if progress.PercentComplete != 40 {
+		t.Errorf("Expected 40%% complete, got %v", progress.PercentComplete)
+	}
+	if progress.EstimatedMinutes != 225 || progress.CompletedMinutes != 90 || progress.RemainingMinutes != 135 {
+		t.Errorf("Unexpected estimate totals: estimated=%d completed=%d remaining=%d",
+			progress.EstimatedMinutes, progress.CompletedMinutes, progress.RemainingMinutes)
+	}
+
+	// Closing the blocker unblocks the child
+	h.closeIssue(blocker.ID, "Done")
+	progress, err = store.GetEpicProgress(h.ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicProgress failed: %v", err)
+	}
+	if progress.BlockedChildren != 0 || progress.OpenChildren != 2 {
+		t.Errorf("Expected blocked child to become open, got %+v", progress)
+	}
+
+	if _, err := store.GetEpicProgress(h.ctx, "bd-nonexistent"); err == nil {
+		t.Error("Expected error for nonexistent epic")
+	}
+}
+
+func TestGetEpicProgressNoChildren(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := newEpicTestHelper(t, store)
+	epic := h.createEpic("Empty Epic")
+
+	progress, err := store.GetEpicProgress(h.ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetEpicProgress failed: %v", err)
+	}
+	if progress.TotalChildren != 0 || progress.PercentComplete != 0 {
+		t.Errorf("Expected empty progress, got %+v", progress)
+	}
+}
+
+func TestProjectEpicCompletion(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := newEpicTestHelper(t, store)
+	epic := h.createEpic("Projected Epic")
+	done := h.createTask("Done")
+	todo1 := h.createTask("Todo 1")
+	todo2 := h.createTask("Todo 2")
+	for id, minutes := range map[string]int{done.ID: 600, todo1.ID: 480, todo2.ID: 240} {
+		h.addParentChildDependency(id, epic.ID)
+		if err := store.UpdateIssue(h.ctx, id, map[string]interface{}{"estimated_minutes": minutes}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	h.closeIssue(done.ID, "Done")
+
+	// 720 remaining minutes at 240 per day is three days out
+	before := time.Now()
+	eta, err := store.ProjectEpicCompletion(h.ctx, epic.ID, 240)
+	if err != nil {
+		t.Fatalf("ProjectEpicCompletion failed: %v", err)
+	}
+	if eta.Before(before.Add(72*time.Hour)) || eta.After(time.Now().Add(72*time.Hour)) {
+		t.Errorf("eta %v is not three days from now", eta)
+	}
+
+	// An unestimated child is flagged rather than counted as zero
+	unestimated := h.createTask("Unestimated")
+	h.addParentChildDependency(unestimated.ID, epic.ID)
+	_, err = store.ProjectEpicCompletion(h.ctx, epic.ID, 240)
+	var missing *types.UnestimatedChildrenError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected UnestimatedChildrenError, got %v", err)
+	}
+	if len(missing.ChildIDs) != 1 || missing.ChildIDs[0] != unestimated.ID {
+		t.Errorf("unestimated children = %v, want [%s]", missing.ChildIDs, unestimated.ID)
+	}
+
+	if _, err := store.ProjectEpicCompletion(h.ctx, "bd-nonexistent", 240); err == nil {
+		t.Error("Expected error for nonexistent epic")
+	}
+}
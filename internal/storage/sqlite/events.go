@@ -3,7 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -51,21 +53,37 @@ func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment
 
 // GetEvents returns the event history for an issue
 func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
+	return s.GetEventsWithFilter(ctx, issueID, types.EventFilter{Limit: limit})
+}
+
+// GetEventsWithFilter returns an issue's events matching filter, newest first.
+// Filtering and the limit happen in the query, so only matching rows are read.
+// Events recorded within the same second are ordered by ID.
+func (s *SQLiteStorage) GetEventsWithFilter(ctx context.Context, issueID string, filter types.EventFilter) ([]*types.Event, error) {
+	whereClauses := []string{"issue_id = ?"}
 	args := []interface{}{issueID}
+	if filter.Actor != "" {
+		whereClauses = append(whereClauses, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.EventType != "" {
+		whereClauses = append(whereClauses, "event_type = ?")
+		args = append(args, filter.EventType)
+	}
 	limitSQL := ""
-	if limit > 0 {
+	if filter.Limit > 0 {
 		limitSQL = limitClause
-		args = append(args, limit)
+		args = append(args, filter.Limit)
 	}
 
 	// #nosec G201 - safe SQL with controlled formatting
 	query := fmt.Sprintf(`
 		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
 		FROM events
-		WHERE issue_id = ?
-		ORDER BY created_at DESC
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
 		%s
-	`, limitSQL)
+	`, strings.Join(whereClauses, " AND "), limitSQL)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -102,6 +120,236 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
+// ImportEvents inserts events read from an export, keeping their original timestamps.
+// Events for issues that don't exist are skipped, as are events already present with
+// the same type, actor, values, and timestamp, so importing a file twice is harmless.
+// Returns the number of events inserted.
+func (s *SQLiteStorage) ImportEvents(ctx context.Context, events []*types.Event) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Existing event keys per issue; nil means the issue doesn't exist
+	existing := make(map[string]map[string]bool)
+	inserted := 0
+	for _, event := range events {
+		keys, loaded := existing[event.IssueID]
+		if !loaded {
+			keys, err = existingEventKeys(ctx, tx, event.IssueID)
+			if err != nil {
+				return 0, err
+			}
+			existing[event.IssueID] = keys
+		}
+		if keys == nil {
+			continue
+		}
+
+		key := eventKey(event)
+		if keys[key] {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, event.IssueID, event.EventType, event.Actor, event.OldValue, event.NewValue, event.Comment, event.CreatedAt.UTC())
+		if err != nil {
+			return 0, fmt.Errorf("failed to import event for %s: %w", event.IssueID, err)
+		}
+		keys[key] = true
+		inserted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return inserted, nil
+}
+
+// existingEventKeys returns the keys of an issue's events, or nil if the issue doesn't exist
+func existingEventKeys(ctx context.Context, tx *sql.Tx, issueID string) (map[string]bool, error) {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT event_type, actor, old_value, new_value, comment, created_at
+		FROM events WHERE issue_id = ?
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := make(map[string]bool)
+	for rows.Next() {
+		event := types.Event{IssueID: issueID}
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(&event.EventType, &event.Actor, &oldValue, &newValue, &comment, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		keys[eventKey(&event)] = true
+	}
+	return keys, rows.Err()
+}
+
+// eventKey identifies an event by content. Timestamps are compared at second
+// resolution, which is what CURRENT_TIMESTAMP stores.
+func eventKey(event *types.Event) string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%s\x00%d", event.EventType, event.Actor,
+		deref(event.OldValue), deref(event.NewValue), deref(event.Comment), event.CreatedAt.Unix())
+}
+
+// GetEventCounts returns the number of events per issue, for issues with more than minCount events
+func (s *SQLiteStorage) GetEventCounts(ctx context.Context, minCount int) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, COUNT(*)
+		FROM events
+		GROUP BY issue_id
+		HAVING COUNT(*) > ?
+	`, minCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var issueID string
+		var count int
+		if err := rows.Scan(&issueID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan event count: %w", err)
+		}
+		counts[issueID] = count
+	}
+	return counts, rows.Err()
+}
+
+// eventCompactionSummary is the comment payload of the event that replaces compacted events
+type eventCompactionSummary struct {
+	EventsDropped int            `json:"events_dropped"`
+	ByType        map[string]int `json:"by_type"`
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+}
+
+// CompactEvents trims an issue's event history to its keepLast most recent events.
+// The older events are replaced by a single "compacted" event, in the place of the
+// newest dropped event, whose comment records how many events of each type were
+// removed and the time range they covered. A summary from an earlier compaction is
+// folded into the new one. Runs in one transaction. Returns the number of events
+// deleted, which does not count the dropped event rewritten as the summary.
+func (s *SQLiteStorage) CompactEvents(ctx context.Context, issueID string, keepLast int) (int, error) {
+	if keepLast < 0 {
+		return 0, fmt.Errorf("keepLast must be non-negative, got %d", keepLast)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Newest first; everything past the first keepLast rows is dropped
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, event_type, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT -1 OFFSET ?
+	`, issueID, keepLast)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	summary := eventCompactionSummary{ByType: make(map[string]int)}
+	var dropIDs []int64
+	for rows.Next() {
+		var id int64
+		var eventType string
+		var comment sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&id, &eventType, &comment, &createdAt); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan event: %w", err)
+		}
+		dropIDs = append(dropIDs, id)
+
+		var previous eventCompactionSummary
+		if eventType == string(types.EventCompacted) && comment.Valid &&
+			json.Unmarshal([]byte(comment.String), &previous) == nil && previous.EventsDropped > 0 {
+			summary.EventsDropped += previous.EventsDropped
+			for t, n := range previous.ByType {
+				summary.ByType[t] += n
+			}
+			createdAt = previous.From
+		} else {
+			summary.EventsDropped++
+			summary.ByType[eventType]++
+		}
+		if summary.To.IsZero() {
+			summary.To = createdAt
+		}
+		summary.From = createdAt
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, fmt.Errorf("error iterating events: %w", err)
+	}
+	_ = rows.Close()
+
+	if len(dropIDs) == 0 {
+		return 0, nil
+	}
+
+	for _, id := range dropIDs[1:] {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM events WHERE id = ?`, id); err != nil {
+			return 0, fmt.Errorf("failed to delete event %d: %w", id, err)
+		}
+	}
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode compaction summary: %w", err)
+	}
+	// Turn the newest dropped event into the summary, keeping its ID and timestamp
+	// so the summary sorts exactly where the dropped events were
+	_, err = tx.ExecContext(ctx, `
+		UPDATE events
+		SET event_type = ?, actor = 'compactor', old_value = NULL, new_value = NULL, comment = ?
+		WHERE id = ?
+	`, types.EventCompacted, string(summaryJSON), dropIDs[0])
+	if err != nil {
+		return 0, fmt.Errorf("failed to record compaction event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(dropIDs) - 1, nil
+}
+
 // GetStatistics returns aggregate statistics
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	var stats types.Statistics
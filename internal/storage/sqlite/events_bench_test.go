@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// BenchmarkGetEventsLastN reads the newest few events of an issue with a long history
+func BenchmarkGetEventsLastN(b *testing.B) {
+	store, cleanup := setupBenchDB(b)
+	defer cleanup()
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		b.Fatalf("Failed to set issue_prefix: %v", err)
+	}
+
+	issue := &types.Issue{
+		Title:     "Long history",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		b.Fatalf("Failed to create issue: %v", err)
+	}
+	for i := 0; i < 5000; i++ {
+		if err := store.AddComment(ctx, issue.ID, "test", "Benchmark event"); err != nil {
+			b.Fatalf("AddComment failed: %v", err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		events, err := store.GetEvents(ctx, issue.ID, 10)
+		if err != nil {
+			b.Fatalf("GetEvents failed: %v", err)
+		}
+		if len(events) != 10 {
+			b.Fatalf("Expected 10 events, got %d", len(events))
+		}
+	}
+}
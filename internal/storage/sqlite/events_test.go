@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -174,6 +176,93 @@ func TestGetEventsWithLimit(t *testing.T) {
 	}
 }
 
+func TestGetEventsWithFilter(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Audited", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.AddComment(ctx, issue.ID, "bob", "Looked at it"); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+	if err := store.AddComment(ctx, issue.ID, "alice", "Me too"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	count := func(filter types.EventFilter) int {
+		t.Helper()
+		events, err := store.GetEventsWithFilter(ctx, issue.ID, filter)
+		if err != nil {
+			t.Fatalf("GetEventsWithFilter(%+v) failed: %v", filter, err)
+		}
+		for _, e := range events {
+			if filter.Actor != "" && e.Actor != filter.Actor {
+				t.Errorf("Event by %s leaked through actor filter %s", e.Actor, filter.Actor)
+			}
+			if filter.EventType != "" && e.EventType != filter.EventType {
+				t.Errorf("Event of type %s leaked through type filter %s", e.EventType, filter.EventType)
+			}
+		}
+		return len(events)
+	}
+
+	if got := count(types.EventFilter{Actor: "bob"}); got != 4 {
+		t.Errorf("Expected 4 events by bob, got %d", got)
+	}
+	if got := count(types.EventFilter{Actor: "alice"}); got != 2 {
+		t.Errorf("Expected 2 events by alice, got %d", got)
+	}
+	if got := count(types.EventFilter{Actor: "bob", EventType: types.EventCommented}); got != 3 {
+		t.Errorf("Expected 3 comments by bob, got %d", got)
+	}
+	if got := count(types.EventFilter{Actor: "bob", EventType: types.EventCommented, Limit: 2}); got != 2 {
+		t.Errorf("Expected limit to cap filtered events at 2, got %d", got)
+	}
+	if got := count(types.EventFilter{Actor: "carol"}); got != 0 {
+		t.Errorf("Expected no events by carol, got %d", got)
+	}
+}
+
+func TestGetEventsLimitReturnsNewest(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Busy", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	// All of these land within the same second, so only the ID orders them
+	for i := 0; i < 10; i++ {
+		if err := store.AddComment(ctx, issue.ID, "alice", fmt.Sprintf("Comment %d", i)); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 3)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	for i, want := range []string{"Comment 9", "Comment 8", "Comment 7"} {
+		if events[i].Comment == nil || *events[i].Comment != want {
+			t.Errorf("Event %d: expected %q, got %v", i, want, events[i].Comment)
+		}
+	}
+}
+
 func TestGetEventsEmpty(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -339,3 +428,99 @@ func TestEventTypesInHistory(t *testing.T) {
 		t.Error("Expected EventClosed in history")
 	}
 }
+
+func TestCompactEvents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Long-lived issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, testUserAlice); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := store.AddComment(ctx, issue.ID, testUserAlice, "progress"); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, testUserAlice); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	// 12 events: created, 10 commented, updated
+	before, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(before) != 12 {
+		t.Fatalf("expected 12 events before compaction, got %d", len(before))
+	}
+
+	removed, err := store.CompactEvents(ctx, issue.ID, 3)
+	if err != nil {
+		t.Fatalf("CompactEvents failed: %v", err)
+	}
+	// 9 events dropped: 8 deleted, the newest rewritten as the summary
+	if removed != 8 {
+		t.Errorf("expected 8 events removed, got %d", removed)
+	}
+
+	after, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(after) != 4 {
+		t.Fatalf("expected 3 kept events plus a summary, got %d", len(after))
+	}
+	var summary *types.Event
+	for _, e := range after {
+		if e.EventType == types.EventCompacted {
+			summary = e
+		}
+	}
+	if summary == nil || summary.Comment == nil {
+		t.Fatal("expected a compacted summary event")
+	}
+	var payload eventCompactionSummary
+	if err := json.Unmarshal([]byte(*summary.Comment), &payload); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	if payload.EventsDropped != 9 || payload.ByType["created"] != 1 || payload.ByType["commented"] != 8 {
+		t.Errorf("unexpected summary: %+v", payload)
+	}
+
+	// The kept events are the newest ones
+	kept := make(map[types.EventType]int)
+	for _, e := range after {
+		kept[e.EventType]++
+	}
+	if kept[types.EventUpdated] != 1 || kept[types.EventCommented] != 2 {
+		t.Errorf("expected the update and last 2 comments to be kept, got %v", kept)
+	}
+
+	// Compacting again folds the earlier summary into the new one
+	removed, err = store.CompactEvents(ctx, issue.ID, 1)
+	if err != nil {
+		t.Fatalf("CompactEvents failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("expected 2 events removed, got %d", removed)
+	}
+	after, _ = store.GetEvents(ctx, issue.ID, 0)
+	if len(after) != 2 {
+		t.Fatalf("expected 1 kept event plus a summary, got %d", len(after))
+	}
+	summary = after[0]
+	if summary.EventType != types.EventCompacted {
+		summary = after[1]
+	}
+	if summary.EventType != types.EventCompacted || summary.Comment == nil {
+		t.Fatal("expected a compacted summary event")
+	}
+	if err := json.Unmarshal([]byte(*summary.Comment), &payload); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	if payload.EventsDropped != 11 || payload.ByType["commented"] != 10 {
+		t.Errorf("unexpected folded summary: %+v", payload)
+	}
+
+	// Nothing to do when the history is already short enough
+	removed, err = store.CompactEvents(ctx, issue.ID, 5)
+	if err != nil || removed != 0 {
+		t.Errorf("expected no-op, got removed=%d err=%v", removed, err)
+	}
+}
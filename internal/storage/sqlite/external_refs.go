@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetExternalRef records ref as the issue's key in tracker, replacing any
+// previous key for that tracker
+func (s *SQLiteStorage) SetExternalRef(ctx context.Context, issueID, tracker, ref, actor string) error {
+	tracker = strings.TrimSpace(tracker)
+	ref = strings.TrimSpace(ref)
+	if tracker == "" || ref == "" {
+		return fmt.Errorf("tracker and ref must not be empty")
+	}
+
+	exists, err := s.IssueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`INSERT INTO external_refs (issue_id, tracker, ref) VALUES (?, ?, ?)
+		 ON CONFLICT (issue_id, tracker) DO UPDATE SET ref = excluded.ref`,
+		[]interface{}{issueID, tracker, ref},
+		types.EventUpdated,
+		fmt.Sprintf("Set external ref %s: %s", tracker, ref),
+		"failed to set external ref",
+	)
+}
+
+// RemoveExternalRef removes the issue's key in tracker. If it was also the
+// legacy single external_ref, that is cleared too so it isn't folded back in.
+func (s *SQLiteStorage) RemoveExternalRef(ctx context.Context, issueID, tracker, actor string) error {
+	var ref string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT ref FROM external_refs WHERE issue_id = ? AND tracker = ?
+	`, issueID, tracker).Scan(&ref)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get external ref: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE issues SET external_ref = NULL WHERE id = ? AND external_ref = ?
+	`, issueID, ref); err != nil {
+		return fmt.Errorf("failed to clear legacy external ref: %w", err)
+	}
+
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`DELETE FROM external_refs WHERE issue_id = ? AND tracker = ?`,
+		[]interface{}{issueID, tracker},
+		types.EventUpdated,
+		fmt.Sprintf("Removed external ref %s: %s", tracker, ref),
+		"failed to remove external ref",
+	)
+}
+
+// GetExternalRefs returns the issue's keys by tracker, or nil if it has none
+func (s *SQLiteStorage) GetExternalRefs(ctx context.Context, issueID string) (types.TrackerRefs, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tracker, ref FROM external_refs WHERE issue_id = ?
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external refs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var refs types.TrackerRefs
+	for rows.Next() {
+		var tracker, ref string
+		if err := rows.Scan(&tracker, &ref); err != nil {
+			return nil, err
+		}
+		if refs == nil {
+			refs = make(types.TrackerRefs)
+		}
+		refs[tracker] = ref
+	}
+	return refs, rows.Err()
+}
+
+// GetIssueByExternalRef returns the issue whose key in any tracker, or whose
+// legacy external_ref, equals ref. Returns nil if no issue matches.
+func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, ref string) (*types.Issue, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT issue_id FROM external_refs WHERE ref = ?
+		UNION
+		SELECT id FROM issues WHERE external_ref = ?
+		ORDER BY 1
+		LIMIT 1
+	`, ref, ref).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up external ref: %w", err)
+	}
+	return s.GetIssue(ctx, id)
+}
+
+// execer is satisfied by *sql.Conn and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// syncLegacyExternalRef keeps external_refs in step with a write of the legacy
+// single external_ref column from oldRef to newRef (either may be empty): the
+// entry oldRef was filed under is dropped, and newRef replaces its tracker's key
+func syncLegacyExternalRef(ctx context.Context, db execer, issueID, oldRef, newRef string) error {
+	if oldRef == newRef {
+		return nil
+	}
+	if oldRef != "" {
+		_, err := db.ExecContext(ctx, `
+			DELETE FROM external_refs WHERE issue_id = ? AND tracker = ? AND ref = ?
+		`, issueID, types.LegacyExternalRefTracker(oldRef), oldRef)
+		if err != nil {
+			return fmt.Errorf("failed to drop external ref %s: %w", oldRef, err)
+		}
+	}
+	if newRef != "" {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO external_refs (issue_id, tracker, ref) VALUES (?, ?, ?)
+			ON CONFLICT (issue_id, tracker) DO UPDATE SET ref = excluded.ref
+		`, issueID, types.LegacyExternalRefTracker(newRef), newRef)
+		if err != nil {
+			return fmt.Errorf("failed to file external ref %s: %w", newRef, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExternalRefsMultipleTrackers(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Synced issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.SetExternalRef(ctx, issue.ID, "jira", "PROJ-123", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+	if err := store.SetExternalRef(ctx, issue.ID, "gh", "gh-456", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+
+	for _, ref := range []string{"PROJ-123", "gh-456"} {
+		found, err := store.GetIssueByExternalRef(ctx, ref)
+		if err != nil {
+			t.Fatalf("GetIssueByExternalRef(%s) failed: %v", ref, err)
+		}
+		if found == nil || found.ID != issue.ID {
+			t.Fatalf("GetIssueByExternalRef(%s) = %v, want %s", ref, found, issue.ID)
+		}
+		if len(found.ExternalRefs) != 2 {
+			t.Errorf("Expected 2 external refs on issue, got %v", found.ExternalRefs)
+		}
+	}
+
+	// Setting a tracker again replaces its key
+	if err := store.SetExternalRef(ctx, issue.ID, "jira", "PROJ-124", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+	refs, err := store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if refs["jira"] != "PROJ-124" || refs["gh"] != "gh-456" {
+		t.Errorf("Unexpected refs after update: %v", refs)
+	}
+	if found, _ := store.GetIssueByExternalRef(ctx, "PROJ-123"); found != nil {
+		t.Errorf("Expected old key to no longer match, got %s", found.ID)
+	}
+
+	if err := store.RemoveExternalRef(ctx, issue.ID, "gh", "test-user"); err != nil {
+		t.Fatalf("RemoveExternalRef failed: %v", err)
+	}
+	refs, _ = store.GetExternalRefs(ctx, issue.ID)
+	if len(refs) != 1 || refs["jira"] != "PROJ-124" {
+		t.Errorf("Unexpected refs after remove: %v", refs)
+	}
+}
+
+func TestExternalRefsMissingIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	err := store.SetExternalRef(context.Background(), "bd-999", "jira", "PROJ-1", "test-user")
+	if err == nil {
+		t.Fatal("Expected error setting external ref on missing issue")
+	}
+}
+
+func TestLegacyExternalRefKeptInStep(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	ref := "gh-9"
+	issue := &types.Issue{
+		Title:       "Mirrored issue",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeTask,
+		ExternalRef: &ref,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.SetExternalRef(ctx, issue.ID, "jira", "PROJ-1", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+
+	refs, err := store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if refs["gh"] != "gh-9" {
+		t.Errorf("Expected created external_ref filed under gh, got %v", refs)
+	}
+
+	// Changing the legacy ref replaces its tracker's entry
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": "gh-10"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	refs, err = store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if refs["gh"] != "gh-10" || refs["jira"] != "PROJ-1" {
+		t.Errorf("Unexpected refs after update: %v", refs)
+	}
+	if found, _ := store.GetIssueByExternalRef(ctx, "gh-9"); found != nil {
+		t.Errorf("Expected old legacy ref to no longer match, got %s", found.ID)
+	}
+
+	// Clearing it drops the entry and leaves other trackers alone
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	refs, err = store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if len(refs) != 1 || refs["jira"] != "PROJ-1" {
+		t.Errorf("Unexpected refs after clearing: %v", refs)
+	}
+}
+
+func TestMigrateLegacyExternalRefs(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	legacy := "gh-42"
+	issue := &types.Issue{
+		Title:       "Legacy issue",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeTask,
+		ExternalRef: &legacy,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Running the fold twice must be harmless
+	for i := 0; i < 2; i++ {
+		if err := migrateLegacyExternalRefs(store.db); err != nil {
+			t.Fatalf("migrateLegacyExternalRefs failed: %v", err)
+		}
+	}
+
+	refs, err := store.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetExternalRefs failed: %v", err)
+	}
+	if len(refs) != 1 || refs["gh"] != legacy {
+		t.Errorf("Expected legacy ref folded under gh, got %v", refs)
+	}
+
+	// Removing the folded tracker clears the legacy column so it stays gone
+	if err := store.RemoveExternalRef(ctx, issue.ID, "gh", "test-user"); err != nil {
+		t.Fatalf("RemoveExternalRef failed: %v", err)
+	}
+	if err := migrateLegacyExternalRefs(store.db); err != nil {
+		t.Fatalf("migrateLegacyExternalRefs failed: %v", err)
+	}
+	if found, _ := store.GetIssueByExternalRef(ctx, legacy); found != nil {
+		t.Errorf("Expected removed legacy ref not to match, got %s", found.ID)
+	}
+}
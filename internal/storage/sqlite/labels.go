@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -101,7 +102,7 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
@@ -114,3 +115,157 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 
 	return s.scanIssues(ctx, rows)
 }
+
+// ListLabels returns every distinct label with the number of issues using it,
+// most used first
+func (s *SQLiteStorage) ListLabels(ctx context.Context) ([]*types.LabelCount, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT label, COUNT(*) AS count
+		FROM labels
+		GROUP BY label
+		ORDER BY count DESC, label ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var counts []*types.LabelCount
+	for rows.Next() {
+		var lc types.LabelCount
+		if err := rows.Scan(&lc.Label, &lc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan label count: %w", err)
+		}
+		counts = append(counts, &lc)
+	}
+
+	return counts, rows.Err()
+}
+
+// expandLabelFilter resolves label filter values to concrete labels.
+// Exact values are kept as-is; patterns are matched against every label in use,
+// which is loaded once into *allLabels and reused across calls.
+func (s *SQLiteStorage) expandLabelFilter(ctx context.Context, values []string, allLabels *[]string) ([]string, error) {
+	var labels []string
+	for _, value := range values {
+		if !types.IsLabelPattern(value) {
+			labels = append(labels, value)
+			continue
+		}
+
+		if *allLabels == nil {
+			counts, err := s.ListLabels(ctx)
+			if err != nil {
+				return nil, err
+			}
+			*allLabels = make([]string, 0, len(counts))
+			for _, lc := range counts {
+				*allLabels = append(*allLabels, lc.Label)
+			}
+		}
+
+		matched, err := types.MatchingLabels(value, *allLabels)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, matched...)
+	}
+	return labels, nil
+}
+
+// labelInClause builds a WHERE clause matching issues with any of the given labels.
+// An empty label set matches nothing.
+func labelInClause(labels []string) (string, []interface{}) {
+	if len(labels) == 0 {
+		return "0 = 1", nil
+	}
+	placeholders := make([]string, len(labels))
+	args := make([]interface{}, len(labels))
+	for i, label := range labels {
+		placeholders[i] = "?"
+		args[i] = label
+	}
+	// #nosec G201 - safe SQL with controlled formatting
+	return fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")), args
+}
+
+// RenameLabel renames a label on every issue that has it.
+// Issues that already have newLabel keep a single copy.
+func (s *SQLiteStorage) RenameLabel(ctx context.Context, oldLabel, newLabel, actor string) error {
+	return s.MergeLabels(ctx, []string{oldLabel}, newLabel, actor)
+}
+
+// MergeLabels replaces each label in from with into on every affected issue,
+// deduplicating where an issue already has into, and bumps each affected issue's
+// updated_at. All changes happen in one transaction.
+func (s *SQLiteStorage) MergeLabels(ctx context.Context, from []string, into, actor string) error {
+	if into == "" {
+		return fmt.Errorf("target label cannot be empty")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, label := range from {
+		if label == into {
+			continue
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT issue_id FROM labels WHERE label = ?`, label)
+		if err != nil {
+			return fmt.Errorf("failed to find issues with label %s: %w", label, err)
+		}
+		var issueIDs []string
+		for rows.Next() {
+			var issueID string
+			if err := rows.Scan(&issueID); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("failed to scan issue ID: %w", err)
+			}
+			issueIDs = append(issueIDs, issueID)
+		}
+		_ = rows.Close()
+		if len(issueIDs) == 0 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO labels (issue_id, label)
+			SELECT issue_id, ? FROM labels WHERE label = ?
+		`, into, label)
+		if err != nil {
+			return fmt.Errorf("failed to add label %s: %w", into, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM labels WHERE label = ?`, label)
+		if err != nil {
+			return fmt.Errorf("failed to remove label %s: %w", label, err)
+		}
+
+		now := time.Now()
+		for _, issueID := range issueIDs {
+			_, err = tx.ExecContext(ctx, `UPDATE issues SET updated_at = ? WHERE id = ?`, now, issueID)
+			if err != nil {
+				return fmt.Errorf("failed to update issue %s: %w", issueID, err)
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, comment)
+				VALUES (?, ?, ?, ?), (?, ?, ?, ?)
+			`, issueID, types.EventLabelRemoved, actor, fmt.Sprintf("Removed label: %s", label),
+				issueID, types.EventLabelAdded, actor, fmt.Sprintf("Added label: %s", into))
+			if err != nil {
+				return fmt.Errorf("failed to record event: %w", err)
+			}
+		}
+
+		if err := markIssuesDirtyTx(ctx, tx, issueIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
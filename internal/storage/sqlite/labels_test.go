@@ -392,3 +392,61 @@ func TestLabelMarksDirty(t *testing.T) {
 		t.Error("Expected issue to be marked dirty after removing label")
 	}
 }
+
+func TestListLabels(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var issues []*types.Issue
+	for _, title := range []string{"First", "Second", "Third"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// "shared" is on all three, "pair" on two, "solo" on one; the third issue has no unique labels
+	labels := map[int][]string{
+		0: {"shared", "pair", "solo"},
+		1: {"shared", "pair"},
+		2: {"shared"},
+	}
+	for i, ls := range labels {
+		for _, label := range ls {
+			if err := store.AddLabel(ctx, issues[i].ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+	}
+
+	counts, err := store.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+
+	want := []types.LabelCount{{Label: "shared", Count: 3}, {Label: "pair", Count: 2}, {Label: "solo", Count: 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("Expected %d labels, got %d", len(want), len(counts))
+	}
+	for i, w := range want {
+		if *counts[i] != w {
+			t.Errorf("Position %d: expected %+v, got %+v", i, w, *counts[i])
+		}
+	}
+}
+
+func TestListLabelsEmpty(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	counts, err := store.ListLabels(context.Background())
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Expected no labels, got %v", counts)
+	}
+}
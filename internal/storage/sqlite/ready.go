@@ -4,18 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // GetReadyWork returns issues with no open blockers
 // By default, shows both 'open' and 'in_progress' issues so epics/tasks
-// ready to close are visible (bd-165)
+// ready to close are visible (bd-165). Issues claimed by an agent other than
+// filter.Assignee are left out; expired claims are released first.
 func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
+	// Archived issues are never ready work
+	whereClauses = append(whereClauses, "i.archived_at IS NULL")
+
 	// Default to open OR in_progress if not specified (bd-165)
 	if filter.Status == "" {
 		whereClauses = append(whereClauses, "i.status IN ('open', 'in_progress')")
@@ -34,6 +40,18 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, *filter.Assignee)
 	}
 
+	// Issues under a live claim aren't ready for anyone but the claim holder
+	if err := s.releaseExpiredClaims(ctx); err != nil {
+		return nil, err
+	}
+	claimSQL := "NOT EXISTS (SELECT 1 FROM issue_claims c WHERE c.issue_id = i.id AND c.expires_at > ?"
+	args = append(args, time.Now().UTC())
+	if filter.Assignee != nil {
+		claimSQL += " AND c.agent != ?"
+		args = append(args, *filter.Assignee)
+	}
+	whereClauses = append(whereClauses, claimSQL+")")
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
@@ -87,7 +105,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		-- Step 3: Select ready issues (excluding all blocked)
 		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		i.created_at, i.updated_at, i.closed_at, i.external_ref
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -106,6 +124,58 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	return s.scanIssues(ctx, rows)
 }
 
+// GetNewlyUnblocked returns the issues that became ready because closedID was closed:
+// issues with a 'blocks' dependency on it that now have no open blockers. Returns
+// nothing while closedID is still open.
+func (s *SQLiteStorage) GetNewlyUnblocked(ctx context.Context, closedID string) ([]*types.Issue, error) {
+	closed, err := s.GetIssue(ctx, closedID)
+	if err != nil {
+		return nil, err
+	}
+	if closed == nil || closed.Status != types.StatusClosed {
+		return []*types.Issue{}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id FROM dependencies
+		WHERE depends_on_id = ? AND type = 'blocks'
+	`, closedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	dependents := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan dependent: %w", err)
+		}
+		dependents[id] = true
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	if len(dependents) == 0 {
+		return []*types.Issue{}, nil
+	}
+
+	// A dependent is unblocked if it is now ready work (no other open blockers,
+	// directly or through its parents)
+	ready, err := s.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		return nil, err
+	}
+	unblocked := []*types.Issue{}
+	for _, issue := range ready {
+		if dependents[issue.ID] {
+			unblocked = append(unblocked, issue)
+		}
+	}
+	return unblocked, nil
+}
+
 // GetBlockedIssues returns issues that are blocked by dependencies
 func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
 	// Use GROUP_CONCAT to get all blocker IDs in a single query (no N+1)
@@ -122,6 +192,7 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		WHERE i.status IN ('open', 'in_progress', 'blocked')
 		  AND d.type = 'blocks'
 		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  AND i.archived_at IS NULL
 		GROUP BY i.id
 		ORDER BY i.priority ASC
 	`)
@@ -171,15 +242,134 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 
 		blocked = append(blocked, &issue)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read blocked issues: %w", err)
+	}
+
+	if err := s.populateBlockedSince(ctx, blocked); err != nil {
+		return nil, err
+	}
+
+	// Highest priority first, then longest blocked
+	sort.SliceStable(blocked, func(i, j int) bool {
+		if blocked[i].Priority != blocked[j].Priority {
+			return blocked[i].Priority < blocked[j].Priority
+		}
+		return blocked[i].BlockedSince.Before(blocked[j].BlockedSince)
+	})
 
 	return blocked, nil
 }
 
+// populateBlockedSince sets BlockedSince from each issue's most recent dependency_added
+// event, falling back to UpdatedAt when there is no such event (e.g. imported dependencies)
+func (s *SQLiteStorage) populateBlockedSince(ctx context.Context, blocked []*types.BlockedIssue) error {
+	if len(blocked) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*types.BlockedIssue, len(blocked))
+	placeholders := make([]string, len(blocked))
+	args := make([]interface{}, 0, len(blocked)+1)
+	args = append(args, types.EventDependencyAdded)
+	for i, issue := range blocked {
+		issue.BlockedSince = issue.UpdatedAt
+		byID[issue.ID] = issue
+		placeholders[i] = "?"
+		args = append(args, issue.ID)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT issue_id, created_at FROM events
+		WHERE event_type = ? AND issue_id IN (%s)
+	`, strings.Join(placeholders, ", "))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get dependency events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	seen := make(map[string]bool, len(blocked))
+	for rows.Next() {
+		var issueID string
+		var createdAt time.Time
+		if err := rows.Scan(&issueID, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan dependency event: %w", err)
+		}
+		issue := byID[issueID]
+		if !seen[issueID] || createdAt.After(issue.BlockedSince) {
+			issue.BlockedSince = createdAt
+			seen[issueID] = true
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetOverdueIssues returns unclosed, unarchived issues whose due date has passed,
+// most overdue first
+func (s *SQLiteStorage) GetOverdueIssues(ctx context.Context) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
+		FROM issues i
+		WHERE i.status != 'closed'
+		  AND i.archived_at IS NULL
+		  AND i.due_date IS NOT NULL
+		  AND i.due_date < ?
+		ORDER BY i.due_date ASC, i.priority ASC
+	`, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// GetSLABreaches returns unclosed, unarchived issues older than the SLA
+// configured for their priority under sla, furthest past it first. With no
+// SLAs configured nothing is in breach.
+func (s *SQLiteStorage) GetSLABreaches(ctx context.Context) ([]*types.SLABreach, error) {
+	spec, err := s.GetConfig(ctx, types.SLAConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", types.SLAConfigKey, err)
+	}
+	policy, err := types.ParseSLAPolicy(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", types.SLAConfigKey, err)
+	}
+	if len(policy) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.archived_at, i.reporter, i.due_date, i.rank
+		FROM issues i
+		WHERE i.status != 'closed'
+		  AND i.archived_at IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unresolved issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return types.FindSLABreaches(issues, policy, time.Now()), nil
+}
+
 // buildOrderByClause generates the ORDER BY clause based on sort policy
 func buildOrderByClause(policy types.SortPolicy) string {
 	switch policy {
 	case types.SortPolicyPriority:
-		return `ORDER BY i.priority ASC, i.created_at ASC`
+		return `ORDER BY i.priority ASC, i.rank IS NULL, i.rank ASC, i.created_at ASC`
 
 	case types.SortPolicyOldest:
 		return `ORDER BY i.created_at ASC`
@@ -196,6 +386,14 @@ func buildOrderByClause(policy types.SortPolicy) string {
 				WHEN datetime(i.created_at) >= datetime('now', '-48 hours') THEN i.priority
 				ELSE NULL
 			END ASC,
+			CASE
+				WHEN datetime(i.created_at) >= datetime('now', '-48 hours') THEN i.rank IS NULL
+				ELSE NULL
+			END ASC,
+			CASE
+				WHEN datetime(i.created_at) >= datetime('now', '-48 hours') THEN i.rank
+				ELSE NULL
+			END ASC,
 			CASE
 				WHEN datetime(i.created_at) < datetime('now', '-48 hours') THEN i.created_at
 				ELSE NULL
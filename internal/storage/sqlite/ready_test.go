@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -106,6 +107,57 @@ func TestGetReadyWorkPriorityOrder(t *testing.T) {
 	}
 }
 
+func TestGetReadyWorkRankOrder(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	older := &types.Issue{Title: "Older", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	newer := &types.Issue{Title: "Newer", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	unranked := &types.Issue{Title: "Unranked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{older, newer, unranked} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Rank the newer issue ahead of the older one; the unranked issue goes last
+	if err := store.UpdateIssue(ctx, newer.ID, map[string]interface{}{"rank": 1.0}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, older.ID, map[string]interface{}{"rank": 2.0}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	for _, policy := range []types.SortPolicy{types.SortPolicyPriority, types.SortPolicyHybrid} {
+		ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, SortPolicy: policy})
+		if err != nil {
+			t.Fatalf("GetReadyWork(%s) failed: %v", policy, err)
+		}
+		var got []string
+		for _, issue := range ready {
+			got = append(got, issue.Title)
+		}
+		if strings.Join(got, ",") != "Newer,Older,Unranked" {
+			t.Errorf("%s order = %v, want [Newer Older Unranked]", policy, got)
+		}
+	}
+
+	// Clearing the rank restores creation order among the remaining ties
+	if err := store.UpdateIssue(ctx, newer.ID, map[string]interface{}{"rank": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, newer.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Rank != nil {
+		t.Errorf("Expected rank cleared, got %v", *got.Rank)
+	}
+}
+
 func TestGetReadyWorkWithPriorityFilter(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -274,6 +326,43 @@ func TestGetBlockedIssues(t *testing.T) {
 	}
 }
 
+func TestGetBlockedIssuesSkipsArchived(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	kept := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	archived := &types.Issue{Title: "Blocked and archived", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, kept, archived} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, issue := range []*types.Issue{kept, archived} {
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.ArchiveIssue(ctx, archived.ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+
+	blocked, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != kept.ID {
+		var ids []string
+		for _, b := range blocked {
+			ids = append(ids, b.ID)
+		}
+		t.Errorf("Expected only %s to be blocked, got %v", kept.ID, ids)
+	}
+}
+
 // TestParentBlockerBlocksChildren tests that children inherit blockage from parents
 func TestParentBlockerBlocksChildren(t *testing.T) {
 	store, cleanup := setupTestDB(t)
@@ -383,6 +472,82 @@ func TestGrandparentBlockerBlocksGrandchildren(t *testing.T) {
 }
 
 // TestMultipleParentsOneBlocked tests that a child is blocked if ANY parent is blocked
+func TestGetBlockedIssuesBlockedSince(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	recent := &types.Issue{Title: "Blocked recently", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	longAgo := &types.Issue{Title: "Blocked long ago", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	noEvents := &types.Issue{Title: "No dependency events", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, recent, longAgo, noEvents} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, issue := range []*types.Issue{recent, longAgo, noEvents} {
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	// Rewrite event history so the blocked-since times are known
+	recentTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	longAgoTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := store.UnderlyingDB()
+	setEventTime := func(issueID string, at time.Time) {
+		if _, err := db.ExecContext(ctx, `UPDATE events SET created_at = ? WHERE issue_id = ? AND event_type = ?`,
+			at, issueID, types.EventDependencyAdded); err != nil {
+			t.Fatalf("Failed to update event time: %v", err)
+		}
+	}
+	setEventTime(recent.ID, recentTime)
+	setEventTime(longAgo.ID, longAgoTime)
+	// An older dependency_added event must not override the most recent one
+	if _, err := db.ExecContext(ctx, `INSERT INTO events (issue_id, event_type, actor, created_at) VALUES (?, ?, ?, ?)`,
+		recent.ID, types.EventDependencyAdded, "test-user", longAgoTime); err != nil {
+		t.Fatalf("Failed to insert event: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ? AND event_type = ?`,
+		noEvents.ID, types.EventDependencyAdded); err != nil {
+		t.Fatalf("Failed to delete events: %v", err)
+	}
+
+	blocked, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 3 {
+		t.Fatalf("Expected 3 blocked issues, got %d", len(blocked))
+	}
+
+	// Same priority: longest blocked first
+	wantOrder := []string{longAgo.ID, recent.ID, noEvents.ID}
+	for i, id := range wantOrder {
+		if blocked[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, blocked[i].ID)
+		}
+	}
+
+	byID := make(map[string]*types.BlockedIssue)
+	for _, b := range blocked {
+		byID[b.ID] = b
+	}
+	if !byID[recent.ID].BlockedSince.Equal(recentTime) {
+		t.Errorf("Expected %s blocked since %v, got %v", recent.ID, recentTime, byID[recent.ID].BlockedSince)
+	}
+	if !byID[longAgo.ID].BlockedSince.Equal(longAgoTime) {
+		t.Errorf("Expected %s blocked since %v, got %v", longAgo.ID, longAgoTime, byID[longAgo.ID].BlockedSince)
+	}
+	if !byID[noEvents.ID].BlockedSince.Equal(byID[noEvents.ID].UpdatedAt) {
+		t.Errorf("Expected %s to fall back to updated_at %v, got %v",
+			noEvents.ID, byID[noEvents.ID].UpdatedAt, byID[noEvents.ID].BlockedSince)
+	}
+}
+
 func TestMultipleParentsOneBlocked(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1094,3 +1259,177 @@ func TestSortPolicyDefault(t *testing.T) {
 		t.Errorf("Expected P2 second, got P%d", ready[1].Priority)
 	}
 }
+
+func TestGetOverdueIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	past := time.Now().Add(-48 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+
+	create := func(title string, due *time.Time) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueDate: due}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	longOverdue := create("Long overdue", &past)
+	overdue := create("Overdue", &yesterday)
+	create("Due later", &future)
+	create("No due date", nil)
+	closedOverdue := create("Closed overdue", &past)
+	if err := store.CloseIssue(ctx, closedOverdue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	issues, err := store.GetOverdueIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetOverdueIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 overdue issues, got %d", len(issues))
+	}
+	if issues[0].ID != longOverdue.ID || issues[1].ID != overdue.ID {
+		t.Errorf("Expected most overdue first (%s, %s), got (%s, %s)", longOverdue.ID, overdue.ID, issues[0].ID, issues[1].ID)
+	}
+	if issues[0].DueDate == nil || !issues[0].DueDate.Equal(past) {
+		t.Errorf("Expected due date %v, got %v", past, issues[0].DueDate)
+	}
+
+	// Moving the due date into the future clears the overdue state
+	if err := store.UpdateIssue(ctx, overdue.ID, map[string]interface{}{"due_date": future}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	// Clearing the due date removes it entirely
+	if err := store.UpdateIssue(ctx, longOverdue.ID, map[string]interface{}{"due_date": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	issues, err = store.GetOverdueIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetOverdueIssues failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no overdue issues after updates, got %d", len(issues))
+	}
+
+	cutoff := time.Now().Add(72 * time.Hour)
+	dueSoon, err := store.SearchIssues(ctx, "", types.IssueFilter{DueBefore: &cutoff})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	// Due later, Overdue (moved to future), and the closed issue all have due dates before the cutoff
+	if len(dueSoon) != 3 {
+		t.Errorf("Expected 3 issues due before cutoff, got %d", len(dueSoon))
+	}
+}
+
+func TestGetNewlyUnblocked(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// A blocks B; A and D both block C
+	a := &types.Issue{Title: "Schema migration", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "Backfill", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	c := &types.Issue{Title: "Drop old column", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	d := &types.Issue{Title: "Update readers", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b, c, d} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+		{IssueID: c.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+		{IssueID: c.ID, DependsOnID: d.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	// Nothing is unblocked while the blocker is still open
+	unblocked, err := store.GetNewlyUnblocked(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetNewlyUnblocked failed: %v", err)
+	}
+	if len(unblocked) != 0 {
+		t.Errorf("expected nothing unblocked before close, got %d issues", len(unblocked))
+	}
+
+	if err := store.CloseIssue(ctx, a.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// C is still blocked by D
+	unblocked, err = store.GetNewlyUnblocked(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetNewlyUnblocked failed: %v", err)
+	}
+	if len(unblocked) != 1 || unblocked[0].ID != b.ID {
+		ids := make([]string, len(unblocked))
+		for i, issue := range unblocked {
+			ids[i] = issue.ID
+		}
+		t.Errorf("expected only %s unblocked, got %v", b.ID, ids)
+	}
+}
+
+func TestGetSLABreaches(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// No SLAs configured: nothing is in breach
+	if breaches, err := store.GetSLABreaches(ctx); err != nil || len(breaches) != 0 {
+		t.Fatalf("GetSLABreaches with no SLAs = %v, %v", breaches, err)
+	}
+
+	now := time.Now()
+	issue := func(title string, priority int, age time.Duration) *types.Issue {
+		return &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask, CreatedAt: now.Add(-age)}
+	}
+	p0Late := issue("P0 late", 0, 25*time.Hour)
+	p0Fresh := issue("P0 fresh", 0, 23*time.Hour)
+	p1Late := issue("P1 late", 1, 4*24*time.Hour)
+	p1Fresh := issue("P1 fresh", 1, 2*24*time.Hour)
+	p3Old := issue("P3 old", 3, 365*24*time.Hour)
+	p0Closed := issue("P0 closed", 0, 48*time.Hour)
+	if err := store.CreateIssues(ctx, []*types.Issue{p0Late, p0Fresh, p1Late, p1Fresh, p3Old, p0Closed}, "test-user"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, p0Closed.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, types.SLAConfigKey, "0:24h; 1:3d"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	breaches, err := store.GetSLABreaches(ctx)
+	if err != nil {
+		t.Fatalf("GetSLABreaches failed: %v", err)
+	}
+	if len(breaches) != 2 {
+		t.Fatalf("Expected 2 breaches, got %d", len(breaches))
+	}
+	if breaches[0].Issue.ID != p1Late.ID || breaches[1].Issue.ID != p0Late.ID {
+		t.Errorf("Expected furthest over first (%s, %s), got (%s, %s)", p1Late.ID, p0Late.ID, breaches[0].Issue.ID, breaches[1].Issue.ID)
+	}
+	if over := breaches[0].Over(); over < 24*time.Hour || over > 25*time.Hour {
+		t.Errorf("Expected %s about 1 day over, got %v", p1Late.ID, over)
+	}
+
+	if err := store.SetConfig(ctx, types.SLAConfigKey, "0:sometime"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := store.GetSLABreaches(ctx); err == nil {
+		t.Error("Expected an error for an invalid SLA config")
+	}
+}
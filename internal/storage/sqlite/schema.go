@@ -22,6 +22,10 @@ CREATE TABLE IF NOT EXISTS issues (
     compacted_at DATETIME,
     compacted_at_commit TEXT,
     original_size INTEGER,
+    archived_at DATETIME,
+    reporter TEXT,
+    due_date DATETIME,
+    rank REAL,
     CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 );
 
@@ -56,6 +60,25 @@ CREATE TABLE IF NOT EXISTS labels (
 
 CREATE INDEX IF NOT EXISTS idx_labels_label ON labels(label);
 
+-- External references table (one key per tracker per issue)
+CREATE TABLE IF NOT EXISTS external_refs (
+    issue_id TEXT NOT NULL,
+    tracker TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    PRIMARY KEY (issue_id, tracker),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_refs_ref ON external_refs(ref);
+
+-- Watchers table (people following an issue)
+CREATE TABLE IF NOT EXISTS watchers (
+    issue_id TEXT NOT NULL,
+    watcher TEXT NOT NULL,
+    PRIMARY KEY (issue_id, watcher),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Comments table
 CREATE TABLE IF NOT EXISTS comments (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -84,6 +107,7 @@ CREATE TABLE IF NOT EXISTS events (
 
 CREATE INDEX IF NOT EXISTS idx_events_issue ON events(issue_id);
 CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
+CREATE INDEX IF NOT EXISTS idx_events_issue_created ON events(issue_id, created_at, id);
 
 -- Config table (for storing settings like issue prefix)
 CREATE TABLE IF NOT EXISTS config (
@@ -102,7 +126,8 @@ INSERT OR IGNORE INTO config (key, value) VALUES
     ('compact_model', 'claude-3-5-haiku-20241022'),
     ('compact_batch_size', '50'),
     ('compact_parallel_workers', '5'),
-    ('auto_compact_enabled', 'false');
+    ('auto_compact_enabled', 'false'),
+    ('trash_retention_days', '30');
 
 -- Metadata table (for storing internal state like import hashes)
 CREATE TABLE IF NOT EXISTS metadata (
@@ -128,6 +153,26 @@ CREATE TABLE IF NOT EXISTS export_hashes (
     FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
+-- Snapshots of deleted issues so they can be restored with 'bd undo-delete'
+CREATE TABLE IF NOT EXISTS deleted_issues (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    data TEXT NOT NULL,
+    deleted_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_deleted_issues_issue ON deleted_issues(issue_id);
+
+-- Claims on issues (for agents coordinating over ready work)
+-- A claim past expires_at no longer holds the issue
+CREATE TABLE IF NOT EXISTS issue_claims (
+    issue_id TEXT PRIMARY KEY,
+    agent TEXT NOT NULL,
+    claimed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Issue counters table (for atomic ID generation)
 CREATE TABLE IF NOT EXISTS issue_counters (
     prefix TEXT PRIMARY KEY,
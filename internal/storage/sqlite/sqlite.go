@@ -13,7 +13,9 @@ import (
 	"time"
 
 	// Import SQLite driver
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 	_ "modernc.org/sqlite"
 )
 
@@ -86,6 +88,11 @@ func New(path string) (*SQLiteStorage, error) {
 		return nil, fmt.Errorf("failed to migrate external_ref column: %w", err)
 	}
 
+	// Fold single external_ref values into the per-tracker external_refs table
+	if err := migrateLegacyExternalRefs(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate external refs: %w", err)
+	}
+
 	// Migrate existing databases to add composite index on dependencies
 	if err := migrateCompositeIndexes(db); err != nil {
 		return nil, fmt.Errorf("failed to migrate composite indexes: %w", err)
@@ -121,6 +128,26 @@ func New(path string) (*SQLiteStorage, error) {
 		return nil, fmt.Errorf("failed to migrate export_hashes table: %w", err)
 	}
 
+	// Migrate existing databases to add archived_at column
+	if err := migrateArchivedAtColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate archived_at column: %w", err)
+	}
+
+	// Migrate existing databases to add reporter column
+	if err := migrateReporterColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate reporter column: %w", err)
+	}
+
+	// Migrate existing databases to add due_date column
+	if err := migrateDueDateColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate due_date column: %w", err)
+	}
+
+	// Migrate existing databases to add rank column
+	if err := migrateRankColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate rank column: %w", err)
+	}
+
 	// Convert to absolute path for consistency
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -287,6 +314,48 @@ func migrateExternalRefColumn(db *sql.DB) error {
 	return nil
 }
 
+// migrateLegacyExternalRefs folds each issue's single external_ref into the
+// external_refs table, filed under LegacyExternalRefTracker. The column is kept
+// for backward compatibility. Idempotent: refs already present under any
+// tracker are left alone, and an occupied tracker slot is not overwritten.
+func migrateLegacyExternalRefs(db *sql.DB) error {
+	rows, err := db.Query(`
+		SELECT id, external_ref FROM issues
+		WHERE external_ref IS NOT NULL AND external_ref != ''
+		  AND NOT EXISTS (
+			SELECT 1 FROM external_refs e
+			WHERE e.issue_id = issues.id AND e.ref = issues.external_ref
+		  )
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to find legacy external refs: %w", err)
+	}
+	type legacyRef struct{ id, ref string }
+	var pending []legacyRef
+	for rows.Next() {
+		var r legacyRef
+		if err := rows.Scan(&r.id, &r.ref); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan legacy external ref: %w", err)
+		}
+		pending = append(pending, r)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range pending {
+		_, err := db.Exec(`
+			INSERT OR IGNORE INTO external_refs (issue_id, tracker, ref) VALUES (?, ?, ?)
+		`, r.id, types.LegacyExternalRefTracker(r.ref), r.ref)
+		if err != nil {
+			return fmt.Errorf("failed to fold external ref for %s: %w", r.id, err)
+		}
+	}
+	return nil
+}
+
 // migrateCompositeIndexes checks if composite indexes exist and creates them if missing.
 // This ensures existing databases get performance optimizations from new indexes.
 func migrateCompositeIndexes(db *sql.DB) error {
@@ -518,6 +587,106 @@ func migrateExportHashesTable(db *sql.DB) error {
 	return nil
 }
 
+// migrateArchivedAtColumn adds archived_at column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateArchivedAtColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'archived_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check archived_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN archived_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add archived_at column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateReporterColumn adds reporter column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateReporterColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'reporter'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check reporter column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN reporter TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add reporter column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateDueDateColumn adds due_date column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateDueDateColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'due_date'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check due_date column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN due_date DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add due_date column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateRankColumn adds rank column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateRankColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'rank'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check rank column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN rank REAL`)
+	if err != nil {
+		return fmt.Errorf("failed to add rank column: %w", err)
+	}
+
+	return nil
+}
+
 // getNextIDForPrefix atomically generates the next ID for a given prefix
 // Uses the issue_counters table for atomic, cross-process ID generation
 func (s *SQLiteStorage) getNextIDForPrefix(ctx context.Context, prefix string) (int, error) {
@@ -579,7 +748,11 @@ func (s *SQLiteStorage) SyncAllCounters(ctx context.Context) error {
 // CreateIssue creates a new issue
 func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
 	// Validate issue before creating
-	if err := issue.Validate(); err != nil {
+	required, err := s.requiredFields(ctx)
+	if err != nil {
+		return err
+	}
+	if err := issue.ValidateWith(required); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
@@ -588,6 +761,11 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	issue.CreatedAt = now
 	issue.UpdatedAt = now
 
+	// Record who created the issue
+	if issue.Reporter == "" {
+		issue.Reporter = actor
+	}
+
 	// Acquire a dedicated connection for the transaction.
 	// This is necessary because we need to execute raw SQL ("BEGIN IMMEDIATE", "COMMIT")
 	// on the same connection, and database/sql's connection pool would otherwise
@@ -679,18 +857,23 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		INSERT INTO issues (
 			id, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, archived_at, reporter, due_date, rank
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		issue.ID, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-		issue.ClosedAt, issue.ExternalRef,
+		issue.ClosedAt, issue.ExternalRef, issue.ArchivedAt, issue.Reporter, issue.DueDate, issue.Rank,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
 	}
+	if issue.ExternalRef != nil {
+		if err := syncLegacyExternalRef(ctx, conn, issue.ID, "", *issue.ExternalRef); err != nil {
+			return err
+		}
+	}
 
 	// Record creation event
 	eventData, err := json.Marshal(issue)
@@ -817,8 +1000,8 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 		INSERT INTO issues (
 			id, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, archived_at, reporter, due_date, rank
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -831,11 +1014,16 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-			issue.ClosedAt, issue.ExternalRef,
+			issue.ClosedAt, issue.ExternalRef, issue.ArchivedAt, issue.Reporter, issue.DueDate, issue.Rank,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
 		}
+		if issue.ExternalRef != nil {
+			if err := syncLegacyExternalRef(ctx, conn, issue.ID, "", *issue.ExternalRef); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -994,8 +1182,16 @@ func (s *SQLiteStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 	return nil
 }
 
-// GetIssue retrieves an issue by ID
-func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+// issueDetailColumns are the columns read by scanIssueDetail
+const issueDetailColumns = `id, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref,
+		       compaction_level, compacted_at, compacted_at_commit, original_size,
+		       archived_at, reporter, due_date, rank`
+
+// scanIssueDetail scans one row of issueDetailColumns, including compaction fields.
+// Labels are not loaded.
+func scanIssueDetail(row interface{ Scan(dest ...interface{}) error }) (*types.Issue, error) {
 	var issue types.Issue
 	var closedAt sql.NullTime
 	var estimatedMinutes sql.NullInt64
@@ -1005,26 +1201,20 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	var originalSize sql.NullInt64
 
 	var compactedAtCommit sql.NullString
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref,
-		       compaction_level, compacted_at, compacted_at_commit, original_size
-		FROM issues
-		WHERE id = ?
-	`, id).Scan(
+	var archivedAt sql.NullTime
+	var reporter sql.NullString
+	var dueDate sql.NullTime
+	var rank sql.NullFloat64
+	err := row.Scan(
 		&issue.ID, &issue.Title, &issue.Description, &issue.Design,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize,
+		&archivedAt, &reporter, &dueDate, &rank,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue: %w", err)
+		return nil, err
 	}
 
 	if closedAt.Valid {
@@ -1049,6 +1239,36 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if originalSize.Valid {
 		issue.OriginalSize = int(originalSize.Int64)
 	}
+	if archivedAt.Valid {
+		issue.ArchivedAt = &archivedAt.Time
+	}
+	if reporter.Valid {
+		issue.Reporter = reporter.String
+	}
+	if dueDate.Valid {
+		issue.DueDate = &dueDate.Time
+	}
+	if rank.Valid {
+		issue.Rank = &rank.Float64
+	}
+	return &issue, nil
+}
+
+// GetIssue retrieves an issue by ID
+func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	// #nosec G201 - safe SQL with controlled formatting
+	issue, err := scanIssueDetail(s.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM issues
+		WHERE id = ?
+	`, issueDetailColumns), id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -1057,7 +1277,102 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	}
 	issue.Labels = labels
 
-	return &issue, nil
+	refs, err := s.GetExternalRefs(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	issue.ExternalRefs = refs
+
+	watchers, err := s.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	issue.Watchers = watchers
+
+	return issue, nil
+}
+
+// IssueExists reports whether an issue with the given ID exists, without loading it
+func (s *SQLiteStorage) IssueExists(ctx context.Context, id string) (bool, error) {
+	var one int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, id).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check issue %s: %w", id, err)
+	}
+	return true, nil
+}
+
+// getIssuesBatchSize bounds the number of IDs bound into one IN (...) query,
+// keeping well under SQLite's host parameter limit
+const getIssuesBatchSize = 500
+
+// GetIssues retrieves several issues by ID with one query per batch of IDs.
+// IDs that don't exist are absent from the returned map.
+func (s *SQLiteStorage) GetIssues(ctx context.Context, ids []string) (map[string]*types.Issue, error) {
+	result := make(map[string]*types.Issue, len(ids))
+	for start := 0; start < len(ids); start += getIssuesBatchSize {
+		end := start + getIssuesBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+
+		// #nosec G201 - safe SQL with controlled formatting
+		rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+			SELECT %s
+			FROM issues
+			WHERE id IN (%s)
+		`, issueDetailColumns, strings.Join(placeholders, ", ")), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+		for rows.Next() {
+			issue, err := scanIssueDetail(rows)
+			if err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan issue: %w", err)
+			}
+			result[issue.ID] = issue
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+	}
+
+	// Labels, tracker refs, and watchers are loaded after the rows are closed
+	// so the queries don't overlap
+	for id, issue := range result {
+		labels, err := s.GetLabels(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for issue %s: %w", id, err)
+		}
+		issue.Labels = labels
+
+		refs, err := s.GetExternalRefs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		issue.ExternalRefs = refs
+
+		watchers, err := s.GetWatchers(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		issue.Watchers = watchers
+	}
+	return result, nil
 }
 
 // Allowed fields for update to prevent SQL injection
@@ -1073,6 +1388,9 @@ var allowedUpdateFields = map[string]bool{
 	"issue_type":          true,
 	"estimated_minutes":   true,
 	"external_ref":        true,
+	"due_date":            true,
+	"rank":                true,
+	"archived_at":         true,
 }
 
 // validatePriority validates a priority value
@@ -1125,6 +1443,36 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validateDueDate validates a due_date value (a time, or nil to clear it)
+func validateDueDate(value interface{}) error {
+	switch value.(type) {
+	case nil, time.Time, *time.Time:
+		return nil
+	default:
+		return fmt.Errorf("due_date must be a time, got %T", value)
+	}
+}
+
+// validateArchivedAt validates an archived_at value (a time, or nil to unarchive)
+func validateArchivedAt(value interface{}) error {
+	switch value.(type) {
+	case nil, time.Time, *time.Time:
+		return nil
+	default:
+		return fmt.Errorf("archived_at must be a time, got %T", value)
+	}
+}
+
+// validateRank validates a rank value (a number, or nil to clear it)
+func validateRank(value interface{}) error {
+	switch value.(type) {
+	case nil, float64, *float64:
+		return nil
+	default:
+		return fmt.Errorf("rank must be a number, got %T", value)
+	}
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":           validatePriority,
@@ -1132,6 +1480,9 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":         validateIssueType,
 	"title":              validateTitle,
 	"estimated_minutes":  validateEstimatedMinutes,
+	"due_date":           validateDueDate,
+	"rank":               validateRank,
+	"archived_at":        validateArchivedAt,
 }
 
 // validateFieldUpdate validates a field update value
@@ -1199,6 +1550,49 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	return setClauses, args
 }
 
+// updateStatus returns the new status in an updates map, if any
+func updateStatus(updates map[string]interface{}) (types.Status, bool) {
+	switch v := updates["status"].(type) {
+	case string:
+		return types.Status(v), true
+	case types.Status:
+		return v, true
+	}
+	return "", false
+}
+
+// checkStatusTransition returns an error if the status workflow configured under
+// status_workflow doesn't allow moving from one status to another. Contexts from
+// workflow.WithForce skip the check.
+func (s *SQLiteStorage) checkStatusTransition(ctx context.Context, from, to types.Status) error {
+	if from == to || workflow.Forced(ctx) {
+		return nil
+	}
+	spec, err := s.GetConfig(ctx, workflow.ConfigKey)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", workflow.ConfigKey, err)
+	}
+	w, err := workflow.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", workflow.ConfigKey, err)
+	}
+	return w.Check(from, to)
+}
+
+// requiredFields returns the per-type required sections configured under
+// required_fields
+func (s *SQLiteStorage) requiredFields(ctx context.Context) (types.RequiredFields, error) {
+	spec, err := s.GetConfig(ctx, types.RequiredFieldsConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", types.RequiredFieldsConfigKey, err)
+	}
+	required, err := types.ParseRequiredFields(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", types.RequiredFieldsConfigKey, err)
+	}
+	return required, nil
+}
+
 // UpdateIssue updates fields on an issue
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	// Get old issue for event
@@ -1229,57 +1623,219 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		args = append(args, value)
 	}
 
+	// Reject status changes the configured workflow doesn't allow
+	if newStatus, ok := updateStatus(updates); ok {
+		if err := s.checkStatusTransition(ctx, oldIssue.Status, newStatus); err != nil {
+			return err
+		}
+	}
+
+	if dryRun := storage.DryRunFrom(ctx); dryRun != nil {
+		if err := storage.CheckVersion(ctx, oldIssue); err != nil {
+			return err
+		}
+		dryRun.Preview(oldIssue, updates)
+		return nil
+	}
+
 	// Auto-manage closed_at when status changes (enforce invariant)
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
 
 	args = append(args, id)
 
-	// Start transaction
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
+	// Take the write lock up front: the version is read before the update, and
+	// a deferred transaction upgrading from a read would fail with SQLITE_BUSY
+	// rather than wait when another process writes in between
+	return s.withImmediateTx(ctx, func(conn *sql.Conn) error {
+		// Check the version inside the transaction so a write made by another
+		// process since GetIssue above still counts as a conflict
+		current := *oldIssue
+		if err := conn.QueryRowContext(ctx, `SELECT updated_at FROM issues WHERE id = ?`, id).Scan(&current.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to read issue version: %w", err)
+		}
+		if err := storage.CheckVersion(ctx, &current); err != nil {
+			return err
+		}
 
-	// Update issue
-	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
-	_, err = tx.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
-	}
+		// Update issue
+		query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
+		_, err := conn.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
 
-	// Record event
-	oldData, err := json.Marshal(oldIssue)
-	if err != nil {
-		// Fall back to minimal description if marshaling fails
-		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
-	}
-	newData, err := json.Marshal(updates)
+		// A closed issue can't be held by a claim
+		if newStatus, ok := updateStatus(updates); ok && newStatus == types.StatusClosed {
+			if _, err := conn.ExecContext(ctx, `DELETE FROM issue_claims WHERE issue_id = ?`, id); err != nil {
+				return fmt.Errorf("failed to drop claim: %w", err)
+			}
+		}
+
+		// Keep the per-tracker refs in step with the legacy external_ref column
+		if value, ok := updates["external_ref"]; ok {
+			var oldRef, newRef string
+			if oldIssue.ExternalRef != nil {
+				oldRef = *oldIssue.ExternalRef
+			}
+			switch v := value.(type) {
+			case string:
+				newRef = v
+			case *string:
+				if v != nil {
+					newRef = *v
+				}
+			}
+			if err := syncLegacyExternalRef(ctx, conn, id, oldRef, newRef); err != nil {
+				return err
+			}
+		}
+
+		// Record event
+		oldData, err := json.Marshal(oldIssue)
+		if err != nil {
+			// Fall back to minimal description if marshaling fails
+			oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
+		}
+		newData, err := json.Marshal(updates)
+		if err != nil {
+			// Fall back to minimal description if marshaling fails
+			newData = []byte(`{}`)
+		}
+		oldDataStr := string(oldData)
+		newDataStr := string(newData)
+
+		eventType := determineEventType(oldIssue, updates)
+
+		_, err = conn.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, ?, ?, ?, ?)
+		`, id, eventType, actor, oldDataStr, newDataStr)
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		// Mark issue as dirty for incremental export
+		_, err = conn.ExecContext(ctx, `
+			INSERT INTO dirty_issues (issue_id, marked_at)
+			VALUES (?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+		`, id, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReassignAll moves every unclosed issue assigned to from over to to (empty to unassigns).
+// Both names are trimmed; a to of only whitespace is rejected rather than written.
+// Closed issues keep their historical assignee. Returns the number of issues changed.
+func (s *SQLiteStorage) ReassignAll(ctx context.Context, from, to string, actor string) (int, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return 0, fmt.Errorf("assignee to reassign from cannot be empty")
+	}
+	if to != "" && strings.TrimSpace(to) == "" {
+		return 0, fmt.Errorf("assignee to reassign to cannot be blank (use an empty assignee to unassign)")
+	}
+	to = strings.TrimSpace(to)
+	if from == to {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		// Fall back to minimal description if marshaling fails
-		newData = []byte(`{}`)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	oldDataStr := string(oldData)
-	newDataStr := string(newData)
+	defer func() { _ = tx.Rollback() }()
 
-	eventType := determineEventType(oldIssue, updates)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM issues WHERE assignee = ? AND status != ?
+	`, from, types.StatusClosed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find issues assigned to %s: %w", from, err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		_, err = tx.ExecContext(ctx, `UPDATE issues SET assignee = ?, updated_at = ? WHERE id = ?`, to, now, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reassign %s: %w", id, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, ?, ?, ?, ?)
+		`, id, types.EventReassigned, actor, from, to)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, ids); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit reassignment: %w", err)
+	}
+	return len(ids), nil
+}
+
+// AppendNotes appends a timestamped entry to an issue's notes in place, without the
+// caller reading and rewriting the whole issue
+func (s *SQLiteStorage) AppendNotes(ctx context.Context, id, text, actor string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("notes to append cannot be empty")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	entry := types.NotesEntry(now, actor, text)
+	result, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET notes = CASE WHEN notes = '' THEN ? ELSE notes || ? END, updated_at = ?
+		WHERE id = ?
+	`, entry, types.NotesEntrySeparator+entry, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to append notes: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issue %s not found", id)
+	}
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, ?, ?, ?)
-	`, id, eventType, actor, oldDataStr, newDataStr)
+		VALUES (?, ?, ?, NULL, ?)
+	`, id, types.EventUpdated, actor, entry)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	// Mark issue as dirty for incremental export
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO dirty_issues (issue_id, marked_at)
-		VALUES (?, ?)
-		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-	`, id, time.Now())
-	if err != nil {
-		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	if err := markIssuesDirtyTx(ctx, tx, []string{id}); err != nil {
+		return err
 	}
 
 	return tx.Commit()
@@ -1335,6 +1891,16 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update labels: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE external_refs SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update external refs: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE watchers SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update watchers: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `UPDATE comments SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
 	if err != nil {
 		return fmt.Errorf("failed to update comments: %w", err)
@@ -1357,6 +1923,11 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update compaction_snapshots: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE issue_claims SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_claims: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -1368,8 +1939,8 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, 'renamed', ?, ?, ?)
-	`, newID, actor, oldID, newID)
+		VALUES (?, ?, ?, ?, ?)
+	`, newID, types.EventRenamed, actor, oldID, newID)
 	if err != nil {
 		return fmt.Errorf("failed to record rename event: %w", err)
 	}
@@ -1425,6 +1996,18 @@ func (s *SQLiteStorage) ResetCounter(ctx context.Context, prefix string) error {
 
 // CloseIssue closes an issue with a reason
 func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	if !workflow.Forced(ctx) {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue != nil {
+			if err := s.checkStatusTransition(ctx, issue.Status, types.StatusClosed); err != nil {
+				return err
+			}
+		}
+	}
+
 	now := time.Now()
 
 	// Update with special event handling
@@ -1442,6 +2025,11 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 		return fmt.Errorf("failed to close issue: %w", err)
 	}
 
+	// A closed issue can't be held by a claim
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_claims WHERE issue_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to drop claim: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
 		VALUES (?, ?, ?, ?)
@@ -1463,7 +2051,188 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	return tx.Commit()
 }
 
-// DeleteIssue permanently removes an issue from the database
+// ReopenIssue reopens a closed issue: status becomes open, closed_at is cleared, and
+// a reopened event is recorded with the reason as its comment
+func (s *SQLiteStorage) ReopenIssue(ctx context.Context, id string, reason string, actor string) error {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if issue.Status != types.StatusClosed {
+		return fmt.Errorf("issue %s is not closed (status: %s)", id, issue.Status)
+	}
+	if err := s.checkStatusTransition(ctx, issue.Status, types.StatusOpen); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = NULL, updated_at = ?
+		WHERE id = ?
+	`, types.StatusOpen, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+
+	var comment interface{}
+	if reason != "" {
+		comment = reason
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, types.EventReopened, actor, string(types.StatusClosed), string(types.StatusOpen), comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// The events table isn't exported, so keep the reason as a comment too
+	// for it to reach other clones
+	if reason != "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, text, created_at)
+			VALUES (?, ?, ?, ?)
+		`, id, actor, reason, now)
+		if err != nil {
+			return fmt.Errorf("failed to add reopen comment: %w", err)
+		}
+	}
+
+	// Mark issue as dirty for incremental export
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, id, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// CloneIssue creates a new open issue with a fresh ID, copying the source's title,
+// description, design, acceptance criteria, type, priority, estimate, and labels, then
+// applies overrides (same fields as UpdateIssue). Status, assignee, and dependencies are not copied.
+func (s *SQLiteStorage) CloneIssue(ctx context.Context, id string, overrides map[string]interface{}, actor string) (*types.Issue, error) {
+	// Validate overrides up front so a bad field doesn't leave a half-made clone behind
+	for key, value := range overrides {
+		if !allowedUpdateFields[key] {
+			return nil, fmt.Errorf("invalid field for update: %s", key)
+		}
+		if err := validateFieldUpdate(key, value); err != nil {
+			return nil, fmt.Errorf("failed to validate field update: %w", err)
+		}
+	}
+
+	source, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+
+	clone := &types.Issue{
+		Title:              source.Title,
+		Description:        source.Description,
+		Design:             source.Design,
+		AcceptanceCriteria: source.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           source.Priority,
+		IssueType:          source.IssueType,
+		EstimatedMinutes:   source.EstimatedMinutes,
+	}
+	if err := s.CreateIssue(ctx, clone, actor); err != nil {
+		return nil, fmt.Errorf("failed to create clone: %w", err)
+	}
+
+	for _, label := range source.Labels {
+		if err := s.AddLabel(ctx, clone.ID, label, actor); err != nil {
+			return nil, fmt.Errorf("failed to copy label %s: %w", label, err)
+		}
+	}
+
+	if len(overrides) > 0 {
+		if err := s.UpdateIssue(ctx, clone.ID, overrides, actor); err != nil {
+			return nil, fmt.Errorf("failed to apply overrides to %s: %w", clone.ID, err)
+		}
+	}
+
+	return s.GetIssue(ctx, clone.ID)
+}
+
+// ArchiveIssue hides an issue from default listings without deleting it
+func (s *SQLiteStorage) ArchiveIssue(ctx context.Context, id string, actor string) error {
+	return s.setArchived(ctx, id, true, actor)
+}
+
+// UnarchiveIssue restores an archived issue to default listings
+func (s *SQLiteStorage) UnarchiveIssue(ctx context.Context, id string, actor string) error {
+	return s.setArchived(ctx, id, false, actor)
+}
+
+func (s *SQLiteStorage) setArchived(ctx context.Context, id string, archive bool, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var archivedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `SELECT archived_at FROM issues WHERE id = ?`, id).Scan(&archivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if archivedAt.Valid == archive {
+		if archive {
+			return fmt.Errorf("issue %s is already archived", id)
+		}
+		return fmt.Errorf("issue %s is not archived", id)
+	}
+
+	now := time.Now()
+	var newValue interface{}
+	eventType := types.EventUnarchived
+	if archive {
+		newValue = now
+		eventType = types.EventArchived
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE issues SET archived_at = ?, updated_at = ? WHERE id = ?`, newValue, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to update archived state: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor)
+		VALUES (?, ?, ?)
+	`, id, eventType, actor)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, []string{id}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteIssue removes an issue from the database, keeping a snapshot in the trash
 func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -1471,6 +2240,20 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Keep a snapshot so 'bd undo-delete' can restore it
+	if err := s.trashIssuesTx(ctx, tx, []string{id}); err != nil {
+		return err
+	}
+
+	// Issues linked to this one lose a dependency, so they need re-export
+	linked, err := linkedIssueIDsTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := markIssuesDirtyTx(ctx, tx, linked); err != nil {
+		return err
+	}
+
 	// Delete dependencies (both directions)
 	_, err = tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id)
 	if err != nil {
@@ -1507,10 +2290,36 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return err
 	}
 
+	// Best effort: a bad retention setting shouldn't fail a committed delete
+	_, _ = s.PurgeExpiredTrash(ctx)
+
 	// Sync counters after deletion to keep them accurate
 	return s.SyncAllCounters(ctx)
 }
 
+// linkedIssueIDsTx returns existing issues with a dependency to or from the given issue
+func linkedIssueIDsTx(ctx context.Context, tx *sql.Tx, id string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT d.issue_id FROM dependencies d JOIN issues i ON i.id = d.issue_id WHERE d.depends_on_id = ?
+		UNION
+		SELECT d.depends_on_id FROM dependencies d JOIN issues i ON i.id = d.depends_on_id WHERE d.issue_id = ?
+	`, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issues linked to %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var linkedID string
+		if err := rows.Scan(&linkedID); err != nil {
+			return nil, fmt.Errorf("failed to scan linked issue: %w", err)
+		}
+		ids = append(ids, linkedID)
+	}
+	return ids, rows.Err()
+}
+
 // DeleteIssuesResult contains statistics about a batch deletion operation
 type DeleteIssuesResult struct {
 	DeletedCount      int
@@ -1553,6 +2362,10 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 		return result, nil
 	}
 
+	if err := s.trashIssuesTx(ctx, tx, expandedIDs); err != nil {
+		return nil, err
+	}
+
 	if err := s.executeDelete(ctx, tx, inClause, args, result); err != nil {
 		return nil, err
 	}
@@ -1561,6 +2374,9 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	// Best effort: a bad retention setting shouldn't fail a committed delete
+	_, _ = s.PurgeExpiredTrash(ctx)
+
 	if err := s.SyncAllCounters(ctx); err != nil {
 		return nil, fmt.Errorf("failed to sync counters after deletion: %w", err)
 	}
@@ -1721,6 +2537,7 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 	}{
 		{fmt.Sprintf(`DELETE FROM dependencies WHERE issue_id IN (%s) OR depends_on_id IN (%s)`, inClause, inClause), append(args, args...)},
 		{fmt.Sprintf(`DELETE FROM labels WHERE issue_id IN (%s)`, inClause), args},
+		{fmt.Sprintf(`DELETE FROM external_refs WHERE issue_id IN (%s)`, inClause), args},
 		{fmt.Sprintf(`DELETE FROM events WHERE issue_id IN (%s)`, inClause), args},
 		{fmt.Sprintf(`DELETE FROM dirty_issues WHERE issue_id IN (%s)`, inClause), args},
 		{fmt.Sprintf(`DELETE FROM issues WHERE id IN (%s)`, inClause), args},
@@ -1783,8 +2600,9 @@ func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx *sql.
 	return result, nil
 }
 
-// SearchIssues finds issues matching query and filters
-func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+// issueFilterWhere builds the WHERE clause (including the keyword) and its arguments
+// for a search query and filter. The filter's Limit is not applied.
+func (s *SQLiteStorage) issueFilterWhere(ctx context.Context, query string, filter types.IssueFilter) (string, []interface{}, error) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
@@ -1820,22 +2638,40 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, *filter.Assignee)
 	}
 
-	// Label filtering: issue must have ALL specified labels
+	if filter.Reporter != nil {
+		whereClauses = append(whereClauses, "reporter = ?")
+		args = append(args, *filter.Reporter)
+	}
+
+	if filter.DueBefore != nil {
+		whereClauses = append(whereClauses, "due_date IS NOT NULL AND due_date < ?")
+		args = append(args, *filter.DueBefore)
+	}
+
+	// Label filtering: issue must have ALL specified labels.
+	// Glob and re: patterns are expanded to the labels they match.
+	var allLabels []string
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
-			args = append(args, label)
+			labels, err := s.expandLabelFilter(ctx, []string{label}, &allLabels)
+			if err != nil {
+				return "", nil, err
+			}
+			clause, clauseArgs := labelInClause(labels)
+			whereClauses = append(whereClauses, clause)
+			args = append(args, clauseArgs...)
 		}
 	}
 
 	// Label filtering (OR): issue must have AT LEAST ONE of these labels
 	if len(filter.LabelsAny) > 0 {
-		placeholders := make([]string, len(filter.LabelsAny))
-		for i, label := range filter.LabelsAny {
-			placeholders[i] = "?"
-			args = append(args, label)
+		labels, err := s.expandLabelFilter(ctx, filter.LabelsAny, &allLabels)
+		if err != nil {
+			return "", nil, err
 		}
-		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")))
+		clause, clauseArgs := labelInClause(labels)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
 	}
 
 	// ID filtering: match specific issue IDs
@@ -1848,13 +2684,29 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, fmt.Sprintf("id IN (%s)", strings.Join(placeholders, ", ")))
 	}
 
+	// Archived issues are hidden unless explicitly requested
+	if !filter.IncludeArchived {
+		whereClauses = append(whereClauses, "archived_at IS NULL")
+	}
+
 	whereSQL := ""
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
+	return whereSQL, args, nil
+}
+
+// SearchIssues finds issues matching query and filters
+func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	whereSQL, args, err := s.issueFilterWhere(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relevance and Match are evaluated in Go, so every row is needed before limiting
 	limitSQL := ""
-	if filter.Limit > 0 {
+	if filter.Limit > 0 && !filter.SortByRelevance && filter.Match == nil {
 		limitSQL = " LIMIT ?"
 		args = append(args, filter.Limit)
 	}
@@ -1863,7 +2715,7 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	querySQL := fmt.Sprintf(`
 		SELECT id, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref
+		       created_at, updated_at, closed_at, external_ref, archived_at, reporter, due_date, rank
 		FROM issues
 		%s
 		ORDER BY priority ASC, created_at DESC
@@ -1876,7 +2728,108 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	}
 	defer func() { _ = rows.Close() }()
 
-	return s.scanIssues(ctx, rows)
+	issues, err := s.scanIssues(ctx, rows)
+	if err != nil || (!filter.SortByRelevance && filter.Match == nil) {
+		return issues, err
+	}
+	if filter.Match != nil {
+		matched := issues[:0]
+		for _, issue := range issues {
+			if filter.Match(issue) {
+				matched = append(matched, issue)
+			}
+		}
+		issues = matched
+	}
+	if filter.SortByRelevance {
+		types.SortByRelevance(issues, filter.RelevanceQuery(query))
+	}
+	if filter.Limit > 0 && len(issues) > filter.Limit {
+		issues = issues[:filter.Limit]
+	}
+	return issues, nil
+}
+
+// CountIssues returns the number of issues matching filter without loading them.
+// A filter Limit caps the count.
+func (s *SQLiteStorage) CountIssues(ctx context.Context, filter types.IssueFilter) (int, error) {
+	if filter.Match != nil {
+		// The predicate can only be checked on loaded issues
+		issues, err := s.SearchIssues(ctx, "", filter)
+		return len(issues), err
+	}
+
+	whereSQL, args, err := s.issueFilterWhere(ctx, "", filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	// #nosec G201 - safe SQL with controlled formatting
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM issues %s`, whereSQL), args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+	if filter.Limit > 0 && count > filter.Limit {
+		count = filter.Limit
+	}
+	return count, nil
+}
+
+// IterateIssues calls fn for every issue, including archived ones, in ID order.
+// Issues are read batchSize at a time using the last seen ID as a cursor, so memory
+// use stays bounded however large the database is. A nil status matches all issues.
+// Iteration stops at the first error returned by fn.
+func (s *SQLiteStorage) IterateIssues(ctx context.Context, status *types.Status, batchSize int, fn func(*types.Issue) error) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	statusSQL := ""
+	if status != nil {
+		statusSQL = " AND status = ?"
+	}
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, archived_at, reporter, due_date, rank
+		FROM issues
+		WHERE id > ?%s
+		ORDER BY id
+		LIMIT ?
+	`, statusSQL)
+
+	cursor := ""
+	for {
+		args := []interface{}{cursor}
+		if status != nil {
+			args = append(args, *status)
+		}
+		args = append(args, batchSize)
+
+		rows, err := s.db.QueryContext(ctx, querySQL, args...)
+		if err != nil {
+			return fmt.Errorf("failed to iterate issues: %w", err)
+		}
+		batch, err := s.scanIssues(ctx, rows)
+		_ = rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(issue); err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		cursor = batch[len(batch)-1].ID
+	}
 }
 
 // SetConfig sets a configuration value
@@ -2017,12 +2970,108 @@ func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([
 	return comments, nil
 }
 
+// ImportComments inserts comments read from an export, keeping their original timestamps.
+// Comments for issues that don't exist are skipped, as are comments whose author and
+// text already appear on the issue (matching how JSONL import dedupes inline comments).
+// Returns the number of comments inserted.
+func (s *SQLiteStorage) ImportComments(ctx context.Context, comments []*types.Comment) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Existing author/text keys per issue; nil means the issue doesn't exist
+	existing := make(map[string]map[string]bool)
+	touched := make(map[string]bool)
+	var touchedIDs []string
+	inserted := 0
+	for _, comment := range comments {
+		keys, loaded := existing[comment.IssueID]
+		if !loaded {
+			keys, err = existingCommentKeys(ctx, tx, comment.IssueID)
+			if err != nil {
+				return 0, err
+			}
+			existing[comment.IssueID] = keys
+		}
+		if keys == nil {
+			continue
+		}
+
+		key := comment.Author + ":" + strings.TrimSpace(comment.Text)
+		if keys[key] {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)
+		`, comment.IssueID, comment.Author, comment.Text, comment.CreatedAt.UTC())
+		if err != nil {
+			return 0, fmt.Errorf("failed to import comment for %s: %w", comment.IssueID, err)
+		}
+		keys[key] = true
+		if !touched[comment.IssueID] {
+			touched[comment.IssueID] = true
+			touchedIDs = append(touchedIDs, comment.IssueID)
+		}
+		inserted++
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, touchedIDs); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return inserted, nil
+}
+
+// existingCommentKeys returns the author/text keys of an issue's comments,
+// or nil if the issue doesn't exist
+func existingCommentKeys(ctx context.Context, tx *sql.Tx, issueID string) (map[string]bool, error) {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT author, text FROM comments WHERE issue_id = ?`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := make(map[string]bool)
+	for rows.Next() {
+		var author, text string
+		if err := rows.Scan(&author, &text); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		keys[author+":"+strings.TrimSpace(text)] = true
+	}
+	return keys, rows.Err()
+}
+
 // Close closes the database connection
 func (s *SQLiteStorage) Close() error {
 	s.closed.Store(true)
 	return s.db.Close()
 }
 
+// Vacuum rebuilds the database file to reclaim space left by deleted rows, then
+// checkpoints the WAL so the savings show up in the main database file
+func (s *SQLiteStorage) Vacuum(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	return nil
+}
+
 // Path returns the absolute path to the database file
 func (s *SQLiteStorage) Path() string {
 	return s.dbPath
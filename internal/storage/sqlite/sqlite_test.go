@@ -2,12 +2,20 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/workflow"
 	_ "modernc.org/sqlite"
 )
 
@@ -200,6 +208,109 @@ func TestGetIssueNotFound(t *testing.T) {
 	}
 }
 
+func TestIssueExists(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	live := &types.Issue{Title: "Live", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	archived := &types.Issue{Title: "Archived", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	deleted := &types.Issue{Title: "Deleted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{live, archived, deleted} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.ArchiveIssue(ctx, archived.ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+	if err := store.DeleteIssue(ctx, deleted.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{live.ID, true},
+		{archived.ID, true}, // Archived issues are hidden, not gone
+		{deleted.ID, false}, // Trashed issues no longer exist
+		{"bd-999", false},
+	}
+	for _, tt := range tests {
+		got, err := store.IssueExists(ctx, tt.id)
+		if err != nil {
+			t.Fatalf("IssueExists(%s) failed: %v", tt.id, err)
+		}
+		if got != tt.want {
+			t.Errorf("IssueExists(%s) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestGetIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < getIssuesBatchSize+3; i++ {
+		issue := &types.Issue{Title: "Issue " + strconv.Itoa(i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddLabel(ctx, ids[0], "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddWatcher(ctx, ids[0], "alice", "test-user"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	if err := store.SetExternalRef(ctx, ids[0], "jira", "ABC-1", "test-user"); err != nil {
+		t.Fatalf("SetExternalRef failed: %v", err)
+	}
+
+	// Request every issue (spanning more than one batch) plus a missing ID
+	issues, err := store.GetIssues(ctx, append(ids, "bd-99999"))
+	if err != nil {
+		t.Fatalf("GetIssues failed: %v", err)
+	}
+	if len(issues) != len(ids) {
+		t.Fatalf("expected %d issues, got %d", len(ids), len(issues))
+	}
+	if _, ok := issues["bd-99999"]; ok {
+		t.Error("missing ID should be absent from the result")
+	}
+	for _, id := range ids {
+		if issues[id] == nil || issues[id].ID != id {
+			t.Errorf("issue %s not returned", id)
+		}
+	}
+	if labels := issues[ids[0]].Labels; len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("expected labels [backend], got %v", labels)
+	}
+
+	// Matches GetIssue field for field
+	for _, id := range ids[:2] {
+		single, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if !reflect.DeepEqual(issues[id], single) {
+			t.Errorf("GetIssues returned %+v, GetIssue returned %+v", issues[id], single)
+		}
+	}
+
+	empty, err := store.GetIssues(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetIssues(nil) failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected empty result, got %d issues", len(empty))
+	}
+}
+
 // createIssuesTestHelper provides test setup and assertion methods
 type createIssuesTestHelper struct {
 	t     *testing.T
@@ -614,6 +725,91 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueExpectedVersion(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Two agents read the same revision
+	read, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	stale := read.Version()
+
+	// The first write succeeds
+	first := storage.WithExpectedVersion(ctx, stale)
+	if err := store.UpdateIssue(first, issue.ID, map[string]interface{}{"title": "Agent A"}, "agent-a"); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+
+	// The second, based on the same read, is rejected
+	err = store.UpdateIssue(first, issue.ID, map[string]interface{}{"title": "Agent B"}, "agent-b")
+	if !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("stale update error = %v, want ErrVersionConflict", err)
+	}
+
+	current, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if current.Title != "Agent A" {
+		t.Errorf("title = %q, stale write clobbered it", current.Title)
+	}
+
+	// Retrying against the fresh version succeeds
+	retry := storage.WithExpectedVersion(ctx, current.Version())
+	if err := store.UpdateIssue(retry, issue.ID, map[string]interface{}{"title": "Agent B"}, "agent-b"); err != nil {
+		t.Fatalf("retried update failed: %v", err)
+	}
+}
+
+func TestSearchIssuesByRelevance(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	// The description-only match has the higher priority, so it would come
+	// first without relevance ranking
+	descOnly := &types.Issue{Title: "Flaky CI", Description: "Caused by the parser rewrite", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	titleHit := &types.Issue{Title: "Parser rewrite", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{descOnly, titleHit} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	results, err := store.SearchIssues(ctx, "parser rewrite", types.IssueFilter{SortByRelevance: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != titleHit.ID {
+		t.Fatalf("results = %v, want %s first", issueIDs(results), titleHit.ID)
+	}
+
+	// The limit applies after ranking
+	results, err = store.SearchIssues(ctx, "parser rewrite", types.IssueFilter{SortByRelevance: true, Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != titleHit.ID {
+		t.Errorf("limited results = %v, want [%s]", issueIDs(results), titleHit.ID)
+	}
+}
+
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
 func TestUpdateIssueValidation(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -705,6 +901,148 @@ func TestCloseIssue(t *testing.T) {
 	}
 }
 
+func TestReopenIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Flaky test", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Only closed issues can be reopened
+	if err := store.ReopenIssue(ctx, issue.ID, "", "test-user"); err == nil {
+		t.Error("expected error reopening an open issue")
+	}
+	if err := store.ReopenIssue(ctx, "bd-999", "", "test-user"); err == nil {
+		t.Error("expected error reopening a missing issue")
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "Fixed", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ReopenIssue(ctx, issue.ID, "Failed again in CI", "bob"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.Status != types.StatusOpen {
+		t.Errorf("expected status open, got %s", reopened.Status)
+	}
+	if reopened.ClosedAt != nil {
+		t.Errorf("expected closed_at to be cleared, got %v", reopened.ClosedAt)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventReopened {
+			found = true
+			if e.Actor != "bob" || e.Comment == nil || *e.Comment != "Failed again in CI" {
+				t.Errorf("unexpected reopened event: actor=%s comment=%v", e.Actor, e.Comment)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a reopened event")
+	}
+
+	// The reason is kept as a comment too, since events aren't exported
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "bob" || comments[0].Text != "Failed again in CI" {
+		t.Errorf("expected the reason as a comment by bob, got %+v", comments)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, types.RequiredFieldsConfigKey, "bug:notes; feature:acceptance_criteria"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	bug := &types.Issue{Title: "Crash", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	err := store.CreateIssue(ctx, bug, "test-user")
+	if err == nil || !strings.Contains(err.Error(), "bug issues require notes") {
+		t.Fatalf("expected missing notes error, got %v", err)
+	}
+
+	bug.Notes = "1. Run bd list\n2. Observe crash"
+	if err := store.CreateIssue(ctx, bug, "test-user"); err != nil {
+		t.Fatalf("CreateIssue with notes failed: %v", err)
+	}
+
+	// Types without requirements are unaffected
+	task := &types.Issue{Title: "Chore", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, task, "test-user"); err != nil {
+		t.Fatalf("CreateIssue for task failed: %v", err)
+	}
+}
+
+func TestStatusWorkflow(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, workflow.ConfigKey, workflow.Strict); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	issue := &types.Issue{Title: "Workflow", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// open → closed skips in_progress
+	var transitionErr *workflow.TransitionError
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); !errors.As(err, &transitionErr) {
+		t.Fatalf("expected TransitionError closing an open issue, got %v", err)
+	}
+
+	// open → in_progress → closed is legal
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("open → in_progress failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("in_progress → closed failed: %v", err)
+	}
+
+	// closed → in_progress is illegal unless forced
+	err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user")
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected TransitionError for closed → in_progress, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.Status != types.StatusClosed {
+		t.Errorf("rejected transition changed status to %s", got.Status)
+	}
+
+	// Reopening is allowed and clears closed_at
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test-user"); err != nil {
+		t.Fatalf("closed → open failed: %v", err)
+	}
+	reopened, _ := store.GetIssue(ctx, issue.ID)
+	if reopened.Status != types.StatusOpen || reopened.ClosedAt != nil {
+		t.Errorf("expected open issue without closed_at, got %s / %v", reopened.Status, reopened.ClosedAt)
+	}
+
+	// Forcing skips the workflow
+	if err := store.CloseIssue(workflow.WithForce(ctx), issue.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("forced close failed: %v", err)
+	}
+}
+
 func TestClosedAtInvariant(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -969,6 +1307,414 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestCountIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issues := []*types.Issue{
+		{Title: "Bug in login", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug, Assignee: "alice"},
+		{Title: "Feature request", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature},
+		{Title: "Another bug", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug, Assignee: "alice"},
+		{Title: "Old task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, issues[1].ID, "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.ArchiveIssue(ctx, issues[3].ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+
+	openStatus := types.StatusOpen
+	bugType := types.TypeBug
+	featureType := types.TypeFeature
+	alice := "alice"
+	filters := map[string]types.IssueFilter{
+		"all":           {},
+		"with archived": {IncludeArchived: true},
+		"status":        {Status: &openStatus},
+		"type":          {IssueType: &bugType},
+		"assignee":      {Assignee: &alice},
+		"label":         {Labels: []string{"backend"}},
+		"ids":           {IDs: []string{issues[0].ID, issues[2].ID}},
+		"limit":         {Limit: 1},
+		"no matches":    {Status: &openStatus, Assignee: &alice, IssueType: &featureType},
+	}
+	for name, filter := range filters {
+		results, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("%s: SearchIssues failed: %v", name, err)
+		}
+		count, err := store.CountIssues(ctx, filter)
+		if err != nil {
+			t.Fatalf("%s: CountIssues failed: %v", name, err)
+		}
+		if count != len(results) {
+			t.Errorf("%s: CountIssues = %d, SearchIssues returned %d", name, count, len(results))
+		}
+	}
+
+	if count, _ := store.CountIssues(ctx, types.IssueFilter{}); count != 3 {
+		t.Errorf("expected 3 unarchived issues, got %d", count)
+	}
+}
+
+func TestSearchIssuesLabelPatterns(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	labelSets := [][]string{
+		{"area:frontend", "urgent"},
+		{"area:backend"},
+		{"team:core"},
+	}
+	var issues []*types.Issue
+	for i, labels := range labelSets {
+		issue := &types.Issue{Title: "Issue " + strconv.Itoa(i), Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		issues = append(issues, issue)
+	}
+
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"glob", types.IssueFilter{Labels: []string{"area:*"}}, []string{issues[0].ID, issues[1].ID}},
+		{"glob single char", types.IssueFilter{Labels: []string{"team:cor?"}}, []string{issues[2].ID}},
+		{"glob combined with exact", types.IssueFilter{Labels: []string{"area:*", "urgent"}}, []string{issues[0].ID}},
+		{"regex", types.IssueFilter{Labels: []string{"re:end$"}}, []string{issues[0].ID, issues[1].ID}},
+		{"regex any", types.IssueFilter{LabelsAny: []string{"re:^team:", "urgent"}}, []string{issues[0].ID, issues[2].ID}},
+		{"glob no match", types.IssueFilter{Labels: []string{"nope:*"}}, nil},
+		{"exact label is not a pattern", types.IssueFilter{Labels: []string{"area:"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SearchIssues(ctx, "", tt.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			got := make(map[string]bool)
+			for _, issue := range results {
+				got[issue.ID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("Expected %s in results, got %v", id, got)
+				}
+			}
+		})
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"re:("}}); err == nil {
+		t.Error("Expected error for invalid regex label pattern")
+	}
+}
+
+func TestIssueReporter(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	byAlice := &types.Issue{Title: "Reported by alice", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, byAlice, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	byBob := &types.Issue{Title: "Reported by bob", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, byBob, "bob"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, byAlice.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Reporter != "alice" {
+		t.Errorf("Expected reporter alice, got %q", got.Reporter)
+	}
+
+	reporter := "bob"
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Reporter: &reporter})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != byBob.ID || results[0].Reporter != "bob" {
+		t.Errorf("Expected only %s reported by bob, got %v", byBob.ID, results)
+	}
+
+	// Reporter survives a JSONL round-trip through bulk import
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var imported types.Issue
+	if err := json.Unmarshal(data, &imported); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	imported.ID = "bd-100"
+	if err := store.CreateIssues(ctx, []*types.Issue{&imported}, "import"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	roundTripped, err := store.GetIssue(ctx, "bd-100")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if roundTripped.Reporter != "alice" {
+		t.Errorf("Expected reporter alice after round-trip, got %q", roundTripped.Reporter)
+	}
+}
+
+func TestArchiveIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	kept := &types.Issue{Title: "Kept", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	archived := &types.Issue{Title: "Archived", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{kept, archived} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("ClearDirtyIssues failed: %v", err)
+	}
+
+	if err := store.ArchiveIssue(ctx, archived.ID, "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+	if err := store.ArchiveIssue(ctx, archived.ID, "test-user"); err == nil {
+		t.Error("Expected error archiving an already archived issue")
+	}
+
+	listed := func(filter types.IssueFilter) map[string]bool {
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, issue := range issues {
+			ids[issue.ID] = true
+		}
+		return ids
+	}
+
+	if ids := listed(types.IssueFilter{}); !ids[kept.ID] || ids[archived.ID] {
+		t.Errorf("Expected default listing to hide archived issue, got %v", ids)
+	}
+	if ids := listed(types.IssueFilter{IncludeArchived: true}); !ids[kept.ID] || !ids[archived.ID] {
+		t.Errorf("Expected IncludeArchived listing to show both issues, got %v", ids)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	for _, issue := range ready {
+		if issue.ID == archived.ID {
+			t.Error("Expected archived issue to be excluded from ready work")
+		}
+	}
+
+	got, err := store.GetIssue(ctx, archived.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ArchivedAt == nil {
+		t.Error("Expected ArchivedAt to be set")
+	}
+
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0] != archived.ID {
+		t.Errorf("Expected only %s to be dirty, got %v", archived.ID, dirty)
+	}
+
+	if err := store.UnarchiveIssue(ctx, archived.ID, "test-user"); err != nil {
+		t.Fatalf("UnarchiveIssue failed: %v", err)
+	}
+	if err := store.UnarchiveIssue(ctx, archived.ID, "test-user"); err == nil {
+		t.Error("Expected error unarchiving an issue that isn't archived")
+	}
+	if ids := listed(types.IssueFilter{}); !ids[archived.ID] {
+		t.Errorf("Expected unarchived issue to be listed again, got %v", ids)
+	}
+
+	events, err := store.GetEvents(ctx, archived.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	seen := make(map[types.EventType]bool)
+	for _, e := range events {
+		seen[e.EventType] = true
+	}
+	if !seen[types.EventArchived] || !seen[types.EventUnarchived] {
+		t.Errorf("Expected archived and unarchived events, got %v", seen)
+	}
+
+	if err := store.ArchiveIssue(ctx, "bd-999", "test-user"); err == nil {
+		t.Error("Expected error archiving a nonexistent issue")
+	}
+}
+
+func TestReassignAll(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	specs := []struct {
+		assignee string
+		status   types.Status
+	}{
+		{"alice", types.StatusOpen},
+		{"alice", types.StatusInProgress},
+		{"alice", types.StatusClosed},
+		{"carol", types.StatusOpen},
+	}
+	var issues []*types.Issue
+	for i, spec := range specs {
+		issue := &types.Issue{Title: "Issue " + strconv.Itoa(i), Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: spec.assignee}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if spec.status != types.StatusOpen {
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(spec.status)}, "test-user"); err != nil {
+				t.Fatalf("UpdateIssue failed: %v", err)
+			}
+		}
+		issues = append(issues, issue)
+	}
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("ClearDirtyIssues failed: %v", err)
+	}
+
+	count, err := store.ReassignAll(ctx, "alice", "bob", "test-user")
+	if err != nil {
+		t.Fatalf("ReassignAll failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 issues reassigned, got %d", count)
+	}
+
+	wantAssignee := []string{"bob", "bob", "alice", "carol"}
+	for i, issue := range issues {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Assignee != wantAssignee[i] {
+			t.Errorf("%s: expected assignee %q, got %q", issue.ID, wantAssignee[i], got.Assignee)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, issues[0].ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	foundEvent := false
+	for _, e := range events {
+		if e.EventType == types.EventReassigned &&
+			e.OldValue != nil && *e.OldValue == "alice" &&
+			e.NewValue != nil && *e.NewValue == "bob" {
+			foundEvent = true
+		}
+	}
+	if !foundEvent {
+		t.Error("Expected reassigned event alice -> bob")
+	}
+
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 2 {
+		t.Errorf("Expected only reassigned issues to be dirty, got %v", dirty)
+	}
+
+	// Nothing left to reassign
+	count, err = store.ReassignAll(ctx, "alice", "bob", "test-user")
+	if err != nil {
+		t.Fatalf("ReassignAll failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 issues on second reassign, got %d", count)
+	}
+}
+
+func TestReassignAllBlankTarget(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Assigned", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice"}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if _, err := store.ReassignAll(ctx, "alice", "   ", "test-user"); err == nil {
+		t.Error("Expected error reassigning to a whitespace-only assignee")
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Assignee != "alice" {
+		t.Errorf("Expected assignee to stay alice after rejected reassign, got %q", got.Assignee)
+	}
+
+	// Surrounding whitespace is trimmed from both names
+	count, err := store.ReassignAll(ctx, " alice ", " bob ", "test-user")
+	if err != nil {
+		t.Fatalf("ReassignAll failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 issue reassigned, got %d", count)
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Assignee != "bob" {
+		t.Errorf("Expected assignee bob, got %q", got.Assignee)
+	}
+
+	// An empty target unassigns
+	if _, err := store.ReassignAll(ctx, "bob", "", "test-user"); err != nil {
+		t.Fatalf("ReassignAll to empty failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Assignee != "" {
+		t.Errorf("Expected issue to be unassigned, got %q", got.Assignee)
+	}
+}
+
 func TestGetStatistics(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1510,3 +2256,208 @@ func TestIsClosed(t *testing.T) {
 		t.Error("Store should be closed after calling Close()")
 	}
 }
+
+func TestCloneIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	source := &types.Issue{
+		Title:       "Migrate service A",
+		Description: "Move it to the new cluster",
+		Design:      "Blue/green",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeFeature,
+		Assignee:    "alice",
+	}
+	if err := store.CreateIssue(ctx, source, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, source.ID, "infra", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, source.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	clone, err := store.CloneIssue(ctx, source.ID, map[string]interface{}{"title": "Migrate service B"}, "test-user")
+	if err != nil {
+		t.Fatalf("CloneIssue failed: %v", err)
+	}
+	if clone.ID == source.ID {
+		t.Fatalf("Expected a new ID, got %s", clone.ID)
+	}
+	if clone.Title != "Migrate service B" {
+		t.Errorf("Expected overridden title, got %q", clone.Title)
+	}
+	if clone.Description != source.Description || clone.Design != source.Design ||
+		clone.Priority != source.Priority || clone.IssueType != source.IssueType {
+		t.Errorf("Expected copied fields, got %+v", clone)
+	}
+	if clone.Status != types.StatusOpen || clone.ClosedAt != nil || clone.Assignee != "" {
+		t.Errorf("Expected open unassigned clone, got status %s closed_at %v assignee %q", clone.Status, clone.ClosedAt, clone.Assignee)
+	}
+	if len(clone.Labels) != 1 || clone.Labels[0] != "infra" {
+		t.Errorf("Expected label infra, got %v", clone.Labels)
+	}
+
+	// The clone is independent of the source
+	if err := store.UpdateIssue(ctx, clone.ID, map[string]interface{}{"description": "Changed"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, clone.ID, "phase-2", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	original, err := store.GetIssue(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if original.Description != "Move it to the new cluster" || len(original.Labels) != 1 {
+		t.Errorf("Expected source to be unchanged, got %q %v", original.Description, original.Labels)
+	}
+
+	if _, err := store.CloneIssue(ctx, source.ID, map[string]interface{}{"bogus": 1}, "test-user"); err == nil {
+		t.Error("Expected error for invalid override field")
+	}
+	if _, err := store.CloneIssue(ctx, "bd-999", nil, "test-user"); err == nil {
+		t.Error("Expected error cloning a missing issue")
+	}
+}
+
+func TestIterateIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 7; i++ {
+		issue := &types.Issue{Title: "Issue " + strconv.Itoa(i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddLabel(ctx, ids[0], "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, ids[1], "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ArchiveIssue(ctx, ids[2], "test-user"); err != nil {
+		t.Fatalf("ArchiveIssue failed: %v", err)
+	}
+	sort.Strings(ids)
+
+	// A batch size smaller than the issue count exercises the cursor across batches
+	var seen []string
+	err := store.IterateIssues(ctx, nil, 3, func(issue *types.Issue) error {
+		seen = append(seen, issue.ID)
+		if issue.ID == ids[0] && len(issue.Labels) != 1 {
+			t.Errorf("expected labels on %s, got %v", issue.ID, issue.Labels)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateIssues failed: %v", err)
+	}
+	if strings.Join(seen, ",") != strings.Join(ids, ",") {
+		t.Errorf("expected %v in ID order (archived included), got %v", ids, seen)
+	}
+
+	closed := types.StatusClosed
+	seen = nil
+	if err := store.IterateIssues(ctx, &closed, 3, func(issue *types.Issue) error {
+		seen = append(seen, issue.ID)
+		return nil
+	}); err != nil {
+		t.Fatalf("IterateIssues failed: %v", err)
+	}
+	if len(seen) != 1 {
+		t.Errorf("expected 1 closed issue, got %v", seen)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = store.IterateIssues(ctx, nil, 3, func(issue *types.Issue) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected iteration to stop on first error, got err=%v after %d calls", err, calls)
+	}
+}
+
+func TestIterateIssuesCanceled(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 10; i++ {
+		issue := &types.Issue{Title: "Issue " + strconv.Itoa(i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	// Cancel partway through the first batch; iteration stops at the next issue
+	seen := 0
+	err := store.IterateIssues(ctx, nil, 3, func(issue *types.Issue) error {
+		seen++
+		if seen == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("expected iteration to stop right after cancel, saw %d issues", seen)
+	}
+}
+
+func TestAppendNotes(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Migrate callers", Notes: "Started planning", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, text := range []string{"First half migrated", "Second half migrated"} {
+		if err := store.AppendNotes(ctx, issue.ID, text, "alice"); err != nil {
+			t.Fatalf("AppendNotes failed: %v", err)
+		}
+	}
+
+	updated, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	entries := strings.Split(updated.Notes, types.NotesEntrySeparator+"---\n")
+	if len(entries) != 3 {
+		t.Fatalf("expected original notes plus 2 entries, got %q", updated.Notes)
+	}
+	if entries[0] != "Started planning" {
+		t.Errorf("original notes changed: %q", entries[0])
+	}
+	for i, want := range []string{"First half migrated", "Second half migrated"} {
+		if !strings.HasSuffix(entries[i+1], "] alice\n\n"+want) {
+			t.Errorf("entry %d = %q, want it to end with %q", i+1, entries[i+1], want)
+		}
+	}
+
+	if err := store.AppendNotes(ctx, "bd-999", "text", "alice"); err == nil {
+		t.Error("expected error appending to a missing issue")
+	}
+	if err := store.AppendNotes(ctx, issue.ID, "  ", "alice"); err == nil {
+		t.Error("expected error appending empty text")
+	}
+}
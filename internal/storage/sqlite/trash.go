@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// snapshotIssue captures an issue with its labels, dependencies (both directions),
+// and comments so it can be restored after deletion. Returns nil if the issue doesn't exist.
+func (s *SQLiteStorage) snapshotIssue(ctx context.Context, id string) (*types.DeletedIssue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, nil
+	}
+
+	issue.Dependencies, err = s.GetDependencyRecords(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	issue.Comments, err = s.GetIssueComments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, type, created_at, created_by
+		FROM dependencies
+		WHERE depends_on_id = ?
+		ORDER BY created_at ASC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependents of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	deleted := &types.DeletedIssue{Issue: issue}
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		deleted.Dependents = append(deleted.Dependents, &dep)
+	}
+	return deleted, rows.Err()
+}
+
+// trashIssuesTx saves snapshots of issues into deleted_issues before they are removed
+func (s *SQLiteStorage) trashIssuesTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	now := time.Now()
+	for _, id := range ids {
+		deleted, err := s.snapshotIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot %s: %w", id, err)
+		}
+		if deleted == nil {
+			continue
+		}
+		deleted.DeletedAt = now
+
+		data, err := json.Marshal(deleted)
+		if err != nil {
+			return fmt.Errorf("failed to serialize %s: %w", id, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO deleted_issues (issue_id, data, deleted_at) VALUES (?, ?, ?)
+		`, id, string(data), now)
+		if err != nil {
+			return fmt.Errorf("failed to move %s to trash: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ListTrash returns deleted issues still in the trash, most recently deleted first
+func (s *SQLiteStorage) ListTrash(ctx context.Context) ([]*types.DeletedIssue, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM deleted_issues ORDER BY deleted_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var trash []*types.DeletedIssue
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan trash entry: %w", err)
+		}
+		var deleted types.DeletedIssue
+		if err := json.Unmarshal([]byte(data), &deleted); err != nil {
+			return nil, fmt.Errorf("failed to parse trash entry: %w", err)
+		}
+		trash = append(trash, &deleted)
+	}
+	return trash, rows.Err()
+}
+
+// RestoreDeletedIssue restores an issue from the trash with its labels, comments, and
+// any dependencies whose other end still exists. An empty id restores the most recently
+// deleted issue. Text references rewritten to [deleted:ID] at deletion are not reverted.
+func (s *SQLiteStorage) RestoreDeletedIssue(ctx context.Context, id string, actor string) (*types.Issue, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `SELECT id, data FROM deleted_issues ORDER BY deleted_at DESC, id DESC LIMIT 1`
+	args := []interface{}{}
+	if id != "" {
+		query = `SELECT id, data FROM deleted_issues WHERE issue_id = ? ORDER BY deleted_at DESC, id DESC LIMIT 1`
+		args = append(args, id)
+	}
+	var trashID int64
+	var data string
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&trashID, &data)
+	if err == sql.ErrNoRows {
+		if id == "" {
+			return nil, fmt.Errorf("trash is empty")
+		}
+		return nil, fmt.Errorf("issue %s not found in trash", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	var deleted types.DeletedIssue
+	if err := json.Unmarshal([]byte(data), &deleted); err != nil {
+		return nil, fmt.Errorf("failed to parse trash entry: %w", err)
+	}
+	issue := deleted.Issue
+	if issue == nil {
+		return nil, fmt.Errorf("trash entry %d has no issue data", trashID)
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issue.ID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check for %s: %w", issue.ID, err)
+	}
+	if exists {
+		return nil, fmt.Errorf("cannot restore %s: an issue with that ID already exists", issue.ID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO issues (
+			id, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			created_at, updated_at, closed_at, external_ref, archived_at, reporter, due_date, rank,
+			compaction_level, compacted_at, compacted_at_commit, original_size
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		issue.ID, issue.Title, issue.Description, issue.Design,
+		issue.AcceptanceCriteria, issue.Notes, issue.Status,
+		issue.Priority, issue.IssueType, issue.Assignee,
+		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
+		issue.ClosedAt, issue.ExternalRef, issue.ArchivedAt, issue.Reporter, issue.DueDate, issue.Rank,
+		issue.CompactionLevel, issue.CompactedAt, issue.CompactedAtCommit, issue.OriginalSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore issue: %w", err)
+	}
+
+	for _, label := range issue.Labels {
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
+			return nil, fmt.Errorf("failed to restore label %s: %w", label, err)
+		}
+	}
+
+	for tracker, ref := range issue.ExternalRefs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO external_refs (issue_id, tracker, ref) VALUES (?, ?, ?)
+		`, issue.ID, tracker, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore external ref %s: %w", tracker, err)
+		}
+	}
+
+	for _, watcher := range issue.Watchers {
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO watchers (issue_id, watcher) VALUES (?, ?)`, issue.ID, watcher); err != nil {
+			return nil, fmt.Errorf("failed to restore watcher %s: %w", watcher, err)
+		}
+	}
+
+	for _, comment := range issue.Comments {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)
+		`, issue.ID, comment.Author, comment.Text, comment.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore comment: %w", err)
+		}
+	}
+
+	// Restore links in both directions, skipping any whose other end is gone
+	links := append(append([]*types.Dependency{}, issue.Dependencies...), deleted.Dependents...)
+	var touched []string
+	for _, dep := range links {
+		other := dep.DependsOnID
+		if other == issue.ID {
+			other = dep.IssueID
+		}
+		var otherExists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, other).Scan(&otherExists); err != nil {
+			return nil, fmt.Errorf("failed to check for %s: %w", other, err)
+		}
+		if !otherExists {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+			VALUES (?, ?, ?, ?, ?)
+		`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
+		}
+		if other != issue.ID {
+			touched = append(touched, other)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor) VALUES (?, ?, ?)
+	`, issue.ID, types.EventRestored, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, append([]string{issue.ID}, touched...)); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM deleted_issues WHERE id = ?`, trashID); err != nil {
+		return nil, fmt.Errorf("failed to remove trash entry: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+
+	if err := s.SyncAllCounters(ctx); err != nil {
+		return nil, fmt.Errorf("failed to sync counters after restore: %w", err)
+	}
+
+	return s.GetIssue(ctx, issue.ID)
+}
+
+// PurgeTrash permanently removes trash entries deleted before the cutoff.
+// Returns the number of entries removed.
+func (s *SQLiteStorage) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM deleted_issues WHERE deleted_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trash: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// PurgeExpiredTrash removes trash entries older than trash_retention_days (default 30)
+func (s *SQLiteStorage) PurgeExpiredTrash(ctx context.Context) (int, error) {
+	cutoff, err := s.TrashRetentionCutoff(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.PurgeTrash(ctx, cutoff)
+}
+
+// TrashRetentionCutoff returns the time before which trash entries have expired,
+// based on trash_retention_days (default 30)
+func (s *SQLiteStorage) TrashRetentionCutoff(ctx context.Context) (time.Time, error) {
+	daysStr, err := s.GetConfig(ctx, "trash_retention_days")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get trash_retention_days: %w", err)
+	}
+	if daysStr == "" {
+		daysStr = "30"
+	}
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days < 0 {
+		return time.Time{}, fmt.Errorf("invalid trash_retention_days %q: must be a non-negative integer", daysStr)
+	}
+	return time.Now().AddDate(0, 0, -days), nil
+}
@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDeleteAndRestoreIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	mins := 45
+	issue := &types.Issue{
+		Title:              "Keep me",
+		Description:        "Full description",
+		Design:             "Design notes",
+		AcceptanceCriteria: "It works",
+		Notes:              "Some notes",
+		Status:             types.StatusInProgress,
+		Priority:           1,
+		IssueType:          types.TypeFeature,
+		Assignee:           "alice",
+		EstimatedMinutes:   &mins,
+	}
+	parent := &types.Issue{Title: "Parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	dependent := &types.Issue{Title: "Dependent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, parent, dependent} {
+		if err := store.CreateIssue(ctx, i, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, issue.ID, "backend", "test-user"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, issue.ID, "bob", "First comment"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: issue.ID, DependsOnID: parent.ID, Type: types.DepParentChild},
+		{IssueID: dependent.ID, DependsOnID: issue.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	before, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	if err := store.DeleteIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got != nil {
+		t.Fatal("Expected issue to be deleted")
+	}
+
+	trash, err := store.ListTrash(ctx)
+	if err != nil {
+		t.Fatalf("ListTrash failed: %v", err)
+	}
+	if len(trash) != 1 || trash[0].Issue.ID != issue.ID {
+		t.Fatalf("Expected %s in trash, got %v", issue.ID, trash)
+	}
+
+	restored, err := store.RestoreDeletedIssue(ctx, "", "test-user")
+	if err != nil {
+		t.Fatalf("RestoreDeletedIssue failed: %v", err)
+	}
+
+	if restored.Title != before.Title || restored.Description != before.Description ||
+		restored.Design != before.Design || restored.AcceptanceCriteria != before.AcceptanceCriteria ||
+		restored.Notes != before.Notes || restored.Status != before.Status ||
+		restored.Priority != before.Priority || restored.IssueType != before.IssueType ||
+		restored.Assignee != before.Assignee || *restored.EstimatedMinutes != *before.EstimatedMinutes {
+		t.Errorf("Restored issue differs:\n got %+v\nwant %+v", restored, before)
+	}
+	if !restored.CreatedAt.Equal(before.CreatedAt) || !restored.UpdatedAt.Equal(before.UpdatedAt) {
+		t.Errorf("Expected timestamps to be preserved, got created %v updated %v", restored.CreatedAt, restored.UpdatedAt)
+	}
+	if len(restored.Labels) != 1 || restored.Labels[0] != "backend" {
+		t.Errorf("Expected label backend, got %v", restored.Labels)
+	}
+
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "bob" || comments[0].Text != "First comment" {
+		t.Errorf("Expected comment to be restored, got %v", comments)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != parent.ID || deps[0].Type != types.DepParentChild {
+		t.Errorf("Expected parent-child link to %s, got %v", parent.ID, deps)
+	}
+	dependents, err := store.GetDependents(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetDependents failed: %v", err)
+	}
+	if len(dependents) != 1 || dependents[0].ID != dependent.ID {
+		t.Errorf("Expected %s to depend on restored issue, got %v", dependent.ID, dependents)
+	}
+
+	trash, err = store.ListTrash(ctx)
+	if err != nil {
+		t.Fatalf("ListTrash failed: %v", err)
+	}
+	if len(trash) != 0 {
+		t.Errorf("Expected trash to be empty after restore, got %d entries", len(trash))
+	}
+
+	if _, err := store.RestoreDeletedIssue(ctx, issue.ID, "test-user"); err == nil {
+		t.Error("Expected error restoring an issue that isn't in the trash")
+	}
+}
+
+func TestDeleteIssuesMovesToTrash(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	first := &types.Issue{Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	second := &types.Issue{Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{first, second} {
+		if err := store.CreateIssue(ctx, i, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if _, err := store.DeleteIssues(ctx, []string{first.ID, second.ID}, false, true, false); err != nil {
+		t.Fatalf("DeleteIssues failed: %v", err)
+	}
+
+	trash, err := store.ListTrash(ctx)
+	if err != nil {
+		t.Fatalf("ListTrash failed: %v", err)
+	}
+	if len(trash) != 2 {
+		t.Fatalf("Expected 2 trash entries, got %d", len(trash))
+	}
+
+	if _, err := store.RestoreDeletedIssue(ctx, second.ID, "test-user"); err != nil {
+		t.Fatalf("RestoreDeletedIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, second.ID); got == nil || got.Title != "Second" {
+		t.Errorf("Expected %s to be restored, got %v", second.ID, got)
+	}
+
+	// Purge with a cutoff in the future removes everything left
+	purged, err := store.PurgeTrash(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("PurgeTrash failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 entry purged, got %d", purged)
+	}
+
+	if err := store.SetConfig(ctx, "trash_retention_days", "30"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.DeleteIssue(ctx, second.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	purged, err = store.PurgeExpiredTrash(ctx)
+	if err != nil {
+		t.Fatalf("PurgeExpiredTrash failed: %v", err)
+	}
+	if purged != 0 {
+		t.Errorf("Expected recent deletion to be kept, got %d purged", purged)
+	}
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddWatcher subscribes watcher to the issue. Adding someone who is already
+// watching is an error, so callers can tell them so.
+func (s *SQLiteStorage) AddWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	watcher = strings.TrimSpace(watcher)
+	if watcher == "" {
+		return fmt.Errorf("watcher must not be empty")
+	}
+
+	exists, err := s.IssueExists(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	watching, err := s.isWatching(ctx, issueID, watcher)
+	if err != nil {
+		return err
+	}
+	if watching {
+		return fmt.Errorf("%s is already watching %s", watcher, issueID)
+	}
+
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`INSERT INTO watchers (issue_id, watcher) VALUES (?, ?)`,
+		[]interface{}{issueID, watcher},
+		types.EventUpdated,
+		fmt.Sprintf("Added watcher %s", watcher),
+		"failed to add watcher",
+	)
+}
+
+// RemoveWatcher unsubscribes watcher from the issue
+func (s *SQLiteStorage) RemoveWatcher(ctx context.Context, issueID, watcher, actor string) error {
+	watching, err := s.isWatching(ctx, issueID, watcher)
+	if err != nil {
+		return err
+	}
+	if !watching {
+		return fmt.Errorf("%s is not watching %s", watcher, issueID)
+	}
+
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`DELETE FROM watchers WHERE issue_id = ? AND watcher = ?`,
+		[]interface{}{issueID, watcher},
+		types.EventUpdated,
+		fmt.Sprintf("Removed watcher %s", watcher),
+		"failed to remove watcher",
+	)
+}
+
+// GetWatchers returns the issue's watchers in sorted order, or nil if it has none
+func (s *SQLiteStorage) GetWatchers(ctx context.Context, issueID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT watcher FROM watchers WHERE issue_id = ? ORDER BY watcher
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watchers []string
+	for rows.Next() {
+		var watcher string
+		if err := rows.Scan(&watcher); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, watcher)
+	}
+	return watchers, rows.Err()
+}
+
+func (s *SQLiteStorage) isWatching(ctx context.Context, issueID, watcher string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM watchers WHERE issue_id = ? AND watcher = ?
+	`, issueID, watcher).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check watcher: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWatchers(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, watcher := range []string{"carol", "alice"} {
+		if err := store.AddWatcher(ctx, issue.ID, watcher, "test"); err != nil {
+			t.Fatalf("AddWatcher(%s) failed: %v", watcher, err)
+		}
+	}
+	if err := store.AddWatcher(ctx, issue.ID, "alice", "test"); err == nil || !strings.Contains(err.Error(), "already watching") {
+		t.Errorf("duplicate AddWatcher error = %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, " ", "test"); err == nil {
+		t.Error("expected an error adding an empty watcher")
+	}
+	if err := store.AddWatcher(ctx, "bd-999", "alice", "test"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("AddWatcher on a missing issue error = %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if want := []string{"alice", "carol"}; !reflect.DeepEqual(got.Watchers, want) {
+		t.Errorf("Watchers = %v, want %v", got.Watchers, want)
+	}
+
+	if err := store.RemoveWatcher(ctx, issue.ID, "carol", "test"); err != nil {
+		t.Fatalf("RemoveWatcher failed: %v", err)
+	}
+	if err := store.RemoveWatcher(ctx, issue.ID, "carol", "test"); err == nil || !strings.Contains(err.Error(), "not watching") {
+		t.Errorf("second RemoveWatcher error = %v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || !reflect.DeepEqual(issues[0].Watchers, []string{"alice"}) {
+		t.Errorf("SearchIssues watchers = %v, want [alice]", issues[0].Watchers)
+	}
+}
+
+func TestWatchersPersist(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	ctx := context.Background()
+
+	store := newTestStore(t, dbPath)
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddWatcher(ctx, issue.ID, "bob", "test"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer reopened.Close()
+	watchers, err := reopened.GetWatchers(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if !reflect.DeepEqual(watchers, []string{"bob"}) {
+		t.Errorf("watchers after reopening = %v, want [bob]", watchers)
+	}
+}
+
+func TestWatchersFollowRename(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	oldID := issue.ID
+	if err := store.AddWatcher(ctx, oldID, "alice", "test"); err != nil {
+		t.Fatalf("AddWatcher failed: %v", err)
+	}
+
+	if err := store.UpdateIssueID(ctx, oldID, "bd-renamed", issue, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+
+	watchers, err := store.GetWatchers(ctx, "bd-renamed")
+	if err != nil {
+		t.Fatalf("GetWatchers failed: %v", err)
+	}
+	if !reflect.DeepEqual(watchers, []string{"alice"}) {
+		t.Errorf("watchers after rename = %v, want [alice]", watchers)
+	}
+
+	var orphaned int
+	if err := store.UnderlyingDB().QueryRowContext(ctx, `SELECT COUNT(*) FROM watchers WHERE issue_id = ?`, oldID).Scan(&orphaned); err != nil {
+		t.Fatalf("failed to count watchers: %v", err)
+	}
+	if orphaned != 0 {
+		t.Errorf("%d watcher row(s) left under the old ID %s", orphaned, oldID)
+	}
+}
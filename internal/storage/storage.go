@@ -4,6 +4,9 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -14,9 +17,20 @@ type Storage interface {
 	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
 	CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error
 	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetIssues(ctx context.Context, ids []string) (map[string]*types.Issue, error) // Missing IDs are absent from the map
+	IssueExists(ctx context.Context, id string) (bool, error)
 	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	ReassignAll(ctx context.Context, from, to string, actor string) (int, error)
+	AppendNotes(ctx context.Context, id, text, actor string) error
 	CloseIssue(ctx context.Context, id string, reason string, actor string) error
+	ReopenIssue(ctx context.Context, id string, reason string, actor string) error
+	CloneIssue(ctx context.Context, id string, overrides map[string]interface{}, actor string) (*types.Issue, error)
+	ArchiveIssue(ctx context.Context, id string, actor string) error
+	UnarchiveIssue(ctx context.Context, id string, actor string) error
+	ClaimIssue(ctx context.Context, id, agent string, lease time.Duration) (bool, error) // False if another agent holds an unexpired claim
+	ReleaseIssue(ctx context.Context, id, agent string) error
 	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	CountIssues(ctx context.Context, filter types.IssueFilter) (int, error)
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
@@ -27,21 +41,43 @@ type Storage interface {
 	GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error)
 	GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error)
 	DetectCycles(ctx context.Context) ([][]*types.Issue, error)
+	GetDanglingDependencies(ctx context.Context) ([]*types.DanglingDependency, error)
+	GetOrphanIssues(ctx context.Context) ([]*types.Issue, error)
 
 	// Labels
 	AddLabel(ctx context.Context, issueID, label, actor string) error
 	RemoveLabel(ctx context.Context, issueID, label, actor string) error
 	GetLabels(ctx context.Context, issueID string) ([]string, error)
 	GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error)
+	ListLabels(ctx context.Context) ([]*types.LabelCount, error)
+	RenameLabel(ctx context.Context, oldLabel, newLabel, actor string) error
+	MergeLabels(ctx context.Context, from []string, into, actor string) error
+
+	// Watchers
+	AddWatcher(ctx context.Context, issueID, watcher, actor string) error
+	RemoveWatcher(ctx context.Context, issueID, watcher, actor string) error
+	GetWatchers(ctx context.Context, issueID string) ([]string, error)
+
+	// External references (one key per tracker)
+	SetExternalRef(ctx context.Context, issueID, tracker, ref, actor string) error
+	RemoveExternalRef(ctx context.Context, issueID, tracker, actor string) error
+	GetExternalRefs(ctx context.Context, issueID string) (types.TrackerRefs, error)
+	GetIssueByExternalRef(ctx context.Context, ref string) (*types.Issue, error)
 
 	// Ready Work & Blocking
 	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
 	GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error)
+	GetNewlyUnblocked(ctx context.Context, closedID string) ([]*types.Issue, error)
+	GetOverdueIssues(ctx context.Context) ([]*types.Issue, error)
+	GetSLABreaches(ctx context.Context) ([]*types.SLABreach, error)
 	GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error)
+	GetEpicProgress(ctx context.Context, epicID string) (*types.EpicProgress, error)
+	ProjectEpicCompletion(ctx context.Context, epicID string, minutesPerDay float64) (time.Time, error)
 
 	// Events
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+	GetEventsWithFilter(ctx context.Context, issueID string, filter types.EventFilter) ([]*types.Event, error)
 
 	// Comments
 	AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error)
@@ -109,3 +145,25 @@ type Config struct {
 	Password string
 	SSLMode  string
 }
+
+// ErrVersionConflict is returned by UpdateIssue when the issue changed after the
+// caller read it. Callers should re-read the issue and retry.
+var ErrVersionConflict = errors.New("issue was modified since it was read")
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion returns a context under which UpdateIssue only writes if
+// the issue is still at version (see types.Issue.Version)
+func WithExpectedVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// CheckVersion returns ErrVersionConflict if ctx expects a version of issue
+// other than its current one
+func CheckVersion(ctx context.Context, issue *types.Issue) error {
+	expected, ok := ctx.Value(expectedVersionKey{}).(string)
+	if !ok || expected == issue.Version() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is at version %s, expected %s", ErrVersionConflict, issue.ID, issue.Version(), expected)
+}
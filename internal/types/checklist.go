@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// checklistItemRegex matches a markdown task-list item such as "- [ ] Write docs" or
+// "  * [x] Add tests", capturing the text before the mark, the mark, and the rest
+var checklistItemRegex = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\].*)$`)
+
+// ChecklistItem is one task-list item in a free-text field
+type ChecklistItem struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// ParseChecklist returns the task-list items ("- [ ]" / "- [x]") in text, in order.
+// Other lines are ignored.
+func ParseChecklist(text string) []ChecklistItem {
+	var items []ChecklistItem
+	for _, line := range strings.Split(text, "\n") {
+		m := checklistItemRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		items = append(items, ChecklistItem{
+			Text:    strings.TrimSpace(strings.TrimPrefix(m[3], "]")),
+			Checked: m[2] != " ",
+		})
+	}
+	return items
+}
+
+// SetChecklistItem checks or unchecks the nth (1-based) task-list item in text and
+// returns the updated text. Everything else, including line endings, is left as is.
+func SetChecklistItem(text string, n int, checked bool) (string, error) {
+	lines := strings.Split(text, "\n")
+	count := 0
+	for i, line := range lines {
+		m := checklistItemRegex.FindStringSubmatchIndex(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		count++
+		if count != n {
+			continue
+		}
+		mark := " "
+		if checked {
+			mark = "x"
+		}
+		lines[i] = line[:m[4]] + mark + line[m[5]:]
+		return strings.Join(lines, "\n"), nil
+	}
+	return "", fmt.Errorf("no checklist item %d (found %d)", n, count)
+}
+
+// AcceptanceProgress returns how many acceptance-criteria checklist items are
+// checked and how many there are in total
+func (i *Issue) AcceptanceProgress() (checked, total int) {
+	for _, item := range ParseChecklist(i.AcceptanceCriteria) {
+		total++
+		if item.Checked {
+			checked++
+		}
+	}
+	return checked, total
+}
@@ -0,0 +1,81 @@
+package types
+
+import "testing"
+
+const sampleCriteria = `Login works when:
+- [x] Valid credentials sign in
+- [ ] Invalid password shows an error
+  * [X] Lockout after 5 attempts
+- Not a checklist item
+- [ ] Session expires after an hour`
+
+func TestParseChecklist(t *testing.T) {
+	items := ParseChecklist(sampleCriteria)
+	want := []ChecklistItem{
+		{Text: "Valid credentials sign in", Checked: true},
+		{Text: "Invalid password shows an error", Checked: false},
+		{Text: "Lockout after 5 attempts", Checked: true},
+		{Text: "Session expires after an hour", Checked: false},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i+1, items[i], want[i])
+		}
+	}
+
+	if items := ParseChecklist("Plain prose criteria"); len(items) != 0 {
+		t.Errorf("expected no items in prose, got %+v", items)
+	}
+}
+
+func TestAcceptanceProgress(t *testing.T) {
+	issue := &Issue{AcceptanceCriteria: sampleCriteria}
+	checked, total := issue.AcceptanceProgress()
+	if checked != 2 || total != 4 {
+		t.Errorf("AcceptanceProgress() = %d/%d, want 2/4", checked, total)
+	}
+}
+
+func TestSetChecklistItem(t *testing.T) {
+	updated, err := SetChecklistItem(sampleCriteria, 2, true)
+	if err != nil {
+		t.Fatalf("SetChecklistItem failed: %v", err)
+	}
+	want := `Login works when:
+- [x] Valid credentials sign in
+- [x] Invalid password shows an error
+  * [X] Lockout after 5 attempts
+- Not a checklist item
+- [ ] Session expires after an hour`
+	if updated != want {
+		t.Errorf("check item 2:\ngot:\n%s\nwant:\n%s", updated, want)
+	}
+
+	// Unchecking restores the original text exactly
+	restored, err := SetChecklistItem(updated, 2, false)
+	if err != nil {
+		t.Fatalf("SetChecklistItem failed: %v", err)
+	}
+	if restored != sampleCriteria {
+		t.Errorf("round trip changed the text:\n%s", restored)
+	}
+
+	// CRLF line endings survive
+	crlf, err := SetChecklistItem("- [ ] a\r\n- [ ] b\r\n", 2, true)
+	if err != nil {
+		t.Fatalf("SetChecklistItem failed: %v", err)
+	}
+	if crlf != "- [ ] a\r\n- [x] b\r\n" {
+		t.Errorf("CRLF text = %q", crlf)
+	}
+
+	if _, err := SetChecklistItem(sampleCriteria, 5, true); err == nil {
+		t.Error("expected error for out-of-range item")
+	}
+	if _, err := SetChecklistItem(sampleCriteria, 0, true); err == nil {
+		t.Error("expected error for item 0")
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LabelRegexPrefix marks a label filter value as a regular expression (e.g. "re:^area:")
+const LabelRegexPrefix = "re:"
+
+// IsLabelPattern reports whether a label filter value is a pattern rather than an exact label.
+// Values containing * or ? are globs; values starting with "re:" are regular expressions.
+func IsLabelPattern(value string) bool {
+	return strings.HasPrefix(value, LabelRegexPrefix) || strings.ContainsAny(value, "*?")
+}
+
+// CompileLabelPattern returns a function reporting whether a label matches the filter value.
+// Exact values compare for equality; globs must match the whole label, where * matches any
+// run of characters and ? matches a single character; regular expressions use Go syntax
+// and match anywhere in the label unless anchored.
+func CompileLabelPattern(value string) (func(label string) bool, error) {
+	if !IsLabelPattern(value) {
+		return func(label string) bool { return label == value }, nil
+	}
+
+	var expr string
+	if strings.HasPrefix(value, LabelRegexPrefix) {
+		expr = strings.TrimPrefix(value, LabelRegexPrefix)
+	} else {
+		quoted := regexp.QuoteMeta(value)
+		quoted = strings.ReplaceAll(quoted, `\*`, `.*`)
+		quoted = strings.ReplaceAll(quoted, `\?`, `.`)
+		expr = "^" + quoted + "$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label pattern %q: %w", value, err)
+	}
+	return re.MatchString, nil
+}
+
+// MatchingLabels returns the labels from candidates that match the filter value
+func MatchingLabels(value string, candidates []string) ([]string, error) {
+	match, err := CompileLabelPattern(value)
+	if err != nil {
+		return nil, err
+	}
+	var matched []string
+	for _, label := range candidates {
+		if match(label) {
+			matched = append(matched, label)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import "testing"
+
+func TestCompileLabelPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		label   string
+		want    bool
+	}{
+		{"backend", "backend", true},
+		{"backend", "backend-api", false},
+		{"area:*", "area:frontend", true},
+		{"area:*", "area:", true},
+		{"area:*", "team:area:x", false},
+		{"*end", "frontend", true},
+		{"v?", "v1", true},
+		{"v?", "v10", false},
+		{"a.b", "axb", false}, // dots are literal in exact labels
+		{"a.*", "a.b", true},  // and in globs
+		{"a.*", "axb", false},
+		{"re:^area:", "area:frontend", true},
+		{"re:^area:", "team:area", false},
+		{"re:end$", "backend", true},
+		{"re:(front|back)end", "frontend-ui", true},
+	}
+
+	for _, tt := range tests {
+		match, err := CompileLabelPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("CompileLabelPattern(%q) failed: %v", tt.pattern, err)
+		}
+		if got := match(tt.label); got != tt.want {
+			t.Errorf("pattern %q on label %q: got %v, want %v", tt.pattern, tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestCompileLabelPatternInvalidRegex(t *testing.T) {
+	if _, err := CompileLabelPattern("re:[unclosed"); err == nil {
+		t.Error("Expected error for invalid regular expression")
+	}
+}
+
+func TestIsLabelPattern(t *testing.T) {
+	for value, want := range map[string]bool{
+		"backend":   false,
+		"area:core": false,
+		"area:*":    true,
+		"v?":        true,
+		"re:^x":     true,
+	} {
+		if got := IsLabelPattern(value); got != want {
+			t.Errorf("IsLabelPattern(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotesEntrySeparator goes between an issue's existing notes and an appended entry
+const NotesEntrySeparator = "\n\n"
+
+// NotesEntry formats text as an appended notes entry: a rule, then a header with
+// the UTC timestamp and actor, then the text.
+//
+//	---
+//	[2025-06-15T12:00:00Z] alice
+//
+//	Migrated the first half of the callers.
+func NotesEntry(at time.Time, actor, text string) string {
+	return fmt.Sprintf("---\n[%s] %s\n\n%s", at.UTC().Format(time.RFC3339), actor, strings.TrimSpace(text))
+}
+
+// AppendNotesEntry appends a formatted entry to existing notes
+func AppendNotesEntry(notes, entry string) string {
+	if notes == "" {
+		return entry
+	}
+	return notes + NotesEntrySeparator + entry
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Weights of the fields Relevance looks at. A hit on the whole query counts
+// double a hit on all of its words separately.
+const (
+	relevanceIDWeight          = 16.0
+	relevanceTitleWeight       = 4.0
+	relevanceLabelWeight       = 2.0
+	relevanceDescriptionWeight = 1.0
+)
+
+// Relevance scores how well issue matches a search query; higher is better and
+// zero means no match. A hit in the title outranks one in a label, which
+// outranks one in the description, and the query appearing as an exact phrase
+// outranks its words scattered through a field. An issue whose ID is the
+// query outranks everything.
+func Relevance(issue *Issue, query string) float64 {
+	phrase := strings.ToLower(strings.TrimSpace(query))
+	if phrase == "" {
+		return 0
+	}
+	words := strings.Fields(phrase)
+
+	var score float64
+	if strings.EqualFold(issue.ID, phrase) {
+		score += relevanceIDWeight
+	}
+	score += relevanceTitleWeight * fieldRelevance(issue.Title, phrase, words)
+
+	var best float64
+	for _, label := range issue.Labels {
+		if r := fieldRelevance(label, phrase, words); r > best {
+			best = r
+		}
+	}
+	score += relevanceLabelWeight * best
+
+	score += relevanceDescriptionWeight * fieldRelevance(issue.Description, phrase, words)
+	return score
+}
+
+// fieldRelevance returns 2 if text contains phrase, otherwise the fraction of
+// words it contains
+func fieldRelevance(text, phrase string, words []string) float64 {
+	text = strings.ToLower(text)
+	if strings.Contains(text, phrase) {
+		return 2
+	}
+	found := 0
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(words))
+}
+
+// SortByRelevance orders issues by Relevance to query, most relevant first.
+// Ties go to the most recently updated issue.
+func SortByRelevance(issues []*Issue, query string) {
+	scores := make(map[*Issue]float64, len(issues))
+	for _, issue := range issues {
+		scores[issue] = Relevance(issue, query)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelevanceFieldOrder(t *testing.T) {
+	title := &Issue{ID: "bd-1", Title: "Fix login timeout"}
+	label := &Issue{ID: "bd-2", Title: "Session handling", Labels: []string{"login timeout"}}
+	description := &Issue{ID: "bd-3", Title: "Auth bug", Description: "The login timeout is too short"}
+
+	query := "login timeout"
+	if Relevance(title, query) <= Relevance(label, query) {
+		t.Error("title hit should outrank label hit")
+	}
+	if Relevance(label, query) <= Relevance(description, query) {
+		t.Error("label hit should outrank description hit")
+	}
+	if Relevance(&Issue{ID: "bd-4", Title: "Unrelated"}, query) != 0 {
+		t.Error("unrelated issue should score zero")
+	}
+}
+
+func TestRelevancePhraseOverScatteredWords(t *testing.T) {
+	phrase := &Issue{ID: "bd-1", Title: "Login timeout too short"}
+	scattered := &Issue{ID: "bd-2", Title: "Timeout after the login page loads"}
+	if Relevance(phrase, "login timeout") <= Relevance(scattered, "login timeout") {
+		t.Error("exact phrase should outrank scattered words")
+	}
+}
+
+func TestSortByRelevance(t *testing.T) {
+	now := time.Now()
+	older := &Issue{ID: "bd-1", Title: "Cache eviction", UpdatedAt: now.Add(-time.Hour)}
+	newer := &Issue{ID: "bd-2", Title: "Cache eviction", UpdatedAt: now}
+	body := &Issue{ID: "bd-3", Title: "Memory", Description: "cache eviction", UpdatedAt: now.Add(time.Hour)}
+
+	issues := []*Issue{body, older, newer}
+	SortByRelevance(issues, "cache eviction")
+
+	got := []string{issues[0].ID, issues[1].ID, issues[2].ID}
+	want := []string{"bd-2", "bd-1", "bd-3"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SLAConfigKey is the database config key holding the per-priority SLAs
+const SLAConfigKey = "sla"
+
+// SLAPolicy maps a priority to how long an issue of that priority may stay
+// unresolved. Priorities without an entry have no SLA.
+type SLAPolicy map[int]time.Duration
+
+// ParseSLAPolicy parses a spec of semicolon-separated "priority:duration"
+// entries, e.g. "0:24h; 1:3d; P2:2w". Durations take Go units (h, m, s) plus
+// d for days and w for weeks. An empty spec sets no SLAs.
+func ParseSLAPolicy(spec string) (SLAPolicy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	policy := make(SLAPolicy)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		prio, dur, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid SLA entry %q (expected priority:duration)", entry)
+		}
+		prio = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(prio)), "P")
+		priority, err := strconv.Atoi(prio)
+		if err != nil || priority < 0 || priority > 4 {
+			return nil, fmt.Errorf("invalid priority in SLA entry %q (expected 0-4)", entry)
+		}
+		d, err := parseSLADuration(strings.TrimSpace(dur))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in SLA entry %q: %w", entry, err)
+		}
+		policy[priority] = d
+	}
+	return policy, nil
+}
+
+// parseSLADuration parses a positive Go duration, or a whole number of days
+// ("3d") or weeks ("2w")
+func parseSLADuration(s string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	var d time.Duration
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("expected a whole number before %q", s[len(s)-1:])
+		}
+		d = time.Duration(n) * unit
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, err
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return d, nil
+}
+
+// SLABreach is an unresolved issue older than the SLA for its priority
+type SLABreach struct {
+	Issue *Issue
+	SLA   time.Duration // Allowed age for the issue's priority
+	Age   time.Duration // Time since the issue was created
+}
+
+// Over is how far past its SLA the issue is
+func (b *SLABreach) Over() time.Duration {
+	return b.Age - b.SLA
+}
+
+// MarshalJSON writes durations as Go duration strings ("26h0m0s") and adds
+// the time the SLA ran out
+func (b *SLABreach) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Issue      *Issue    `json:"issue"`
+		SLA        string    `json:"sla"`
+		Age        string    `json:"age"`
+		Over       string    `json:"over"`
+		BreachedAt time.Time `json:"breached_at"`
+	}{
+		Issue:      b.Issue,
+		SLA:        b.SLA.String(),
+		Age:        b.Age.String(),
+		Over:       b.Over().String(),
+		BreachedAt: b.Issue.CreatedAt.Add(b.SLA),
+	})
+}
+
+// FindSLABreaches returns the issues that are unresolved at now and older
+// than policy allows for their priority, furthest past their SLA first.
+// Closed and archived issues are skipped.
+func FindSLABreaches(issues []*Issue, policy SLAPolicy, now time.Time) []*SLABreach {
+	var breaches []*SLABreach
+	for _, issue := range issues {
+		if issue.Status == StatusClosed || issue.ArchivedAt != nil {
+			continue
+		}
+		sla, ok := policy[issue.Priority]
+		if !ok {
+			continue
+		}
+		if age := now.Sub(issue.CreatedAt); age > sla {
+			breaches = append(breaches, &SLABreach{Issue: issue, SLA: sla, Age: age})
+		}
+	}
+
+	sort.SliceStable(breaches, func(i, j int) bool {
+		if breaches[i].Over() != breaches[j].Over() {
+			return breaches[i].Over() > breaches[j].Over()
+		}
+		return breaches[i].Issue.ID < breaches[j].Issue.ID
+	})
+	return breaches
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSLAPolicy(t *testing.T) {
+	policy, err := ParseSLAPolicy("0:24h; P1:3d;p2:2w; 3:90m")
+	if err != nil {
+		t.Fatalf("ParseSLAPolicy failed: %v", err)
+	}
+	want := SLAPolicy{0: 24 * time.Hour, 1: 72 * time.Hour, 2: 14 * 24 * time.Hour, 3: 90 * time.Minute}
+	if len(policy) != len(want) {
+		t.Fatalf("policy = %v, want %v", policy, want)
+	}
+	for priority, d := range want {
+		if policy[priority] != d {
+			t.Errorf("policy[%d] = %v, want %v", priority, policy[priority], d)
+		}
+	}
+
+	if policy, err := ParseSLAPolicy(" "); err != nil || policy != nil {
+		t.Errorf("empty spec = %v, %v; want nil, nil", policy, err)
+	}
+
+	for _, spec := range []string{"0", "5:1h", "x:1h", "0:soon", "0:1.5d", "0:-1h", "0:0s"} {
+		if _, err := ParseSLAPolicy(spec); err == nil {
+			t.Errorf("ParseSLAPolicy(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestFindSLABreaches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := SLAPolicy{0: 24 * time.Hour, 1: 72 * time.Hour}
+	issue := func(id string, priority int, age time.Duration, status Status) *Issue {
+		return &Issue{ID: id, Priority: priority, Status: status, CreatedAt: now.Add(-age)}
+	}
+	archivedAt := now
+	archived := issue("bd-7", 0, 48*time.Hour, StatusOpen)
+	archived.ArchivedAt = &archivedAt
+
+	breaches := FindSLABreaches([]*Issue{
+		issue("bd-1", 0, 25*time.Hour, StatusOpen),       // 1h over
+		issue("bd-2", 0, 23*time.Hour, StatusOpen),       // 1h to go
+		issue("bd-3", 1, 80*time.Hour, StatusInProgress), // 8h over
+		issue("bd-4", 1, 71*time.Hour, StatusBlocked),    // 1h to go
+		issue("bd-5", 0, 48*time.Hour, StatusClosed),
+		issue("bd-6", 2, 1000*time.Hour, StatusOpen), // no SLA for P2
+		archived,
+	}, policy, now)
+
+	if len(breaches) != 2 {
+		t.Fatalf("got %d breaches, want 2", len(breaches))
+	}
+	if breaches[0].Issue.ID != "bd-3" || breaches[1].Issue.ID != "bd-1" {
+		t.Errorf("breaches = %s, %s; want bd-3, bd-1 (furthest over first)", breaches[0].Issue.ID, breaches[1].Issue.ID)
+	}
+	if over := breaches[0].Over(); over != 8*time.Hour {
+		t.Errorf("bd-3 over = %v, want 8h", over)
+	}
+
+	data, err := json.Marshal(breaches[1])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{`"sla":"24h0m0s"`, `"over":"1h0m0s"`, `"breached_at":"2025-06-01T11:00:00Z"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON %s missing %s", data, want)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package types
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,50 +21,276 @@ type Issue struct {
 	Priority           int            `json:"priority"`
 	IssueType          IssueType      `json:"issue_type"`
 	Assignee           string         `json:"assignee,omitempty"`
+	Reporter           string         `json:"reporter,omitempty"` // Actor who created the issue
 	EstimatedMinutes   *int           `json:"estimated_minutes,omitempty"`
+	DueDate            *time.Time     `json:"due_date,omitempty"`
+	Rank               *float64       `json:"rank,omitempty"` // Orders issues within a priority; lower first
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 	ClosedAt           *time.Time     `json:"closed_at,omitempty"`
 	ExternalRef        *string        `json:"external_ref,omitempty"` // e.g., "gh-9", "jira-ABC"
+	ExternalRefs       TrackerRefs    `json:"external_refs,omitempty"` // Tracker -> key, for issues mirrored in several trackers
+	ArchivedAt         *time.Time     `json:"archived_at,omitempty"`  // Set when archived; hidden from default listings
 	CompactionLevel    int            `json:"compaction_level,omitempty"`
 	CompactedAt        *time.Time     `json:"compacted_at,omitempty"`
 	CompactedAtCommit  *string        `json:"compacted_at_commit,omitempty"` // Git commit hash when compacted
 	OriginalSize       int            `json:"original_size,omitempty"`
 	Labels             []string       `json:"labels,omitempty"` // Populated only for export/import
+	Watchers           []string       `json:"watchers,omitempty"` // People following the issue, sorted
 	Dependencies       []*Dependency  `json:"dependencies,omitempty"` // Populated only for export/import
 	Comments           []*Comment     `json:"comments,omitempty"`     // Populated only for export/import
 }
 
-// Validate checks if the issue has valid field values
+// TrackerRefs maps an external tracker name to the issue's key in that
+// tracker, e.g. {"jira": "ABC-12", "github": "gh-9"}
+type TrackerRefs map[string]string
+
+// Contains reports whether ref is the key in any tracker
+func (r TrackerRefs) Contains(ref string) bool {
+	for _, key := range r {
+		if key == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// LegacyExternalRefTracker returns the tracker name a single ExternalRef is
+// filed under when folded into ExternalRefs: the prefix before the first '-'
+// ("gh-9" -> "gh", "jira-ABC" -> "jira"), or "external" if there is none.
+func LegacyExternalRefTracker(ref string) string {
+	if i := strings.Index(ref, "-"); i > 0 {
+		return ref[:i]
+	}
+	return "external"
+}
+
+// ReplaceLegacy returns a copy of r in step with a change of the legacy
+// ExternalRef from oldRef to newRef: oldRef's entry is dropped if it still
+// holds oldRef, and newRef replaces its tracker's key. Either may be empty.
+// Returns nil if no entries remain.
+func (r TrackerRefs) ReplaceLegacy(oldRef, newRef string) TrackerRefs {
+	refs := make(TrackerRefs, len(r)+1)
+	for tracker, ref := range r {
+		refs[tracker] = ref
+	}
+	if oldRef != "" {
+		if tracker := LegacyExternalRefTracker(oldRef); refs[tracker] == oldRef {
+			delete(refs, tracker)
+		}
+	}
+	if newRef != "" {
+		refs[LegacyExternalRefTracker(newRef)] = newRef
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return refs
+}
+
+// HasExternalRef reports whether ref matches the issue's ExternalRef or its
+// key in any tracker
+func (i *Issue) HasExternalRef(ref string) bool {
+	if i.ExternalRef != nil && *i.ExternalRef == ref {
+		return true
+	}
+	return i.ExternalRefs.Contains(ref)
+}
+
+// Version returns an opaque token identifying this revision of the issue.
+// Every update bumps UpdatedAt, so a changed token means the issue was written
+// since it was read.
+func (i *Issue) Version() string {
+	return strconv.FormatInt(i.UpdatedAt.UnixNano(), 36)
+}
+
+// SortForExport puts Labels and Dependencies in a canonical order (labels
+// alphabetically, dependencies by target ID then type) so an issue always
+// serializes to the same JSONL line regardless of storage order
+func (i *Issue) SortForExport() {
+	sort.Strings(i.Labels)
+	sort.SliceStable(i.Dependencies, func(a, b int) bool {
+		da, db := i.Dependencies[a], i.Dependencies[b]
+		if da.DependsOnID != db.DependsOnID {
+			return da.DependsOnID < db.DependsOnID
+		}
+		return da.Type < db.Type
+	})
+}
+
+// FieldError is a validation failure of one field of an issue
+type FieldError struct {
+	Field string // JSON name of the field
+	Err   error
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Validate checks if the issue has valid field values. It returns the first
+// of FieldErrors, if any.
 func (i *Issue) Validate() error {
+	if errs := i.FieldErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// FieldErrors returns every validation failure of the issue, at most one per
+// field, in a stable order
+func (i *Issue) FieldErrors() []*FieldError {
+	var errs []*FieldError
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Err: fmt.Errorf(format, args...)})
+	}
+
 	if len(i.Title) == 0 {
-		return fmt.Errorf("title is required")
+		add("title", "title is required")
 	}
 	if len(i.Title) > 500 {
-		return fmt.Errorf("title must be 500 characters or less (got %d)", len(i.Title))
+		add("title", "title must be 500 characters or less (got %d)", len(i.Title))
 	}
 	if i.Priority < 0 || i.Priority > 4 {
-		return fmt.Errorf("priority must be between 0 and 4 (got %d)", i.Priority)
+		add("priority", "priority must be between 0 and 4 (got %d)", i.Priority)
 	}
 	if !i.Status.IsValid() {
-		return fmt.Errorf("invalid status: %s", i.Status)
+		add("status", "invalid status: %s", i.Status)
 	}
 	if !i.IssueType.IsValid() {
-		return fmt.Errorf("invalid issue type: %s", i.IssueType)
+		add("issue_type", "invalid issue type: %s", i.IssueType)
 	}
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
-		return fmt.Errorf("estimated_minutes cannot be negative")
+		add("estimated_minutes", "estimated_minutes cannot be negative")
 	}
 	// Enforce closed_at invariant: closed_at should be set if and only if status is closed
 	if i.Status == StatusClosed && i.ClosedAt == nil {
-		return fmt.Errorf("closed issues must have closed_at timestamp")
+		add("closed_at", "closed issues must have closed_at timestamp")
 	}
 	if i.Status != StatusClosed && i.ClosedAt != nil {
-		return fmt.Errorf("non-closed issues cannot have closed_at timestamp")
+		add("closed_at", "non-closed issues cannot have closed_at timestamp")
+	}
+	seen := make(map[string]bool, len(i.Watchers))
+	for _, watcher := range i.Watchers {
+		if strings.TrimSpace(watcher) == "" {
+			add("watchers", "watchers cannot be empty")
+			break
+		}
+		if seen[watcher] {
+			add("watchers", "duplicate watcher: %s", watcher)
+			break
+		}
+		seen[watcher] = true
+	}
+	return errs
+}
+
+// ValidateWith runs Validate and then checks the sections required for the
+// issue's type
+func (i *Issue) ValidateWith(required RequiredFields) error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+	for _, field := range required[i.IssueType] {
+		if strings.TrimSpace(i.section(field)) == "" {
+			return fmt.Errorf("%s issues require %s (see %s)", i.IssueType, field, RequiredFieldsConfigKey)
+		}
 	}
 	return nil
 }
 
+// section returns the text of a requirable section by its JSON name
+func (i *Issue) section(field string) string {
+	switch field {
+	case "description":
+		return i.Description
+	case "design":
+		return i.Design
+	case "acceptance_criteria":
+		return i.AcceptanceCriteria
+	case "notes":
+		return i.Notes
+	}
+	return ""
+}
+
+// CompareRank orders two issues by Rank: ranked issues come before unranked
+// ones, and lower ranks first. It returns 0 if neither is ranked or the ranks
+// are equal.
+func CompareRank(a, b *Issue) int {
+	switch {
+	case a.Rank == nil && b.Rank == nil:
+		return 0
+	case b.Rank == nil:
+		return -1
+	case a.Rank == nil:
+		return 1
+	case *a.Rank < *b.Rank:
+		return -1
+	case *a.Rank > *b.Rank:
+		return 1
+	}
+	return 0
+}
+
+// RequiredFieldsConfigKey is the database config key listing the sections each
+// issue type must fill in
+const RequiredFieldsConfigKey = "required_fields"
+
+// requirableFields are the sections RequiredFields may name
+var requirableFields = []string{"description", "design", "acceptance_criteria", "notes"}
+
+// RequiredFields maps an issue type to the sections new issues of that type
+// must fill in. A nil RequiredFields requires nothing.
+type RequiredFields map[IssueType][]string
+
+// ParseRequiredFields parses a spec of semicolon-separated "type:field,field"
+// entries, e.g. "bug:notes; feature:acceptance_criteria". An empty spec
+// requires nothing.
+func ParseRequiredFields(spec string) (RequiredFields, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+
+	required := make(RequiredFields)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		typ, fields, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid required fields entry %q (expected type:field,field)", entry)
+		}
+		issueType := IssueType(strings.TrimSpace(typ))
+		if !issueType.IsValid() {
+			return nil, fmt.Errorf("invalid issue type %q in required fields", issueType)
+		}
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if !isRequirableField(field) {
+				return nil, fmt.Errorf("invalid field %q in required fields (valid: %s)",
+					field, strings.Join(requirableFields, ", "))
+			}
+			required[issueType] = append(required[issueType], field)
+		}
+	}
+	return required, nil
+}
+
+func isRequirableField(field string) bool {
+	for _, f := range requirableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 // Status represents the current state of an issue
 type Status string
 
@@ -121,12 +350,13 @@ const (
 	DepRelated        DependencyType = "related"
 	DepParentChild    DependencyType = "parent-child"
 	DepDiscoveredFrom DependencyType = "discovered-from"
+	DepDuplicateOf    DependencyType = "duplicate-of"
 )
 
 // IsValid checks if the dependency type value is valid
 func (d DependencyType) IsValid() bool {
 	switch d {
-	case DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom:
+	case DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom, DepDuplicateOf:
 		return true
 	}
 	return false
@@ -138,6 +368,22 @@ type Label struct {
 	Label   string `json:"label"`
 }
 
+// LabelCount is a distinct label with the number of issues using it
+type LabelCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// SortLabelCounts orders label counts by count descending, then label ascending
+func SortLabelCounts(counts []*LabelCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Label < counts[j].Label
+	})
+}
+
 // Comment represents a comment on an issue
 type Comment struct {
 	ID        int64     `json:"id"`
@@ -159,6 +405,13 @@ type Event struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// EventFilter narrows an issue's event history. Zero fields match everything.
+type EventFilter struct {
+	Actor     string    // Only events recorded by this actor
+	EventType EventType // Only events of this type
+	Limit     int       // Only the most recent N matching events
+}
+
 // EventType categorizes audit trail events
 type EventType string
 
@@ -175,13 +428,28 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventReassigned        EventType = "reassigned"
+	EventRenamed           EventType = "renamed"
+	EventArchived          EventType = "archived"
+	EventUnarchived        EventType = "unarchived"
+	EventRestored          EventType = "restored"
+	EventClaimed           EventType = "claimed"
+	EventReleased          EventType = "released"
 )
 
 // BlockedIssue extends Issue with blocking information
 type BlockedIssue struct {
 	Issue
-	BlockedByCount int      `json:"blocked_by_count"`
-	BlockedBy      []string `json:"blocked_by"`
+	BlockedByCount int       `json:"blocked_by_count"`
+	BlockedBy      []string  `json:"blocked_by"`
+	BlockedSince   time.Time `json:"blocked_since"` // Best effort: last dependency_added event, else updated_at
+}
+
+// DeletedIssue is a snapshot of a deleted issue kept in the trash so it can be restored
+type DeletedIssue struct {
+	Issue      *Issue        `json:"issue"`                // Includes labels, dependencies, and comments
+	Dependents []*Dependency `json:"dependents,omitempty"` // Links from other issues to this one
+	DeletedAt  time.Time     `json:"deleted_at"`
 }
 
 // TreeNode represents a node in a dependency tree
@@ -191,6 +459,13 @@ type TreeNode struct {
 	Truncated bool `json:"truncated"`
 }
 
+// DanglingDependency is a dependency edge whose target issue no longer exists
+type DanglingDependency struct {
+	IssueID     string         `json:"issue_id"`
+	DependsOnID string         `json:"depends_on_id"`
+	Type        DependencyType `json:"type"`
+}
+
 // Statistics provides aggregate metrics
 type Statistics struct {
 	TotalIssues              int     `json:"total_issues"`
@@ -203,17 +478,43 @@ type Statistics struct {
 	AverageLeadTime          float64 `json:"average_lead_time_hours"`
 }
 
+// MeAlias stands for the current actor wherever an assignee or reporter is
+// given, so "--assignee @me" filters on whoever runs the command. A user
+// literally named "me" is unaffected.
+const MeAlias = "@me"
+
+// ResolveMe returns actor if name is MeAlias, and name otherwise
+func ResolveMe(name, actor string) string {
+	if name == MeAlias {
+		return actor
+	}
+	return name
+}
+
 // IssueFilter is used to filter issue queries
 type IssueFilter struct {
 	Status      *Status
 	Priority    *int
 	IssueType   *IssueType
 	Assignee    *string
+	Reporter    *string
+	DueBefore   *time.Time // Only issues with a due date before this time
 	Labels      []string  // AND semantics: issue must have ALL these labels
 	LabelsAny   []string  // OR semantics: issue must have AT LEAST ONE of these labels
 	TitleSearch string
 	IDs         []string  // Filter by specific issue IDs
 	Limit       int
+	IncludeArchived bool // Include archived issues (excluded by default)
+	SortByRelevance bool // Order by Relevance to the search text instead of by priority
+	// Match, if set, is a further predicate every result must satisfy, such as
+	// a compiled search query. Backends apply it before Limit.
+	Match func(*Issue) bool
+}
+
+// RelevanceQuery returns the text SortByRelevance ranks against: the search
+// query and the title filter together
+func (f IssueFilter) RelevanceQuery(query string) string {
+	return strings.TrimSpace(query + " " + f.TitleSearch)
 }
 
 // SortPolicy determines how ready work is ordered
@@ -260,3 +561,82 @@ type EpicStatus struct {
 	ClosedChildren  int    `json:"closed_children"`
 	EligibleForClose bool  `json:"eligible_for_close"`
 }
+
+// EpicProgress summarizes the state of an epic's direct children.
+// Each child is counted in exactly one of the open/in-progress/blocked/closed buckets;
+// a child with an unclosed blocker counts as blocked regardless of its status.
+type EpicProgress struct {
+	Epic               *Issue  `json:"epic"`
+	TotalChildren      int     `json:"total_children"`
+	OpenChildren       int     `json:"open_children"`
+	InProgressChildren int     `json:"in_progress_children"`
+	BlockedChildren    int     `json:"blocked_children"`
+	ClosedChildren     int     `json:"closed_children"`
+	PercentComplete    float64 `json:"percent_complete"`
+	EstimatedMinutes   int     `json:"estimated_minutes"` // Sum of estimates across all children
+	CompletedMinutes   int     `json:"completed_minutes"` // Sum of estimates across closed children
+	RemainingMinutes   int     `json:"remaining_minutes"` // Sum of estimates across unclosed children
+
+	UnestimatedChildren []string `json:"unestimated_children,omitempty"` // Unclosed children without an estimate
+}
+
+// AddChild counts a child issue into the progress totals
+func (p *EpicProgress) AddChild(child *Issue, hasOpenBlocker bool) {
+	p.TotalChildren++
+	estimate := 0
+	if child.EstimatedMinutes != nil {
+		estimate = *child.EstimatedMinutes
+	}
+	p.EstimatedMinutes += estimate
+	if child.EstimatedMinutes == nil && child.Status != StatusClosed {
+		p.UnestimatedChildren = append(p.UnestimatedChildren, child.ID)
+	}
+
+	switch {
+	case child.Status == StatusClosed:
+		p.ClosedChildren++
+		p.CompletedMinutes += estimate
+	case child.Status == StatusBlocked || hasOpenBlocker:
+		p.BlockedChildren++
+		p.RemainingMinutes += estimate
+	case child.Status == StatusInProgress:
+		p.InProgressChildren++
+		p.RemainingMinutes += estimate
+	default:
+		p.OpenChildren++
+		p.RemainingMinutes += estimate
+	}
+
+	p.PercentComplete = float64(p.ClosedChildren) * 100 / float64(p.TotalChildren)
+}
+
+// UnestimatedChildrenError is returned when an epic's completion can't be projected
+// because some unclosed children have no estimate
+type UnestimatedChildrenError struct {
+	EpicID   string
+	ChildIDs []string
+}
+
+func (e *UnestimatedChildrenError) Error() string {
+	noun := "children"
+	if len(e.ChildIDs) == 1 {
+		noun = "child"
+	}
+	return fmt.Sprintf("cannot project completion of %s: %d unclosed %s without an estimate (%s)",
+		e.EpicID, len(e.ChildIDs), noun, strings.Join(e.ChildIDs, ", "))
+}
+
+// ProjectCompletion projects when the epic's remaining work will be done, starting
+// from now and burning down minutesPerDay of estimated work per calendar day.
+// Children without an estimate make the projection unknowable rather than counting
+// as zero, so they are reported in an *UnestimatedChildrenError.
+func (p *EpicProgress) ProjectCompletion(now time.Time, minutesPerDay float64) (time.Time, error) {
+	if minutesPerDay <= 0 {
+		return time.Time{}, fmt.Errorf("rate must be positive, got %v minutes per day", minutesPerDay)
+	}
+	if len(p.UnestimatedChildren) > 0 {
+		return time.Time{}, &UnestimatedChildrenError{EpicID: p.Epic.ID, ChildIDs: p.UnestimatedChildren}
+	}
+	days := float64(p.RemainingMinutes) / minutesPerDay
+	return now.Add(time.Duration(days * float64(24*time.Hour))), nil
+}
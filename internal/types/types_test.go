@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -354,3 +355,153 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestEpicProgressProjectCompletion(t *testing.T) {
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	minutes := func(n int) *int { return &n }
+
+	progress := &EpicProgress{Epic: &Issue{ID: "bd-1"}}
+	progress.AddChild(&Issue{ID: "bd-2", Status: StatusClosed, EstimatedMinutes: minutes(300)}, false)
+	progress.AddChild(&Issue{ID: "bd-3", Status: StatusOpen, EstimatedMinutes: minutes(480)}, false)
+	progress.AddChild(&Issue{ID: "bd-4", Status: StatusInProgress, EstimatedMinutes: minutes(240)}, false)
+
+	// 720 remaining minutes at 240 per day is three days of work
+	eta, err := progress.ProjectCompletion(now, 240)
+	if err != nil {
+		t.Fatalf("ProjectCompletion failed: %v", err)
+	}
+	if want := time.Date(2025, 3, 6, 9, 0, 0, 0, time.UTC); !eta.Equal(want) {
+		t.Errorf("eta = %v, want %v", eta, want)
+	}
+
+	// A partial day is kept rather than rounded
+	eta, _ = progress.ProjectCompletion(now, 480)
+	if want := time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC); !eta.Equal(want) {
+		t.Errorf("eta = %v, want %v", eta, want)
+	}
+
+	if _, err := progress.ProjectCompletion(now, 0); err == nil {
+		t.Error("expected error for zero rate")
+	}
+
+	// Unestimated closed children don't matter, unestimated open ones do
+	progress.AddChild(&Issue{ID: "bd-5", Status: StatusClosed}, false)
+	if _, err := progress.ProjectCompletion(now, 240); err != nil {
+		t.Errorf("unestimated closed child should not block projection: %v", err)
+	}
+	progress.AddChild(&Issue{ID: "bd-6", Status: StatusOpen}, false)
+	_, err = progress.ProjectCompletion(now, 240)
+	unestimated, ok := err.(*UnestimatedChildrenError)
+	if !ok {
+		t.Fatalf("expected *UnestimatedChildrenError, got %v", err)
+	}
+	if len(unestimated.ChildIDs) != 1 || unestimated.ChildIDs[0] != "bd-6" {
+		t.Errorf("unestimated children = %v, want [bd-6]", unestimated.ChildIDs)
+	}
+}
+
+func TestSortForExport(t *testing.T) {
+	issue := &Issue{
+		Labels: []string{"zeta", "alpha", "mid"},
+		Dependencies: []*Dependency{
+			{DependsOnID: "bd-3", Type: DepBlocks},
+			{DependsOnID: "bd-1", Type: DepRelated},
+			{DependsOnID: "bd-1", Type: DepBlocks},
+		},
+	}
+	issue.SortForExport()
+
+	if got := strings.Join(issue.Labels, ","); got != "alpha,mid,zeta" {
+		t.Errorf("labels = %s, want alpha,mid,zeta", got)
+	}
+	var deps []string
+	for _, d := range issue.Dependencies {
+		deps = append(deps, d.DependsOnID+":"+string(d.Type))
+	}
+	if got := strings.Join(deps, ","); got != "bd-1:blocks,bd-1:related,bd-3:blocks" {
+		t.Errorf("dependencies = %s", got)
+	}
+}
+
+func TestParseRequiredFields(t *testing.T) {
+	required, err := ParseRequiredFields("bug:notes, description; feature:acceptance_criteria")
+	if err != nil {
+		t.Fatalf("ParseRequiredFields failed: %v", err)
+	}
+	if got := strings.Join(required[TypeBug], ","); got != "notes,description" {
+		t.Errorf("bug fields = %q", got)
+	}
+	if got := strings.Join(required[TypeFeature], ","); got != "acceptance_criteria" {
+		t.Errorf("feature fields = %q", got)
+	}
+
+	if required, err := ParseRequiredFields(""); err != nil || required != nil {
+		t.Errorf("empty spec = %v, %v; want nil, nil", required, err)
+	}
+
+	for _, spec := range []string{"bug", "bogus:notes", "bug:steps"} {
+		if _, err := ParseRequiredFields(spec); err == nil {
+			t.Errorf("ParseRequiredFields(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestValidateWithRequiredFields(t *testing.T) {
+	required := RequiredFields{TypeBug: {"notes"}}
+	issue := Issue{Title: "Crash", Status: StatusOpen, Priority: 1, IssueType: TypeBug}
+
+	if err := issue.ValidateWith(required); err == nil || !strings.Contains(err.Error(), "notes") {
+		t.Fatalf("expected error naming notes, got %v", err)
+	}
+	issue.Notes = "   "
+	if err := issue.ValidateWith(required); err == nil {
+		t.Fatal("expected whitespace-only notes to be rejected")
+	}
+	issue.Notes = "Steps to reproduce"
+	if err := issue.ValidateWith(required); err != nil {
+		t.Fatalf("ValidateWith failed: %v", err)
+	}
+	if err := issue.ValidateWith(nil); err != nil {
+		t.Fatalf("ValidateWith(nil) failed: %v", err)
+	}
+}
+
+func TestValidateWatchers(t *testing.T) {
+	issue := Issue{Title: "Watched", Status: StatusOpen, Priority: 1, IssueType: TypeTask, Watchers: []string{"alice", "bob"}}
+	if err := issue.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	issue.Watchers = []string{"alice", "bob", "alice"}
+	if err := issue.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate watcher") {
+		t.Errorf("expected a duplicate watcher error, got %v", err)
+	}
+	issue.Watchers = []string{""}
+	if err := issue.Validate(); err == nil {
+		t.Error("expected an empty watcher to be rejected")
+	}
+}
+
+func TestResolveMe(t *testing.T) {
+	if got := ResolveMe(MeAlias, "alice"); got != "alice" {
+		t.Errorf("ResolveMe(@me) = %q, want alice", got)
+	}
+	for _, name := range []string{"me", "bob", ""} {
+		if got := ResolveMe(name, "alice"); got != name {
+			t.Errorf("ResolveMe(%q) = %q, want it unchanged", name, got)
+		}
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	issue := Issue{Title: "", Status: StatusClosed, Priority: 7, IssueType: TypeTask}
+	var fields []string
+	for _, e := range issue.FieldErrors() {
+		fields = append(fields, e.Field)
+	}
+	if got := strings.Join(fields, ","); got != "title,priority,closed_at" {
+		t.Errorf("FieldErrors fields = %s, want title,priority,closed_at", got)
+	}
+	if err := issue.Validate(); err == nil || err.Error() != "title is required" {
+		t.Errorf("Validate = %v, want the first field error", err)
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"os"
+	"runtime"
+)
+
+// DurableWritesEnabled makes SyncFile and SyncDir flush to stable storage, so a JSONL
+// file replaced by temp file + rename survives a power loss right after the rename.
+// It is off by default because fsync is slow; enable it with the durable-writes
+// config key (BD_DURABLE_WRITES).
+var DurableWritesEnabled = false
+
+// fsSyncer flushes files and directories to disk; replaced in tests
+type fsSyncer interface {
+	SyncFile(f *os.File) error
+	SyncDir(dir string) error
+}
+
+type osSyncer struct{}
+
+func (osSyncer) SyncFile(f *os.File) error {
+	return f.Sync()
+}
+
+func (osSyncer) SyncDir(dir string) error {
+	// Windows can't open a directory for syncing; NTFS journals the rename itself
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir) // #nosec G304 - directory of a controlled path
+	if err != nil {
+		return err
+	}
+	defer func() { _ = d.Close() }()
+	return d.Sync()
+}
+
+var syncer fsSyncer = osSyncer{}
+
+// SyncFile flushes a written temp file to disk before it is closed and renamed.
+// It does nothing unless DurableWritesEnabled is set.
+func SyncFile(f *os.File) error {
+	if !DurableWritesEnabled {
+		return nil
+	}
+	return syncer.SyncFile(f)
+}
+
+// SyncDir flushes a directory after a file was renamed into it, making the rename
+// itself durable. It does nothing unless DurableWritesEnabled is set.
+func SyncDir(dir string) error {
+	if !DurableWritesEnabled {
+		return nil
+	}
+	return syncer.SyncDir(dir)
+}
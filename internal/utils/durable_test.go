@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recordingSyncer records sync calls instead of touching the disk
+type recordingSyncer struct {
+	files []string
+	dirs  []string
+}
+
+func (r *recordingSyncer) SyncFile(f *os.File) error {
+	r.files = append(r.files, f.Name())
+	return nil
+}
+
+func (r *recordingSyncer) SyncDir(dir string) error {
+	r.dirs = append(r.dirs, dir)
+	return nil
+}
+
+// withSyncer installs a recording syncer and sets DurableWritesEnabled for a test
+func withSyncer(t *testing.T, enabled bool) *recordingSyncer {
+	t.Helper()
+	rec := &recordingSyncer{}
+	oldEnabled, oldSyncer := DurableWritesEnabled, syncer
+	DurableWritesEnabled, syncer = enabled, rec
+	t.Cleanup(func() {
+		DurableWritesEnabled, syncer = oldEnabled, oldSyncer
+	})
+	return rec
+}
+
+// replaceFile writes data to path the way bd writes JSONL files
+func replaceFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncFile(f); err != nil {
+		t.Fatalf("SyncFile failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := RenameWithRetry(f.Name(), path); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncDir(filepath.Dir(path)); err != nil {
+		t.Fatalf("SyncDir failed: %v", err)
+	}
+}
+
+func TestDurableWritesSyncFileAndDir(t *testing.T) {
+	rec := withSyncer(t, true)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	replaceFile(t, path, "{}\n")
+
+	if len(rec.files) != 1 || filepath.Dir(rec.files[0]) != dir {
+		t.Errorf("expected the temp file to be synced once, got %v", rec.files)
+	}
+	if len(rec.dirs) != 1 || rec.dirs[0] != dir {
+		t.Errorf("expected %s to be synced once, got %v", dir, rec.dirs)
+	}
+}
+
+func TestDurableWritesDisabledSkipsSync(t *testing.T) {
+	rec := withSyncer(t, false)
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	replaceFile(t, path, "{}\n")
+
+	if len(rec.files) != 0 || len(rec.dirs) != 0 {
+		t.Errorf("expected no syncs when disabled, got files=%v dirs=%v", rec.files, rec.dirs)
+	}
+}
+
+func TestOSSyncer(t *testing.T) {
+	oldEnabled := DurableWritesEnabled
+	DurableWritesEnabled = true
+	defer func() { DurableWritesEnabled = oldEnabled }()
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	replaceFile(t, path, "{\"id\":\"bd-1\"}\n")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\"id\":\"bd-1\"}\n" {
+		t.Errorf("unexpected content %q", data)
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// FSRetryEnabled turns on retrying of transient filesystem errors in RenameWithRetry
+// and ReadFileWithRetry. It is off by default because local filesystems don't return
+// these errors spuriously; enable it (fs-retry config key, BD_FS_RETRY) for workspaces
+// on NFS or SMB mounts.
+var FSRetryEnabled = false
+
+// Retry bounds: up to fsRetryAttempts tries, doubling the delay from fsRetryBackoff
+var (
+	fsRetryAttempts = 5
+	fsRetryBackoff  = 20 * time.Millisecond
+)
+
+// isTransientFSError reports whether err is an errno that networked filesystems
+// return spuriously and that usually clears up on its own
+func isTransientFSError(err error) bool {
+	return errors.Is(err, syscall.EAGAIN) ||
+		errors.Is(err, syscall.ESTALE) ||
+		errors.Is(err, syscall.EINTR) ||
+		errors.Is(err, syscall.EBUSY)
+}
+
+// retryFS runs op, retrying with exponential backoff while it fails with a transient
+// error and retries are enabled. Other errors are returned immediately.
+func retryFS(op func() error) error {
+	delay := fsRetryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = op()
+		if err == nil || !FSRetryEnabled || attempt >= fsRetryAttempts || !isTransientFSError(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// RenameWithRetry is os.Rename that retries transient errors when FSRetryEnabled is set
+func RenameWithRetry(oldpath, newpath string) error {
+	return retryFS(func() error {
+		return os.Rename(oldpath, newpath)
+	})
+}
+
+// ReadFileWithRetry is os.ReadFile that retries transient errors when FSRetryEnabled is set
+func ReadFileWithRetry(path string) ([]byte, error) {
+	var data []byte
+	err := retryFS(func() error {
+		var err error
+		data, err = os.ReadFile(path) // #nosec G304 - callers pass controlled paths
+		return err
+	})
+	return data, err
+}
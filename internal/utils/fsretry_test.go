@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// withFSRetry enables retries with no backoff for the duration of a test
+func withFSRetry(t *testing.T, enabled bool) {
+	t.Helper()
+	oldEnabled, oldBackoff := FSRetryEnabled, fsRetryBackoff
+	FSRetryEnabled, fsRetryBackoff = enabled, time.Microsecond
+	t.Cleanup(func() {
+		FSRetryEnabled, fsRetryBackoff = oldEnabled, oldBackoff
+	})
+}
+
+// flakyOp fails with err for the first failures calls, then succeeds
+func flakyOp(failures int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= failures {
+			return &os.PathError{Op: "rename", Path: "issues.jsonl", Err: err}
+		}
+		return nil
+	}, &calls
+}
+
+func TestRetryFSRecoversFromTransientError(t *testing.T) {
+	withFSRetry(t, true)
+
+	op, calls := flakyOp(2, syscall.ESTALE)
+	if err := retryFS(op); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 calls, got %d", *calls)
+	}
+}
+
+func TestRetryFSGivesUp(t *testing.T) {
+	withFSRetry(t, true)
+
+	op, calls := flakyOp(100, syscall.EAGAIN)
+	if err := retryFS(op); !errors.Is(err, syscall.EAGAIN) {
+		t.Fatalf("expected EAGAIN after exhausting retries, got %v", err)
+	}
+	if *calls != fsRetryAttempts {
+		t.Errorf("expected %d calls, got %d", fsRetryAttempts, *calls)
+	}
+}
+
+func TestRetryFSSkipsPermanentErrors(t *testing.T) {
+	withFSRetry(t, true)
+
+	op, calls := flakyOp(1, syscall.ENOENT)
+	if err := retryFS(op); !errors.Is(err, syscall.ENOENT) {
+		t.Fatalf("expected ENOENT, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("permanent errors must not be retried, got %d calls", *calls)
+	}
+}
+
+func TestRetryFSDisabled(t *testing.T) {
+	withFSRetry(t, false)
+
+	op, calls := flakyOp(1, syscall.ESTALE)
+	if err := retryFS(op); !errors.Is(err, syscall.ESTALE) {
+		t.Fatalf("expected ESTALE with retries disabled, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("expected a single call with retries disabled, got %d", *calls)
+	}
+}
+
+func TestRenameAndReadWithRetry(t *testing.T) {
+	withFSRetry(t, true)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "issues.jsonl.tmp")
+	dst := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(src, []byte("{}\n"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := RenameWithRetry(src, dst); err != nil {
+		t.Fatalf("RenameWithRetry failed: %v", err)
+	}
+	data, err := ReadFileWithRetry(dst)
+	if err != nil || string(data) != "{}\n" {
+		t.Errorf("ReadFileWithRetry = %q, %v", data, err)
+	}
+	if _, err := ReadFileWithRetry(filepath.Join(dir, "missing.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
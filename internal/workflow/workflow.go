@@ -0,0 +1,123 @@
+// Package workflow defines the optional status workflow: the status transitions
+// UpdateIssue and CloseIssue allow. Without a configured workflow every transition
+// is allowed.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ConfigKey is the database config key holding the workflow spec
+const ConfigKey = "status_workflow"
+
+// Strict is the name of the built-in workflow: work starts before it is closed,
+// and closed issues can only be reopened
+const Strict = "strict"
+
+const strictSpec = "open:in_progress,blocked; in_progress:open,blocked,closed; blocked:open,in_progress; closed:open"
+
+// Workflow maps each status to the statuses it may move to.
+// A nil Workflow allows every transition.
+type Workflow map[types.Status]map[types.Status]bool
+
+// Parse parses a workflow spec. An empty spec or "permissive" allows everything,
+// "strict" selects the built-in workflow, and anything else lists transitions as
+// semicolon-separated "from:to,to" entries, e.g. "open:in_progress; in_progress:closed".
+// Statuses without an entry can't be left.
+func Parse(spec string) (Workflow, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "", "permissive":
+		return nil, nil
+	case Strict:
+		spec = strictSpec
+	}
+
+	w := make(Workflow)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, targets, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid workflow entry %q (expected from:to,to)", entry)
+		}
+		fromStatus := types.Status(strings.TrimSpace(from))
+		if !fromStatus.IsValid() {
+			return nil, fmt.Errorf("invalid status %q in workflow", fromStatus)
+		}
+		if w[fromStatus] == nil {
+			w[fromStatus] = make(map[types.Status]bool)
+		}
+		for _, to := range strings.Split(targets, ",") {
+			toStatus := types.Status(strings.TrimSpace(to))
+			if toStatus == "" {
+				continue
+			}
+			if !toStatus.IsValid() {
+				return nil, fmt.Errorf("invalid status %q in workflow", toStatus)
+			}
+			w[fromStatus][toStatus] = true
+		}
+	}
+	return w, nil
+}
+
+// Allows reports whether an issue may move from one status to another.
+// Staying in the same status is always allowed.
+func (w Workflow) Allows(from, to types.Status) bool {
+	if w == nil || from == to {
+		return true
+	}
+	return w[from][to]
+}
+
+// Check returns a *TransitionError if the transition is not allowed
+func (w Workflow) Check(from, to types.Status) error {
+	if w.Allows(from, to) {
+		return nil
+	}
+	allowed := make([]string, 0, len(w[from]))
+	for status := range w[from] {
+		allowed = append(allowed, string(status))
+	}
+	sort.Strings(allowed)
+	return &TransitionError{From: from, To: to, Allowed: allowed}
+}
+
+// TransitionError is returned for a status change the workflow doesn't allow
+type TransitionError struct {
+	From    types.Status
+	To      types.Status
+	Allowed []string
+}
+
+func (e *TransitionError) Error() string {
+	allowed := "none"
+	if len(e.Allowed) > 0 {
+		allowed = strings.Join(e.Allowed, ", ")
+	}
+	return fmt.Sprintf("status workflow does not allow %s → %s (allowed from %s: %s); use --force to override",
+		e.From, e.To, e.From, allowed)
+}
+
+type forceKey struct{}
+
+// WithForce returns a context under which UpdateIssue and CloseIssue skip workflow
+// checks, for --force and for operations that restore state rather than move it
+// forward, such as import
+func WithForce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceKey{}, true)
+}
+
+// Forced reports whether ctx was created by WithForce
+func Forced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forceKey{}).(bool)
+	return forced
+}
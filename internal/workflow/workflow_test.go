@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParsePermissive(t *testing.T) {
+	for _, spec := range []string{"", "  ", "permissive"} {
+		w, err := Parse(spec)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", spec, err)
+		}
+		if !w.Allows(types.StatusClosed, types.StatusInProgress) {
+			t.Errorf("Parse(%q) should allow every transition", spec)
+		}
+	}
+}
+
+func TestStrictWorkflow(t *testing.T) {
+	w, err := Parse(Strict)
+	if err != nil {
+		t.Fatalf("Parse(strict) failed: %v", err)
+	}
+
+	tests := []struct {
+		from, to types.Status
+		want     bool
+	}{
+		{types.StatusOpen, types.StatusInProgress, true},
+		{types.StatusInProgress, types.StatusClosed, true},
+		{types.StatusClosed, types.StatusOpen, true},
+		{types.StatusOpen, types.StatusClosed, false},
+		{types.StatusClosed, types.StatusInProgress, false},
+		{types.StatusClosed, types.StatusClosed, true},
+	}
+	for _, tt := range tests {
+		if got := w.Allows(tt.from, tt.to); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestParseCustom(t *testing.T) {
+	w, err := Parse("open: closed; closed:open")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !w.Allows(types.StatusOpen, types.StatusClosed) {
+		t.Error("open → closed should be allowed")
+	}
+	// in_progress has no entry, so it can't be left
+	err = w.Check(types.StatusInProgress, types.StatusOpen)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected *TransitionError, got %v", err)
+	}
+	if len(transitionErr.Allowed) != 0 {
+		t.Errorf("expected no allowed targets, got %v", transitionErr.Allowed)
+	}
+
+	for _, spec := range []string{"open", "open:done", "later:open"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should fail", spec)
+		}
+	}
+}
+
+func TestWithForce(t *testing.T) {
+	ctx := context.Background()
+	if Forced(ctx) {
+		t.Error("background context should not be forced")
+	}
+	if !Forced(WithForce(ctx)) {
+		t.Error("WithForce context should be forced")
+	}
+}